@@ -2,9 +2,10 @@
 //
 // Two independent checks live here. ARM64 hand-encoded WORD directives are
 // decoded and compared against the instruction their comment claims, using the
-// same disassembler go tool objdump uses. AMD64 kernels are classified by the
-// x86 SIMD feature level their instructions require, so a kernel's body cannot
-// outrun the CPU feature its dispatch guard demands.
+// same disassembler go tool objdump uses, extended with the SVE forms it
+// predates. AMD64 kernels are classified by the x86 SIMD feature level their
+// instructions require, so a kernel's body cannot outrun the CPU feature its
+// dispatch guard demands.
 //
 // Both are pure source analysis, so they run on any architecture with no ARM or
 // x86 hardware.
@@ -126,11 +127,15 @@ func ParseWordLine(line string) (hex uint32, comment string, ok bool) {
 }
 
 // Decode returns the GNU-syntax disassembly of a 32-bit ARM64 instruction word.
+// Words arm64asm rejects are retried against the SVE subset in sve.go.
 func Decode(hex uint32) (string, error) {
 	var b [4]byte
 	binary.LittleEndian.PutUint32(b[:], hex)
 	inst, err := arm64asm.Decode(b[:])
 	if err != nil {
+		if sve, ok := decodeSVE(hex); ok {
+			return sve, nil
+		}
 		return "", err
 	}
 	return arm64asm.GNUSyntax(inst), nil
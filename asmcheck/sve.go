@@ -0,0 +1,188 @@
+package asmcheck
+
+// This file decodes the SVE instructions the f32 and f64 SVE kernels
+// hand-encode. golang.org/x/arch/arm64/arm64asm predates SVE and rejects every
+// word in its encoding space, so without this the kernels would depend on the
+// lenient objdump cross-check. Only the forms the kernels use are recognized;
+// anything else stays undecodable. Output follows GNU objdump syntax.
+
+import "fmt"
+
+// Field layout shared by the SVE forms decoded here.
+const (
+	sveRegWidth   = 5  // Z, X or predicate-pattern field width
+	svePgWidth    = 3  // governing predicate P0-P7
+	svePdWidth    = 4  // destination predicate P0-P15
+	sveRnLo       = 5  // first source (Zn, Rn) or pattern field
+	sveRmLo       = 16 // second source (Zm, Rm) or immediate field
+	svePgLo       = 10 // governing predicate field
+	sveSizeLo     = 22 // element size field
+	sveSizeWidth  = 2
+	sveImm4Width  = 4  // signed vector-length offset, or multiplier minus one
+	sveImm8Width  = 8  // DUP signed immediate
+	sveShiftLo    = 13 // DUP immediate shift (LSL #8) flag
+	sveScalarLo   = 14 // set for a scalar-plus-scalar address
+	sveRegSPOrZR  = 31 // register 31 names SP as a base, XZR as an operand
+	svePatternAll = 31 // the "all elements" predicate-constraint pattern
+	sveWordShift  = 2  // log2 of the byte size of a word element
+	sveDwordShift = 3  // log2 of the byte size of a doubleword element
+	sveImm4Sign   = 32 - sveImm4Width
+)
+
+// sveForm is one SVE instruction form: a word w with w&mask == value decodes
+// through format, which reports false for field values outside the subset.
+type sveForm struct {
+	mask, value uint32
+	format      func(w uint32) (string, bool)
+}
+
+var sveForms = []sveForm{
+	{0xFF3FFC10, 0x2518E000, svePTRUE},
+	{0xFF3FE000, 0x2538C000, sveDUPImm},
+	{0xFF20FC10, 0x25201C00, sveWHILELO},
+	{0xFFF0E000, 0xA540A000, sveLoad("ld1w", sveWordShift)},
+	{0xFFF0E000, 0xA5E0A000, sveLoad("ld1d", sveDwordShift)},
+	{0xFFE0E000, 0xA5404000, sveLoad("ld1w", sveWordShift)},
+	{0xFFE0E000, 0xA5E04000, sveLoad("ld1d", sveDwordShift)},
+	{0xFFE0E000, 0xE5404000, sveStore("st1w", sveWordShift)},
+	{0xFFE0E000, 0xE5E04000, sveStore("st1d", sveDwordShift)},
+	{0xFF20E000, 0x65200000, sveFMLA},
+	{0xFF20FC00, 0x65000000, sveUnpredicated("fadd")},
+	{0xFF20FC00, 0x65000800, sveUnpredicated("fmul")},
+	{0xFF3FE000, 0x65008000, sveFADDPredicated},
+	{0xFF3FE000, 0x65002000, sveFADDV},
+	{0xFF30FC00, 0x0430E000, sveElementCount("inc")},
+	{0xFF30FC00, 0x0420E000, sveElementCount("cnt")},
+}
+
+// decodeSVE returns the GNU-syntax disassembly of w if it is one of the SVE
+// forms in sveForms.
+func decodeSVE(w uint32) (string, bool) {
+	for _, f := range sveForms {
+		if w&f.mask == f.value {
+			return f.format(w)
+		}
+	}
+	return "", false
+}
+
+// sveBits extracts the width-bit field of w starting at bit lo.
+func sveBits(w uint32, lo, width uint) uint32 {
+	return (w >> lo) & (1<<width - 1)
+}
+
+// sveSize returns the element-size suffix of the size field at bits 23:22.
+func sveSize(w uint32) string {
+	return string("bhsd"[sveBits(w, sveSizeLo, sveSizeWidth)])
+}
+
+// sveBase names the base register of a memory operand, where 31 is the stack pointer.
+func sveBase(n uint32) string {
+	if n == sveRegSPOrZR {
+		return "sp"
+	}
+	return fmt.Sprintf("x%d", n)
+}
+
+// sveXReg names a general-purpose operand, where 31 is the zero register.
+func sveXReg(n uint32) string {
+	if n == sveRegSPOrZR {
+		return "xzr"
+	}
+	return fmt.Sprintf("x%d", n)
+}
+
+func svePTRUE(w uint32) (string, bool) {
+	if sveBits(w, sveRnLo, sveRegWidth) != svePatternAll {
+		return "", false
+	}
+	return fmt.Sprintf("ptrue p%d.%s", sveBits(w, 0, svePdWidth), sveSize(w)), true
+}
+
+// sveDUPImm decodes DUP (immediate) with an unshifted immediate, which GNU
+// prints as its MOV alias.
+func sveDUPImm(w uint32) (string, bool) {
+	if sveBits(w, sveShiftLo, 1) != 0 {
+		return "", false
+	}
+	imm := int8(sveBits(w, sveRnLo, sveImm8Width))
+	return fmt.Sprintf("mov z%d.%s, #%d", sveBits(w, 0, sveRegWidth), sveSize(w), imm), true
+}
+
+func sveWHILELO(w uint32) (string, bool) {
+	return fmt.Sprintf("whilelo p%d.%s, %s, %s", sveBits(w, 0, svePdWidth), sveSize(w),
+		sveXReg(sveBits(w, sveRnLo, sveRegWidth)), sveXReg(sveBits(w, sveRmLo, sveRegWidth))), true
+}
+
+// sveAddress formats the memory operand of a contiguous load or store: scalar
+// plus scalar (bit 14 set) with the index scaled by the element size, or scalar
+// plus a signed immediate counted in vector lengths.
+func sveAddress(w uint32, shift int) string {
+	base := sveBase(sveBits(w, sveRnLo, sveRegWidth))
+	if sveBits(w, sveScalarLo, 1) == 1 {
+		return fmt.Sprintf("[%s, %s, lsl #%d]", base, sveXReg(sveBits(w, sveRmLo, sveRegWidth)), shift)
+	}
+	imm := int32(sveBits(w, sveRmLo, sveImm4Width)<<sveImm4Sign) >> sveImm4Sign
+	if imm == 0 {
+		return "[" + base + "]"
+	}
+	return fmt.Sprintf("[%s, #%d, mul vl]", base, imm)
+}
+
+func sveLoad(mnemonic string, shift int) func(uint32) (string, bool) {
+	return func(w uint32) (string, bool) {
+		return fmt.Sprintf("%s {z%d.%s}, p%d/z, %s", mnemonic, sveBits(w, 0, sveRegWidth), "bhsd"[shift:shift+1],
+			sveBits(w, svePgLo, svePgWidth), sveAddress(w, shift)), true
+	}
+}
+
+func sveStore(mnemonic string, shift int) func(uint32) (string, bool) {
+	return func(w uint32) (string, bool) {
+		return fmt.Sprintf("%s {z%d.%s}, p%d, %s", mnemonic, sveBits(w, 0, sveRegWidth), "bhsd"[shift:shift+1],
+			sveBits(w, svePgLo, svePgWidth), sveAddress(w, shift)), true
+	}
+}
+
+func sveFMLA(w uint32) (string, bool) {
+	t := sveSize(w)
+	return fmt.Sprintf("fmla z%d.%s, p%d/m, z%d.%s, z%d.%s", sveBits(w, 0, sveRegWidth), t,
+		sveBits(w, svePgLo, svePgWidth), sveBits(w, sveRnLo, sveRegWidth), t, sveBits(w, sveRmLo, sveRegWidth), t), true
+}
+
+func sveUnpredicated(mnemonic string) func(uint32) (string, bool) {
+	return func(w uint32) (string, bool) {
+		t := sveSize(w)
+		return fmt.Sprintf("%s z%d.%s, z%d.%s, z%d.%s", mnemonic, sveBits(w, 0, sveRegWidth), t,
+			sveBits(w, sveRnLo, sveRegWidth), t, sveBits(w, sveRmLo, sveRegWidth), t), true
+	}
+}
+
+func sveFADDPredicated(w uint32) (string, bool) {
+	t := sveSize(w)
+	zdn := sveBits(w, 0, sveRegWidth)
+	return fmt.Sprintf("fadd z%d.%s, p%d/m, z%d.%s, z%d.%s", zdn, t,
+		sveBits(w, svePgLo, svePgWidth), zdn, t, sveBits(w, sveRnLo, sveRegWidth), t), true
+}
+
+func sveFADDV(w uint32) (string, bool) {
+	t := sveSize(w)
+	return fmt.Sprintf("faddv %s%d, p%d, z%d.%s", t, sveBits(w, 0, sveRegWidth),
+		sveBits(w, svePgLo, svePgWidth), sveBits(w, sveRnLo, sveRegWidth), t), true
+}
+
+// sveElementCount decodes INC<T> and CNT<T> on a general-purpose register.
+// GNU omits the pattern and multiplier when they are "all" and 1.
+func sveElementCount(op string) func(uint32) (string, bool) {
+	return func(w uint32) (string, bool) {
+		if sveBits(w, sveRnLo, sveRegWidth) != svePatternAll {
+			return "", false
+		}
+		// The mnemonic names the element as a word (INCW), not a single (.S).
+		unit := "bhwd"[sveBits(w, sveSizeLo, sveSizeWidth)]
+		s := fmt.Sprintf("%s%c x%d", op, unit, sveBits(w, 0, sveRegWidth))
+		if mul := sveBits(w, sveRmLo, sveImm4Width) + 1; mul > 1 {
+			s += fmt.Sprintf(", all, mul #%d", mul)
+		}
+		return s, true
+	}
+}
@@ -0,0 +1,75 @@
+package asmcheck
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"golang.org/x/arch/arm64/arm64asm"
+)
+
+// TestDecodeSVE checks the SVE subset against encodings produced by
+// llvm-mc -triple=aarch64 -mattr=+sve, in GNU objdump syntax.
+func TestDecodeSVE(t *testing.T) {
+	tests := []struct {
+		hex  uint32
+		want string
+	}{
+		{0x2598E3E1, "ptrue p1.s"},
+		{0x25D8E3E1, "ptrue p1.d"},
+		{0x25B8C000, "mov z0.s, #0"},
+		{0x25F8C003, "mov z3.d, #0"},
+		{0x25A21C80, "whilelo p0.s, x4, x2"},
+		{0x25E61CE0, "whilelo p0.d, x7, x6"},
+		{0xA540A504, "ld1w {z4.s}, p1/z, [x8]"},
+		{0xA541A505, "ld1w {z5.s}, p1/z, [x8, #1, mul vl]"},
+		{0xA54CA511, "ld1w {z17.s}, p1/z, [x8, #-4, mul vl]"},
+		{0xA5444004, "ld1w {z4.s}, p0/z, [x0, x4, lsl #2]"},
+		{0xA5E44004, "ld1d {z4.d}, p0/z, [x0, x4, lsl #3]"},
+		{0xA5E3A505, "ld1d {z5.d}, p1/z, [x8, #3, mul vl]"},
+		{0xE5444000, "st1w {z0.s}, p0, [x0, x4, lsl #2]"},
+		{0xE5E44000, "st1d {z0.d}, p0, [x0, x4, lsl #3]"},
+		{0x65B00480, "fmla z0.s, p1/m, z4.s, z16.s"},
+		{0x65F00080, "fmla z0.d, p0/m, z4.d, z16.d"},
+		{0x65810000, "fadd z0.s, z0.s, z1.s"},
+		{0x65C10800, "fmul z0.d, z0.d, z1.d"},
+		{0x65808080, "fadd z0.s, p0/m, z0.s, z4.s"},
+		{0x65802400, "faddv s0, p1, z0.s"},
+		{0x65C02463, "faddv d3, p1, z3.d"},
+		{0x04B0E3E4, "incw x4"},
+		{0x04F0E3E4, "incd x4"},
+		{0x04A3E3E6, "cntw x6, all, mul #4"},
+		{0x04E3E3E6, "cntd x6, all, mul #4"},
+	}
+	for _, tt := range tests {
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], tt.hex)
+		if _, err := arm64asm.Decode(b[:]); err == nil {
+			t.Errorf("arm64asm decodes 0x%08X; the SVE fallback is not what is under test", tt.hex)
+		}
+		got, err := Decode(tt.hex)
+		if err != nil {
+			t.Errorf("Decode(0x%08X) error: %v, want %q", tt.hex, err, tt.want)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Decode(0x%08X) = %q, want %q", tt.hex, got, tt.want)
+		}
+	}
+}
+
+// TestDecodeSVEOutsideSubset checks SVE words using fields the kernels never
+// encode stay undecodable rather than being misreported.
+func TestDecodeSVEOutsideSubset(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		hex  uint32
+	}{
+		{"ptrue p1.s, vl4", 0x2598E081},
+		{"mov z0.s, #256", 0x25B8E020},
+		{"incw x4, vl8", 0x04B0E104},
+	} {
+		if got, err := Decode(tt.hex); err == nil {
+			t.Errorf("Decode(0x%08X) (%s) = %q, want an error", tt.hex, tt.name, got)
+		}
+	}
+}
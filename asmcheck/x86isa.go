@@ -311,14 +311,15 @@ func ScanX86Source(src string) []X86Kernel {
 }
 
 // DeclaredX86Level reports the feature level a kernel's name claims, from its
-// suffix. Names ending in AVX512 claim AVX-512 and names ending in AVX2 claim
-// AVX2. AVXVNNI also claims AVX2: AVX-VNNI is the VEX-encoded form of the
+// suffix. Names ending in AVX512, AVX512BW or AVX512VNNI claim AVX-512 and
+// names ending in AVX2 claim AVX2. AVXVNNI also claims AVX2: AVX-VNNI is the VEX-encoded form of the
 // dot-product instructions and every part that implements it also implements
 // AVX2. Everything else (an ...AVX, ...SSE2 or unsuffixed kernel) claims no more
 // than AVX1.
 func DeclaredX86Level(name string) X86Level {
 	switch {
-	case strings.HasSuffix(name, "AVX512"):
+	case strings.HasSuffix(name, "AVX512"), strings.HasSuffix(name, "AVX512BW"),
+		strings.HasSuffix(name, "AVX512VNNI"):
 		return X86LevelAVX512
 	case strings.HasSuffix(name, "AVX2"), strings.HasSuffix(name, "AVXVNNI"):
 		return X86LevelAVX2
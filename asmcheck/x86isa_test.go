@@ -159,6 +159,8 @@ func TestDeclaredX86Level(t *testing.T) {
 		"xcorr4AVXVNNI":    X86LevelAVX2,
 		"addScaledAVX512":  X86LevelAVX512,
 		"dotProductAVX512": X86LevelAVX512,
+		"sadAVX512BW":      X86LevelAVX512,
+		"dotAVX512VNNI":    X86LevelAVX512,
 	}
 	for name, want := range cases {
 		if got := DeclaredX86Level(name); got != want {
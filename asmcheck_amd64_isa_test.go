@@ -62,6 +62,7 @@ var avx2GatedAVXKernels = map[string]string{
 	"f32/f32_amd64.s:sigmoidAVX":             "f32_amd64.go sigmoid32: cpu.X86.AVX2",
 	"f32/f32_amd64.s:tanhAVX":                "f32_amd64.go tanh32: cpu.X86.AVX2",
 	"f32/f32_amd64.s:expAVX":                 "f32_amd64.go exp32: cpu.X86.AVX2",
+	"f32/f32_amd64.s:expSumAVX":              "f32_amd64.go expSum32: cpu.X86.AVX2",
 	"f32/f32_amd64.s:logAVX":                 f32LogGate,
 	"f32/f32_amd64.s:powAVX":                 f32LogGate,
 	"f32/f32_amd64.s:powElemAVX":             f32LogGate,
@@ -74,6 +75,7 @@ var avx2GatedAVXKernels = map[string]string{
 	"f64/f64_amd64.s:sigmoidAVX":       "f64_amd64.go sigmoid64: cpu.X86.AVX2",
 	"f64/f64_amd64.s:tanhAVX":          "f64_amd64.go tanh64: cpu.X86.AVX2",
 	"f64/f64_amd64.s:expAVX":           "f64_amd64.go exp64: cpu.X86.AVX2",
+	"f64/f64_amd64.s:expSumAVX":        "f64_amd64.go expSum64: cpu.X86.AVX2",
 	"f64/f64_amd64.s:logAVX":           f64LogGate,
 	"f64/f64_amd64.s:powAVX":           f64LogGate,
 	"f64/f64_amd64.s:powElemAVX":       f64LogGate,
@@ -7,6 +7,7 @@
 // - DotProductConj: Hermitian inner product sum(a[i]*conj(b[i])) for correlation
 // - Scale: Scale by complex scalar
 // - FromReal: Convert real float64 to complex128 (FFT input preparation)
+// - FFTPlan: Forward/inverse complex FFT (any size) to close the loop
 //
 // All functions automatically select the optimal implementation based on
 // runtime CPU feature detection. Functions gracefully fall back to pure Go
@@ -34,6 +35,8 @@
 // Abs, AbsSq and FromReal convert between complex128 and float64, so their input
 // and output have distinct element types and cannot alias in safe Go. DotProduct
 // and DotProductConj write no output slice, so aliasing does not apply to them.
+// FFTPlan's Forward, Inverse and InverseScaled run through split-format scratch,
+// so they support dst == src exactly; dst must not otherwise overlap src.
 package c128
 
 // Mul computes element-wise complex multiplication: dst[i] = a[i] * b[i].
@@ -38,6 +38,12 @@ var (
 )
 
 func init() {
+	bindKernels()
+}
+
+// bindKernels selects the implementations from the current cpu.X86 flags. It
+// runs from init, and again from cpu.Override.
+func bindKernels() {
 	selectImpl(
 		cpu.X86.AVX512F && cpu.X86.AVX512VL,
 		cpu.X86.AVX && cpu.X86.FMA,
@@ -48,8 +54,9 @@ func init() {
 
 // selectImpl assigns the operation implementations from CPU feature predicates.
 // Priority: AVX-512 > AVX+FMA > AVX (no FMA) > SSE2 > Go.
-// It is split out from init so the dispatch priority can be unit-tested on any
-// host, including the AVX-without-FMA path that never runs on FMA-capable CI.
+// It is split out from bindKernels so the dispatch priority can be unit-tested
+// on any host, including the AVX-without-FMA path that never runs on FMA-capable
+// CI.
 func selectImpl(avx512, avxFMA, avx, sse2 bool) {
 	switch {
 	case avx512:
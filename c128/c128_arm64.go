@@ -8,6 +8,12 @@ var (
 	hasNEON = cpu.ARM64.NEON
 )
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+}
+
 func mul128(dst, a, b []complex128) {
 	if hasNEON && len(dst) >= 1 {
 		mulNEON(dst, a, b)
@@ -0,0 +1,127 @@
+package c128
+
+import (
+	"errors"
+
+	"github.com/tphakala/simd/f64"
+)
+
+// This file provides a standalone complex FFT over interleaved []complex128 data,
+// so frequency-domain convolution and correlation (FFT, Mul or MulConj, inverse
+// FFT) run end to end inside the library. The transform itself is an
+// f64.FFTPlan, the same split-format core STFTPlan uses: radix-4 stages through
+// f64.ButterflyComplexStage4 and f64.ButterflyComplexStage, scalar radix-3/5
+// stages for 2^a*3^b*5^c sizes, and a Bluestein fallback for every other size.
+// This plan deinterleaves into resident split scratch, runs it, and interleaves
+// back.
+
+// ErrFFTSize is returned by NewFFTPlan when n < 1.
+var ErrFFTSize = errors.New("c128: FFT size must be >= 1")
+
+// FFTPlan is a reusable n-point complex FFT over []complex128. Build one with
+// NewFFTPlan and reuse it across calls to stay allocation-free.
+//
+// A plan holds per-transform scratch, so its methods are NOT safe for concurrent
+// use on the same plan; use one plan per goroutine. Distinct plans share no
+// mutable state.
+type FFTPlan struct {
+	fft    *f64.FFTPlan
+	re, im []float64 // split-format scratch, FFT'd in place
+}
+
+// NewFFTPlan builds a reusable plan for n-point complex FFTs. Any n >= 1 is
+// accepted; otherwise ErrFFTSize is returned. Powers of two are fastest, and
+// sizes with a prime factor above 5 take the slower Bluestein path.
+func NewFFTPlan(n int) (*FFTPlan, error) {
+	fft, err := f64.NewFFTPlan(n)
+	if err != nil {
+		return nil, ErrFFTSize
+	}
+	return &FFTPlan{
+		fft: fft,
+		re:  make([]float64, n),
+		im:  make([]float64, n),
+	}, nil
+}
+
+// Len returns the transform size the plan was built for.
+func (p *FFTPlan) Len() int { return p.fft.Len() }
+
+// Forward computes the unnormalized forward DFT of src into dst:
+//
+//	dst[k] = sum_{t=0}^{n-1} src[t] * exp(-i*2*pi*k*t/n)
+//
+// It reads src[:n] and writes dst[:n], where n is Len(), and is a no-op when
+// either slice is shorter than n. The transform may run in place (dst == src
+// exactly); dst must not otherwise overlap src. Allocation-free.
+func (p *FFTPlan) Forward(dst, src []complex128) {
+	n := p.Len()
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	p.load(src[:n], false)
+	p.fft.Forward(p.re, p.im)
+	p.store(dst[:n], 1, false)
+}
+
+// Inverse computes the unnormalized inverse DFT of src into dst:
+//
+//	dst[t] = sum_{k=0}^{n-1} src[k] * exp(+i*2*pi*k*t/n)
+//
+// No 1/n factor is applied (the FFTW convention), so Inverse(Forward(x)) is
+// Len()*x; use InverseScaled for the normalized inverse. Length handling and
+// aliasing follow Forward. Allocation-free.
+func (p *FFTPlan) Inverse(dst, src []complex128) {
+	n := p.Len()
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	// ifft(x) = conj(fft(conj(x))); the conjugations fold into the load and store.
+	p.load(src[:n], true)
+	p.fft.Forward(p.re, p.im)
+	p.store(dst[:n], 1, true)
+}
+
+// InverseScaled computes the normalized inverse DFT, Inverse scaled by 1/Len()
+// (numpy.fft.ifft), so InverseScaled(Forward(x)) recovers x. The scaling is
+// fused into the output pass. Length handling and aliasing follow Forward.
+// Allocation-free.
+func (p *FFTPlan) InverseScaled(dst, src []complex128) {
+	n := p.Len()
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	p.load(src[:n], true)
+	p.fft.Forward(p.re, p.im)
+	p.store(dst[:n], 1/float64(n), true)
+}
+
+// load deinterleaves src into the split scratch, conjugating when conj is set.
+func (p *FFTPlan) load(src []complex128, conj bool) {
+	re, im := p.re[:len(src)], p.im[:len(src)]
+	if conj {
+		for i, v := range src {
+			re[i], im[i] = real(v), -imag(v)
+		}
+		return
+	}
+	for i, v := range src {
+		re[i], im[i] = real(v), imag(v)
+	}
+}
+
+// store interleaves the split scratch into dst, scaling by s and conjugating
+// when conj is set.
+func (p *FFTPlan) store(dst []complex128, s float64, conj bool) {
+	re, im := p.re[:len(dst)], p.im[:len(dst)]
+	if conj {
+		s2 := -s
+		for i := range dst {
+			dst[i] = complex(re[i]*s, im[i]*s2)
+		}
+		return
+	}
+	for i := range dst {
+		dst[i] = complex(re[i]*s, im[i]*s)
+	}
+}
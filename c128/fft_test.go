@@ -0,0 +1,211 @@
+package c128
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// dftRef computes the full DFT of x directly, as an independent reference for
+// FFTPlan. sign is -1 for the forward transform and +1 for the
+// unnormalized inverse.
+func dftRef(x []complex128, sign float64) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range n {
+		var s complex128
+		for t := range n {
+			ang := sign * 2 * math.Pi * float64(k*t%n) / float64(n)
+			s += x[t] * cmplx.Rect(1, ang)
+		}
+		out[k] = s
+	}
+	return out
+}
+
+func fftInput(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		fi := float64(i)
+		x[i] = complex(math.Sin(0.37*fi)+0.25*math.Cos(1.9*fi), math.Cos(0.61*fi)-0.5*math.Sin(0.13*fi))
+	}
+	return x
+}
+
+// fftTol bounds the FFT error against the direct DFT, scaled by the L1 norm of
+// the input.
+func fftTol(x []complex128) float64 {
+	var l1 float64
+	for _, v := range x {
+		l1 += cmplx.Abs(v)
+	}
+	return 1e-12*math.Log2(float64(max(len(x), 2)))*l1 + 1e-12
+}
+
+func TestNewFFTPlanErrors(t *testing.T) {
+	for _, bad := range []int{-1, 0} {
+		if _, err := NewFFTPlan(bad); err == nil {
+			t.Errorf("NewFFTPlan(%d) = nil error, want ErrFFTSize", bad)
+		}
+	}
+	for _, good := range []int{1, 2, 3, 6, 64, 97, 400, 1000, 1024} {
+		p, err := NewFFTPlan(good)
+		if err != nil {
+			t.Errorf("NewFFTPlan(%d) unexpected error: %v", good, err)
+			continue
+		}
+		if p.Len() != good {
+			t.Errorf("NewFFTPlan(%d).Len() = %d", good, p.Len())
+		}
+	}
+}
+
+// TestFFTPlanAgainstDFT checks Forward and Inverse, out of place and in place,
+// against a direct complex128 DFT, across power-of-two, mixed-radix, and
+// Bluestein sizes.
+func TestFFTPlanAgainstDFT(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 16, 32, 128, 512, 3, 5, 12, 400, 480, 7, 97} {
+		p, err := NewFFTPlan(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src := fftInput(n)
+		tol := fftTol(src)
+		for _, dir := range []string{"forward", "inverse"} {
+			for _, inPlace := range []bool{false, true} {
+				in := append([]complex128(nil), src...)
+				dst := make([]complex128, n)
+				if inPlace {
+					dst = in
+				}
+				sign := -1.0
+				if dir == "forward" {
+					p.Forward(dst, in)
+				} else {
+					sign = 1
+					p.Inverse(dst, in)
+				}
+				want := dftRef(src, sign)
+				for k := range n {
+					if d := cmplx.Abs(dst[k] - want[k]); d > tol {
+						t.Fatalf("n=%d %s inPlace=%v bin=%d: got %v want %v (|diff|=%g tol=%g)",
+							n, dir, inPlace, k, dst[k], want[k], d, tol)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestFFTPlanInverseScaled checks InverseScaled(Forward(x)) == x and that it
+// equals Inverse divided by Len().
+func TestFFTPlanInverseScaled(t *testing.T) {
+	for _, n := range []int{1, 8, 256, 4096, 480, 1009} {
+		p, _ := NewFFTPlan(n)
+		src := fftInput(n)
+		spec := make([]complex128, n)
+		p.Forward(spec, src)
+		got := make([]complex128, n)
+		p.InverseScaled(got, spec)
+		raw := make([]complex128, n)
+		p.Inverse(raw, spec)
+		for i := range n {
+			if d := cmplx.Abs(got[i] - src[i]); d > 1e-12 {
+				t.Fatalf("n=%d: round trip[%d] = %v want %v", n, i, got[i], src[i])
+			}
+			want := raw[i] * complex(1/float64(n), 0)
+			if d := cmplx.Abs(got[i] - want); d > 1e-14*(1+cmplx.Abs(want)) {
+				t.Fatalf("n=%d: InverseScaled[%d] = %v, want Inverse/n = %v", n, i, got[i], want)
+			}
+		}
+	}
+}
+
+// TestFFTPlanCircularConvolution runs the frequency-domain convolution the plan
+// exists for (FFT, Mul, inverse FFT) against a direct circular convolution.
+func TestFFTPlanCircularConvolution(t *testing.T) {
+	const n = 64
+	p, _ := NewFFTPlan(n)
+	a := fftInput(n)
+	b := make([]complex128, n)
+	for i := range 5 {
+		b[i] = complex(float64(i+1), float64(-i))
+	}
+	fa := make([]complex128, n)
+	fb := make([]complex128, n)
+	p.Forward(fa, a)
+	p.Forward(fb, b)
+	Mul(fa, fa, fb)
+	p.InverseScaled(fa, fa)
+	for i := range n {
+		var want complex128
+		for j := range n {
+			want += a[j] * b[(i-j+n)%n]
+		}
+		if d := cmplx.Abs(fa[i] - want); d > 1e-12*(1+cmplx.Abs(want)) {
+			t.Fatalf("conv[%d] = %v, want %v", i, fa[i], want)
+		}
+	}
+}
+
+// TestFFTPlanGuards checks that short slices are a no-op and that only the first
+// Len() elements are read and written.
+func TestFFTPlanGuards(t *testing.T) {
+	p, _ := NewFFTPlan(8)
+	short := fftInput(7)
+	orig := append([]complex128(nil), short...)
+	p.Forward(short, fftInput(8))
+	p.Inverse(make([]complex128, 8), short)
+	p.InverseScaled(short, fftInput(8))
+	for i := range short {
+		if short[i] != orig[i] {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+
+	long := fftInput(12)
+	want := make([]complex128, 8)
+	p.Forward(want, long[:8])
+	tail := append([]complex128(nil), long[8:]...)
+	p.Forward(long, long)
+	for i := range 8 {
+		if long[i] != want[i] {
+			t.Fatalf("long input bin %d differs from exact-length transform", i)
+		}
+	}
+	for i, v := range tail {
+		if long[8+i] != v {
+			t.Fatalf("element %d past Len() modified", 8+i)
+		}
+	}
+}
+
+func TestFFTPlanAllocFree(t *testing.T) {
+	p, _ := NewFFTPlan(1024)
+	src := fftInput(1024)
+	dst := make([]complex128, 1024)
+	if a := testing.AllocsPerRun(5, func() { p.Forward(dst, src) }); a != 0 {
+		t.Errorf("Forward allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { p.Inverse(dst, src) }); a != 0 {
+		t.Errorf("Inverse allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { p.InverseScaled(dst, dst) }); a != 0 {
+		t.Errorf("InverseScaled allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkFFTPlanForward(b *testing.B) {
+	for _, n := range []int{256, 1024, 4096} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			p, _ := NewFFTPlan(n)
+			src := fftInput(n)
+			dst := make([]complex128, n)
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(dst, src)
+			}
+		})
+	}
+}
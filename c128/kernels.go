@@ -0,0 +1,25 @@
+package c128
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":            absGo,
+	"AbsSq":          absSqGo,
+	"Add":            addGo,
+	"Conj":           conjGo,
+	"DotProduct":     dotProductGo,
+	"DotProductConj": dotProductConjGo,
+	"FromReal":       fromRealGo,
+	"Mul":            mulGo,
+	"MulConj":        mulConjGo,
+	"Scale":          scaleGo,
+	"Sub":            subGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "c128", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
@@ -0,0 +1,56 @@
+//go:build amd64
+
+package c128
+
+import (
+	"github.com/tphakala/simd/cpu"
+	"github.com/tphakala/simd/internal/dispatch"
+)
+
+// kernelSuffixes names the tier of each kernel the initXXX functions install in
+// the function pointers. initAVXNoFMA keeps the FMA-free AVX kernels, so an AVX
+// kernel reports AVX+FMA only on hosts with FMA.
+func kernelSuffixes() []dispatch.Suffix {
+	avx := dispatch.AVX
+	if cpu.X86.FMA {
+		avx = dispatch.AVXFMA
+	}
+	return []dispatch.Suffix{
+		{Suffix: "AVX512", Tier: dispatch.AVX512},
+		{Suffix: "AVX", Tier: avx},
+		{Suffix: "SSE2", Tier: dispatch.SSE2},
+	}
+}
+
+// kernelBinding reports the kernel op is bound to, from the function pointer it
+// dispatches through.
+func kernelBinding(op string) dispatch.Binding {
+	var fn any
+	switch op {
+	case "Add":
+		fn = addImpl
+	case "Sub":
+		fn = subImpl
+	case "Mul":
+		fn = mulImpl
+	case "MulConj":
+		fn = mulConjImpl
+	case "DotProduct":
+		fn = dotProductImpl
+	case "DotProductConj":
+		fn = dotProductConjImpl
+	case "Scale":
+		fn = scaleImpl
+	case "Abs":
+		fn = absImpl
+	case "AbsSq":
+		fn = absSqImpl
+	case "Conj":
+		fn = conjImpl
+	case "FromReal":
+		fn = fromRealImpl
+	default:
+		return dispatch.Binding{}
+	}
+	return dispatch.Bound(fn, kernelSuffixes())
+}
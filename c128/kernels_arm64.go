@@ -0,0 +1,27 @@
+//go:build arm64
+
+package c128
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation to its NEON kernel.
+var neonKernels = map[string]any{
+	"Add":            addNEON,
+	"Sub":            subNEON,
+	"Mul":            mulNEON,
+	"MulConj":        mulConjNEON,
+	"DotProduct":     dotProductNEON,
+	"DotProductConj": dotProductConjNEON,
+	"Scale":          scaleNEON,
+	"Abs":            absNEON,
+	"AbsSq":          absSqNEON,
+	"Conj":           conjNEON,
+	"FromReal":       fromRealNEON,
+}
+
+// kernelBinding reports the kernel op is bound to: its NEON kernel when the
+// host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
@@ -10,6 +10,7 @@
 // - Add/Sub: Complex addition/subtraction for FFT butterflies
 // - AbsSq: Magnitude squared for power spectrum computation
 // - FromReal: Convert real float32 to complex64
+// - FFTPlan: Forward/inverse complex FFT (any size) to close the loop
 //
 // Complex64 uses float32 internally, providing 2x the throughput of complex128
 // operations on SIMD registers (8 complex64 per AVX-512 vs 4 complex128).
@@ -40,6 +41,8 @@
 // Abs, AbsSq and FromReal convert between complex64 and float32, so their input
 // and output have distinct element types and cannot alias in safe Go. DotProduct
 // and DotProductConj write no output slice, so aliasing does not apply to them.
+// FFTPlan's Forward, Inverse and InverseScaled run through split-format scratch,
+// so they support dst == src exactly; dst must not otherwise overlap src.
 package c64
 
 // Mul computes element-wise complex multiplication: dst[i] = a[i] * b[i].
@@ -30,6 +30,12 @@ var (
 )
 
 func init() {
+	bindKernels()
+}
+
+// bindKernels selects the implementations from the current cpu.X86 flags. It
+// runs from init, and again from cpu.Override.
+func bindKernels() {
 	// Select optimal implementation based on CPU features
 	// Priority: AVX-512 > AVX+FMA > SSE4.1 > Go
 	// Note: "SSE2" routines use BLENDPS which requires SSE4.1
@@ -6,6 +6,12 @@ import "github.com/tphakala/simd/cpu"
 
 var hasNEON = cpu.ARM64.NEON
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+}
+
 func mul64(dst, a, b []complex64) {
 	if hasNEON {
 		mulNEON(dst, a, b)
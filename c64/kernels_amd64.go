@@ -0,0 +1,47 @@
+//go:build amd64
+
+package c64
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelSuffixes names the tier of each kernel initAVX512, initAVX and initSSE2
+// install in the function pointers. The "SSE2" kernels use BLENDPS, so their
+// tier is SSE4.1.
+var kernelSuffixes = []dispatch.Suffix{
+	{Suffix: "AVX512", Tier: dispatch.AVX512},
+	{Suffix: "AVX", Tier: dispatch.AVXFMA},
+	{Suffix: "SSE2", Tier: dispatch.SSE41},
+}
+
+// kernelBinding reports the kernel op is bound to, from the function pointer it
+// dispatches through.
+func kernelBinding(op string) dispatch.Binding {
+	var fn any
+	switch op {
+	case "Add":
+		fn = addImpl
+	case "Sub":
+		fn = subImpl
+	case "Mul":
+		fn = mulImpl
+	case "MulConj":
+		fn = mulConjImpl
+	case "DotProduct":
+		fn = dotProductImpl
+	case "DotProductConj":
+		fn = dotProductConjImpl
+	case "Scale":
+		fn = scaleImpl
+	case "Abs":
+		fn = absImpl
+	case "AbsSq":
+		fn = absSqImpl
+	case "Conj":
+		fn = conjImpl
+	case "FromReal":
+		fn = fromRealImpl
+	default:
+		return dispatch.Binding{}
+	}
+	return dispatch.Bound(fn, kernelSuffixes)
+}
@@ -1,6 +1,7 @@
 // Package cint provides SIMD-accelerated fixed-point complex arithmetic on
 // interleaved int32 slices, the integer FFT butterfly kernels of libopus
-// kiss_fft.
+// kiss_fft, and the bit-exact kiss_fft driver (FFTPlan) and fixed-point MDCT
+// (MDCTPlan) built on them.
 //
 // # Data model
 //
@@ -45,7 +46,9 @@
 // # Guarantees
 //
 // All functions are zero-allocation (they write into caller-provided slices or
-// in place) and safe for concurrent use on non-overlapping slices.
+// in place) and safe for concurrent use on non-overlapping slices. FFTPlan (the
+// kiss_fft driver) and MDCTPlan, built on these kernels, hold scratch and are one
+// per goroutine.
 package cint
 
 // Add writes the wrapping complex sum dst[k] = a[k] + b[k] over the leading whole
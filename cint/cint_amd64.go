@@ -15,6 +15,18 @@ import "github.com/tphakala/simd/cpu"
 // zero-allocation.
 var hasAVX2 = cpu.X86.AVX2
 
+// hasAVX512 gates the 512-bit ports of the same kernels. Their ZMM work is all
+// AVX-512F; they require VL as well, like the other packages' AVX-512 tier, and
+// their 256-bit remainder block is AVX2, which every AVX-512 CPU has.
+var hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
+
+// bindKernels re-reads the feature flags cached above from cpu.X86.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasAVX2 = cpu.X86.AVX2
+	hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
+}
+
 // Tier thresholds: the minimum lane count at which the AVX2 kernel is worth its
 // setup over the scalar loop, one 8-lane (256-bit) block each. Add, Sub and
 // MulByScalar step 8 int32 per block; Mul and MulConj step 8 int32 (4 complex)
@@ -29,44 +41,69 @@ const (
 	minAVX2MulConj     = 8
 )
 
+// The AVX-512 kernels step 16 int32 (8 complex) per block and gate at one block;
+// below that the AVX2 kernel runs.
+const (
+	minAVX512Add         = 16
+	minAVX512Sub         = 16
+	minAVX512MulByScalar = 16
+	minAVX512Mul         = 16
+	minAVX512MulConj     = 16
+)
+
 func addCint(dst, a, b []int32) {
-	if hasAVX2 && len(dst) >= minAVX2Add {
+	switch {
+	case hasAVX512 && len(dst) >= minAVX512Add:
+		addAVX512(dst, a, b)
+	case hasAVX2 && len(dst) >= minAVX2Add:
 		addAVX2(dst, a, b)
-		return
+	default:
+		addGo(dst, a, b)
 	}
-	addGo(dst, a, b)
 }
 
 func subCint(dst, a, b []int32) {
-	if hasAVX2 && len(dst) >= minAVX2Sub {
+	switch {
+	case hasAVX512 && len(dst) >= minAVX512Sub:
+		subAVX512(dst, a, b)
+	case hasAVX2 && len(dst) >= minAVX2Sub:
 		subAVX2(dst, a, b)
-		return
+	default:
+		subGo(dst, a, b)
 	}
-	subGo(dst, a, b)
 }
 
 func mulByScalarCint(a []int32, s int16) {
-	if hasAVX2 && len(a) >= minAVX2MulByScalar {
+	switch {
+	case hasAVX512 && len(a) >= minAVX512MulByScalar:
+		mulByScalarAVX512(a, s)
+	case hasAVX2 && len(a) >= minAVX2MulByScalar:
 		mulByScalarAVX2(a, s)
-		return
+	default:
+		mulByScalarGo(a, s)
 	}
-	mulByScalarGo(a, s)
 }
 
 func mulCint(dst, a []int32, tw []int16) {
-	if hasAVX2 && len(dst) >= minAVX2Mul {
+	switch {
+	case hasAVX512 && len(dst) >= minAVX512Mul:
+		mulAVX512(dst, a, tw)
+	case hasAVX2 && len(dst) >= minAVX2Mul:
 		mulAVX2(dst, a, tw)
-		return
+	default:
+		mulGo(dst, a, tw)
 	}
-	mulGo(dst, a, tw)
 }
 
 func mulConjCint(dst, a []int32, tw []int16) {
-	if hasAVX2 && len(dst) >= minAVX2MulConj {
+	switch {
+	case hasAVX512 && len(dst) >= minAVX512MulConj:
+		mulConjAVX512(dst, a, tw)
+	case hasAVX2 && len(dst) >= minAVX2MulConj:
 		mulConjAVX2(dst, a, tw)
-		return
+	default:
+		mulConjGo(dst, a, tw)
 	}
-	mulConjGo(dst, a, tw)
 }
 
 //go:noescape
@@ -83,3 +120,18 @@ func mulAVX2(dst, a []int32, tw []int16)
 
 //go:noescape
 func mulConjAVX2(dst, a []int32, tw []int16)
+
+//go:noescape
+func addAVX512(dst, a, b []int32)
+
+//go:noescape
+func subAVX512(dst, a, b []int32)
+
+//go:noescape
+func mulByScalarAVX512(a []int32, s int16)
+
+//go:noescape
+func mulAVX512(dst, a []int32, tw []int16)
+
+//go:noescape
+func mulConjAVX512(dst, a []int32, tw []int16)
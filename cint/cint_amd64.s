@@ -303,3 +303,369 @@ mulconj_avx2_scalar:
 mulconj_avx2_done:
     VZEROUPPER
     RET
+
+// AVX-512 kernels: the AVX2 kernels above at 16 int32 (8 complex) per
+// iteration. Every instruction is AVX-512F (VPADDD / VPSUBD / VPMULDQ / VPSRLQ /
+// VPSLLQ / VPMOVSXWD on ZMM, VPBROADCASTD from a GP register) except the
+// 256-bit block each kernel runs once after its loop, which is the AVX2 kernel's
+// body. VPBLENDD has no 512-bit form, so the complex multiplies merge the odd
+// (imaginary) lanes with VPBLENDMD under the 0xAAAA opmask in K1. A remainder of
+// 8-15 int32 takes that 256-bit block and the last n mod 8 the scalar tail, which
+// is the AVX2 kernel's. dst may alias a exactly, as in the AVX2 kernels.
+
+// func addAVX512(dst, a, b []int32)
+TEXT ·addAVX512(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DX
+    MOVQ dst_len+8(FP), CX
+    MOVQ a_base+24(FP), SI
+    MOVQ b_base+48(FP), DI
+
+    MOVQ CX, AX
+    SHRQ $4, AX
+    JZ   add_512_block8
+
+add_512_loop16:
+    VMOVDQU32 (SI), Z0
+    VMOVDQU32 (DI), Z1
+    VPADDD    Z1, Z0, Z2
+    VMOVDQU32 Z2, (DX)
+    ADDQ $64, SI
+    ADDQ $64, DI
+    ADDQ $64, DX
+    DECQ AX
+    JNZ  add_512_loop16
+
+add_512_block8:
+    TESTQ $8, CX
+    JZ   add_512_remainder
+    VMOVDQU (SI), Y0
+    VMOVDQU (DI), Y1
+    VPADDD    Y1, Y0, Y2
+    VMOVDQU Y2, (DX)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    ADDQ $32, DX
+
+add_512_remainder:
+    ANDQ $7, CX
+    JZ   add_512_done
+
+add_512_scalar:
+    MOVL (SI), AX
+    ADDL (DI), AX
+    MOVL AX, (DX)
+    ADDQ $4, SI
+    ADDQ $4, DI
+    ADDQ $4, DX
+    DECQ CX
+    JNZ  add_512_scalar
+
+add_512_done:
+    VZEROUPPER
+    RET
+
+// func subAVX512(dst, a, b []int32)
+TEXT ·subAVX512(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DX
+    MOVQ dst_len+8(FP), CX
+    MOVQ a_base+24(FP), SI
+    MOVQ b_base+48(FP), DI
+
+    MOVQ CX, AX
+    SHRQ $4, AX
+    JZ   sub_512_block8
+
+sub_512_loop16:
+    VMOVDQU32 (SI), Z0
+    VMOVDQU32 (DI), Z1
+    VPSUBD    Z1, Z0, Z2
+    VMOVDQU32 Z2, (DX)
+    ADDQ $64, SI
+    ADDQ $64, DI
+    ADDQ $64, DX
+    DECQ AX
+    JNZ  sub_512_loop16
+
+sub_512_block8:
+    TESTQ $8, CX
+    JZ   sub_512_remainder
+    VMOVDQU (SI), Y0
+    VMOVDQU (DI), Y1
+    VPSUBD    Y1, Y0, Y2
+    VMOVDQU Y2, (DX)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    ADDQ $32, DX
+
+sub_512_remainder:
+    ANDQ $7, CX
+    JZ   sub_512_done
+
+sub_512_scalar:
+    MOVL (SI), AX
+    SUBL (DI), AX
+    MOVL AX, (DX)
+    ADDQ $4, SI
+    ADDQ $4, DI
+    ADDQ $4, DX
+    DECQ CX
+    JNZ  sub_512_scalar
+
+sub_512_done:
+    VZEROUPPER
+    RET
+
+// func mulByScalarAVX512(a []int32, s int16)
+TEXT ·mulByScalarAVX512(SB), NOSPLIT, $0-26
+    MOVQ    a_base+0(FP), SI
+    MOVQ    a_len+8(FP), CX
+    MOVWQSX s+24(FP), BX          // BX = int64(s), sign-extended int16 (also tail s)
+    VPBROADCASTD BX, Z3           // int32(s) in all 16 lanes
+
+    MOVL  $0xAAAA, AX
+    KMOVW AX, K1                  // odd int32 lanes
+
+    MOVQ CX, AX
+    SHRQ $4, AX
+    JZ   mulbyscalar_512_block8
+
+mulbyscalar_512_loop16:
+    VMOVDQU32 (SI), Z0            // a[i..i+15]
+    VPMULDQ   Z3, Z0, Z4          // even-lane products (8x int64)
+    VPSRLQ    $32, Z0, Z1         // slide odd lanes into even positions
+    VPMULDQ   Z3, Z1, Z5          // odd-lane products
+    VPSRLQ    $15, Z4, Z4         // low 32 of each int64 = even result lanes
+    VPSRLQ    $15, Z5, Z5
+    VPSLLQ    $32, Z5, Z5         // lift odd results to the odd positions
+    VPBLENDMD Z5, Z4, K1, Z2      // odd <- Z5, even <- Z4
+    VMOVDQU32 Z2, (SI)            // in place
+    ADDQ $64, SI
+    DECQ AX
+    JNZ  mulbyscalar_512_loop16
+
+mulbyscalar_512_block8:
+    TESTQ $8, CX
+    JZ   mulbyscalar_512_tail
+    VMOVDQU  (SI), Y0
+    VPMULDQ  Y3, Y0, Y4           // Y3 is the low half of the Z3 broadcast
+    VPSRLQ   $32, Y0, Y1
+    VPMULDQ  Y3, Y1, Y5
+    VPSRLQ   $15, Y4, Y4
+    VPSRLQ   $15, Y5, Y5
+    VPSLLQ   $32, Y5, Y5
+    VPBLENDD $0xAA, Y5, Y4, Y2
+    VMOVDQU  Y2, (SI)
+    ADDQ $32, SI
+
+mulbyscalar_512_tail:
+    ANDQ $7, CX
+    JZ   mulbyscalar_512_done
+
+mulbyscalar_512_scalar:
+    MOVLQSX (SI), AX              // int64(a[i])
+    IMULQ   BX, AX                // s * a[i] (64-bit, |p| <= 2^46)
+    SARQ    $15, AX               // arithmetic shift right 15
+    MOVL    AX, (SI)              // low 32 bits: wraps like int32()
+    ADDQ $4, SI
+    DECQ CX
+    JNZ  mulbyscalar_512_scalar
+
+mulbyscalar_512_done:
+    VZEROUPPER
+    RET
+
+// func mulAVX512(dst, a []int32, tw []int16)
+TEXT ·mulAVX512(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DX
+    MOVQ dst_len+8(FP), CX        // n (int32 count, even)
+    MOVQ a_base+24(FP), SI
+    MOVQ tw_base+48(FP), DI
+
+    MOVL  $0xAAAA, AX
+    KMOVW AX, K1                  // odd (imaginary) int32 lanes
+
+    MOVQ CX, AX
+    SHRQ $4, AX                   // AX = n/16 = 8-complex blocks
+    JZ   mul_512_block8
+
+mul_512_loop16:
+    VMOVDQU32 (SI), Z0            // A = [ar0,ai0,...,ar7,ai7]
+    VPMOVSXWD (DI), Z1            // T = [br0,bi0,...] sign-extended int16 -> int32
+    VPSRLQ    $32, Z0, Z2         // As: even 32-lanes = ai
+    VPSRLQ    $32, Z1, Z3         // Ts: even 32-lanes = bi
+    VPMULDQ   Z1, Z0, Z4          // prr = ar*br (8x int64)
+    VPMULDQ   Z3, Z2, Z5          // pii = ai*bi
+    VPMULDQ   Z3, Z0, Z6          // pri = ar*bi
+    VPMULDQ   Z1, Z2, Z7          // pir = ai*br
+    VPSRLQ    $15, Z4, Z4         // Q15 truncate: low 32 of each int64 (even lanes)
+    VPSRLQ    $15, Z5, Z5
+    VPSRLQ    $15, Z6, Z6
+    VPSRLQ    $15, Z7, Z7
+    VPSUBD    Z5, Z4, Z8          // re = prr - pii
+    VPADDD    Z7, Z6, Z9          // im = pri + pir
+    VPSLLQ    $32, Z9, Z9         // move imag results to the odd lanes
+    VPBLENDMD Z9, Z8, K1, Z8      // even <- re, odd <- im: interleaved
+    VMOVDQU32 Z8, (DX)
+    ADDQ $64, SI
+    ADDQ $32, DI
+    ADDQ $64, DX
+    DECQ AX
+    JNZ  mul_512_loop16
+
+mul_512_block8:
+    TESTQ $8, CX                  // 4 more complex?
+    JZ   mul_512_tail
+    VMOVDQU   (SI), Y0
+    VPMOVSXWD (DI), Y1
+    VPSRLQ    $32, Y0, Y2
+    VPSRLQ    $32, Y1, Y3
+    VPMULDQ   Y1, Y0, Y4
+    VPMULDQ   Y3, Y2, Y5
+    VPMULDQ   Y3, Y0, Y6
+    VPMULDQ   Y1, Y2, Y7
+    VPSRLQ    $15, Y4, Y4
+    VPSRLQ    $15, Y5, Y5
+    VPSRLQ    $15, Y6, Y6
+    VPSRLQ    $15, Y7, Y7
+    VPSUBD    Y5, Y4, Y8
+    VPADDD    Y7, Y6, Y9
+    VPSLLQ    $32, Y9, Y9
+    VPBLENDD  $0xAA, Y9, Y8, Y8
+    VMOVDQU   Y8, (DX)
+    ADDQ $32, SI
+    ADDQ $16, DI
+    ADDQ $32, DX
+
+mul_512_tail:
+    MOVQ CX, BX
+    ANDQ $7, BX                   // leftover int32 (even: 0,2,4,6)
+    SHRQ $1, BX                   // leftover complex count
+    JZ   mul_512_done
+
+mul_512_scalar:
+    MOVLQSX (SI), AX              // ar
+    MOVLQSX 4(SI), R8             // ai
+    MOVWQSX (DI), R9              // br
+    MOVWQSX 2(DI), R10            // bi
+    MOVQ  AX, R11
+    IMULQ R9, R11                 // ar*br
+    SARQ  $15, R11                // prr
+    MOVQ  R8, R12
+    IMULQ R10, R12                // ai*bi
+    SARQ  $15, R12                // pii
+    SUBL  R12, R11                // re = prr - pii (int32 wrap on low 32)
+    MOVL  R11, (DX)               // dst[2k] = re
+    MOVQ  AX, R11
+    IMULQ R10, R11                // ar*bi
+    SARQ  $15, R11                // pri
+    IMULQ R9, R8                  // ai*br
+    SARQ  $15, R8                 // pir
+    ADDL  R8, R11                 // im = pri + pir (int32 wrap on low 32)
+    MOVL  R11, 4(DX)              // dst[2k+1] = im
+    ADDQ $8, SI
+    ADDQ $4, DI
+    ADDQ $8, DX
+    DECQ BX
+    JNZ  mul_512_scalar
+
+mul_512_done:
+    VZEROUPPER
+    RET
+
+// func mulConjAVX512(dst, a []int32, tw []int16)
+TEXT ·mulConjAVX512(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DX
+    MOVQ dst_len+8(FP), CX        // n (int32 count, even)
+    MOVQ a_base+24(FP), SI
+    MOVQ tw_base+48(FP), DI
+
+    MOVL  $0xAAAA, AX
+    KMOVW AX, K1                  // odd (imaginary) int32 lanes
+
+    MOVQ CX, AX
+    SHRQ $4, AX                   // AX = n/16 = 8-complex blocks
+    JZ   mulconj_512_block8
+
+mulconj_512_loop16:
+    VMOVDQU32 (SI), Z0            // A = [ar0,ai0,...,ar7,ai7]
+    VPMOVSXWD (DI), Z1            // T = [br0,bi0,...] sign-extended int16 -> int32
+    VPSRLQ    $32, Z0, Z2         // As: even 32-lanes = ai
+    VPSRLQ    $32, Z1, Z3         // Ts: even 32-lanes = bi
+    VPMULDQ   Z1, Z0, Z4          // prr = ar*br (8x int64)
+    VPMULDQ   Z3, Z2, Z5          // pii = ai*bi
+    VPMULDQ   Z3, Z0, Z6          // pri = ar*bi
+    VPMULDQ   Z1, Z2, Z7          // pir = ai*br
+    VPSRLQ    $15, Z4, Z4         // Q15 truncate: low 32 of each int64 (even lanes)
+    VPSRLQ    $15, Z5, Z5
+    VPSRLQ    $15, Z6, Z6
+    VPSRLQ    $15, Z7, Z7
+    VPADDD    Z5, Z4, Z8          // re = prr + pii
+    VPSUBD    Z6, Z7, Z9          // im = pir - pri
+    VPSLLQ    $32, Z9, Z9         // move imag results to the odd lanes
+    VPBLENDMD Z9, Z8, K1, Z8      // even <- re, odd <- im: interleaved
+    VMOVDQU32 Z8, (DX)
+    ADDQ $64, SI
+    ADDQ $32, DI
+    ADDQ $64, DX
+    DECQ AX
+    JNZ  mulconj_512_loop16
+
+mulconj_512_block8:
+    TESTQ $8, CX                  // 4 more complex?
+    JZ   mulconj_512_tail
+    VMOVDQU   (SI), Y0
+    VPMOVSXWD (DI), Y1
+    VPSRLQ    $32, Y0, Y2
+    VPSRLQ    $32, Y1, Y3
+    VPMULDQ   Y1, Y0, Y4
+    VPMULDQ   Y3, Y2, Y5
+    VPMULDQ   Y3, Y0, Y6
+    VPMULDQ   Y1, Y2, Y7
+    VPSRLQ    $15, Y4, Y4
+    VPSRLQ    $15, Y5, Y5
+    VPSRLQ    $15, Y6, Y6
+    VPSRLQ    $15, Y7, Y7
+    VPADDD    Y5, Y4, Y8
+    VPSUBD    Y6, Y7, Y9
+    VPSLLQ    $32, Y9, Y9
+    VPBLENDD  $0xAA, Y9, Y8, Y8
+    VMOVDQU   Y8, (DX)
+    ADDQ $32, SI
+    ADDQ $16, DI
+    ADDQ $32, DX
+
+mulconj_512_tail:
+    MOVQ CX, BX
+    ANDQ $7, BX                   // leftover int32 (even: 0,2,4,6)
+    SHRQ $1, BX                   // leftover complex count
+    JZ   mulconj_512_done
+
+mulconj_512_scalar:
+    MOVLQSX (SI), AX              // ar
+    MOVLQSX 4(SI), R8             // ai
+    MOVWQSX (DI), R9              // br
+    MOVWQSX 2(DI), R10            // bi
+    MOVQ  AX, R11
+    IMULQ R9, R11                 // ar*br
+    SARQ  $15, R11                // prr
+    MOVQ  R8, R12
+    IMULQ R10, R12                // ai*bi
+    SARQ  $15, R12                // pii
+    ADDL  R12, R11                // re = prr + pii (int32 wrap on low 32)
+    MOVL  R11, (DX)               // dst[2k] = re
+    MOVQ  AX, R11
+    IMULQ R10, R11                // ar*bi
+    SARQ  $15, R11                // pri
+    IMULQ R9, R8                  // ai*br
+    SARQ  $15, R8                 // pir
+    SUBL  R11, R8                 // im = pir - pri (int32 wrap on low 32)
+    MOVL  R8, 4(DX)               // dst[2k+1] = im
+    ADDQ $8, SI
+    ADDQ $4, DI
+    ADDQ $8, DX
+    DECQ BX
+    JNZ  mulconj_512_scalar
+
+mulconj_512_done:
+    VZEROUPPER
+    RET
@@ -104,7 +104,7 @@ func TestAddSubAVX2_ParityWithGo(t *testing.T) {
 // TestMulAVX2_OverRead catches a kernel that reads past n or writes past n. The
 // in-range body is tame (values in -2..2, so every product is small), while the
 // slack past n in a and tw is poisoned with the absolute extremes, and the slack in
-// dst carries a sentinel. Backings are n + one full 8-int32 (8-int16) block, so a
+// dst carries a sentinel. Backings are n + one full block of the widest kernel, so a
 // kernel that reads a stray block lands in the poison and its in-range results flip
 // away from the tame oracle, and a kernel that writes a stray block overwrites the
 // dst sentinel. A correct kernel stops exactly at n on both sides.
@@ -118,9 +118,9 @@ func TestMulAVX2_OverRead(t *testing.T) {
 
 func testMulOverRead(t *testing.T, name string, conj bool, kernel func(dst, a []int32, tw []int16)) {
 	t.Helper()
-	const slack = 8 // one full 4-complex block in int32 (and in int16) units
+	const slack = 16 // one full AVX-512 8-complex block in int32 (and in int16) units
 	const sentinel = int32(0x5EED1234)
-	for _, n := range []int{8, 10, 12, 14, 18, 22, 30} {
+	for _, n := range []int{8, 10, 12, 14, 18, 22, 30, 40, 46} {
 		ab := make([]int32, n+slack)
 		twb := make([]int16, n+slack)
 		dstb := make([]int32, n+slack)
@@ -202,6 +202,9 @@ func TestDispatchReachesSIMD_AVX2(t *testing.T) {
 	if hasAVX2 != cpu.X86.AVX2 {
 		t.Fatalf("hasAVX2 = %v but cpu.X86.AVX2 = %v: dispatch flag is not wired to CPU detection", hasAVX2, cpu.X86.AVX2)
 	}
+	if want := cpu.X86.AVX512F && cpu.X86.AVX512VL; hasAVX512 != want {
+		t.Fatalf("hasAVX512 = %v but cpu.X86 AVX-512F+VL = %v: dispatch flag is not wired to CPU detection", hasAVX512, want)
+	}
 	for name, th := range map[string]int{
 		"minAVX2Add": minAVX2Add, "minAVX2Sub": minAVX2Sub,
 		"minAVX2MulByScalar": minAVX2MulByScalar, "minAVX2Mul": minAVX2Mul,
@@ -212,3 +215,91 @@ func TestDispatchReachesSIMD_AVX2(t *testing.T) {
 		}
 	}
 }
+
+// avx512Lengths adds to kernelEvenLengths the sizes that take the 8-int32
+// remainder block after one or more 16-int32 AVX-512 blocks, with and without a
+// scalar tail.
+var avx512Lengths = append([]int{40, 42, 56, 62, 200, 1030}, kernelEvenLengths...)
+
+// TestAVX512_ParityWithGo drives every AVX-512 kernel directly against the Go
+// reference, with the int32 and int16 extremes planted at both ends.
+func TestAVX512_ParityWithGo(t *testing.T) {
+	if !cpu.X86.AVX512F || !cpu.X86.AVX512VL {
+		t.Skip("AVX-512 not available")
+	}
+	for _, n := range avx512Lengths {
+		a, b := genI32(n, 111), genI32(n, 112)
+		tw := genI16(n, 113)
+		plantExtremes(a, tw)
+		got := make([]int32, n)
+		ref := make([]int32, n)
+
+		mulAVX512(got, a, tw)
+		checkMul(t, "mulAVX512", false, got, a, tw)
+		mulConjAVX512(got, a, tw)
+		checkMul(t, "mulConjAVX512", true, got, a, tw)
+
+		addAVX512(got, a, b)
+		addGo(ref, a, b)
+		for i := range got {
+			if got[i] != ref[i] {
+				t.Fatalf("addAVX512 n=%d at %d: got %d want %d", n, i, got[i], ref[i])
+			}
+		}
+		subAVX512(got, a, b)
+		subGo(ref, a, b)
+		for i := range got {
+			if got[i] != ref[i] {
+				t.Fatalf("subAVX512 n=%d at %d: got %d want %d", n, i, got[i], ref[i])
+			}
+		}
+
+		for _, s := range []int16{math.MinInt16, math.MaxInt16, -1, 0x4000} {
+			copy(got, a)
+			mulByScalarAVX512(got, s)
+			for i := range got {
+				if want := sMulBig(a[i], s); got[i] != want {
+					t.Fatalf("mulByScalarAVX512 n=%d s=%d at %d: got %d want %d", n, s, i, got[i], want)
+				}
+			}
+		}
+	}
+}
+
+func TestMulAVX512_OverRead(t *testing.T) {
+	if !cpu.X86.AVX512F || !cpu.X86.AVX512VL {
+		t.Skip("AVX-512 not available")
+	}
+	testMulOverRead(t, "mulAVX512", false, mulAVX512)
+	testMulOverRead(t, "mulConjAVX512", true, mulConjAVX512)
+
+	const n = 26 // one 16-block, one 8-block, a 2-lane tail
+	a := make([]int32, n+16)
+	for i := range a {
+		a[i] = math.MaxInt32
+	}
+	mulByScalarAVX512(a[:n], 0x1234)
+	for i := n; i < len(a); i++ {
+		if a[i] != math.MaxInt32 {
+			t.Errorf("mulByScalarAVX512 wrote past end at a[%d] = %d", i, a[i])
+		}
+	}
+}
+
+func TestMulAVX512_AllocFree(t *testing.T) {
+	if !cpu.X86.AVX512F || !cpu.X86.AVX512VL {
+		t.Skip("AVX-512 not available")
+	}
+	const n = 1024
+	a, b, dst := make([]int32, n), make([]int32, n), make([]int32, n)
+	tw := make([]int16, n)
+	if got := testing.AllocsPerRun(100, func() {
+		mulAVX512(dst, a, tw)
+		mulConjAVX512(dst, a, tw)
+		mulByScalarAVX512(a, 0x1234)
+		addAVX512(dst, a, b)
+		subAVX512(dst, a, b)
+	}); got != 0 {
+		t.Errorf("AVX-512 kernels allocated %v times per run, want 0", got)
+	}
+}
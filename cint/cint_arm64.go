@@ -14,6 +14,12 @@ import "github.com/tphakala/simd/cpu"
 // zero-allocation.
 var hasNEON = cpu.ARM64.NEON
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+}
+
 // Tier thresholds: one vector block each. Add, Sub and MulByScalar step 4 int32
 // (one .4S register) per block, so they gate at 4. Mul and MulConj deinterleave 4
 // complex (8 int32) per block via LD2/ST2, so they gate at 8. Every kernel is
@@ -12,3 +12,6 @@ func mulCint(dst, a []int32, tw []int16) { mulGo(dst, a, tw) }
 func mulConjCint(dst, a []int32, tw []int16) {
 	mulConjGo(dst, a, tw)
 }
+
+// bindKernels has no flags to re-read: every operation runs pure Go here.
+func bindKernels() {}
@@ -0,0 +1,516 @@
+package cint
+
+import "errors"
+
+// ErrFFTSize is returned by NewFFTPlan when n is not a size kiss_fft supports.
+var ErrFFTSize = errors.New("cint: FFT size must be in [2, 65536) with no prime factor above 5")
+
+// FFTPlan is a reusable fixed-point n-point complex FFT over interleaved int32
+// data ([r0, i0, r1, i1, ...], 2n lanes) that reproduces the libopus kiss_fft
+// (celt/kiss_fft.c, FIXED_POINT) bit for bit: the same factorization, digit
+// reversal, Q15 twiddles from celt_cos_norm, butterfly constants and operation
+// order, with the butterflies running on the package's Mul, MulByScalar, Add and
+// Sub kernels. Forward is opus_fft and Inverse is opus_ifft.
+//
+// Scaling follows libopus: the whole 1/n of the forward transform is applied to
+// the input during the digit-reversal scatter (a Q15 reciprocal and shift, with
+// 32767 for powers of two), and no stage rescales, so no
+// stage of Forward can overflow for any int32 input. The inverse is unscaled, so
+// its input needs log2(n) bits of headroom. This is the libopus 1.2+ layout; the
+// original kissfft's per-butterfly C_FIXDIV scaling is not reproduced.
+//
+// A plan holds per-transform scratch, so its methods are NOT safe for concurrent
+// use on the same plan; use one plan per goroutine. Distinct plans share no
+// mutable state.
+type FFTPlan struct {
+	st *kissFFT
+}
+
+// NewFFTPlan builds a reusable plan for n-point fixed-point FFTs. n must be at
+// least 2, below 65536 and have no prime factor above 5 (kiss_fft's radix 2, 3,
+// 4 and 5 stages), which covers every Opus CELT size; otherwise ErrFFTSize is
+// returned.
+func NewFFTPlan(n int) (*FFTPlan, error) {
+	st := newKissFFT(n)
+	if st == nil {
+		return nil, ErrFFTSize
+	}
+	return &FFTPlan{st: st}, nil
+}
+
+// Len returns the transform size the plan was built for, in complex samples.
+func (p *FFTPlan) Len() int { return p.st.n }
+
+// Twiddles returns the plan's Q15 twiddle table exp(-2*pi*i*k/n), k in [0, n),
+// interleaved (kiss_fft's st->twiddles). The slice is the plan's own storage
+// and must not be modified.
+func (p *FFTPlan) Twiddles() []int16 { return p.st.twiddles }
+
+// Forward computes the scaled forward DFT of src into dst, as opus_fft does:
+//
+//	dst[k] = 1/n * sum_{j=0}^{n-1} src[j] * exp(-2*pi*i*j*k/n)
+//
+// up to the fixed-point rounding of the input scale, twiddles and butterfly
+// constants. It reads src[:2n] and writes dst[:2n], and is a no-op when either
+// slice is shorter. dst must not overlap src. Allocation-free.
+func (p *FFTPlan) Forward(dst, src []int32) {
+	w := 2 * p.st.n
+	if len(dst) < w || len(src) < w {
+		return
+	}
+	p.st.forward(dst[:w], src[:w])
+}
+
+// Inverse computes the unscaled inverse DFT of src into dst, as opus_ifft does
+// (conjugate, forward butterflies, conjugate):
+//
+//	dst[j] = sum_{k=0}^{n-1} src[k] * exp(2*pi*i*j*k/n)
+//
+// so Inverse(Forward(x)) recovers x up to rounding. Length handling and aliasing
+// follow Forward. Allocation-free.
+func (p *FFTPlan) Inverse(dst, src []int32) {
+	w := 2 * p.st.n
+	if len(dst) < w || len(src) < w {
+		return
+	}
+	p.st.inverse(dst[:w], src[:w])
+}
+
+// Fixed-point mixed-radix FFT core, a structural port of the libopus kiss_fft
+// driver (celt/kiss_fft.c built with FIXED_POINT): the same factorization (radix
+// 4 first, then 2, 3 and 5, with the single radix-2 stage placed just after a
+// radix-4 stage and the order reversed), the same digit-reversal table, the same
+// Q15 twiddles from celt_cos_norm, the same fixed radix-3/5 constants, and the
+// same per-butterfly operation order. The input of the forward transform is
+// scaled by 1/n during the digit-reversal scatter, as opus_fft does, so no stage
+// can overflow; the butterflies themselves never scale.
+//
+// Integer arithmetic is exact, so the butterflies are free to run their twiddle
+// multiplies and add/subtract passes as whole-vector Mul, MulByScalar, Add and
+// Sub calls over the m columns of each group (with per-stage contiguous twiddle
+// tables) and still match the scalar C driver bit for bit.
+
+// kissMaxRadix is the largest radix the libopus driver implements.
+const kissMaxRadix = 5
+
+// Radices of the kiss_fft factorization.
+const (
+	kissRadix2 = 2
+	kissRadix3 = 3
+	kissRadix4 = 4
+	kissRadix5 = 5
+)
+
+// Fixed-point constants of the libopus butterflies: the radix-2 1/sqrt(2)
+// (QCONST16(0.7071067812, 15)), the radix-3 -sin(2*pi/3), and the radix-5
+// exp(-2*pi*i/5) and exp(-4*pi*i/5), all Q15.
+const (
+	kissTw2    int16 = 23170
+	kissEpi3I  int16 = -28378
+	kissYaR    int16 = 10126
+	kissYaI    int16 = -31164
+	kissYbR    int16 = -26510
+	kissYbI    int16 = -19261
+	kissQ15One int16 = 32767
+)
+
+// kissStage is one radix-p pass of the driver, in application order.
+type kissStage struct {
+	p, m   int // radix and butterfly span (columns per group)
+	groups int // number of butterfly groups (kiss_fft's fstride[i])
+	stride int // complex distance between groups (p*m)
+
+	// tw[q-1] holds twiddles[q*j*fstride] for j in [0, m), interleaved Q15,
+	// contiguous so each twiddle pass is one Mul.
+	tw [kissMaxRadix - 1][]int16
+}
+
+// kissFFT is a resident fixed-point FFT plan of size n.
+type kissFFT struct {
+	n       int
+	factors []int // (p, m) pairs in kiss_fft order
+	bitrev  []int // input index -> output index of the digit-reversal scatter
+
+	twiddles []int16 // exp(-2*pi*i*k/n), k in [0, n), interleaved Q15
+
+	scale      int16 // forward input scale, with scaleShift
+	scaleShift int
+
+	stages  []kissStage
+	scratch [4][]int32
+}
+
+// kissFactor reproduces kf_factor: it returns the (p, m) pairs of n, or nil when
+// n has a prime factor above 5.
+func kissFactor(n int) []int {
+	var fac []int
+	p, rem := kissRadix4, n
+	for rem > 1 {
+		for rem%p != 0 {
+			switch p {
+			case kissRadix4:
+				p = kissRadix2
+			case kissRadix2:
+				p = kissRadix3
+			default:
+				p += 2
+			}
+			if p*p > rem {
+				p = rem
+			}
+		}
+		rem /= p
+		if p > kissMaxRadix {
+			return nil
+		}
+		fac = append(fac, p)
+		if p == kissRadix2 && len(fac) > 2 {
+			fac[len(fac)-1] = kissRadix4
+			fac[1] = kissRadix2
+		}
+	}
+	// Reverse so the radix-4 stage runs first (the degenerate m == 1 case), then
+	// record each stage's span.
+	for i, j := 0, len(fac)-1; i < j; i, j = i+1, j-1 {
+		fac[i], fac[j] = fac[j], fac[i]
+	}
+	out := make([]int, 0, 2*len(fac))
+	for _, f := range fac {
+		n /= f
+		out = append(out, f, n)
+	}
+	return out
+}
+
+// kissBitrev reproduces compute_bitrev_table.
+func kissBitrev(bitrev []int, fout, f, fstride int, factors []int) {
+	p, m := factors[0], factors[1]
+	for j := range p {
+		if m == 1 {
+			bitrev[f] = fout + j
+		} else {
+			kissBitrev(bitrev, fout, f, fstride*p, factors[2:])
+			fout += m
+		}
+		f += fstride
+	}
+}
+
+// kissMulP15 is MULT16_16_P15: a rounded Q15 product of two int16 values.
+func kissMulP15(a, b int32) int32 {
+	const round = 1 << (sMulShift - 1)
+	return (int32(int16(a))*int32(int16(b)) + round) >> sMulShift
+}
+
+// kissCosPi2 is _celt_cos_pi_2, the polynomial cos(pi/2 * x/32768) for x in
+// [0, 32768).
+func kissCosPi2(x int32) int16 {
+	const (
+		l1 = 32767
+		l2 = -7651
+		l3 = 8277
+		l4 = -626
+	)
+	x2 := kissMulP15(x, x)
+	v := (l1 - x2) + kissMulP15(x2, l2+kissMulP15(x2, l3+kissMulP15(l4, x2)))
+	return int16(1 + min(int32(kissQ15One)-1, v))
+}
+
+// kissCosNorm is celt_cos_norm: cos(pi * x/65536) in Q15 for a phase x whose full
+// turn is 2^17.
+func kissCosNorm(x int32) int16 {
+	const (
+		quarter = 1 << 15
+		half    = 1 << 16
+		turn    = 1 << 17
+	)
+	x &= turn - 1
+	if x > half {
+		x = turn - x
+	}
+	if x&(quarter-1) != 0 {
+		if x < quarter {
+			return kissCosPi2(x)
+		}
+		return -kissCosPi2(half - x)
+	}
+	switch {
+	case x&(half-1) != 0:
+		return 0
+	case x != 0:
+		return -kissQ15One
+	default:
+		return kissQ15One
+	}
+}
+
+// kissMaxSize bounds n: the non-power-of-two forward scale is shifted by
+// 15 - log2(n), so log2(n) may not exceed 15.
+const kissMaxSize = 1 << 16
+
+// newKissFFT builds the plan for an n-point transform, 2 <= n < 65536 with no
+// prime factor above 5; it returns nil otherwise.
+func newKissFFT(n int) *kissFFT {
+	if n < 2 || n >= kissMaxSize {
+		return nil
+	}
+	factors := kissFactor(n)
+	if factors == nil {
+		return nil
+	}
+	st := &kissFFT{
+		n:        n,
+		factors:  factors,
+		bitrev:   make([]int, n),
+		twiddles: make([]int16, 2*n),
+	}
+	for i := range st.scratch {
+		st.scratch[i] = make([]int32, 2*n)
+	}
+
+	// compute_twiddles: kf_cexp2 of the phase -i*2^17/n (C division truncates).
+	const quarterTurn = 1 << 15
+	for i := range n {
+		phase := int32(-i * (1 << 17) / n)
+		st.twiddles[2*i] = kissCosNorm(phase)
+		st.twiddles[2*i+1] = kissCosNorm(phase - quarterTurn)
+	}
+
+	// Forward scale 1/n as (scale, scaleShift); QCONST16(1, 15) = 32767 for powers of
+	// two.
+	shift := 0
+	for 1<<(shift+1) <= n {
+		shift++
+	}
+	st.scaleShift = shift
+	if n == 1<<shift {
+		st.scale = kissQ15One
+	} else {
+		const one30 = 1 << 30
+		st.scale = int16((one30 + n/2) / n >> (sMulShift - shift))
+	}
+
+	kissBitrev(st.bitrev, 0, 0, 1, factors)
+
+	// Stage i (kiss order) has fstride[i] groups of stride factors[2i-1]; the
+	// driver applies them from the last to the first.
+	stages := len(factors) / 2
+	fstride := make([]int, stages+1)
+	fstride[0] = 1
+	for i := range stages {
+		fstride[i+1] = fstride[i] * factors[2*i]
+	}
+	for i := stages - 1; i >= 0; i-- {
+		p, m := factors[2*i], factors[2*i+1]
+		s := kissStage{p: p, m: m, groups: fstride[i], stride: p * m}
+		if p != kissRadix2 {
+			for q := 1; q < p; q++ {
+				tw := make([]int16, 2*m)
+				for j := range m {
+					k := q * j * fstride[i]
+					tw[2*j], tw[2*j+1] = st.twiddles[2*k], st.twiddles[2*k+1]
+				}
+				s.tw[q-1] = tw
+			}
+		}
+		st.stages = append(st.stages, s)
+	}
+	return st
+}
+
+// forward is opus_fft: fout = FFT(fin)/n, both interleaved with 2n lanes. fout
+// must not overlap fin.
+func (st *kissFFT) forward(fout, fin []int32) {
+	shift := st.scaleShift - 1
+	scale := int64(st.scale)
+	const q16 = 16
+	for i, r := range st.bitrev {
+		fout[2*r] = int32(scale*int64(fin[2*i])>>q16) >> shift
+		fout[2*r+1] = int32(scale*int64(fin[2*i+1])>>q16) >> shift
+	}
+	st.impl(fout)
+}
+
+// unscaled is the forward transform without the 1/n scale (the digit-reversal
+// scatter followed by opus_fft_impl, as clt_mdct_backward runs it). fout must not
+// overlap fin.
+func (st *kissFFT) unscaled(fout, fin []int32) {
+	for i, r := range st.bitrev {
+		fout[2*r], fout[2*r+1] = fin[2*i], fin[2*i+1]
+	}
+	st.impl(fout)
+}
+
+// inverse is opus_ifft: the digit-reversal scatter of the conjugated input, the
+// forward butterflies, and a conjugated output, unscaled. fout must not overlap
+// fin.
+func (st *kissFFT) inverse(fout, fin []int32) {
+	for i, r := range st.bitrev {
+		fout[2*r], fout[2*r+1] = fin[2*i], -fin[2*i+1]
+	}
+	st.impl(fout)
+	for j := 1; j < len(fout); j += 2 {
+		fout[j] = -fout[j]
+	}
+}
+
+// impl is opus_fft_impl: the butterfly stages over digit-reversed data, in place.
+func (st *kissFFT) impl(fout []int32) {
+	for i := range st.stages {
+		s := &st.stages[i]
+		switch s.p {
+		case kissRadix2:
+			st.bfly2(fout, s)
+		case kissRadix4:
+			st.bfly4(fout, s)
+		case kissRadix3:
+			st.bfly3(fout, s)
+		case kissRadix5:
+			st.bfly5(fout, s)
+		}
+	}
+}
+
+// bfly2 is kf_bfly2: the degenerate m == 1 butterfly (libopus custom modes), or
+// the m == 4 butterfly that follows a radix-4 stage, with its twiddles 1,
+// (1-i)/sqrt(2), -i and -(1+i)/sqrt(2) folded in.
+func (st *kissFFT) bfly2(fout []int32, s *kissStage) {
+	if s.m == 1 {
+		for g := range s.groups {
+			f := fout[4*g : 4*g+4]
+			tr, ti := f[2], f[3]
+			f[2], f[3] = f[0]-tr, f[1]-ti
+			f[0], f[1] = f[0]+tr, f[1]+ti
+		}
+		return
+	}
+	const span = 8 // 2*m lanes with m == 4
+	for g := range s.groups {
+		f := fout[2*span*g : 2*span*(g+1)]
+		f1 := f[span:]
+		// Column 0: twiddle 1.
+		tr, ti := f1[0], f1[1]
+		f1[0], f1[1] = f[0]-tr, f[1]-ti
+		f[0], f[1] = f[0]+tr, f[1]+ti
+		// Column 1: twiddle (1-i)/sqrt(2).
+		tr = sMul(f1[2]+f1[3], kissTw2)
+		ti = sMul(f1[3]-f1[2], kissTw2)
+		f1[2], f1[3] = f[2]-tr, f[3]-ti
+		f[2], f[3] = f[2]+tr, f[3]+ti
+		// Column 2: twiddle -i.
+		tr, ti = f1[5], -f1[4]
+		f1[4], f1[5] = f[4]-tr, f[5]-ti
+		f[4], f[5] = f[4]+tr, f[5]+ti
+		// Column 3: twiddle -(1+i)/sqrt(2).
+		tr = sMul(f1[7]-f1[6], kissTw2)
+		ti = sMul(-(f1[7] + f1[6]), kissTw2)
+		f1[6], f1[7] = f[6]-tr, f[7]-ti
+		f[6], f[7] = f[6]+tr, f[7]+ti
+	}
+}
+
+// bfly4 is kf_bfly4.
+func (st *kissFFT) bfly4(fout []int32, s *kissStage) {
+	m := s.m
+	if m == 1 {
+		// Degenerate case where all the twiddles are 1.
+		for g := range s.groups {
+			f := fout[8*g : 8*g+8]
+			s0r, s0i := f[0]-f[4], f[1]-f[5]
+			f0r, f0i := f[0]+f[4], f[1]+f[5]
+			s1r, s1i := f[2]+f[6], f[3]+f[7]
+			f[4], f[5] = f0r-s1r, f0i-s1i
+			f[0], f[1] = f0r+s1r, f0i+s1i
+			s1r, s1i = f[2]-f[6], f[3]-f[7]
+			f[2], f[3] = s0r+s1i, s0i-s1r
+			f[6], f[7] = s0r-s1i, s0i+s1r
+		}
+		return
+	}
+	w := 2 * m
+	s0, s1, s2, s5 := st.scratch[0][:w], st.scratch[1][:w], st.scratch[2][:w], st.scratch[3][:w]
+	for g := range s.groups {
+		base := 2 * g * s.stride
+		f0 := fout[base : base+w]
+		f1 := fout[base+w : base+2*w]
+		f2 := fout[base+2*w : base+3*w]
+		f3 := fout[base+3*w : base+4*w]
+		mulCint(s0, f1, s.tw[0])
+		mulCint(s1, f2, s.tw[1])
+		mulCint(s2, f3, s.tw[2])
+		subCint(s5, f0, s1)
+		addCint(f0, f0, s1)
+		addCint(s1, s0, s2) // scratch[3] of kf_bfly4
+		subCint(s0, s0, s2) // scratch[4]
+		subCint(f2, f0, s1)
+		addCint(f0, f0, s1)
+		for j := 0; j < w; j += 2 {
+			f1[j], f1[j+1] = s5[j]+s0[j+1], s5[j+1]-s0[j]
+			f3[j], f3[j+1] = s5[j]-s0[j+1], s5[j+1]+s0[j]
+		}
+	}
+}
+
+// bfly3 is kf_bfly3.
+func (st *kissFFT) bfly3(fout []int32, s *kissStage) {
+	w := 2 * s.m
+	s0, s1, s3 := st.scratch[0][:w], st.scratch[1][:w], st.scratch[2][:w]
+	for g := range s.groups {
+		base := 2 * g * s.stride
+		f0 := fout[base : base+w]
+		f1 := fout[base+w : base+2*w]
+		f2 := fout[base+2*w : base+3*w]
+		mulCint(s1, f1, s.tw[0])
+		mulCint(s0, f2, s.tw[1]) // scratch[2] of kf_bfly3
+		addCint(s3, s1, s0)
+		subCint(s0, s1, s0) // scratch[0]
+		mulByScalarCint(s0, kissEpi3I)
+		for j := 0; j < w; j += 2 {
+			mr, mi := f0[j]-s3[j]>>1, f0[j+1]-s3[j+1]>>1
+			f0[j], f0[j+1] = f0[j]+s3[j], f0[j+1]+s3[j+1]
+			f2[j], f2[j+1] = mr+s0[j+1], mi-s0[j]
+			f1[j], f1[j+1] = mr-s0[j+1], mi+s0[j]
+		}
+	}
+}
+
+// bfly5 is kf_bfly5.
+func (st *kissFFT) bfly5(fout []int32, s *kissStage) {
+	w := 2 * s.m
+	s1, s2, s3, s4 := st.scratch[0][:w], st.scratch[1][:w], st.scratch[2][:w], st.scratch[3][:w]
+	for g := range s.groups {
+		base := 2 * g * s.stride
+		f0 := fout[base : base+w]
+		f1 := fout[base+w : base+2*w]
+		f2 := fout[base+2*w : base+3*w]
+		f3 := fout[base+3*w : base+4*w]
+		f4 := fout[base+4*w : base+5*w]
+		mulCint(s1, f1, s.tw[0])
+		mulCint(s2, f2, s.tw[1])
+		mulCint(s3, f3, s.tw[2])
+		mulCint(s4, f4, s.tw[3])
+		for j := 0; j < w; j += 2 {
+			z0r, z0i := f0[j], f0[j+1]
+			s7r, s7i := s1[j]+s4[j], s1[j+1]+s4[j+1]
+			s10r, s10i := s1[j]-s4[j], s1[j+1]-s4[j+1]
+			s8r, s8i := s2[j]+s3[j], s2[j+1]+s3[j+1]
+			s9r, s9i := s2[j]-s3[j], s2[j+1]-s3[j+1]
+
+			f0[j], f0[j+1] = z0r+(s7r+s8r), z0i+(s7i+s8i)
+
+			s5r := z0r + (sMul(s7r, kissYaR) + sMul(s8r, kissYbR))
+			s5i := z0i + (sMul(s7i, kissYaR) + sMul(s8i, kissYbR))
+			s6r := sMul(s10i, kissYaI) + sMul(s9i, kissYbI)
+			s6i := -(sMul(s10r, kissYaI) + sMul(s9r, kissYbI))
+			f1[j], f1[j+1] = s5r-s6r, s5i-s6i
+			f4[j], f4[j+1] = s5r+s6r, s5i+s6i
+
+			s11r := z0r + (sMul(s7r, kissYbR) + sMul(s8r, kissYaR))
+			s11i := z0i + (sMul(s7i, kissYbR) + sMul(s8i, kissYaR))
+			s12r := sMul(s9i, kissYaI) - sMul(s10i, kissYbI)
+			s12i := sMul(s10r, kissYbI) - sMul(s9r, kissYaI)
+			f2[j], f2[j+1] = s11r+s12r, s11i+s12i
+			f3[j], f3[j+1] = s11r-s12r, s11i-s12i
+		}
+	}
+}
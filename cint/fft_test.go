@@ -0,0 +1,197 @@
+package cint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// fftSizes covers every kiss_fft stage shape: radix 2 at m == 1 and m == 4,
+// radix 3 and 5 at the first and inner stages, and the Opus CELT sizes.
+var fftSizes = []int{2, 3, 4, 5, 6, 8, 10, 12, 15, 16, 24, 30, 60, 64, 120, 240, 256, 480}
+
+func TestNewFFTPlanErrors(t *testing.T) {
+	for _, n := range []int{-1, 0, 1, 7, 14, 121, 1 << 16} {
+		if _, err := NewFFTPlan(n); !errors.Is(err, ErrFFTSize) {
+			t.Errorf("NewFFTPlan(%d) error = %v, want ErrFFTSize", n, err)
+		}
+	}
+	p, err := NewFFTPlan(480)
+	if err != nil || p.Len() != 480 || len(p.Twiddles()) != 960 {
+		t.Fatalf("NewFFTPlan(480) = %v, %v", p, err)
+	}
+}
+
+// TestKissFactor pins the libopus factorizations, including the radix-2
+// placement just after a radix-4 stage.
+func TestKissFactor(t *testing.T) {
+	cases := map[int][]int{
+		2:   {2, 1},
+		4:   {4, 1},
+		8:   {2, 4, 4, 1},
+		10:  {5, 2, 2, 1},
+		32:  {4, 8, 2, 4, 4, 1},
+		60:  {5, 12, 3, 4, 4, 1},
+		120: {5, 24, 3, 8, 2, 4, 4, 1},
+		240: {5, 48, 3, 16, 4, 4, 4, 1},
+		480: {5, 96, 3, 32, 4, 8, 2, 4, 4, 1},
+	}
+	for n, want := range cases {
+		if got := kissFactor(n); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("kissFactor(%d) = %v, want %v", n, got, want)
+		}
+	}
+	for _, bad := range []int{7, 14, 22, 97} {
+		if kissFactor(bad) != nil || newKissFFT(bad) != nil {
+			t.Errorf("kissFactor(%d) accepted a prime factor above 5", bad)
+		}
+	}
+}
+
+// TestKissTwiddles checks the celt_cos_norm twiddles against exp(-2*pi*i*k/n)
+// and pins the exact table values at the axes.
+func TestKissTwiddles(t *testing.T) {
+	for _, n := range []int{8, 60, 480} {
+		st := newKissFFT(n)
+		for k := range n {
+			s, c := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+			if d := math.Hypot(float64(st.twiddles[2*k])/32768-c, float64(st.twiddles[2*k+1])/32768-s); d > 2e-4 {
+				t.Fatalf("n=%d k=%d: twiddle (%d, %d) off by %g", n, k, st.twiddles[2*k], st.twiddles[2*k+1], d)
+			}
+		}
+		if st.twiddles[0] != 32767 || st.twiddles[1] != 0 {
+			t.Errorf("n=%d: twiddle 0 = (%d, %d), want (32767, 0)", n, st.twiddles[0], st.twiddles[1])
+		}
+		if q := n / 4; st.twiddles[2*q] != 0 || st.twiddles[2*q+1] != -32767 {
+			t.Errorf("n=%d: twiddle n/4 = (%d, %d), want (0, -32767)", n, st.twiddles[2*q], st.twiddles[2*q+1])
+		}
+	}
+}
+
+// TestFFTPlanAgainstRef checks the vectorized core bit for bit against the
+// scalar kiss_fft transcription over the full int32 range (where the unscaled
+// transforms wrap identically), and the scaled forward transform's accuracy
+// against a float64 DFT scaled by 1/n.
+func TestFFTPlanAgainstRef(t *testing.T) {
+	for _, n := range fftSizes {
+		p, err := NewFFTPlan(n)
+		if err != nil {
+			t.Fatalf("NewFFTPlan(%d): %v", n, err)
+		}
+		st := p.st
+		for seed := range uint32(3) {
+			in := genI32(2*n, seed+uint32(n))
+			got := make([]int32, 2*n)
+			check := func(name string, want []int32) {
+				t.Helper()
+				for i := range got {
+					if got[i] != want[i] {
+						t.Fatalf("n=%d seed=%d: %s lane %d = %d, ref %d", n, seed, name, i, got[i], want[i])
+					}
+				}
+			}
+			p.Forward(got, in)
+			check("Forward", kissRefForward(st, in))
+			p.Inverse(got, in)
+			check("Inverse", kissRefInverse(st, in))
+			st.unscaled(got, in)
+			check("unscaled", kissRefUnscaled(st, in))
+		}
+
+		// In-range input: the scaled transform approximates DFT/n.
+		const amp = 1 << 24
+		in := mdctSignal(2*n, amp, n)
+		got := make([]int32, 2*n)
+		p.Forward(got, in)
+		for k := range n {
+			var re, im float64
+			for j := range n {
+				s, c := math.Sincos(-2 * math.Pi * float64(j*k%n) / float64(n))
+				re += float64(in[2*j])*c - float64(in[2*j+1])*s
+				im += float64(in[2*j])*s + float64(in[2*j+1])*c
+			}
+			re, im = re/float64(n), im/float64(n)
+			if d := math.Hypot(float64(got[2*k])-re, float64(got[2*k+1])-im); d > amp*1e-3 {
+				t.Fatalf("n=%d k=%d: (%d, %d) want (%.0f, %.0f)", n, k, got[2*k], got[2*k+1], re, im)
+			}
+		}
+	}
+}
+
+// TestFFTPlanRoundTrip checks that Inverse undoes the scaled Forward to within
+// the fixed-point rounding, and that an impulse spreads flat.
+func TestFFTPlanRoundTrip(t *testing.T) {
+	const amp = 1 << 24
+	for _, n := range fftSizes {
+		p, _ := NewFFTPlan(n)
+		in := mdctSignal(2*n, amp, 5)
+		spec := make([]int32, 2*n)
+		out := make([]int32, 2*n)
+		p.Forward(spec, in)
+		p.Inverse(out, spec)
+		for i := range in {
+			if d := math.Abs(float64(out[i] - in[i])); d > amp*1e-3 {
+				t.Fatalf("n=%d: lane %d = %d, want %d", n, i, out[i], in[i])
+			}
+		}
+
+		// An impulse spreads to the same value in every bin: its scaled input
+		// sample, since only one lane is nonzero.
+		clear(in)
+		in[0] = int32(n) << 10
+		p.Forward(spec, in)
+		for k := range n {
+			if spec[2*k] != spec[0] || spec[2*k+1] != 0 || math.Abs(float64(spec[0])-1024) > 1 {
+				t.Fatalf("n=%d: impulse bin %d = (%d, %d), want (~1024, 0)", n, k, spec[2*k], spec[2*k+1])
+			}
+		}
+	}
+}
+
+// TestFFTPlanGuards checks that short slices are a no-op and that only the
+// leading 2n lanes are touched.
+func TestFFTPlanGuards(t *testing.T) {
+	p, _ := NewFFTPlan(4)
+	short := []int32{1, 2, 3, 4, 5, 6, 7}
+	p.Forward(short, genI32(8, 1))
+	p.Inverse(make([]int32, 8), genI32(7, 1))
+	for i, v := range short {
+		if v != int32(i+1) {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+	long := make([]int32, 9)
+	long[8] = 42
+	p.Forward(long, genI32(9, 2))
+	p.Inverse(long, genI32(8, 2))
+	if long[8] != 42 {
+		t.Fatalf("lane past 2n modified")
+	}
+}
+
+func TestFFTPlanAllocFree(t *testing.T) {
+	p, _ := NewFFTPlan(480)
+	in := genI32(960, 3)
+	out := make([]int32, 960)
+	if a := testing.AllocsPerRun(5, func() { p.Forward(out, in) }); a != 0 {
+		t.Errorf("Forward allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { p.Inverse(out, in) }); a != 0 {
+		t.Errorf("Inverse allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkFFTPlanForward(b *testing.B) {
+	for _, n := range []int{120, 240, 480, 256} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			p, _ := NewFFTPlan(n)
+			in := genI32(2*n, 1)
+			out := make([]int32, 2*n)
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(out, in)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package cint
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Add":         addGo,
+	"Mul":         mulGo,
+	"MulByScalar": mulByScalarGo,
+	"MulConj":     mulConjGo,
+	"Sub":         subGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "cint", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
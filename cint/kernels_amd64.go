@@ -0,0 +1,33 @@
+//go:build amd64
+
+package cint
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// avx2Kernels maps each operation to its AVX2 kernel.
+var avx2Kernels = map[string]any{
+	"Add":         addAVX2,
+	"Sub":         subAVX2,
+	"Mul":         mulAVX2,
+	"MulConj":     mulConjAVX2,
+	"MulByScalar": mulByScalarAVX2,
+}
+
+// avx512Kernels maps each operation to its AVX-512 kernel.
+var avx512Kernels = map[string]any{
+	"Add":         addAVX512,
+	"Sub":         subAVX512,
+	"Mul":         mulAVX512,
+	"MulConj":     mulConjAVX512,
+	"MulByScalar": mulByScalarAVX512,
+}
+
+// kernelBinding reports the kernel op is bound to: its AVX-512 kernel when the
+// host has AVX-512F and VL, else its AVX2 kernel when the host has AVX2.
+func kernelBinding(op string) dispatch.Binding {
+	if fn, ok := avx512Kernels[op]; ok && hasAVX512 {
+		return dispatch.Bind(dispatch.AVX512, fn)
+	}
+	fn, ok := avx2Kernels[op]
+	return dispatch.When(ok && hasAVX2, dispatch.AVX2, fn)
+}
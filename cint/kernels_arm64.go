@@ -0,0 +1,21 @@
+//go:build arm64
+
+package cint
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation to its NEON kernel.
+var neonKernels = map[string]any{
+	"Add":         addNEON,
+	"Sub":         subNEON,
+	"Mul":         mulNEON,
+	"MulConj":     mulConjNEON,
+	"MulByScalar": mulByScalarNEON,
+}
+
+// kernelBinding reports the kernel op is bound to: its NEON kernel when the
+// host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
@@ -0,0 +1,229 @@
+package cint
+
+// A literal scalar transcription of the libopus fixed-point kiss_fft driver
+// (celt/kiss_fft.c, FIXED_POINT, no custom-mode restrictions): pointer-walking
+// butterflies over a complex struct, strided twiddle reads and one S_MUL at a
+// time. It shares only the plan tables (factors, bitrev, twiddles, scale) with
+// the production core, so the vectorized butterflies are checked bit for bit
+// against the C control flow rather than against themselves.
+
+type kissCpx struct{ r, i int32 }
+
+type kissTw struct{ r, i int16 }
+
+func kissCMul(a kissCpx, b kissTw) kissCpx {
+	return kissCpx{sMul(a.r, b.r) - sMul(a.i, b.i), sMul(a.r, b.i) + sMul(a.i, b.r)}
+}
+
+func kissCAdd(a, b kissCpx) kissCpx { return kissCpx{a.r + b.r, a.i + b.i} }
+func kissCSub(a, b kissCpx) kissCpx { return kissCpx{a.r - b.r, a.i - b.i} }
+
+// kissRefForward is opus_fft_c on the interleaved layout.
+func kissRefForward(st *kissFFT, fin []int32) []int32 {
+	n := st.n
+	fout := make([]kissCpx, n)
+	shift := st.scaleShift - 1
+	for i := range n {
+		x := kissCpx{fin[2*i], fin[2*i+1]}
+		fout[st.bitrev[i]] = kissCpx{
+			int32(int64(st.scale)*int64(x.r)>>16) >> shift,
+			int32(int64(st.scale)*int64(x.i)>>16) >> shift,
+		}
+	}
+	kissRefImpl(st, fout)
+	return kissFlatten(fout)
+}
+
+// kissRefUnscaled is the digit-reversal scatter followed by opus_fft_impl.
+func kissRefUnscaled(st *kissFFT, fin []int32) []int32 {
+	fout := make([]kissCpx, st.n)
+	for i := range st.n {
+		fout[st.bitrev[i]] = kissCpx{fin[2*i], fin[2*i+1]}
+	}
+	kissRefImpl(st, fout)
+	return kissFlatten(fout)
+}
+
+// kissRefInverse is opus_ifft_c: the scatter, conjugate, opus_fft_impl, conjugate.
+func kissRefInverse(st *kissFFT, fin []int32) []int32 {
+	fout := make([]kissCpx, st.n)
+	for i := range st.n {
+		fout[st.bitrev[i]] = kissCpx{fin[2*i], fin[2*i+1]}
+	}
+	for i := range fout {
+		fout[i].i = -fout[i].i
+	}
+	kissRefImpl(st, fout)
+	for i := range fout {
+		fout[i].i = -fout[i].i
+	}
+	return kissFlatten(fout)
+}
+
+func kissFlatten(c []kissCpx) []int32 {
+	out := make([]int32, 2*len(c))
+	for i, v := range c {
+		out[2*i], out[2*i+1] = v.r, v.i
+	}
+	return out
+}
+
+func kissRefImpl(st *kissFFT, fout []kissCpx) {
+	tw := make([]kissTw, st.n)
+	for i := range tw {
+		tw[i] = kissTw{st.twiddles[2*i], st.twiddles[2*i+1]}
+	}
+	f := st.factors
+	var fstride [32]int
+	fstride[0] = 1
+	l := 0
+	for {
+		p, m := f[2*l], f[2*l+1]
+		fstride[l+1] = fstride[l] * p
+		l++
+		if m == 1 {
+			break
+		}
+	}
+	m := f[2*l-1]
+	for i := l - 1; i >= 0; i-- {
+		m2 := 1
+		if i != 0 {
+			m2 = f[2*i-1]
+		}
+		switch f[2*i] {
+		case 2:
+			kissRefBfly2(fout, m, fstride[i])
+		case 4:
+			kissRefBfly4(fout, fstride[i], tw, m, fstride[i], m2)
+		case 3:
+			kissRefBfly3(fout, fstride[i], tw, m, fstride[i], m2)
+		case 5:
+			kissRefBfly5(fout, fstride[i], tw, m, fstride[i], m2)
+		}
+		m = m2
+	}
+}
+
+func kissRefBfly2(fout []kissCpx, m, n int) {
+	if m == 1 {
+		for i := range n {
+			f := fout[2*i:]
+			t := f[1]
+			f[1] = kissCSub(f[0], t)
+			f[0] = kissCAdd(f[0], t)
+		}
+		return
+	}
+	tw := kissTw2
+	for i := range n {
+		f := fout[8*i:]
+		f2 := f[4:]
+		t := f2[0]
+		f2[0] = kissCSub(f[0], t)
+		f[0] = kissCAdd(f[0], t)
+
+		t = kissCpx{sMul(f2[1].r+f2[1].i, tw), sMul(f2[1].i-f2[1].r, tw)}
+		f2[1] = kissCSub(f[1], t)
+		f[1] = kissCAdd(f[1], t)
+
+		t = kissCpx{f2[2].i, -f2[2].r}
+		f2[2] = kissCSub(f[2], t)
+		f[2] = kissCAdd(f[2], t)
+
+		t = kissCpx{sMul(f2[3].i-f2[3].r, tw), sMul(-(f2[3].i + f2[3].r), tw)}
+		f2[3] = kissCSub(f[3], t)
+		f[3] = kissCAdd(f[3], t)
+	}
+}
+
+func kissRefBfly4(fout []kissCpx, fstride int, tw []kissTw, m, n, mm int) {
+	if m == 1 {
+		for i := range n {
+			f := fout[4*i:]
+			s0 := kissCSub(f[0], f[2])
+			f[0] = kissCAdd(f[0], f[2])
+			s1 := kissCAdd(f[1], f[3])
+			f[2] = kissCSub(f[0], s1)
+			f[0] = kissCAdd(f[0], s1)
+			s1 = kissCSub(f[1], f[3])
+			f[1] = kissCpx{s0.r + s1.i, s0.i - s1.r}
+			f[3] = kissCpx{s0.r - s1.i, s0.i + s1.r}
+		}
+		return
+	}
+	m2, m3 := 2*m, 3*m
+	for i := range n {
+		f := fout[i*mm:]
+		t1, t2, t3 := 0, 0, 0
+		for j := range m {
+			s0 := kissCMul(f[j+m], tw[t1])
+			s1 := kissCMul(f[j+m2], tw[t2])
+			s2 := kissCMul(f[j+m3], tw[t3])
+			s5 := kissCSub(f[j], s1)
+			f[j] = kissCAdd(f[j], s1)
+			s3 := kissCAdd(s0, s2)
+			s4 := kissCSub(s0, s2)
+			f[j+m2] = kissCSub(f[j], s3)
+			t1 += fstride
+			t2 += 2 * fstride
+			t3 += 3 * fstride
+			f[j] = kissCAdd(f[j], s3)
+			f[j+m] = kissCpx{s5.r + s4.i, s5.i - s4.r}
+			f[j+m3] = kissCpx{s5.r - s4.i, s5.i + s4.r}
+		}
+	}
+}
+
+func kissRefBfly3(fout []kissCpx, fstride int, tw []kissTw, m, n, mm int) {
+	m2 := 2 * m
+	for i := range n {
+		f := fout[i*mm:]
+		t1, t2 := 0, 0
+		for j := range m {
+			s1 := kissCMul(f[j+m], tw[t1])
+			s2 := kissCMul(f[j+m2], tw[t2])
+			s3 := kissCAdd(s1, s2)
+			s0 := kissCSub(s1, s2)
+			t1 += fstride
+			t2 += 2 * fstride
+			f[j+m] = kissCpx{f[j].r - s3.r>>1, f[j].i - s3.i>>1}
+			s0 = kissCpx{sMul(s0.r, kissEpi3I), sMul(s0.i, kissEpi3I)}
+			f[j] = kissCAdd(f[j], s3)
+			f[j+m2] = kissCpx{f[j+m].r + s0.i, f[j+m].i - s0.r}
+			f[j+m] = kissCpx{f[j+m].r - s0.i, f[j+m].i + s0.r}
+		}
+	}
+}
+
+func kissRefBfly5(fout []kissCpx, fstride int, tw []kissTw, m, n, mm int) {
+	ya := kissTw{kissYaR, kissYaI}
+	yb := kissTw{kissYbR, kissYbI}
+	for i := range n {
+		f := fout[i*mm:]
+		for u := range m {
+			s0 := f[u]
+			s1 := kissCMul(f[u+m], tw[u*fstride])
+			s2 := kissCMul(f[u+2*m], tw[2*u*fstride])
+			s3 := kissCMul(f[u+3*m], tw[3*u*fstride])
+			s4 := kissCMul(f[u+4*m], tw[4*u*fstride])
+
+			s7 := kissCAdd(s1, s4)
+			s10 := kissCSub(s1, s4)
+			s8 := kissCAdd(s2, s3)
+			s9 := kissCSub(s2, s3)
+
+			f[u] = kissCpx{f[u].r + (s7.r + s8.r), f[u].i + (s7.i + s8.i)}
+
+			s5 := kissCpx{s0.r + (sMul(s7.r, ya.r) + sMul(s8.r, yb.r)), s0.i + (sMul(s7.i, ya.r) + sMul(s8.i, yb.r))}
+			s6 := kissCpx{sMul(s10.i, ya.i) + sMul(s9.i, yb.i), -(sMul(s10.r, ya.i) + sMul(s9.r, yb.i))}
+			f[u+m] = kissCSub(s5, s6)
+			f[u+4*m] = kissCAdd(s5, s6)
+
+			s11 := kissCpx{s0.r + (sMul(s7.r, yb.r) + sMul(s8.r, ya.r)), s0.i + (sMul(s7.i, yb.r) + sMul(s8.i, ya.r))}
+			s12 := kissCpx{sMul(s9.i, ya.i) - sMul(s10.i, yb.i), sMul(s10.r, yb.i) - sMul(s9.r, ya.i)}
+			f[u+2*m] = kissCAdd(s11, s12)
+			f[u+3*m] = kissCSub(s11, s12)
+		}
+	}
+}
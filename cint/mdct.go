@@ -0,0 +1,260 @@
+package cint
+
+import (
+	"errors"
+	"math"
+)
+
+// Fixed-point MDCT for integer transform codecs, the counterpart of f32.MDCTPlan
+// on the kiss_fft core: 2n windowed int32 samples fold into n, whose DCT-IV runs
+// as an n/2-point complex FFT between a Q15 pre- and post-twiddle (both Mul
+// passes). The forward FFT is scaled by 1/(n/2) as opus_fft is, and the inverse
+// runs it unscaled, as clt_mdct_backward does. The 2/n normalization therefore
+// sits on the forward transform, the opposite side from f32.MDCTPlan (which
+// scales its inverse); the round-trip gain is the same.
+//
+// Every step is integer arithmetic with the package's truncating S_MUL and int32
+// wrap, so the output is bit-identical on every architecture and SIMD tier.
+
+// ErrMDCTConfig is returned by NewMDCTPlan when n is not twice a kiss_fft size (an
+// even n >= 4 whose half has no prime factor above 5), or when a non-nil window
+// is not 2n samples long.
+var ErrMDCTConfig = errors.New("cint: MDCT size must be 2*k with k >= 2 a product of 2, 3 and 5, with a 2*size window")
+
+// MDCTPlan is a reusable fixed-point MDCT of size n: Forward maps 2n int32
+// samples to n coefficients,
+//
+//	X[k] = 2/n * sum_{t=0}^{2n-1} w[t]*x[t] * cos(pi/n * (t + 1/2 + n/2) * (k + 1/2))
+//
+// and Inverse maps n coefficients back to 2n windowed samples,
+//
+//	y[t] = w[t] * sum_{k=0}^{n-1} X[k] * cos(pi/n * (t + 1/2 + n/2) * (k + 1/2))
+//
+// both up to the fixed-point rounding of the Q15 window and twiddles, so TDAC
+// overlap-add at hop n reconstructs the input with a Princen-Bradley window
+// (SineWindow, VorbisWindow, KBDWindow). Samples need two bits of headroom
+// (|x| < 2^29): the fold adds two windowed samples and the unscaled inverse FFT
+// grows by up to the fold's gain.
+//
+// A plan holds transform scratch and the Synthesize overlap, so its methods are
+// NOT safe for concurrent use on the same plan; use one plan per stream.
+type MDCTPlan struct {
+	n, half int
+
+	fft *kissFFT // size-half complex FFT core
+
+	// DCT-IV twiddles, interleaved Q15: pre exp(-i*pi*(4j+1)/(4n)) and post
+	// exp(-i*pi*k/n) for j, k in [0, half).
+	pre, post []int16
+
+	window []int16 // 2n Q15 analysis/synthesis window, nil for none
+
+	u       []int32 // folded block (n)
+	z, f    []int32 // interleaved FFT input and output (half complex)
+	block   []int32 // Synthesize's inverse block (2n)
+	overlap []int32 // second half of the previous Synthesize block (n)
+}
+
+// NewMDCTPlan builds a reusable fixed-point MDCT plan producing n coefficients
+// per 2n-sample block. window, if non-nil, must hold 2n Q15 samples; it is
+// copied, and applied both before Forward and after Inverse. n/2 must be a size
+// kiss_fft supports (at least 2, no prime factor above 5, below 65536), which
+// covers every Opus CELT frame; otherwise, or on a window of the wrong length,
+// ErrMDCTConfig is returned.
+func NewMDCTPlan(n int, window []int16) (*MDCTPlan, error) {
+	if n%2 != 0 || (window != nil && len(window) != 2*n) {
+		return nil, ErrMDCTConfig
+	}
+	half := n / 2
+	fft := newKissFFT(half)
+	if fft == nil {
+		return nil, ErrMDCTConfig
+	}
+	p := &MDCTPlan{
+		n:       n,
+		half:    half,
+		fft:     fft,
+		pre:     make([]int16, n),
+		post:    make([]int16, n),
+		u:       make([]int32, n),
+		z:       make([]int32, n),
+		f:       make([]int32, n),
+		block:   make([]int32, 2*n),
+		overlap: make([]int32, n),
+	}
+	if window != nil {
+		p.window = append([]int16(nil), window...)
+	}
+	for j := range half {
+		s, c := math.Sincos(math.Pi * float64(4*j+1) / float64(4*n))
+		p.pre[2*j], p.pre[2*j+1] = q15(c), q15(-s)
+		s, c = math.Sincos(math.Pi * float64(j) / float64(n))
+		p.post[2*j], p.post[2*j+1] = q15(c), q15(-s)
+	}
+	return p, nil
+}
+
+// Len returns the number of coefficients per block, n (the hop); blocks are 2n
+// samples.
+func (p *MDCTPlan) Len() int { return p.n }
+
+// Forward computes the fixed-point MDCT of the 2n-sample block src into the n
+// coefficients dst, applying the plan's window first. It reads src[:2n] and
+// writes dst[:n], and is a no-op when either slice is shorter. dst must not
+// overlap src. Allocation-free.
+func (p *MDCTPlan) Forward(dst, src []int32) {
+	n, h := p.n, p.half
+	if len(dst) < n || len(src) < 2*n {
+		return
+	}
+	x := src[:2*n]
+	if p.window != nil {
+		x = p.block
+		for t, w := range p.window {
+			x[t] = sMul(src[t], w)
+		}
+	}
+	// Fold the quarters (a, b, c, d) into (-c_r - d, a - b_r).
+	u := p.u
+	for t := range h {
+		u[t] = -x[3*h-1-t] - x[3*h+t]
+		u[h+t] = x[t] - x[n-1-t]
+	}
+	p.pack(u)
+	p.fft.forward(p.f, p.z)
+	p.unpack(dst)
+}
+
+// Inverse computes the windowed fixed-point IMDCT of the n coefficients src into
+// the 2n samples dst (see MDCTPlan). It reads src[:n] and writes dst[:2n], and is
+// a no-op when either slice is shorter. dst must not overlap src.
+// Allocation-free.
+func (p *MDCTPlan) Inverse(dst, src []int32) {
+	n, h := p.n, p.half
+	if len(dst) < 2*n || len(src) < n {
+		return
+	}
+	u := p.u
+	p.pack(src)
+	p.fft.unscaled(p.f, p.z)
+	p.unpack(u)
+	// Unfold: (q, -q_r, -p_r, -p) from u = (p, q).
+	for t := range h {
+		dst[t] = u[h+t]
+		dst[h+t] = -u[n-1-t]
+		dst[n+t] = -u[h-1-t]
+		dst[3*h+t] = -u[t]
+	}
+	if p.window != nil {
+		for t, w := range p.window {
+			dst[t] = sMul(dst[t], w)
+		}
+	}
+}
+
+// Synthesize is the streaming TDAC overlap-add: it runs Inverse on the n
+// coefficients src, adds the first half of the block to the second half of the
+// previous block, writes the n finished samples to dst, and keeps the new second
+// half for the next call. When the calls are fed the Forward coefficients of
+// consecutive 2n-sample blocks x[f*n : f*n+2n], call f writes x[f*n : f*n+n] up
+// to fixed-point rounding, from the second call on. Length handling follows
+// Inverse with dst n samples long. Allocation-free.
+func (p *MDCTPlan) Synthesize(dst, src []int32) {
+	n := p.n
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	p.Inverse(p.block, src)
+	addCint(dst[:n], p.overlap, p.block[:n])
+	copy(p.overlap, p.block[n:])
+}
+
+// Reset clears the Synthesize overlap, so the next call starts a new stream.
+func (p *MDCTPlan) Reset() { clear(p.overlap) }
+
+// pack loads u (n values) as the half-length complex sequence
+// z[j] = (u[2j] + i*u[n-1-2j]) * pre[j].
+func (p *MDCTPlan) pack(u []int32) {
+	n, z := p.n, p.z
+	for j := range p.half {
+		z[2*j], z[2*j+1] = u[2*j], u[n-1-2*j]
+	}
+	mulCint(z, z, p.pre)
+}
+
+// unpack post-twiddles the FFT output and spreads Y[k] = Z[k]*post[k] into dst
+// (n values) as dst[2k] = Re(Y[k]), dst[n-1-2k] = -Im(Y[k]).
+func (p *MDCTPlan) unpack(dst []int32) {
+	n, f := p.n, p.f
+	mulCint(f, f, p.post)
+	for k := range p.half {
+		dst[2*k], dst[n-1-2*k] = f[2*k], -f[2*k+1]
+	}
+}
+
+// q15One is the largest Q15 value, 32767 (0.99997).
+const q15One = 32767
+
+// q15 rounds v in [-1, 1] to Q15, saturating +1 to q15One.
+func q15(v float64) int16 {
+	return int16(max(-q15One-1, min(q15One, math.Round(v*(q15One+1)))))
+}
+
+// mdctPhase is the half-sample offset of the MDCT window phases.
+const mdctPhase = 0.5
+
+// SineWindow fills dst with the Q15 MDCT sine window
+// w[t] = sin(pi*(t + 1/2)/L), L = len(dst). See f32.SineWindow.
+func SineWindow(dst []int16) {
+	l := float64(len(dst))
+	for t := range dst {
+		dst[t] = q15(math.Sin(math.Pi * (float64(t) + mdctPhase) / l))
+	}
+}
+
+// VorbisWindow fills dst with the Q15 Vorbis power-complementary window
+// w[t] = sin(pi/2 * sin^2(pi*(t + 1/2)/L)), L = len(dst), also the shape of the
+// Opus CELT overlap. See f32.VorbisWindow.
+func VorbisWindow(dst []int16) {
+	l := float64(len(dst))
+	for t := range dst {
+		s := math.Sin(math.Pi * (float64(t) + mdctPhase) / l)
+		dst[t] = q15(math.Sin(math.Pi / 2 * s * s))
+	}
+}
+
+// KBDWindow fills dst with the Q15 Kaiser-Bessel-derived window for shape
+// parameter alpha. See f32.KBDWindow; an odd-length dst is left untouched.
+func KBDWindow(dst []int16, alpha float64) {
+	l := len(dst)
+	if l%2 != 0 {
+		return
+	}
+	m := l / 2
+	kernel := func(j int) float64 {
+		r := 2*float64(j)/float64(m) - 1
+		return besselI0(math.Pi * alpha * math.Sqrt(1-r*r))
+	}
+	var total float64
+	for j := range m + 1 {
+		total += kernel(j)
+	}
+	var acc float64
+	for t := range m {
+		acc += kernel(t)
+		w := q15(math.Sqrt(acc / total))
+		dst[t], dst[l-1-t] = w, w
+	}
+}
+
+// besselI0 is the zeroth-order modified Bessel function of the first kind,
+// I0(x) = sum_k ((x/2)^k / k!)^2, summed until the terms stop contributing.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	q := x * x / 4
+	for k := 1; term > sum*1e-17; k++ {
+		term *= q / float64(k*k)
+		sum += term
+	}
+	return sum
+}
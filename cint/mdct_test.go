@@ -0,0 +1,294 @@
+package cint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// mdctSizes covers every kiss_fft stage shape for the half-length FFT: radix 2
+// at m == 1 (half 10) and m == 4 (half 8, 24, 120), radix 3 and 5, the Opus CELT
+// frames (n = 120, 240, 480, 960), and the smallest plan.
+var mdctSizes = []int{4, 8, 16, 20, 24, 48, 64, 120, 240, 256, 480, 960}
+
+// kissRefCMul is one scalar C_MUL over interleaved lanes.
+func kissRefCMul(a []int32, tw []int16) {
+	for j := 0; j+1 < len(a); j += 2 {
+		c := kissCMul(kissCpx{a[j], a[j+1]}, kissTw{tw[j], tw[j+1]})
+		a[j], a[j+1] = c.r, c.i
+	}
+}
+
+// mdctRefForward and mdctRefInverse transcribe MDCTPlan step by step with
+// scalar S_MULs and the scalar kiss_fft reference, sharing only the plan's
+// tables.
+func mdctRefForward(p *MDCTPlan, src []int32) []int32 {
+	n, h := p.n, p.half
+	x := make([]int32, 2*n)
+	for t := range x {
+		x[t] = src[t]
+		if p.window != nil {
+			x[t] = sMul(src[t], p.window[t])
+		}
+	}
+	u := make([]int32, n)
+	for t := range h {
+		u[t] = -x[3*h-1-t] - x[3*h+t]
+		u[h+t] = x[t] - x[n-1-t]
+	}
+	return mdctRefDCT4(p, u, true)
+}
+
+func mdctRefInverse(p *MDCTPlan, src []int32) []int32 {
+	n, h := p.n, p.half
+	u := mdctRefDCT4(p, src, false)
+	y := make([]int32, 2*n)
+	for t := range h {
+		y[t], y[h+t], y[n+t], y[3*h+t] = u[h+t], -u[n-1-t], -u[h-1-t], -u[t]
+	}
+	if p.window != nil {
+		for t := range y {
+			y[t] = sMul(y[t], p.window[t])
+		}
+	}
+	return y
+}
+
+func mdctRefDCT4(p *MDCTPlan, u []int32, scaled bool) []int32 {
+	n := p.n
+	z := make([]int32, n)
+	for j := range p.half {
+		z[2*j], z[2*j+1] = u[2*j], u[n-1-2*j]
+	}
+	kissRefCMul(z, p.pre)
+	var f []int32
+	if scaled {
+		f = kissRefForward(p.fft, z)
+	} else {
+		f = kissRefUnscaled(p.fft, z)
+	}
+	kissRefCMul(f, p.post)
+	out := make([]int32, n)
+	for k := range p.half {
+		out[2*k], out[n-1-2*k] = f[2*k], -f[2*k+1]
+	}
+	return out
+}
+
+// mdctSignal is a deterministic signal at amplitude amp.
+func mdctSignal(n int, amp float64, seed int) []int32 {
+	s := make([]int32, n)
+	for i := range s {
+		x := float64(i + seed)
+		s[i] = int32(amp * (0.5*math.Sin(0.05*x) + 0.3*math.Cos(0.31*x+1) + 0.15*math.Sin(1.7*x)))
+	}
+	return s
+}
+
+func TestNewMDCTPlanErrors(t *testing.T) {
+	for _, c := range []struct {
+		n int
+		w []int16
+	}{{0, nil}, {2, nil}, {3, nil}, {14, nil}, {-4, nil}, {8, make([]int16, 8)}, {8, make([]int16, 17)}} {
+		if _, err := NewMDCTPlan(c.n, c.w); !errors.Is(err, ErrMDCTConfig) {
+			t.Errorf("NewMDCTPlan(%d, len %d) error = %v, want ErrMDCTConfig", c.n, len(c.w), err)
+		}
+	}
+	p, err := NewMDCTPlan(960, make([]int16, 1920))
+	if err != nil || p.Len() != 960 {
+		t.Fatalf("NewMDCTPlan(960) = %v, %v", p, err)
+	}
+}
+
+// TestMDCTBitExact checks Forward and Inverse bit for bit against the scalar
+// transcription, windowed and not, on in-range and full-range (wrapping) input.
+func TestMDCTBitExact(t *testing.T) {
+	for _, n := range mdctSizes {
+		win := make([]int16, 2*n)
+		VorbisWindow(win)
+		for _, w := range [][]int16{nil, win} {
+			p, err := NewMDCTPlan(n, w)
+			if err != nil {
+				t.Fatalf("n=%d: %v", n, err)
+			}
+			for _, src := range [][]int32{mdctSignal(2*n, 1<<28, 0), genI32(2*n, uint32(n))} {
+				got := make([]int32, n)
+				p.Forward(got, src)
+				want := mdctRefForward(p, src)
+				for k := range got {
+					if got[k] != want[k] {
+						t.Fatalf("n=%d windowed=%v: Forward[%d] = %d, ref %d", n, w != nil, k, got[k], want[k])
+					}
+				}
+				gotInv := make([]int32, 2*n)
+				p.Inverse(gotInv, src[:n])
+				wantInv := mdctRefInverse(p, src[:n])
+				for i := range gotInv {
+					if gotInv[i] != wantInv[i] {
+						t.Fatalf("n=%d windowed=%v: Inverse[%d] = %d, ref %d", n, w != nil, i, gotInv[i], wantInv[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestMDCTAccuracy checks the fixed-point Forward against the float64 MDCT
+// scaled by 2/n, and the Inverse against the float64 IMDCT.
+func TestMDCTAccuracy(t *testing.T) {
+	const amp = 1 << 26
+	for _, n := range mdctSizes {
+		win := make([]int16, 2*n)
+		SineWindow(win)
+		p, _ := NewMDCTPlan(n, win)
+		src := mdctSignal(2*n, amp, 3)
+		got := make([]int32, n)
+		p.Forward(got, src)
+		for k := range n {
+			var want float64
+			for i := range 2 * n {
+				x := float64(src[i]) * float64(win[i]) / 32768
+				want += x * math.Cos(math.Pi/float64(n)*(float64(i)+0.5+float64(n)/2)*(float64(k)+0.5))
+			}
+			want *= 2 / float64(n)
+			if d := math.Abs(float64(got[k]) - want); d > amp*1e-3 {
+				t.Fatalf("n=%d k=%d: Forward %d want %.0f", n, k, got[k], want)
+			}
+		}
+		// Keep the unscaled inverse in range: |y| <= n * max|X| < 2^30.
+		coef := mdctSignal(n, 1<<19, 7)
+		inv := make([]int32, 2*n)
+		p.Inverse(inv, coef)
+		for i := range 2 * n {
+			var want float64
+			for k := range n {
+				want += float64(coef[k]) * math.Cos(math.Pi/float64(n)*(float64(i)+0.5+float64(n)/2)*(float64(k)+0.5))
+			}
+			want *= float64(win[i]) / 32768
+			if d := math.Abs(float64(inv[i]) - want); d > amp*1e-3 {
+				t.Fatalf("n=%d t=%d: Inverse %d want %.0f", n, i, inv[i], want)
+			}
+		}
+	}
+}
+
+// TestMDCTTDAC streams a signal through Forward and Synthesize and checks
+// reconstruction to within fixed-point rounding for every window.
+func TestMDCTTDAC(t *testing.T) {
+	const amp = 1 << 26
+	for _, n := range []int{16, 120, 240, 480} {
+		windows := map[string]func([]int16){
+			"sine":   SineWindow,
+			"vorbis": VorbisWindow,
+			"kbd":    func(w []int16) { KBDWindow(w, 4) },
+		}
+		for name, fill := range windows {
+			win := make([]int16, 2*n)
+			fill(win)
+			p, _ := NewMDCTPlan(n, win)
+			const blocks = 6
+			sig := mdctSignal((blocks+1)*n, amp, 11)
+			coef := make([]int32, n)
+			out := make([]int32, n)
+			for f := range blocks {
+				p.Forward(coef, sig[f*n:f*n+2*n])
+				p.Synthesize(out, coef)
+				if f == 0 {
+					continue
+				}
+				for i := range n {
+					if d := math.Abs(float64(out[i]) - float64(sig[f*n+i])); d > amp*2e-4 {
+						t.Fatalf("%s n=%d block %d: out[%d] = %d want %d", name, n, f, i, out[i], sig[f*n+i])
+					}
+				}
+			}
+			p.Reset()
+			for _, v := range p.overlap {
+				if v != 0 {
+					t.Fatalf("Reset left overlap %d", v)
+				}
+			}
+		}
+	}
+}
+
+// TestMDCTWindowsQ15 checks the Q15 windows against the Princen-Bradley condition
+// to Q15 precision.
+func TestMDCTWindowsQ15(t *testing.T) {
+	for _, l := range []int{16, 240, 1920} {
+		for name, fill := range map[string]func([]int16){
+			"sine":   SineWindow,
+			"vorbis": VorbisWindow,
+			"kbd":    func(w []int16) { KBDWindow(w, 6) },
+		} {
+			w := make([]int16, l)
+			fill(w)
+			h := l / 2
+			for i := range h {
+				a, b := float64(w[i])/32768, float64(w[i+h])/32768
+				if math.Abs(a*a+b*b-1) > 1e-4 {
+					t.Fatalf("%s L=%d: PB sum at %d = %g", name, l, i, a*a+b*b)
+				}
+			}
+		}
+	}
+	odd := []int16{7, 7, 7}
+	KBDWindow(odd, 4)
+	if odd[0] != 7 {
+		t.Errorf("KBDWindow modified an odd-length dst")
+	}
+}
+
+// TestMDCTGuards checks that short slices are a no-op and that only the
+// documented prefix is touched.
+func TestMDCTGuards(t *testing.T) {
+	p, _ := NewMDCTPlan(4, nil)
+	short := []int32{1, 2, 3}
+	p.Forward(short, mdctSignal(8, 1<<20, 0))
+	p.Forward(make([]int32, 4), mdctSignal(7, 1<<20, 0))
+	p.Synthesize(short, mdctSignal(4, 1<<20, 0))
+	long := make([]int32, 9)
+	long[8] = 42
+	p.Inverse(long[:7], mdctSignal(4, 1<<20, 0))
+	p.Inverse(long, mdctSignal(4, 1<<20, 0))
+	for i, v := range short {
+		if v != int32(i+1) {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+	if long[8] != 42 {
+		t.Fatalf("sample past 2n modified")
+	}
+}
+
+func TestMDCTAllocFree(t *testing.T) {
+	win := make([]int16, 960)
+	SineWindow(win)
+	p, _ := NewMDCTPlan(480, win)
+	src := mdctSignal(960, 1<<26, 0)
+	coef := make([]int32, 480)
+	out := make([]int32, 480)
+	if a := testing.AllocsPerRun(5, func() { p.Forward(coef, src) }); a != 0 {
+		t.Errorf("Forward allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { p.Synthesize(out, coef) }); a != 0 {
+		t.Errorf("Synthesize allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkMDCTForward(b *testing.B) {
+	for _, n := range []int{240, 480, 960} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			win := make([]int16, 2*n)
+			SineWindow(win)
+			p, _ := NewMDCTPlan(n, win)
+			src := mdctSignal(2*n, 1<<26, 0)
+			dst := make([]int32, n)
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(dst, src)
+			}
+		})
+	}
+}
@@ -6,23 +6,25 @@ import "strings"
 // Features contains detected CPU SIMD capabilities.
 type Features struct {
 	// x86/AMD64 features
-	SSE       bool
-	SSE2      bool
-	SSE3      bool
-	SSSE3     bool
-	SSE41     bool
-	SSE42     bool
-	AVX       bool
-	AVX2      bool
-	AVXVNNI   bool // AVX-VNNI (VEX-encoded VPDPWSSD/VPDPBUSD); Alder Lake+ and Zen 4+
-	AVX512F   bool
-	AVX512VL  bool
-	FMA       bool
-	BMI1      bool
-	BMI2      bool
-	POPCNT    bool
-	PCLMULQDQ bool // carry-less multiply (CLMUL) - used for CRC folding
-	F16C      bool // half<->single float conversion (VCVTPH2PS/VCVTPS2PH)
+	SSE        bool
+	SSE2       bool
+	SSE3       bool
+	SSSE3      bool
+	SSE41      bool
+	SSE42      bool
+	AVX        bool
+	AVX2       bool
+	AVXVNNI    bool // AVX-VNNI (VEX-encoded VPDPWSSD/VPDPBUSD); Alder Lake+ and Zen 4+
+	AVX512F    bool
+	AVX512VL   bool
+	AVX512BW   bool // AVX-512 byte/word integer ops (VPMADDWD/VPSADBW on ZMM)
+	AVX512VNNI bool // AVX-512 VNNI (EVEX-encoded VPDPWSSD/VPDPBUSD); Ice Lake+ and Zen 4+
+	FMA        bool
+	BMI1       bool
+	BMI2       bool
+	POPCNT     bool
+	PCLMULQDQ  bool // carry-less multiply (CLMUL) - used for CRC folding
+	F16C       bool // half<->single float conversion (VCVTPH2PS/VCVTPS2PH)
 
 	// ARM64 features
 	NEON    bool
@@ -80,24 +82,46 @@ func HasDOTPROD() bool { return ARM64.DOTPROD }
 // HasFP16 returns true if ARM FP16 (half-precision) is available.
 func HasFP16() bool { return ARM64.FP16 }
 
+// HasSVE returns true if the ARM64 Scalable Vector Extension is available. The
+// f32 and f64 dot products, sums and element-wise Add/Mul/FMA run their
+// vector-length-agnostic SVE kernels in place of NEON when it is.
+func HasSVE() bool { return ARM64.SVE }
+
+// HasSVE2 returns true if SVE2 is available. No kernel requires SVE2 beyond
+// SVE; SVE2 hosts run the SVE kernels.
+func HasSVE2() bool { return ARM64.SVE2 }
+
 // HasAVX512VL returns true if AVX-512VL is available.
 func HasAVX512VL() bool { return X86.AVX512VL }
 
+// HasAVX512BW returns true if AVX-512BW, the byte and word integer subset of
+// AVX-512, is available. The i8 and i16 dot products, i8.SAD and i16.XCorr run
+// 512-bit kernels when it is. Detection gates on AVX512F.
+func HasAVX512BW() bool { return X86.AVX512BW }
+
+// HasAVX512VNNI returns true if the EVEX-encoded VNNI instructions (VPDPBUSD /
+// VPDPWSSD on ZMM) are available. The i8 and i16 dot products and i16.XCorr fuse
+// their multiply-accumulate with them. Detection gates on AVX512BW, which every
+// kernel that uses them also needs.
+func HasAVX512VNNI() bool { return X86.AVX512VNNI }
+
 // Info returns a string describing the available SIMD features.
 func Info() string {
 	return cpuInfo()
 }
 
 // applyDisable clears CPU feature flags in f according to the comma-separated,
-// case-insensitive token list in spec (the value of the SIMD_DISABLE env var).
-// Each token clears its own flag plus every flag that depends on it, so the
-// resulting Features value never describes an impossible CPU (for example, AVX2
-// set while AVX is cleared). Unknown and empty tokens are ignored: a library must
+// case-insensitive token list in spec (the value of the SIMD_DISABLE env var, or
+// an Override spec). Each token clears its own flag plus every flag that depends
+// on it, so the resulting Features value never describes an impossible CPU (for
+// example, AVX2 set while AVX is cleared). Unknown and empty tokens are ignored: a library must
 // not panic or write to stderr in response to environment input.
 //
 // Recognized tokens:
 //
-//	avx512     AVX512F, AVX512VL
+//	avx512     AVX512F, AVX512VL and the avx512bw set
+//	avx512bw   AVX512BW and the avx512vnni set
+//	avx512vnni AVX512VNNI only
 //	avxvnni    AVXVNNI only
 //	avx2       AVX2, AVXVNNI and the avx512 set
 //	avx        AVX, FMA, F16C and the avx2 set
@@ -123,6 +147,10 @@ func applyDisable(f *Features, spec string) {
 			// Empty token (e.g. trailing comma): ignore.
 		case "avx512":
 			clearAVX512(f)
+		case "avx512bw":
+			clearAVX512BW(f)
+		case "avx512vnni":
+			f.AVX512VNNI = false
 		case "avxvnni":
 			f.AVXVNNI = false
 		case "avx2":
@@ -166,6 +194,12 @@ func applyDisable(f *Features, spec string) {
 func clearAVX512(f *Features) {
 	f.AVX512F = false
 	f.AVX512VL = false
+	clearAVX512BW(f)
+}
+
+func clearAVX512BW(f *Features) {
+	f.AVX512BW = false
+	f.AVX512VNNI = false
 }
 
 func clearAVX2(f *Features) {
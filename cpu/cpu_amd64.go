@@ -19,6 +19,11 @@ func init() {
 	X86.AVX2 = cpu.X86.HasAVX2
 	X86.AVX512F = cpu.X86.HasAVX512F
 	X86.AVX512VL = cpu.X86.HasAVX512VL
+	// The integer kernels' 512-bit tiers are AVX-512BW and, above it, AVX-512
+	// VNNI; each is gated on the tier below so a Features value never claims
+	// VNNI without the byte/word ops its kernels also use.
+	X86.AVX512BW = X86.AVX512F && cpu.X86.HasAVX512BW
+	X86.AVX512VNNI = X86.AVX512BW && cpu.X86.HasAVX512VNNI
 	X86.FMA = cpu.X86.HasFMA
 	X86.BMI1 = cpu.X86.HasBMI1
 	X86.BMI2 = cpu.X86.HasBMI2
@@ -5,9 +5,9 @@ package cpu
 // cpuInfo is shared by the Linux and darwin arm64 builds: the two files differ
 // only in their init() feature detection, but report the tier identically.
 func cpuInfo() string {
-	// Report the tier the library actually runs (NEON), not SVE: there are no SVE
-	// kernels yet, so an SVE-capable host still executes the NEON path. SVE/SVE2 is
-	// annotated as detected-but-unused so the capability is still visible.
+	// SVE hosts run the SVE kernels for the f32/f64 dot products, sums and
+	// element-wise Add/Mul/FMA, and NEON for everything else. There are no
+	// SVE2-only kernels, so an SVE2 host reports the SVE tier and notes SVE2.
 	var base string
 	switch {
 	case ARM64.NEON && ARM64.FP16:
@@ -19,9 +19,9 @@ func cpuInfo() string {
 	}
 	switch {
 	case ARM64.SVE2:
-		return base + " (SVE2 detected, unused)"
+		return base + "+SVE (SVE2)"
 	case ARM64.SVE:
-		return base + " (SVE detected, unused)"
+		return base + "+SVE"
 	default:
 		return base
 	}
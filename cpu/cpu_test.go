@@ -61,12 +61,29 @@ func TestHasDOTPROD(_ *testing.T) {
 	_ = got
 }
 
+// TestHasSVE tests the HasSVE and HasSVE2 functions
+func TestHasSVE(t *testing.T) {
+	if HasSVE2() && !HasSVE() {
+		t.Error("HasSVE2() without HasSVE()")
+	}
+}
+
 // TestHasAVX512VL tests the HasAVX512VL function
 func TestHasAVX512VL(_ *testing.T) {
 	got := HasAVX512VL()
 	_ = got
 }
 
+// TestHasAVX512BW tests the HasAVX512BW and HasAVX512VNNI functions
+func TestHasAVX512BW(t *testing.T) {
+	if HasAVX512BW() && !X86.AVX512F {
+		t.Error("HasAVX512BW() without AVX512F")
+	}
+	if HasAVX512VNNI() && !HasAVX512BW() {
+		t.Error("HasAVX512VNNI() without HasAVX512BW()")
+	}
+}
+
 // TestInfo tests the Info function
 func TestInfo(t *testing.T) {
 	info := Info()
@@ -101,6 +118,8 @@ func TestFeatures(_ *testing.T) {
 	_ = X86.AVXVNNI
 	_ = X86.AVX512F
 	_ = X86.AVX512VL
+	_ = X86.AVX512BW
+	_ = X86.AVX512VNNI
 	_ = X86.FMA
 	_ = X86.BMI1
 	_ = X86.BMI2
@@ -44,21 +44,25 @@ func TestApplyDisable(t *testing.T) {
 		disabled []string
 	}{
 		{"", nil},
-		{"avx512", []string{"AVX512F", "AVX512VL"}},
+		{"avx512", []string{"AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI"}},
 		// AVXVNNI is VEX-encoded and its dispatch tier sits above AVX2, so clearing
 		// AVX2 (and every token that cascades through clearAVX2) must also clear it.
 		// The avxvnni token itself clears only AVXVNNI.
 		{"avxvnni", []string{"AVXVNNI"}},
-		{"avx2", []string{"AVX2", "AVX512F", "AVX512VL", "AVXVNNI"}},
+		// AVX512VNNI sits above AVX512BW, which sits above AVX512F, so each token
+		// clears its own flag and the tiers over it but not the ones below.
+		{"avx512bw", []string{"AVX512BW", "AVX512VNNI"}},
+		{"avx512vnni", []string{"AVX512VNNI"}},
+		{"avx2", []string{"AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI"}},
 		// F16C is VEX-encoded and gated on AVX, so the avx cascade (and every SSE
 		// token that cascades through clearAVX) must also clear F16C. avx2/fma/avx512
 		// sit above AVX and correctly leave it set.
-		{"avx", []string{"AVX", "AVX2", "AVX512F", "AVX512VL", "AVXVNNI", "F16C", "FMA"}},
+		{"avx", []string{"AVX", "AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI", "F16C", "FMA"}},
 		{"fma", []string{"FMA"}},
-		{"sse42", []string{"AVX", "AVX2", "AVX512F", "AVX512VL", "AVXVNNI", "F16C", "FMA", "SSE42"}},
-		{"sse41", []string{"AVX", "AVX2", "AVX512F", "AVX512VL", "AVXVNNI", "F16C", "FMA", "SSE41", "SSE42"}},
-		{"ssse3", []string{"AVX", "AVX2", "AVX512F", "AVX512VL", "AVXVNNI", "F16C", "FMA", "SSE41", "SSE42", "SSSE3"}},
-		{"sse3", []string{"AVX", "AVX2", "AVX512F", "AVX512VL", "AVXVNNI", "F16C", "FMA", "SSE3", "SSE41", "SSE42", "SSSE3"}},
+		{"sse42", []string{"AVX", "AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI", "F16C", "FMA", "SSE42"}},
+		{"sse41", []string{"AVX", "AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI", "F16C", "FMA", "SSE41", "SSE42"}},
+		{"ssse3", []string{"AVX", "AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI", "F16C", "FMA", "SSE41", "SSE42", "SSSE3"}},
+		{"sse3", []string{"AVX", "AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI", "F16C", "FMA", "SSE3", "SSE41", "SSE42", "SSSE3"}},
 		{"pclmulqdq", []string{"PCLMULQDQ"}},
 		{"neon", []string{"DOTPROD", "FP16", "NEON", "PMULL", "SVE", "SVE2"}},
 		{"fp16", []string{"FP16"}},
@@ -66,16 +70,16 @@ func TestApplyDisable(t *testing.T) {
 		{"pmull", []string{"PMULL"}},
 		{"dotprod", []string{"DOTPROD"}},
 		// Case-insensitivity and surrounding whitespace.
-		{"AVX512", []string{"AVX512F", "AVX512VL"}},
-		{"  avx512  ", []string{"AVX512F", "AVX512VL"}},
-		{"Avx2", []string{"AVX2", "AVX512F", "AVX512VL", "AVXVNNI"}},
+		{"AVX512", []string{"AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI"}},
+		{"  avx512  ", []string{"AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI"}},
+		{"Avx2", []string{"AVX2", "AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "AVXVNNI"}},
 		// Unknown tokens are ignored.
 		{"foobar", nil},
-		{"avx512,foobar", []string{"AVX512F", "AVX512VL"}},
+		{"avx512,foobar", []string{"AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI"}},
 		// Empty tokens between commas are ignored.
-		{"avx512,,neon", []string{"AVX512F", "AVX512VL", "DOTPROD", "FP16", "NEON", "PMULL", "SVE", "SVE2"}},
+		{"avx512,,neon", []string{"AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "DOTPROD", "FP16", "NEON", "PMULL", "SVE", "SVE2"}},
 		// Multiple tokens combine.
-		{"avx512,neon", []string{"AVX512F", "AVX512VL", "DOTPROD", "FP16", "NEON", "PMULL", "SVE", "SVE2"}},
+		{"avx512,neon", []string{"AVX512BW", "AVX512F", "AVX512VL", "AVX512VNNI", "DOTPROD", "FP16", "NEON", "PMULL", "SVE", "SVE2"}},
 	}
 	for _, tt := range tests {
 		f := fullFeatures()
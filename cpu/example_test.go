@@ -36,3 +36,19 @@ func Example() {
 	fmt.Println("FMA:", cpu.HasFMA())
 	fmt.Println("NEON:", cpu.HasNEON())
 }
+
+func ExampleLookupKernel() {
+	// Reports, for example, "AVX-512 dotProductAVX512" or "NEON dotProductNEON";
+	// the package must be imported somewhere in the program to register.
+	if k, ok := cpu.LookupKernel("f32", "DotProduct"); ok {
+		fmt.Println(k.Impl, k.Func)
+	}
+}
+
+func ExampleOverride() {
+	// Run the code below without AVX-512, then return to the detected tier.
+	restore := cpu.Override("avx512")
+	defer restore()
+	fmt.Println(cpu.HasAVX512VL())
+	// Output: false
+}
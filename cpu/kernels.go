@@ -0,0 +1,33 @@
+package cpu
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// Kernel describes the implementation one exported operation of a simd
+// subpackage is bound to on this host: its Package and Op, the tier name in
+// Impl ("Go", "SSE2", "AVX+FMA", "AVX2", "AVX-512", "NEON", ...), the Features
+// fields that tier Requires, the unexported Func that implements it, and the
+// Go Fallback taken on inputs the kernel does not cover.
+//
+// Many operations only run their kernel above a minimum length or on
+// well-formed shapes (see each function's documentation); Impl names the
+// kernel such inputs reach, and Fallback the path everything else takes.
+type Kernel = dispatch.Kernel
+
+// Kernels returns the current binding of every dispatched exported operation of
+// the simd subpackages linked into the program, sorted by package and
+// operation. A subpackage registers itself when it is imported, so a program
+// that only imports f32 sees only f32's operations.
+//
+// The report is computed from the same function pointers and feature flags the
+// operations dispatch on, so it reflects SIMD_DISABLE and Override and is safe
+// to record from telemetry at any time. Each call allocates a fresh slice.
+func Kernels() []Kernel {
+	return dispatch.Kernels()
+}
+
+// LookupKernel returns the current binding of one operation, such as
+// LookupKernel("f32", "DotProduct"). It reports false when the package is not
+// linked into the program or op is not one of its dispatched operations.
+func LookupKernel(pkg, op string) (Kernel, bool) {
+	return dispatch.Lookup(pkg, op)
+}
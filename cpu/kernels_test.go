@@ -0,0 +1,186 @@
+package cpu_test
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/tphakala/simd/c128"
+	"github.com/tphakala/simd/c64"
+	"github.com/tphakala/simd/cint"
+	"github.com/tphakala/simd/cpu"
+	"github.com/tphakala/simd/crc"
+	"github.com/tphakala/simd/f16"
+	"github.com/tphakala/simd/f32"
+	"github.com/tphakala/simd/f64"
+	"github.com/tphakala/simd/i16"
+	"github.com/tphakala/simd/i32"
+	"github.com/tphakala/simd/i8"
+)
+
+// Reference one symbol of each subpackage so the imports, whose init
+// registers the package, are used.
+var (
+	_ = c128.Add
+	_ = c64.Add
+	_ = cint.Add
+	_ = crc.Checksum16
+	_ = f16.FromFloat32
+	_ = f32.Add
+	_ = f64.Add
+	_ = i16.Abs
+	_ = i32.Add
+	_ = i8.Abs
+)
+
+// hostFeatures returns the Features of the running architecture.
+func hostFeatures() *cpu.Features {
+	if runtime.GOARCH == "arm64" {
+		return &cpu.ARM64
+	}
+	return &cpu.X86
+}
+
+// TestKernelsWellFormed checks every report is complete, sorted, unique, and
+// only requires features the host has.
+func TestKernelsWellFormed(t *testing.T) {
+	ks := cpu.Kernels()
+	if len(ks) == 0 {
+		t.Fatal("Kernels() is empty")
+	}
+	feat := reflect.ValueOf(hostFeatures()).Elem()
+	for i, k := range ks {
+		if k.Package == "" || k.Op == "" || k.Impl == "" || k.Func == "" || k.Fallback == "" {
+			t.Errorf("incomplete kernel report %+v", k)
+		}
+		if i > 0 {
+			prev := ks[i-1]
+			if prev.Package > k.Package || (prev.Package == k.Package && prev.Op >= k.Op) {
+				t.Errorf("Kernels() not sorted or not unique at %s.%s after %s.%s", k.Package, k.Op, prev.Package, prev.Op)
+			}
+		}
+		if (k.Impl == "Go") != (len(k.Requires) == 0) {
+			t.Errorf("%s.%s: Impl %q with Requires %v", k.Package, k.Op, k.Impl, k.Requires)
+		}
+		if k.Impl == "Go" && k.Func != k.Fallback {
+			t.Errorf("%s.%s: Go binding Func %q differs from Fallback %q", k.Package, k.Op, k.Func, k.Fallback)
+		}
+		for _, req := range k.Requires {
+			f := feat.FieldByName(req)
+			if !f.IsValid() {
+				t.Errorf("%s.%s: requires unknown feature %q", k.Package, k.Op, req)
+			} else if !f.Bool() {
+				t.Errorf("%s.%s: bound to %s (%s) but host lacks %s", k.Package, k.Op, k.Impl, k.Func, req)
+			}
+		}
+	}
+}
+
+// registered lists every subpackage with one of its operations and the Go
+// reference that operation must report as its Fallback, so a package that
+// registers the wrong map, or maps an operation to another's reference, fails.
+var registered = []struct {
+	pkg, op, fallback string
+}{
+	{"c128", "DotProduct", "dotProductGo"},
+	{"c64", "Mul", "mulGo"},
+	{"cint", "Add", "addGo"},
+	{"crc", "ChecksumIEEE", "(*engine).update"},
+	{"f16", "FromFloat32Slice", "fromFloat32SliceGo"},
+	{"f32", "DotProduct", "dotProductGo"},
+	{"f64", "Autocorrelate", "autocorrelateGo"},
+	{"i16", "MulQ15", "mulQ15Go"},
+	{"i32", "FixedResidual", "fixedResidualGo"},
+	{"i8", "SAD", "sadGo"},
+}
+
+// TestKernelsPackages checks every subpackage registers itself.
+func TestKernelsPackages(t *testing.T) {
+	var want, got []string
+	for _, r := range registered {
+		want = append(want, r.pkg)
+	}
+	for _, k := range cpu.Kernels() {
+		if !slices.Contains(got, k.Package) {
+			got = append(got, k.Package)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Kernels() packages = %v, want %v", got, want)
+	}
+}
+
+// TestKernelsRegistered checks, per subpackage, that every operation it
+// registers resolves through LookupKernel to a complete binding with a Go
+// Fallback, and that the package's sample operation reports its own reference.
+func TestKernelsRegistered(t *testing.T) {
+	byPkg := make(map[string][]cpu.Kernel)
+	for _, k := range cpu.Kernels() {
+		byPkg[k.Package] = append(byPkg[k.Package], k)
+	}
+	for _, r := range registered {
+		t.Run(r.pkg, func(t *testing.T) {
+			ks := byPkg[r.pkg]
+			if len(ks) == 0 {
+				t.Fatal("no operations registered")
+			}
+			for _, k := range ks {
+				got, ok := cpu.LookupKernel(r.pkg, k.Op)
+				if !ok {
+					t.Errorf("LookupKernel(%q) not found", k.Op)
+					continue
+				}
+				if got.Impl == "" || got.Func == "" || got.Fallback == "" {
+					t.Errorf("%s: incomplete binding %+v", k.Op, got)
+				}
+			}
+			k, ok := cpu.LookupKernel(r.pkg, r.op)
+			if !ok {
+				t.Fatalf("LookupKernel(%q) not found", r.op)
+			}
+			if k.Fallback != r.fallback {
+				t.Errorf("%s: Fallback = %q, want %q", r.op, k.Fallback, r.fallback)
+			}
+		})
+	}
+}
+
+// TestLookupKernel checks LookupKernel agrees with Kernels and rejects unknown
+// packages and operations.
+func TestLookupKernel(t *testing.T) {
+	for _, k := range cpu.Kernels() {
+		got, ok := cpu.LookupKernel(k.Package, k.Op)
+		if !ok || !reflect.DeepEqual(got, k) {
+			t.Errorf("LookupKernel(%q, %q) = %+v, %v; want %+v", k.Package, k.Op, got, ok, k)
+		}
+	}
+	for _, tt := range []struct{ pkg, op string }{
+		{"f32", "NoSuchOp"},
+		{"nosuchpkg", "DotProduct"},
+		{"", ""},
+		{"f32", "dotProductGo"},
+	} {
+		if k, ok := cpu.LookupKernel(tt.pkg, tt.op); ok {
+			t.Errorf("LookupKernel(%q, %q) = %+v, want not found", tt.pkg, tt.op, k)
+		}
+	}
+}
+
+// TestLookupKernelRequiresIsCopy checks callers cannot corrupt the tier tables
+// through a returned Requires slice.
+func TestLookupKernelRequiresIsCopy(t *testing.T) {
+	for _, k := range cpu.Kernels() {
+		if len(k.Requires) == 0 {
+			continue
+		}
+		want := k.Requires[0]
+		k.Requires[0] = "clobbered"
+		again, _ := cpu.LookupKernel(k.Package, k.Op)
+		if again.Requires[0] != want {
+			t.Fatalf("%s.%s: Requires aliases the tier table", k.Package, k.Op)
+		}
+		return
+	}
+	t.Skip("no SIMD kernel bound on this host")
+}
@@ -0,0 +1,50 @@
+package cpu
+
+import (
+	"sync"
+
+	"github.com/tphakala/simd/internal/dispatch"
+)
+
+// overrideMu serializes Override and its restore functions.
+var overrideMu sync.Mutex
+
+// Override masks CPU features at runtime the way SIMD_DISABLE does at startup,
+// then rebinds every linked simd subpackage to the kernels the remaining
+// features select. spec is a comma-separated, case-insensitive SIMD_DISABLE
+// token list ("avx512", "avx2,fma", "neon", "all", ...), each token clearing its
+// feature and every tier above it, applied on top of the features currently in
+// effect, so overrides nest. Features can only be masked:
+// nothing the CPU lacks, or SIMD_DISABLE already cleared, can be turned on.
+//
+// The returned function restores the features in effect before the call and
+// rebinds again; calls after the first do nothing. Restore nested overrides in
+// reverse order, as defer and t.Cleanup do:
+//
+//	defer cpu.Override("avx512")() // run the AVX+FMA/AVX2 tiers
+//	t.Cleanup(cpu.Override("all")) // exercise the pure-Go fallbacks
+//
+// Rebinding rewrites the function pointers and cached flags the operations read,
+// without synchronizing with them, so neither Override nor its restore function
+// may run concurrently with any simd operation: call them from main before
+// starting goroutines that use the packages, or from tests that do not run in
+// parallel. Info, the HasXxx accessors, X86, ARM64, Kernels and LookupKernel all
+// reflect the override.
+func Override(spec string) (restore func()) {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	savedX86, savedARM64 := X86, ARM64
+	applyDisable(&X86, spec)
+	applyDisable(&ARM64, spec)
+	dispatch.Rebind()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			overrideMu.Lock()
+			defer overrideMu.Unlock()
+			X86, ARM64 = savedX86, savedARM64
+			dispatch.Rebind()
+		})
+	}
+}
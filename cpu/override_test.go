@@ -0,0 +1,124 @@
+package cpu_test
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+	"github.com/tphakala/simd/f32"
+	"github.com/tphakala/simd/f64"
+	"github.com/tphakala/simd/i16"
+)
+
+// TestOverrideAll checks Override("all") rebinds every operation of every
+// package to Go, and that restoring brings back the original bindings.
+func TestOverrideAll(t *testing.T) {
+	before := cpu.Kernels()
+	x86, arm64 := cpu.X86, cpu.ARM64
+
+	restore := cpu.Override("all")
+	for _, k := range cpu.Kernels() {
+		if k.Impl != "Go" || k.Func != k.Fallback {
+			t.Errorf("%s.%s under Override(\"all\") = %s (%s), want Go (%s)", k.Package, k.Op, k.Impl, k.Func, k.Fallback)
+		}
+	}
+	if got := *hostFeatures(); got != (cpu.Features{}) {
+		t.Errorf("Override(\"all\") left features set: %+v", got)
+	}
+	var want string
+	switch runtime.GOARCH {
+	case "amd64":
+		want = "AMD64 (scalar)"
+	case "arm64":
+		want = "ARM64 (no SIMD)"
+	default:
+		want = "Generic (no SIMD)"
+	}
+	if got := cpu.Info(); got != want {
+		t.Errorf("Info() under Override(\"all\") = %q, want %q", got, want)
+	}
+
+	restore()
+	if cpu.X86 != x86 || cpu.ARM64 != arm64 {
+		t.Errorf("restore left X86=%+v ARM64=%+v, want X86=%+v ARM64=%+v", cpu.X86, cpu.ARM64, x86, arm64)
+	}
+	if after := cpu.Kernels(); !reflect.DeepEqual(after, before) {
+		t.Error("Kernels() after restore differs from before Override")
+	}
+}
+
+// TestOverrideNested checks overrides stack on each other and unwind in
+// reverse order, and that a second call of a restore function does nothing.
+func TestOverrideNested(t *testing.T) {
+	orig := cpu.X86
+
+	restoreAVX512 := cpu.Override("avx512")
+	if cpu.X86.AVX512F || cpu.X86.AVX512VL {
+		t.Fatal("Override(\"avx512\") left AVX-512 set")
+	}
+	if cpu.X86.AVX2 != orig.AVX2 {
+		t.Error("Override(\"avx512\") changed AVX2")
+	}
+	masked := cpu.X86
+
+	restoreAVX := cpu.Override("avx")
+	if cpu.X86.AVX || cpu.X86.AVX2 || cpu.X86.FMA {
+		t.Fatal("Override(\"avx\") left the AVX family set")
+	}
+	if cpu.X86.SSE2 != orig.SSE2 {
+		t.Error("Override(\"avx\") changed SSE2")
+	}
+
+	restoreAVX()
+	if cpu.X86 != masked {
+		t.Errorf("inner restore: X86 = %+v, want %+v", cpu.X86, masked)
+	}
+	restoreAVX512()
+	restoreAVX()
+	if cpu.X86 != orig {
+		t.Errorf("outer restore: X86 = %+v, want %+v", cpu.X86, orig)
+	}
+}
+
+// TestOverrideResults runs a few operations on every tier the host offers
+// and checks each agrees with the pure-Go result.
+func TestOverrideResults(t *testing.T) {
+	const n = 1027
+	a32, b32 := make([]float32, n), make([]float32, n)
+	a64, b64 := make([]float64, n), make([]float64, n)
+	x16, y16 := make([]int16, n), make([]int16, n)
+	for i := range n {
+		a32[i], b32[i] = float32(i%13)-6, float32(i%7)-3
+		a64[i], b64[i] = float64(i%13)-6, float64(i%7)-3
+		x16[i], y16[i] = int16(i%251-125), int16(i%127-63)
+	}
+	type result struct {
+		dot32 float32
+		dot64 float64
+		dot16 int32
+		max32 float32
+		sum64 float64
+	}
+	run := func() result {
+		return result{
+			dot32: f32.DotProduct(a32, b32),
+			dot64: f64.DotProduct(a64, b64),
+			dot16: i16.DotProduct(x16, y16),
+			max32: f32.Max(a32),
+			sum64: f64.Sum(a64),
+		}
+	}
+
+	restore := cpu.Override("all")
+	want := run()
+	restore()
+
+	for _, spec := range []string{"", "avx512vnni", "avx512bw", "avx512", "avx2", "avx", "fma", "sse41", "sve", "neon"} {
+		restore := cpu.Override(spec)
+		if got := run(); got != want {
+			t.Errorf("Override(%q) (%s): results %+v, want %+v", spec, cpu.Info(), got, want)
+		}
+		restore()
+	}
+}
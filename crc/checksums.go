@@ -0,0 +1,56 @@
+package crc
+
+import "sync"
+
+// Engines for the fixed checksums, built from the catalogue parameters on
+// first use (as hash/crc32 builds its tables), so importing the package costs
+// no table construction: each engine carries 32 KiB of slice-by-16 tables.
+var (
+	crc8       = lazyEngine(CRC8SMBUS)
+	crc32IEEE  = lazyEngine(CRC32ISOHDLC)
+	crc32C     = lazyEngine(CRC32ISCSI)
+	crc32MPEG2 = lazyEngine(CRC32MPEG2)
+	// The Ogg page checksum: CRC-32/MPEG-2 with init 0; check 0x89A1897F.
+	crc32Ogg = lazyEngine(Params{Width: 32, Poly: 0x04C11DB7})
+)
+
+// lazyEngine returns a function that builds the engine for p once, on its
+// first call, and returns that engine from every call.
+func lazyEngine(p Params) func() *engine {
+	return sync.OnceValue(func() *engine { return newEngine(p) })
+}
+
+// Checksum8 returns the CRC-8 of p (polynomial 0x07, init 0, MSB-first, no
+// reflection, no final XOR; CRC-8/SMBUS), the CRC that protects a FLAC frame
+// header.
+func Checksum8(p []byte) uint8 {
+	return uint8(crc8().checksum(p))
+}
+
+// ChecksumIEEE returns the CRC-32 of p (polynomial 0x04C11DB7, init and final
+// XOR 0xFFFFFFFF, reflected; CRC-32/ISO-HDLC), the CRC of zip, gzip, PNG and
+// Ethernet. It equals hash/crc32.ChecksumIEEE.
+func ChecksumIEEE(p []byte) uint32 {
+	return uint32(crc32IEEE().checksum(p))
+}
+
+// ChecksumCastagnoli returns the CRC-32C of p (polynomial 0x1EDC6F41, init and
+// final XOR 0xFFFFFFFF, reflected; CRC-32/ISCSI), as used by iSCSI, SCTP, ext4
+// and Btrfs. It equals hash/crc32.Checksum(p, crc32.MakeTable(crc32.Castagnoli)).
+func ChecksumCastagnoli(p []byte) uint32 {
+	return uint32(crc32C().checksum(p))
+}
+
+// ChecksumMPEG2 returns the unreflected CRC-32 of p (polynomial 0x04C11DB7,
+// init 0xFFFFFFFF, MSB-first, no final XOR; CRC-32/MPEG-2), as used by MPEG
+// transport stream tables.
+func ChecksumMPEG2(p []byte) uint32 {
+	return uint32(crc32MPEG2().checksum(p))
+}
+
+// ChecksumOgg returns the Ogg page checksum of p: CRC-32/MPEG-2's polynomial
+// and bit order with init 0. Compute it over the page with its checksum field
+// zeroed.
+func ChecksumOgg(p []byte) uint32 {
+	return uint32(crc32Ogg().checksum(p))
+}
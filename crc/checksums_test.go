@@ -0,0 +1,139 @@
+package crc
+
+import (
+	"hash/crc32"
+	"math/bits"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// refCRC is an independent bit-at-a-time CRC in the Rocksoft model, the oracle
+// for every engine: the direct algorithm on a width-bit register with each input
+// byte reflected when RefIn and the register reflected at the end when RefOut.
+func refCRC(c Params, p []byte) uint64 {
+	top := uint64(1) << (c.Width - 1)
+	mask := top<<1 - 1
+	reg := c.Init
+	for _, b := range p {
+		if c.RefIn {
+			b = bits.Reverse8(b)
+		}
+		for i := 7; i >= 0; i-- {
+			bit := uint64(b>>uint(i))&1 != 0
+			if (reg&top != 0) != bit {
+				reg = reg<<1 ^ c.Poly
+			} else {
+				reg <<= 1
+			}
+			reg &= mask
+		}
+	}
+	if c.RefOut {
+		reg = bits.Reverse64(reg) >> (64 - c.Width)
+	}
+	return reg ^ c.XorOut
+}
+
+var fixedCRCs = []struct {
+	name   string
+	fn     func([]byte) uint32
+	engine func() *engine
+	params Params
+	check  uint32
+}{
+	{"CRC-8/SMBUS", func(p []byte) uint32 { return uint32(Checksum8(p)) }, crc8, CRC8SMBUS, 0xF4},
+	{"CRC-32/ISO-HDLC", ChecksumIEEE, crc32IEEE, CRC32ISOHDLC, 0xCBF43926},
+	{"CRC-32/ISCSI", ChecksumCastagnoli, crc32C, CRC32ISCSI, 0xE3069283},
+	{"CRC-32/MPEG-2", ChecksumMPEG2, crc32MPEG2, CRC32MPEG2, 0x0376E6E7},
+	{"Ogg", ChecksumOgg, crc32Ogg, Params{Width: 32, Poly: 0x04C11DB7}, 0x89A1897F},
+}
+
+func TestFixedCRCCheckValues(t *testing.T) {
+	for _, c := range fixedCRCs {
+		if got := c.fn([]byte("123456789")); got != c.check {
+			t.Errorf("%s(\"123456789\") = %#x, want %#x", c.name, got, c.check)
+		}
+	}
+}
+
+// TestFixedCRCParity checks every fixed CRC against the bit-at-a-time reference
+// across lengths that straddle the fold stride, the fold threshold and odd
+// tails, on multi-KB buffers, and on a buffer walking every byte value.
+func TestFixedCRCParity(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	lengths := make([]int, 0, 300)
+	for n := 0; n <= 260; n++ {
+		lengths = append(lengths, n)
+	}
+	lengths = append(lengths, 1024, 4097, 4111, 16385, 65535)
+	for _, c := range fixedCRCs {
+		for _, n := range lengths {
+			buf := make([]byte, n)
+			r.Read(buf)
+			if got, want := c.fn(buf), refCRC(c.params, buf); uint64(got) != want {
+				t.Fatalf("%s n=%d: %#x, want %#x", c.name, n, got, want)
+			}
+		}
+		walk := make([]byte, 256)
+		for i := range walk {
+			walk[i] = byte(i)
+		}
+		if got, want := c.fn(walk), refCRC(c.params, walk); uint64(got) != want {
+			t.Fatalf("%s all byte values: %#x, want %#x", c.name, got, want)
+		}
+	}
+}
+
+// TestCRC32MatchesStdlib checks the reflected CRC-32s against hash/crc32's
+// tables.
+func TestCRC32MatchesStdlib(t *testing.T) {
+	castagnoli := crc32.MakeTable(crc32.Castagnoli)
+	r := rand.New(rand.NewSource(8))
+	for _, n := range []int{0, 1, 15, 16, 63, 64, 65, 1000, 8191} {
+		buf := make([]byte, n)
+		r.Read(buf)
+		if got, want := ChecksumIEEE(buf), crc32.ChecksumIEEE(buf); got != want {
+			t.Fatalf("n=%d: ChecksumIEEE = %#08x, want %#08x", n, got, want)
+		}
+		if got, want := ChecksumCastagnoli(buf), crc32.Checksum(buf, castagnoli); got != want {
+			t.Fatalf("n=%d: ChecksumCastagnoli = %#08x, want %#08x", n, got, want)
+		}
+	}
+}
+
+func TestFixedCRCZeroAlloc(t *testing.T) {
+	buf := make([]byte, 16384)
+	for _, c := range fixedCRCs {
+		if n := testing.AllocsPerRun(20, func() { _ = c.fn(buf) }); n != 0 {
+			t.Fatalf("%s allocated %v times per run, want 0", c.name, n)
+		}
+	}
+}
+
+func BenchmarkFixedCRC(b *testing.B) {
+	for _, c := range fixedCRCs {
+		for _, n := range []int{64, 4096} {
+			buf := make([]byte, n)
+			for i := range buf {
+				buf[i] = byte(i)
+			}
+			b.Run(c.name+"/"+strconv.Itoa(n), func(b *testing.B) {
+				b.SetBytes(int64(n))
+				for b.Loop() {
+					_ = c.fn(buf)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkFixedCRCScalar measures the slice-by-16 fallback directly.
+func BenchmarkFixedCRCScalar(b *testing.B) {
+	buf := make([]byte, 4096)
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		e := crc32IEEE()
+		_ = e.finish(e.update(e.init, buf))
+	}
+}
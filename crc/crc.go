@@ -5,10 +5,25 @@
 // polynomial is shared by many CRC-16 variants (ARC, Modbus, USB, and others,
 // which differ in reflection, init, and xorout); this MSB-first, unreflected
 // parameterization is the one a FLAC encoder computes over every coded frame,
-// but it is not FLAC-specific. Checksum16 folds the bulk of the buffer 16 bytes
-// at a time using a carry-less-multiply kernel (PCLMULQDQ on amd64, PMULL on
-// arm64) and falls back to a slice-by-16 table loop on architectures without a
-// polynomial-multiply instruction.
+// but it is not FLAC-specific.
+//
+// Checksum8 is the CRC-8 (polynomial 0x07) that protects a FLAC frame header,
+// and ChecksumIEEE, ChecksumCastagnoli, ChecksumMPEG2 and ChecksumOgg are the
+// common CRC-32 variants: the reflected IEEE and Castagnoli CRCs of zip/PNG and
+// iSCSI/ext4 (equal to hash/crc32), and the unreflected CRC-32/MPEG-2 and the Ogg
+// page checksum.
+//
+// Model covers every other CRC: NewModel takes any Rocksoft-model
+// parameterization (width up to 64, polynomial, init, input/output reflection,
+// xorout; the Params catalogue has ARC, Modbus, USB, XMODEM, CRC-64/XZ and
+// others), derives its fold constants at construction, and offers one-shot
+// Checksum, Update, a streaming hash.Hash64 Digest, and Combine for the CRC of
+// concatenated buffers.
+//
+// Every checksum folds the bulk of the buffer 16 bytes at a time using a
+// carry-less-multiply kernel (PCLMULQDQ on amd64, PMULL on arm64) and falls back
+// to a slice-by-16 table loop for the tail, for short buffers, and on
+// architectures without a polynomial-multiply instruction.
 //
 // All functions are bit-identical to the scalar reference and allocation-free.
 //
@@ -16,8 +31,9 @@
 //
 // # Aliasing
 //
-// Checksum16 reads its input byte slice and returns a scalar; it writes no output
-// slice, so aliasing does not apply.
+// The checksums read their input byte slice and return a scalar; they write no
+// output slice, so aliasing does not apply. Digest.Sum appends to its argument
+// like any hash.Hash.
 package crc
 
 import "encoding/binary"
@@ -44,13 +60,16 @@ const (
 //	crc16K2 = x^128 mod P  (applied to the low half,  bits  63..0)
 //
 // Both reduce to degree < 16, so each carry-less product stays below 80 bits.
-// The assembly kernels embed these same literals; crc_model_test.go asserts
-// they equal x^192 mod P and x^128 mod P so the two never drift apart.
+// crc_model_test.go asserts they equal x^192 mod P and x^128 mod P.
 const (
 	crc16K1 = 0x1666 // x^192 mod 0x18005
 	crc16K2 = 0x0106 // x^128 mod 0x18005
 )
 
+// crc16Keys are the fold keys in foldBlocksMSB's lane order: lane 0 holds the
+// low half of the accumulator, lane 1 the high half.
+var crc16Keys = [2]uint64{crc16K2, crc16K1}
+
 var (
 	table16  [256]uint16
 	table16x [16][256]uint16
@@ -95,6 +114,19 @@ func Checksum16(p []byte) uint16 {
 	return checksum16(p)
 }
 
+// checksum16 folds the bulk of p with the carry-less-multiply kernel when
+// available, then reduces the folded accumulator plus the trailing tail with the
+// scalar path.
+func checksum16(p []byte) uint16 {
+	if foldSupported() && len(p) >= minFoldBytes {
+		full := len(p) &^ (blockBytes - 1)
+		var acc [2]uint64
+		foldBlocksMSB(&acc, p[:full], &crc16Keys)
+		return checksum16FromAcc(acc[1], acc[0], p[full:])
+	}
+	return checksum16Go(p)
+}
+
 // checksum16Go is the pure-Go slice-by-16 reference. It is the fallback on
 // architectures without a carry-less-multiply instruction and the final
 // reduction step shared by the SIMD kernels.
@@ -10,11 +10,11 @@ import "github.com/tphakala/simd/cpu"
 // the benchmarks; FLAC frames are kilobytes, so the hot path always folds.
 const minFoldBytes = 64
 
-// hasFoldISA reports whether every instruction crc16FoldBlocks uses is present,
-// which is more than its name suggests. The kernel folds with PCLMULQDQ, but it
-// also byte-swaps with PSHUFB (SSSE3) and moves the accumulator halves with
-// PINSRQ/PEXTRQ (SSE4.1), and PCLMULQDQ implies neither: they are separate CPUID
-// bits, exactly as FMA3 is separate from AVX (#201).
+// hasFoldISA reports whether every instruction the fold kernels use is present,
+// which is more than their names suggest. They fold with PCLMULQDQ, but
+// foldBlocksMSB also byte-swaps with PSHUFB (SSSE3), and PCLMULQDQ does not
+// imply it: they are separate CPUID bits, exactly as FMA3 is separate from AVX
+// (#201).
 //
 // No shipping part has PCLMULQDQ without SSE4.1 (Westmere onward, Bulldozer
 // onward and Jaguar onward all carry both), so this is a latent mismatch rather
@@ -23,20 +23,17 @@ const minFoldBytes = 64
 // implies SSSE3, so testing it covers PSHUFB too.
 var hasFoldISA = cpu.X86.PCLMULQDQ && cpu.X86.SSE41
 
-// foldSupported reports whether the carry-less-multiply kernel is active.
+// bindKernels re-reads the feature flags cached above from cpu.X86.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasFoldISA = cpu.X86.PCLMULQDQ && cpu.X86.SSE41
+}
+
+// foldSupported reports whether the carry-less-multiply kernels are active.
 func foldSupported() bool { return hasFoldISA }
 
-// checksum16 folds the bulk of p with PCLMULQDQ when available, then reduces the
-// folded accumulator plus the trailing tail with the scalar path.
-func checksum16(p []byte) uint16 {
-	if hasFoldISA && len(p) >= minFoldBytes {
-		full := len(p) &^ (blockBytes - 1)
-		var acc [2]uint64
-		crc16FoldBlocks(&acc, p[:full])
-		return checksum16FromAcc(acc[0], acc[1], p[full:])
-	}
-	return checksum16Go(p)
-}
+//go:noescape
+func foldBlocksMSB(acc *[2]uint64, p []byte, keys *[2]uint64)
 
 //go:noescape
-func crc16FoldBlocks(acc *[2]uint64, p []byte)
+func foldBlocksLSB(acc *[2]uint64, p []byte, keys *[2]uint64)
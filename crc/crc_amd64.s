@@ -2,72 +2,100 @@
 
 #include "textflag.h"
 
-// FLAC CRC-16 (polynomial 0x8005, MSB-first, no reflection) carry-less-multiply
-// fold for AMD64 (PCLMULQDQ).
+// CRC carry-less-multiply folds for AMD64 (PCLMULQDQ).
 //
-// crc16FoldBlocks folds every full 16-byte block of p into the 128-bit
-// accumulator *acc (acc[0] = high bits 127..64, acc[1] = low bits 63..0). Each
-// block computes acc = (acc * x^128 mod P) ^ block via two carry-less products:
+// Both kernels fold every full 16-byte block of p into the 128-bit accumulator
+// *acc, held in register lane order (acc[0] = lane 0, bits 63..0; acc[1] = lane
+// 1, bits 127..64). Each block computes
 //
-//	fold = clmul(acc_hi, x^192 mod P) ^ clmul(acc_lo, x^128 mod P)
-//	acc  = fold ^ block
+//	acc = clmul(lane0, keys[0]) ^ clmul(lane1, keys[1]) ^ block
 //
-// which keeps acc congruent to the processed prefix modulo P; the Go caller
-// finishes with the scalar reduction. This is a 1:1 translation of crc16FoldGo
-// in crc_model_test.go and is pinned against it by the parity tests.
+// which multiplies the accumulator by x^128 mod P and adds the block, keeping it
+// congruent to the processed prefix modulo P; the Go caller finishes with the
+// scalar reduction. The keys carry the whole parameterization (see newEngine),
+// so one kernel per bit order serves every CRC: foldBlocksMSB for direct CRCs
+// (Checksum16, CRC-8, CRC-32/MPEG-2), foldBlocksLSB for reflected ones (CRC-32
+// IEEE and Castagnoli). They are 1:1 translations of foldMSBGo and foldLSBGo in
+// crc_model_test.go and are pinned against them by the parity tests.
 //
 // A single accumulator means the loop is bound by PCLMULQDQ latency rather than
 // throughput, but that still clears the slice-by-16 table loop several times
-// over. The internal xmm layout keeps logical bit i in xmm bit i: lane0 holds
-// the low 64 bits, lane1 the high 64 bits. Input blocks are byte-reversed with
-// PSHUFB so the first (most significant) byte lands in the high lane, matching
-// the MSB-first CRC.
+// over.
 
 // bswapMask reverses all 16 bytes of a block: out[i] = in[15-i].
 DATA bswapMask<>+0(SB)/8, $0x08090a0b0c0d0e0f
 DATA bswapMask<>+8(SB)/8, $0x0001020304050607
 GLOBL bswapMask<>(SB), RODATA|NOPTR, $16
 
-// func crc16FoldBlocks(acc *[2]uint64, p []byte)
-TEXT ·crc16FoldBlocks(SB), NOSPLIT, $0-32
+// func foldBlocksMSB(acc *[2]uint64, p []byte, keys *[2]uint64)
+// Direct (MSB-first) CRCs: logical bit i sits in register bit i, so each block is
+// byte-reversed with PSHUFB to put its first, most significant byte in the top
+// of lane 1.
+TEXT ·foldBlocksMSB(SB), NOSPLIT, $0-40
 	MOVQ acc+0(FP), AX     // AX = &acc
 	MOVQ p_base+8(FP), SI  // SI = &p[0]
 	MOVQ p_len+16(FP), CX  // CX = len(p)
+	MOVQ keys+32(FP), DX   // DX = &keys
 	SHRQ $4, CX            // CX = number of full 16-byte blocks
 
-	// Load accumulator into X0: lane0 = accLo = acc[1], lane1 = accHi = acc[0].
-	MOVQ   8(AX), X0
-	MOVQ   0(AX), BX
-	PINSRQ $1, BX, X0
-
-	// Fold constants in low lanes: X1 = K1 (x^192 mod P), X2 = K2 (x^128 mod P).
-	MOVQ $0x1666, BX
-	MOVQ BX, X1
-	MOVQ $0x0106, BX
-	MOVQ BX, X2
+	MOVOU (AX), X0         // X0 = accumulator, lane0 = acc[0], lane1 = acc[1]
+	MOVQ  0(DX), X2        // X2 = keys[0] (multiplies lane 0)
+	MOVQ  8(DX), X1        // X1 = keys[1] (multiplies lane 1)
 
 	MOVOU bswapMask<>(SB), X5 // byte-reverse shuffle control
 
 	TESTQ CX, CX
-	JZ    done
+	JZ    msb_done
 
-loop:
+msb_loop:
 	MOVOU  (SI), X3          // X3 = next 16 bytes (little-endian)
 	PSHUFB X5, X3            // byte-reverse: first byte -> high lane (MSB-first)
 
-	MOVOU     X0, X4         // X4 = acc copy for the high-half product
-	PCLMULQDQ $0x01, X1, X4  // X4 = clmul(acc_hi, K1)  [dst hi qword, src lo qword]
-	PCLMULQDQ $0x00, X2, X0  // X0 = clmul(acc_lo, K2)  [dst lo qword, src lo qword]
-	PXOR      X4, X0         // X0 = clmul(acc_hi,K1) ^ clmul(acc_lo,K2)
+	MOVOU     X0, X4         // X4 = acc copy for the lane-1 product
+	PCLMULQDQ $0x01, X1, X4  // X4 = clmul(lane1, keys[1])  [dst hi qword, src lo qword]
+	PCLMULQDQ $0x00, X2, X0  // X0 = clmul(lane0, keys[0])  [dst lo qword, src lo qword]
+	PXOR      X4, X0
+	PXOR      X3, X0         // X0 = fold ^ block  ->  new accumulator
+
+	ADDQ $16, SI
+	DECQ CX
+	JNZ  msb_loop
+
+msb_done:
+	MOVOU X0, (AX)
+	RET
+
+// func foldBlocksLSB(acc *[2]uint64, p []byte, keys *[2]uint64)
+// Reflected (LSB-first) CRCs: a block is taken in memory order, its first byte's
+// low bit being the highest power, so lane 0 holds the high half and no byte
+// reversal is needed. The keys are bit-reversed to match (see newEngine).
+TEXT ·foldBlocksLSB(SB), NOSPLIT, $0-40
+	MOVQ acc+0(FP), AX     // AX = &acc
+	MOVQ p_base+8(FP), SI  // SI = &p[0]
+	MOVQ p_len+16(FP), CX  // CX = len(p)
+	MOVQ keys+32(FP), DX   // DX = &keys
+	SHRQ $4, CX            // CX = number of full 16-byte blocks
+
+	MOVOU (AX), X0         // X0 = accumulator, lane0 = acc[0], lane1 = acc[1]
+	MOVQ  0(DX), X2        // X2 = keys[0] (multiplies lane 0)
+	MOVQ  8(DX), X1        // X1 = keys[1] (multiplies lane 1)
+
+	TESTQ CX, CX
+	JZ    lsb_done
+
+lsb_loop:
+	MOVOU (SI), X3           // X3 = next 16 bytes, memory order
+
+	MOVOU     X0, X4         // X4 = acc copy for the lane-1 product
+	PCLMULQDQ $0x01, X1, X4  // X4 = clmul(lane1, keys[1])
+	PCLMULQDQ $0x00, X2, X0  // X0 = clmul(lane0, keys[0])
+	PXOR      X4, X0
 	PXOR      X3, X0         // X0 = fold ^ block  ->  new accumulator
 
 	ADDQ $16, SI
 	DECQ CX
-	JNZ  loop
+	JNZ  lsb_loop
 
-done:
-	// Store accumulator back: acc[0] = accHi = lane1, acc[1] = accLo = lane0.
-	MOVQ   X0, 8(AX)
-	PEXTRQ $1, X0, BX
-	MOVQ   BX, 0(AX)
+lsb_done:
+	MOVOU X0, (AX)
 	RET
@@ -12,20 +12,17 @@ const minFoldBytes = 64
 
 var hasPMULL = cpu.ARM64.PMULL
 
-// foldSupported reports whether the carry-less-multiply kernel is active.
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasPMULL = cpu.ARM64.PMULL
+}
+
+// foldSupported reports whether the carry-less-multiply kernels are active.
 func foldSupported() bool { return hasPMULL }
 
-// checksum16 folds the bulk of p with PMULL when available, then reduces the
-// folded accumulator plus the trailing tail with the scalar path.
-func checksum16(p []byte) uint16 {
-	if hasPMULL && len(p) >= minFoldBytes {
-		full := len(p) &^ (blockBytes - 1)
-		var acc [2]uint64
-		crc16FoldBlocks(&acc, p[:full])
-		return checksum16FromAcc(acc[0], acc[1], p[full:])
-	}
-	return checksum16Go(p)
-}
+//go:noescape
+func foldBlocksMSB(acc *[2]uint64, p []byte, keys *[2]uint64)
 
 //go:noescape
-func crc16FoldBlocks(acc *[2]uint64, p []byte)
+func foldBlocksLSB(acc *[2]uint64, p []byte, keys *[2]uint64)
@@ -8,9 +8,9 @@ import (
 
 // This file holds the pure-Go reference model for the carry-less-multiply fold.
 // It is the executable specification the PCLMULQDQ and PMULL kernels must match:
-// the assembly is a 1:1 translation of crc16FoldGo, and the per-arch tests pin
-// the assembly output against this model. Keeping the model in test code means
-// production builds never carry the slow bit-serial clmul emulation.
+// the assembly is a 1:1 translation of foldMSBGo and foldLSBGo, and the per-arch
+// tests pin the assembly output against this model. Keeping the model in test
+// code means production builds never carry the slow bit-serial clmul emulation.
 
 // xnModPRef computes x^n mod P for the FLAC CRC-16 polynomial (P = 0x18005,
 // degree 16); the result has degree < 16.
@@ -55,13 +55,79 @@ func crc16FoldGo(acc *[2]uint64, p []byte) {
 	acc[0], acc[1] = accHi, accLo
 }
 
+// foldMSBGo is the reference for foldBlocksMSB: acc is in register lane order
+// (acc[0] = bits 63..0), each block is read big-endian into the high lane first,
+// and keys[l] multiplies lane l.
+func foldMSBGo(acc *[2]uint64, p []byte, keys *[2]uint64) {
+	for len(p) >= 16 {
+		h1, l1 := clmul64(acc[0], keys[0])
+		h2, l2 := clmul64(acc[1], keys[1])
+		acc[0] = l1 ^ l2 ^ binary.BigEndian.Uint64(p[8:16])
+		acc[1] = h1 ^ h2 ^ binary.BigEndian.Uint64(p[0:8])
+		p = p[16:]
+	}
+}
+
+// foldLSBGo is the reference for foldBlocksLSB: as foldMSBGo, but each block is
+// read little-endian in memory order.
+func foldLSBGo(acc *[2]uint64, p []byte, keys *[2]uint64) {
+	for len(p) >= 16 {
+		h1, l1 := clmul64(acc[0], keys[0])
+		h2, l2 := clmul64(acc[1], keys[1])
+		acc[0] = l1 ^ l2 ^ binary.LittleEndian.Uint64(p[0:8])
+		acc[1] = h1 ^ h2 ^ binary.LittleEndian.Uint64(p[8:16])
+		p = p[16:]
+	}
+}
+
+// foldModelEngine is engine.checksum with the fold model in place of the
+// kernels, so the fold keys and the init/finish handling are checked on every
+// architecture.
+func foldModelEngine(e *engine, p []byte) uint64 {
+	if len(p) < 16 {
+		return e.finish(e.update(e.init, p))
+	}
+	full := len(p) &^ 15
+	var acc [2]uint64
+	var buf []byte
+	if e.reflected {
+		acc[0] = binary.LittleEndian.Uint64(p) ^ e.init
+		acc[1] = binary.LittleEndian.Uint64(p[8:])
+		foldLSBGo(&acc, p[16:full], &e.keys)
+		buf = binary.LittleEndian.AppendUint64(buf, acc[0])
+		buf = binary.LittleEndian.AppendUint64(buf, acc[1])
+	} else {
+		acc[1] = binary.BigEndian.Uint64(p) ^ e.init
+		acc[0] = binary.BigEndian.Uint64(p[8:])
+		foldMSBGo(&acc, p[16:full], &e.keys)
+		buf = binary.BigEndian.AppendUint64(buf, acc[1])
+		buf = binary.BigEndian.AppendUint64(buf, acc[0])
+	}
+	return e.finish(e.update(0, append(buf, p[full:]...)))
+}
+
+// TestFoldModelEngines validates the fold keys of every fixed CRC end-to-end
+// against the bit-at-a-time reference, independent of any assembly.
+func TestFoldModelEngines(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for _, c := range fixedCRCs {
+		for _, n := range []int{16, 17, 31, 32, 33, 100, 255, 256, 1000, 4099} {
+			buf := make([]byte, n)
+			r.Read(buf)
+			if got, want := foldModelEngine(c.engine(), buf), refCRC(c.params, buf); got != want {
+				t.Fatalf("%s n=%d: fold model %#x, want %#x", c.name, n, got, want)
+			}
+		}
+	}
+}
+
 // foldModelChecksum is the full CRC-16 via the fold model plus scalar reduction,
 // mirroring exactly what the SIMD dispatch does with the assembly kernel.
 func foldModelChecksum(p []byte) uint16 {
 	full := len(p) &^ 15
 	var acc [2]uint64
-	crc16FoldGo(&acc, p[:full])
-	return checksum16FromAcc(acc[0], acc[1], p[full:])
+	foldMSBGo(&acc, p[:full], &crc16Keys)
+	return checksum16FromAcc(acc[1], acc[0], p[full:])
 }
 
 // TestFoldConstantsAreXnModP pins the embedded fold constants to their algebraic
@@ -2,6 +2,16 @@
 
 package crc
 
-// checksum16 uses the scalar slice-by-16 loop on architectures without a
-// carry-less-multiply instruction.
-func checksum16(p []byte) uint16 { return checksum16Go(p) }
+// minFoldBytes is unused without a carry-less-multiply instruction; every
+// checksum runs the scalar slice-by-16 loop.
+const minFoldBytes = 0
+
+// foldSupported reports whether the carry-less-multiply kernels are active.
+func foldSupported() bool { return false }
+
+// The fold kernels are never reached here, since foldSupported is false.
+func foldBlocksMSB(_ *[2]uint64, _ []byte, _ *[2]uint64) {}
+func foldBlocksLSB(_ *[2]uint64, _ []byte, _ *[2]uint64) {}
+
+// bindKernels has no flags to re-read: every operation runs pure Go here.
+func bindKernels() {}
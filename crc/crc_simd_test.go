@@ -7,6 +7,14 @@ import (
 	"testing"
 )
 
+// crc16FoldBlocks runs foldBlocksMSB with the CRC-16 keys on an accumulator in
+// crc16FoldGo's order (acc[0] = high bits 127..64).
+func crc16FoldBlocks(acc *[2]uint64, p []byte) {
+	lanes := [2]uint64{acc[1], acc[0]}
+	foldBlocksMSB(&lanes, p, &crc16Keys)
+	acc[0], acc[1] = lanes[1], lanes[0]
+}
+
 // TestCRC16FoldBlocksMatchesModel pins the carry-less-multiply kernel (PCLMULQDQ
 // on amd64, PMULL on arm64) against the pure-Go fold model. A random nonzero
 // starting accumulator exercises the loop-carried fold dependency, not just the
@@ -33,3 +41,33 @@ func TestCRC16FoldBlocksMatchesModel(t *testing.T) {
 		}
 	}
 }
+
+// TestFoldBlocksMatchModel pins both kernels against foldMSBGo and foldLSBGo
+// with the keys of every fixed CRC, from a random nonzero accumulator.
+func TestFoldBlocksMatchModel(t *testing.T) {
+	if !foldSupported() {
+		t.Skip("CPU has no carry-less-multiply instruction")
+	}
+	r := rand.New(rand.NewSource(5))
+	for _, c := range fixedCRCs {
+		e := c.engine()
+		for _, blocks := range []int{0, 1, 2, 3, 7, 64} {
+			buf := make([]byte, blocks*16)
+			r.Read(buf)
+			var model, asm [2]uint64
+			model[0], model[1] = r.Uint64(), r.Uint64()
+			asm = model
+			if e.reflected {
+				foldLSBGo(&model, buf, &e.keys)
+				foldBlocksLSB(&asm, buf, &e.keys)
+			} else {
+				foldMSBGo(&model, buf, &e.keys)
+				foldBlocksMSB(&asm, buf, &e.keys)
+			}
+			if asm != model {
+				t.Fatalf("%s blocks=%d: asm={%#016x,%#016x} model={%#016x,%#016x}",
+					c.name, blocks, asm[0], asm[1], model[0], model[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package crc
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// engine is a table-driven CRC of width 1..64 in the Rocksoft model, plus the
+// fold keys that let the carry-less-multiply kernels (foldBlocksMSB for direct
+// CRCs, foldBlocksLSB for reflected ones) process the bulk of a buffer. The
+// register is held left-aligned in a uint64 for a direct CRC, so every width
+// shares the top-byte table index, and right-aligned (reflected) for a
+// reflected one.
+//
+// Folding works on the raw message polynomial, which is why the register enters
+// by XOR into the first block: a CRC with register value r over M equals the
+// zero-register CRC of M with r XORed into its first width bits. After the
+// fold, the 128-bit accumulator is congruent to the processed prefix, and the
+// zero-register table CRC of its 16 bytes followed by the unfolded tail
+// finishes the job.
+type engine struct {
+	width     uint
+	reflected bool   // RefIn: bytes enter LSB-first and the register is reflected
+	flip      bool   // RefOut != RefIn: reflect the register once more at the end
+	init      uint64 // initial register in register form (left-aligned or reflected)
+	xorout    uint64
+	keys      [2]uint64 // multipliers for accumulator lane 0 and lane 1, see newEngine
+	tab       [blockBytes][256]uint64
+}
+
+// newEngine builds the slice-by-16 tables and fold keys for a CRC model. The
+// parameters must already be valid (see Params).
+//
+// A direct CRC keeps polynomial bit k in register bit k, so lane 1 holds the
+// high half H and lane 0 the low half L of the accumulator A = H*x^64 + L, and
+// folding one block forward multiplies H by x^192 mod P and L by x^128 mod P. A
+// reflected CRC loads blocks without byte reversal, which puts H in lane 0 and
+// stores every 64-bit multiplier bit-reversed; a reflected carry-less product
+// also comes out multiplied by x, so its keys are x^191 and x^127 mod P instead.
+// Every key has degree below 64, so each product fits the 128-bit accumulator
+// for any width up to 64.
+func newEngine(p Params) *engine {
+	w := p.Width
+	e := &engine{
+		width:     w,
+		reflected: p.RefIn,
+		flip:      p.RefIn != p.RefOut,
+		xorout:    p.XorOut,
+	}
+	if p.RefIn {
+		e.init = reflect(p.Init, w)
+		rpoly := reflect(p.Poly, w)
+		for b := range 256 {
+			c := uint64(b)
+			for range bitsPerByte {
+				if c&1 != 0 {
+					c = c>>1 ^ rpoly
+				} else {
+					c >>= 1
+				}
+			}
+			e.tab[0][b] = c
+		}
+		for n := 1; n < blockBytes; n++ {
+			for b := range 256 {
+				prev := e.tab[n-1][b]
+				e.tab[n][b] = prev>>bitsPerByte ^ e.tab[0][byte(prev)]
+			}
+		}
+		e.keys = [2]uint64{
+			bits.Reverse64(xnModP(191, p.Poly, w)),
+			bits.Reverse64(xnModP(127, p.Poly, w)),
+		}
+		return e
+	}
+
+	e.init = p.Init << (64 - w)
+	apoly := p.Poly << (64 - w)
+	for b := range 256 {
+		c := uint64(b) << 56
+		for range bitsPerByte {
+			if c&(1<<63) != 0 {
+				c = c<<1 ^ apoly
+			} else {
+				c <<= 1
+			}
+		}
+		e.tab[0][b] = c
+	}
+	for n := 1; n < blockBytes; n++ {
+		for b := range 256 {
+			prev := e.tab[n-1][b]
+			e.tab[n][b] = prev<<bitsPerByte ^ e.tab[0][byte(prev>>56)]
+		}
+	}
+	e.keys = [2]uint64{xnModP(128, p.Poly, w), xnModP(192, p.Poly, w)}
+	return e
+}
+
+// reflect reverses the low width bits of v.
+func reflect(v uint64, width uint) uint64 {
+	return bits.Reverse64(v) >> (64 - width)
+}
+
+// mulModP returns a*b mod P for width-bit polynomials a and b, P = x^width +
+// poly, one bit of b at a time from the top.
+func mulModP(a, b, poly uint64, width uint) uint64 {
+	mask := ^uint64(0) >> (64 - width)
+	var r uint64
+	for i := int(width) - 1; i >= 0; i-- {
+		top := r>>(width-1)&1 != 0
+		r = r << 1 & mask
+		if top {
+			r ^= poly
+		}
+		if b>>uint(i)&1 != 0 {
+			r ^= a
+		}
+	}
+	return r
+}
+
+// xnModP returns x^n mod P for the width-bit polynomial poly (P = x^width +
+// poly), by square-and-multiply from x^1.
+func xnModP(n uint64, poly uint64, width uint) uint64 {
+	mask := ^uint64(0) >> (64 - width)
+	// x mod P: x itself unless width == 1, where x = P + poly.
+	base := uint64(2) & mask
+	if width == 1 {
+		base = poly
+	}
+	r := uint64(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			r = mulModP(r, base, poly, width)
+		}
+		base = mulModP(base, base, poly, width)
+	}
+	return r
+}
+
+// update runs the register c over p with the slice-by-16 tables, in the
+// engine's register form.
+func (e *engine) update(c uint64, p []byte) uint64 {
+	t := &e.tab
+	if e.reflected {
+		for len(p) >= blockBytes {
+			c ^= binary.LittleEndian.Uint64(p)
+			c = t[15][byte(c)] ^ t[14][byte(c>>8)] ^ t[13][byte(c>>16)] ^ t[12][byte(c>>24)] ^
+				t[11][byte(c>>32)] ^ t[10][byte(c>>40)] ^ t[9][byte(c>>48)] ^ t[8][c>>56] ^
+				t[7][p[8]] ^ t[6][p[9]] ^ t[5][p[10]] ^ t[4][p[11]] ^
+				t[3][p[12]] ^ t[2][p[13]] ^ t[1][p[14]] ^ t[0][p[15]]
+			p = p[blockBytes:]
+		}
+		for _, b := range p {
+			c = c>>bitsPerByte ^ t[0][byte(c)^b]
+		}
+		return c
+	}
+	for len(p) >= blockBytes {
+		c ^= binary.BigEndian.Uint64(p)
+		c = t[15][c>>56] ^ t[14][byte(c>>48)] ^ t[13][byte(c>>40)] ^ t[12][byte(c>>32)] ^
+			t[11][byte(c>>24)] ^ t[10][byte(c>>16)] ^ t[9][byte(c>>8)] ^ t[8][byte(c)] ^
+			t[7][p[8]] ^ t[6][p[9]] ^ t[5][p[10]] ^ t[4][p[11]] ^
+			t[3][p[12]] ^ t[2][p[13]] ^ t[1][p[14]] ^ t[0][p[15]]
+		p = p[blockBytes:]
+	}
+	for _, b := range p {
+		c = c<<bitsPerByte ^ t[0][byte(c>>56)^b]
+	}
+	return c
+}
+
+// run advances the register c over p: the carry-less-multiply fold over the
+// whole 16-byte blocks when the CPU has one and p is long enough, the
+// slice-by-16 table loop otherwise.
+func (e *engine) run(c uint64, p []byte) uint64 {
+	if !foldSupported() || len(p) < minFoldBytes {
+		return e.update(c, p)
+	}
+	full := len(p) &^ (blockBytes - 1)
+	var acc [2]uint64
+	var buf [2*blockBytes - 1]byte // 16 accumulator bytes + at most 15 tail bytes
+	if e.reflected {
+		acc[0] = binary.LittleEndian.Uint64(p) ^ c
+		acc[1] = binary.LittleEndian.Uint64(p[8:])
+		foldBlocksLSB(&acc, p[blockBytes:full], &e.keys)
+		binary.LittleEndian.PutUint64(buf[0:8], acc[0])
+		binary.LittleEndian.PutUint64(buf[8:blockBytes], acc[1])
+	} else {
+		acc[1] = binary.BigEndian.Uint64(p) ^ c
+		acc[0] = binary.BigEndian.Uint64(p[8:])
+		foldBlocksMSB(&acc, p[blockBytes:full], &e.keys)
+		binary.BigEndian.PutUint64(buf[0:8], acc[1])
+		binary.BigEndian.PutUint64(buf[8:blockBytes], acc[0])
+	}
+	n := blockBytes + copy(buf[blockBytes:], p[full:])
+	return e.update(0, buf[:n])
+}
+
+// checksum returns the CRC of p.
+func (e *engine) checksum(p []byte) uint64 {
+	return e.finish(e.run(e.init, p))
+}
+
+// finish turns a register into the CRC value.
+func (e *engine) finish(c uint64) uint64 {
+	if !e.reflected {
+		c >>= 64 - e.width
+	}
+	if e.flip {
+		c = reflect(c, e.width)
+	}
+	return c ^ e.xorout
+}
+
+// unfinish is the inverse of finish: the register a CRC value was produced from.
+func (e *engine) unfinish(crc uint64) uint64 {
+	c := (crc ^ e.xorout) & (^uint64(0) >> (64 - e.width))
+	if e.flip {
+		c = reflect(c, e.width)
+	}
+	if !e.reflected {
+		c <<= 64 - e.width
+	}
+	return c
+}
@@ -28,3 +28,24 @@ func FuzzChecksum16(f *testing.F) {
 		}
 	})
 }
+
+// FuzzFixedCRCs is the differential target for the CRC-8 and CRC-32 variants:
+// the dispatched checksum (folded when available) must agree with the
+// slice-by-16 table loop alone.
+func FuzzFixedCRCs(f *testing.F) {
+	for _, n := range []int{0, 1, 15, 16, 17, 63, 64, 65, 127, 128, 129, 256} {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(i*31 + 7)
+		}
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, p []byte) {
+		for _, c := range fixedCRCs {
+			e := c.engine()
+			if got, want := e.checksum(p), e.finish(e.update(e.init, p)); got != want {
+				t.Fatalf("%s(len=%d) = %#x, table loop = %#x", c.name, len(p), got, want)
+			}
+		}
+	})
+}
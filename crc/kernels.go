@@ -0,0 +1,39 @@
+package crc
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the slice-by-16 table loop that inputs shorter than minFoldBytes
+// take on every architecture. kernelBinding (per architecture) reports the fold
+// kernel the operation is bound to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Checksum16":         checksum16Go,
+	"Checksum8":          (*engine).update,
+	"ChecksumIEEE":       (*engine).update,
+	"ChecksumCastagnoli": (*engine).update,
+	"ChecksumMPEG2":      (*engine).update,
+	"ChecksumOgg":        (*engine).update,
+	"Model.Checksum":     (*engine).update,
+	"Model.Update":       (*engine).update,
+	"Digest.Write":       (*engine).update,
+}
+
+// foldKernels maps each operation to the fold its bulk runs on: the MSB-first
+// fold for the direct CRCs and the reflected fold for the reflected ones. A
+// Model folds in its own orientation; it reports the reflected fold, which the
+// common models (CRC-32/ISO-HDLC, CRC-64/XZ) take.
+var foldKernels = map[string]any{
+	"Checksum16":         foldBlocksMSB,
+	"Checksum8":          foldBlocksMSB,
+	"ChecksumIEEE":       foldBlocksLSB,
+	"ChecksumCastagnoli": foldBlocksLSB,
+	"ChecksumMPEG2":      foldBlocksMSB,
+	"ChecksumOgg":        foldBlocksMSB,
+	"Model.Checksum":     foldBlocksLSB,
+	"Model.Update":       foldBlocksLSB,
+	"Digest.Write":       foldBlocksLSB,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "crc", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
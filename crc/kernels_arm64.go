@@ -0,0 +1,11 @@
+//go:build arm64
+
+package crc
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelBinding reports the fold kernel op is bound to when the host has the
+// PMULL fold.
+func kernelBinding(op string) dispatch.Binding {
+	return dispatch.When(foldSupported(), dispatch.PMULL, foldKernels[op])
+}
@@ -0,0 +1,9 @@
+//go:build !amd64 && !arm64
+
+package crc
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelBinding reports the Go reference for every operation: there is no
+// carry-less multiply on this architecture.
+func kernelBinding(string) dispatch.Binding { return dispatch.Binding{} }
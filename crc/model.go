@@ -0,0 +1,221 @@
+package crc
+
+import (
+	"errors"
+	"hash"
+)
+
+// ErrParams is returned by NewModel for a width outside 1..64 or a Poly, Init or
+// XorOut wider than Width bits.
+var ErrParams = errors.New("crc: model needs a width of 1..64 and poly, init and xorout that fit in it")
+
+// maxWidth is the widest CRC register a Model supports.
+const maxWidth = 64
+
+// Params is a CRC parameterization in the Rocksoft model (Ross Williams, "A
+// Painless Guide to CRC Error Detection Algorithms"), the form the CRC RevEng
+// catalogue uses:
+//
+//   - Width is the degree of the generator polynomial, 1..64;
+//   - Poly is the polynomial without its x^Width term, MSB-first (0x04C11DB7 for
+//     the CRC-32 polynomial), never reflected;
+//   - Init is the register before the first byte, in the unreflected orientation;
+//   - RefIn feeds each byte least significant bit first, and RefOut reflects the
+//     register before the final XOR;
+//   - XorOut is XORed into the register to give the CRC.
+//
+// Check, the CRC of the ASCII string "123456789", and Name are the catalogue's
+// and play no part in the computation.
+type Params struct {
+	Name   string
+	Width  uint
+	Poly   uint64
+	Init   uint64
+	RefIn  bool
+	RefOut bool
+	XorOut uint64
+	Check  uint64
+}
+
+// Catalogue parameterizations, named as in CRC RevEng.
+var (
+	CRC8SMBUS = Params{
+		Name: "CRC-8/SMBUS", Width: 8, Poly: 0x07,
+		Check: 0xF4,
+	}
+
+	// CRC16UMTS is Checksum16's parameterization, the FLAC frame footer CRC.
+	CRC16UMTS = Params{
+		Name: "CRC-16/UMTS", Width: 16, Poly: 0x8005,
+		Check: 0xFEE8,
+	}
+
+	CRC16ARC = Params{
+		Name: "CRC-16/ARC", Width: 16, Poly: 0x8005,
+		RefIn: true, RefOut: true, Check: 0xBB3D,
+	}
+
+	CRC16MODBUS = Params{
+		Name: "CRC-16/MODBUS", Width: 16, Poly: 0x8005,
+		Init: 0xFFFF, RefIn: true, RefOut: true, Check: 0x4B37,
+	}
+
+	CRC16USB = Params{
+		Name: "CRC-16/USB", Width: 16, Poly: 0x8005,
+		Init: 0xFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFF, Check: 0xB4C8,
+	}
+
+	CRC16XMODEM = Params{
+		Name: "CRC-16/XMODEM", Width: 16, Poly: 0x1021,
+		Check: 0x31C3,
+	}
+
+	CRC16KERMIT = Params{
+		Name: "CRC-16/KERMIT", Width: 16, Poly: 0x1021,
+		RefIn: true, RefOut: true, Check: 0x2189,
+	}
+
+	// CRC32ISOHDLC is ChecksumIEEE's parameterization.
+	CRC32ISOHDLC = Params{
+		Name: "CRC-32/ISO-HDLC", Width: 32, Poly: 0x04C11DB7,
+		Init: 0xFFFFFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFFFFFF, Check: 0xCBF43926,
+	}
+
+	// CRC32ISCSI is ChecksumCastagnoli's parameterization.
+	CRC32ISCSI = Params{
+		Name: "CRC-32/ISCSI", Width: 32, Poly: 0x1EDC6F41,
+		Init: 0xFFFFFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFFFFFF, Check: 0xE3069283,
+	}
+
+	CRC32MPEG2 = Params{
+		Name: "CRC-32/MPEG-2", Width: 32, Poly: 0x04C11DB7,
+		Init: 0xFFFFFFFF, Check: 0x0376E6E7,
+	}
+
+	CRC64ECMA182 = Params{
+		Name: "CRC-64/ECMA-182", Width: 64, Poly: 0x42F0E1EBA9EA3693,
+		Check: 0x6C40DF5F0B497347,
+	}
+
+	// CRC64XZ equals hash/crc64 with the ECMA table.
+	CRC64XZ = Params{
+		Name: "CRC-64/XZ", Width: 64, Poly: 0x42F0E1EBA9EA3693,
+		Init: 0xFFFFFFFFFFFFFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFFFFFFFFFFFFFF, Check: 0x995DC9BBDF1939FA,
+	}
+)
+
+// Model computes one CRC parameterization. NewModel derives its slice-by-16
+// tables and carry-less-multiply fold constants once, so every checksum of any
+// width takes the same folded path as the fixed checksums.
+//
+// A Model is immutable and safe for concurrent use; its Digests are not.
+type Model struct {
+	params Params
+	e      *engine
+}
+
+// NewModel builds a Model for p (about 32 KiB of tables). It returns ErrParams
+// for parameters out of range.
+func NewModel(p Params) (*Model, error) {
+	if p.Width < 1 || p.Width > maxWidth {
+		return nil, ErrParams
+	}
+	mask := ^uint64(0) >> (maxWidth - p.Width)
+	if p.Poly&^mask != 0 || p.Init&^mask != 0 || p.XorOut&^mask != 0 {
+		return nil, ErrParams
+	}
+	return &Model{params: p, e: newEngine(p)}, nil
+}
+
+// Params returns the parameters the model was built from.
+func (m *Model) Params() Params { return m.params }
+
+// Checksum returns the CRC of p. Allocation-free.
+func (m *Model) Checksum(p []byte) uint64 {
+	return m.e.checksum(p)
+}
+
+// Update returns the CRC of the concatenation of the data crc was computed over
+// and p, so Update(Checksum(a), b) == Checksum(a||b); Update's crc for empty
+// preceding data is Checksum(nil). Allocation-free.
+func (m *Model) Update(crc uint64, p []byte) uint64 {
+	e := m.e
+	return e.finish(e.run(e.unfinish(crc), p))
+}
+
+// Combine returns the CRC of the concatenation a||b from crc1 = Checksum(a),
+// crc2 = Checksum(b) and len2 = len(b), without the data: zlib's crc32_combine
+// for any model. It costs O(log len2) polynomial multiplications, so CRCs of
+// blocks computed in parallel can be joined cheaply. A len2 <= 0 returns crc1.
+//
+// In the unreflected orientation the register after a||b is (r1 ^ Init) *
+// x^(8*len2) ^ r2 modulo the polynomial, r1 and r2 being the registers behind
+// crc1 and crc2.
+func (m *Model) Combine(crc1, crc2 uint64, len2 int64) uint64 {
+	if len2 <= 0 {
+		return crc1
+	}
+	p := m.params
+	r1, r2 := m.register(crc1), m.register(crc2)
+	shift := xnModP(uint64(len2)*bitsPerByte, p.Poly, p.Width)
+	r := mulModP(r1^p.Init, shift, p.Poly, p.Width) ^ r2
+	if p.RefOut {
+		r = reflect(r, p.Width)
+	}
+	return r ^ p.XorOut
+}
+
+// register returns the unreflected register behind a CRC value.
+func (m *Model) register(crc uint64) uint64 {
+	p := m.params
+	r := (crc ^ p.XorOut) & (^uint64(0) >> (maxWidth - p.Width))
+	if p.RefOut {
+		r = reflect(r, p.Width)
+	}
+	return r
+}
+
+// New returns a Digest computing the model's CRC incrementally.
+func (m *Model) New() *Digest {
+	return &Digest{m: m, reg: m.e.init}
+}
+
+// Digest is a streaming CRC: a hash.Hash64 whose Sum64 after any sequence of
+// Writes equals the model's Checksum of their concatenation. Sum appends the
+// CRC big-endian in Size bytes, as hash/crc32 and hash/crc64 do. Write never
+// fails and is allocation-free.
+//
+// A Digest is NOT safe for concurrent use.
+type Digest struct {
+	m   *Model
+	reg uint64 // register in the engine's form
+}
+
+var _ hash.Hash64 = (*Digest)(nil)
+
+// Write adds p to the running CRC. It always returns len(p), nil.
+func (d *Digest) Write(p []byte) (int, error) {
+	d.reg = d.m.e.run(d.reg, p)
+	return len(p), nil
+}
+
+// Sum64 returns the CRC of the data written so far.
+func (d *Digest) Sum64() uint64 { return d.m.e.finish(d.reg) }
+
+// Sum appends the CRC of the data written so far to b, big-endian in Size bytes.
+func (d *Digest) Sum(b []byte) []byte {
+	s := d.Sum64()
+	for i := d.Size() - 1; i >= 0; i-- {
+		b = append(b, byte(s>>(uint(i)*bitsPerByte)))
+	}
+	return b
+}
+
+// Reset restarts the CRC from the model's Init.
+func (d *Digest) Reset() { d.reg = d.m.e.init }
+
+// Size returns the CRC size in bytes, the width rounded up to whole bytes.
+func (d *Digest) Size() int { return int(d.m.params.Width+bitsPerByte-1) / bitsPerByte }
+
+// BlockSize returns the fold stride, 16 bytes; Write accepts any length.
+func (d *Digest) BlockSize() int { return blockBytes }
@@ -0,0 +1,228 @@
+package crc
+
+import (
+	"bytes"
+	"hash/crc32"
+	"hash/crc64"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+var catalogue = []Params{
+	CRC8SMBUS, CRC16UMTS, CRC16ARC, CRC16MODBUS, CRC16USB, CRC16XMODEM, CRC16KERMIT,
+	CRC32ISOHDLC, CRC32ISCSI, CRC32MPEG2, CRC64ECMA182, CRC64XZ,
+}
+
+// oddModels are catalogue entries that stress the corners of the model: widths
+// below a byte and between the fixed ones, an asymmetric Init with reflection,
+// and RefIn != RefOut.
+var oddModels = []Params{
+	{Name: "CRC-3/GSM", Width: 3, Poly: 0x3, XorOut: 0x7, Check: 0x4},
+	{Name: "CRC-5/USB", Width: 5, Poly: 0x05, Init: 0x1F, RefIn: true, RefOut: true, XorOut: 0x1F, Check: 0x19},
+	{Name: "CRC-7/MMC", Width: 7, Poly: 0x09, Check: 0x75},
+	{Name: "CRC-12/UMTS", Width: 12, Poly: 0x80F, RefOut: true, Check: 0xDAF},
+	{Name: "CRC-16/RIELLO", Width: 16, Poly: 0x1021, Init: 0xB2AA, RefIn: true, RefOut: true, Check: 0x63D0},
+	{Name: "CRC-40/GSM", Width: 40, Poly: 0x0004820009, XorOut: 0xFFFFFFFFFF, Check: 0xD4164FC646},
+	{
+		Name: "CRC-64/WE", Width: 64, Poly: 0x42F0E1EBA9EA3693, Init: 0xFFFFFFFFFFFFFFFF,
+		XorOut: 0xFFFFFFFFFFFFFFFF, Check: 0x62EC59E3F1A4F00A,
+	},
+}
+
+// randomParams draws a model with a random width, polynomial, init, xorout and
+// reflection.
+func randomParams(r *rand.Rand) Params {
+	w := uint(1 + r.Intn(maxWidth))
+	mask := ^uint64(0) >> (maxWidth - w)
+	return Params{
+		Name:   "random/" + strconv.Itoa(int(w)),
+		Width:  w,
+		Poly:   r.Uint64()&mask | 1,
+		Init:   r.Uint64() & mask,
+		RefIn:  r.Intn(2) == 0,
+		RefOut: r.Intn(2) == 0,
+		XorOut: r.Uint64() & mask,
+	}
+}
+
+func mustModel(t testing.TB, p Params) *Model {
+	t.Helper()
+	m, err := NewModel(p)
+	if err != nil {
+		t.Fatalf("NewModel(%s): %v", p.Name, err)
+	}
+	return m
+}
+
+func TestModelCheckValues(t *testing.T) {
+	check := []byte("123456789")
+	for _, p := range append(append([]Params{}, catalogue...), oddModels...) {
+		if got := refCRC(p, check); got != p.Check {
+			t.Errorf("%s: reference %#x, catalogue check %#x", p.Name, got, p.Check)
+		}
+		if got := mustModel(t, p).Checksum(check); got != p.Check {
+			t.Errorf("%s: Checksum = %#x, want %#x", p.Name, got, p.Check)
+		}
+	}
+}
+
+// TestModelParity checks Checksum and the portable fold model against the
+// bit-at-a-time reference for the catalogue and for random models of every
+// width, across lengths that straddle the fold stride and threshold.
+func TestModelParity(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+	models := append(append([]Params{}, catalogue...), oddModels...)
+	for range 64 {
+		models = append(models, randomParams(r))
+	}
+	lengths := []int{0, 1, 7, 8, 15, 16, 17, 31, 32, 33, 63, 64, 65, 100, 255, 256, 1000, 4097}
+	for _, p := range models {
+		m := mustModel(t, p)
+		for _, n := range lengths {
+			buf := make([]byte, n)
+			r.Read(buf)
+			want := refCRC(p, buf)
+			if got := m.Checksum(buf); got != want {
+				t.Fatalf("%+v n=%d: Checksum = %#x, want %#x", p, n, got, want)
+			}
+			if got := foldModelEngine(m.e, buf); got != want {
+				t.Fatalf("%+v n=%d: fold model %#x, want %#x", p, n, got, want)
+			}
+		}
+	}
+}
+
+func TestModelMatchesStdlib(t *testing.T) {
+	ieee, xz := mustModel(t, CRC32ISOHDLC), mustModel(t, CRC64XZ)
+	ecma := crc64.MakeTable(crc64.ECMA)
+	r := rand.New(rand.NewSource(4))
+	for _, n := range []int{0, 1, 16, 64, 65, 1000, 8191} {
+		buf := make([]byte, n)
+		r.Read(buf)
+		if got, want := ieee.Checksum(buf), uint64(crc32.ChecksumIEEE(buf)); got != want {
+			t.Fatalf("n=%d: CRC-32/ISO-HDLC = %#x, hash/crc32 %#x", n, got, want)
+		}
+		if got, want := xz.Checksum(buf), crc64.Checksum(buf, ecma); got != want {
+			t.Fatalf("n=%d: CRC-64/XZ = %#x, hash/crc64 %#x", n, got, want)
+		}
+	}
+}
+
+// TestDigestStreaming writes buffers in random pieces, so pieces both below and
+// above the fold threshold carry the register, and checks Sum64, Sum, Update
+// and Reset against the one-shot Checksum.
+func TestDigestStreaming(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	models := append(append([]Params{}, catalogue...), oddModels...)
+	for _, p := range models {
+		m := mustModel(t, p)
+		d := m.New()
+		for _, n := range []int{0, 5, 200, 3000} {
+			buf := make([]byte, n)
+			r.Read(buf)
+			want := m.Checksum(buf)
+			for range 4 {
+				d.Reset()
+				crc := m.Checksum(nil)
+				for rest := buf; len(rest) > 0; {
+					k := min(len(rest), r.Intn(150))
+					if wn, err := d.Write(rest[:k]); wn != k || err != nil {
+						t.Fatalf("%s: Write = %d, %v", p.Name, wn, err)
+					}
+					crc = m.Update(crc, rest[:k])
+					rest = rest[k:]
+				}
+				if got := d.Sum64(); got != want {
+					t.Fatalf("%s n=%d: Sum64 = %#x, want %#x", p.Name, n, got, want)
+				}
+				if crc != want {
+					t.Fatalf("%s n=%d: Update chain = %#x, want %#x", p.Name, n, crc, want)
+				}
+			}
+			sum := d.Sum([]byte{0xAA})
+			if len(sum) != 1+d.Size() || sum[0] != 0xAA {
+				t.Fatalf("%s: Sum = %x, want the prefix and %d bytes", p.Name, sum, d.Size())
+			}
+			var got uint64
+			for _, b := range sum[1:] {
+				got = got<<8 | uint64(b)
+			}
+			if got != want {
+				t.Fatalf("%s: Sum bytes %x, want %#x", p.Name, sum[1:], want)
+			}
+		}
+	}
+}
+
+func TestModelCombine(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	models := append(append([]Params{}, catalogue...), oddModels...)
+	for range 32 {
+		models = append(models, randomParams(r))
+	}
+	for _, p := range models {
+		m := mustModel(t, p)
+		for _, n := range []int{0, 1, 17, 300, 5000} {
+			buf := make([]byte, n)
+			r.Read(buf)
+			want := m.Checksum(buf)
+			for _, split := range []int{0, n / 3, n} {
+				a, b := buf[:split], buf[split:]
+				got := m.Combine(m.Checksum(a), m.Checksum(b), int64(len(b)))
+				if got != want {
+					t.Fatalf("%s n=%d split=%d: Combine = %#x, want %#x", p.Name, n, split, got, want)
+				}
+			}
+		}
+	}
+	// Matches zlib's crc32_combine semantics on a long second part.
+	ieee := mustModel(t, CRC32ISOHDLC)
+	a, b := []byte("hello, "), bytes.Repeat([]byte("world"), 100000)
+	if got, want := ieee.Combine(ieee.Checksum(a), ieee.Checksum(b), int64(len(b))),
+		uint64(crc32.ChecksumIEEE(append(a, b...))); got != want {
+		t.Fatalf("CRC-32 combine over %d bytes = %#x, want %#x", len(b), got, want)
+	}
+}
+
+func TestNewModelErrors(t *testing.T) {
+	for _, p := range []Params{
+		{Width: 0, Poly: 1},
+		{Width: 65, Poly: 1},
+		{Width: 8, Poly: 0x107},
+		{Width: 16, Poly: 0x8005, Init: 0x10000},
+		{Width: 3, Poly: 0x3, XorOut: 0x8},
+	} {
+		if m, err := NewModel(p); err != ErrParams || m != nil {
+			t.Errorf("NewModel(%+v) = %v, %v; want nil, ErrParams", p, m, err)
+		}
+	}
+}
+
+func TestModelZeroAlloc(t *testing.T) {
+	m := mustModel(t, CRC64XZ)
+	d := m.New()
+	buf := make([]byte, 4096)
+	if n := testing.AllocsPerRun(20, func() { _ = m.Checksum(buf) }); n != 0 {
+		t.Fatalf("Checksum allocated %v times per run, want 0", n)
+	}
+	if n := testing.AllocsPerRun(20, func() { _, _ = d.Write(buf) }); n != 0 {
+		t.Fatalf("Digest.Write allocated %v times per run, want 0", n)
+	}
+}
+
+func BenchmarkModel(b *testing.B) {
+	for _, p := range []Params{CRC16ARC, CRC32ISOHDLC, CRC64XZ} {
+		m := mustModel(b, p)
+		buf := make([]byte, 4096)
+		for i := range buf {
+			buf[i] = byte(i)
+		}
+		b.Run(p.Name, func(b *testing.B) {
+			b.SetBytes(int64(len(buf)))
+			for b.Loop() {
+				_ = m.Checksum(buf)
+			}
+		})
+	}
+}
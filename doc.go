@@ -6,7 +6,7 @@
 //   - [github.com/tphakala/simd/cpu] - CPU feature detection
 //   - [github.com/tphakala/simd/f64] - float64 SIMD operations (FLAC/LPC and scientific surface)
 //   - [github.com/tphakala/simd/f32] - float32 SIMD operations (audio/FFT/ML surface)
-//   - [github.com/tphakala/simd/f16] - float16 storage type (ARM64 NEON+FP16 compute; amd64 F16C slice conversions)
+//   - [github.com/tphakala/simd/f16] - float16 storage type (ARM64 NEON+FP16 compute; amd64 F16C slice conversions and softmax)
 //   - [github.com/tphakala/simd/i32] - int32 SIMD operations (integer DSP)
 //   - [github.com/tphakala/simd/i16] - int16 SIMD operations (PCM movement, and widening int16 x int16 -> int32 reductions)
 //   - [github.com/tphakala/simd/i8] - int8 SIMD operations (saturating arithmetic, int32-accumulated reductions, quantized DSP)
@@ -26,6 +26,9 @@
 //     or SSE4.1 (c64) > pure Go.
 //     i32 needs AVX/AVX2, cint and i8 need AVX2, crc needs PCLMULQDQ, and f16 uses F16C
 //     for its slice conversions only (every other f16 op is pure Go on amd64).
+//     The integer packages add AVX-512 tiers on top: AVX-512BW and AVX-512 VNNI
+//     for the i8 and i16 dot products (and i8.SAD, i16.XCorr), AVX-512F for the
+//     cint arithmetic and i32.FIRValidQ15.
 //     SSE2 is part of the amd64 baseline, so f32/f64/c128 always get SIMD on
 //     amd64, as do i16's interleave/dot/xcorr kernels; i16's element-wise ops
 //     (Abs, MulQ15) and its MaxAbs reduction are AVX2-or-Go, like i8 and the
@@ -33,12 +36,13 @@
 //   - ARM64: NEON/ASIMD throughout (2x float64, 4x float32), with an FP16
 //     (FEAT_FP16) fast path in the f16 package and an SDOT (FEAT_DotProd) fast
 //     path for i8.DotProduct, and SMLAL/SMLAL2 widening multiply-accumulate
-//     for i16.DotProduct. SVE/SVE2 is detected by cpu.Info() but no SVE
-//     kernels exist yet, so SVE hosts run the NEON path.
+//     for i16.DotProduct. SVE hosts run vector-length-agnostic SVE kernels for
+//     the f32/f64 dot products, sums and element-wise Add/Mul/FMA.
 //   - Other: Pure Go fallback
 //
 // f16 is a storage type: SIMD acceleration is ARM64-only for compute (NEON+FP16),
-// plus F16C-accelerated ToFloat32Slice/FromFloat32Slice conversions on amd64.
+// plus F16C-accelerated ToFloat32Slice/FromFloat32Slice conversions and an
+// F16C+AVX2 softmax exponential on amd64.
 //
 // # Disabling SIMD tiers
 //
@@ -46,10 +50,21 @@
 // detected CPU features (a comma-separated, case-insensitive token list such as
 // "avx512", "avx", "neon", or "all"). It is useful for avoiding AVX-512
 // downclocking, exercising the lower tiers locally, and benchmarking tiers
-// against each other. Unknown tokens are ignored. The variable cannot be toggled
-// at runtime, because the SIMD packages cache their selected kernels during
-// package init (function pointers on amd64, capability flags on arm64). See the
-// cpu package for the full token table.
+// against each other. Unknown tokens are ignored. The variable is read once,
+// before the SIMD packages cache their selected kernels during package init
+// (function pointers on amd64, capability flags on arm64). See the cpu package
+// for the full token table.
+//
+// cpu.Override applies the same tokens at runtime, from main or from tests, and
+// re-runs every package's kernel selection; the function it returns restores the
+// previous features.
+//
+// # Dispatch introspection
+//
+// cpu.Info reports the host-wide tier only. cpu.Kernels and cpu.LookupKernel
+// report, per exported operation of each linked subpackage, the implementation
+// tier it is bound to, the CPU features that tier requires, the kernel function,
+// and the Go fallback taken on inputs the kernel does not cover.
 //
 // # Quick Start
 //
@@ -92,7 +107,21 @@
 //
 // Sliding-window argmin (f32): MinIdxOfSum, MinIdxOfSumRows (batched sliding-window argmin of a[i]+k[base+r*slide+i], first-index-wins ties, bit-exact across all paths)
 //
-// Spectral (f64, f32): STFTPlan (NewSTFTPlan, STFT, STFTPower, STFTPowerInto, NumFrames) - fused real-input short-time Fourier transform with optional librosa-style center=true framing (PadMode: NoPad/PadZero/PadReflect)
+// Spectral (f64, f32): STFTPlan (NewSTFTPlan, STFT, STFTPower, STFTPowerInto, NumFrames, ISTFT, NumSamples) - fused real-input short-time Fourier transform with optional librosa-style center=true framing (PadMode: NoPad/PadZero/PadReflect), and its weighted overlap-add inverse; MelFilterbank (NewMelFilterbank, Apply, LogMel) - librosa.filters.mel matrix (Slaney/HTK scale, Slaney norm) with support-restricted projection fused with power_to_db, and PCEN (NewPCEN, Process, Reset) - streaming per-channel energy normalization over it; MFCC (NewMFCC, Apply) - librosa.feature.mfcc cepstra of log-mel frames with optional liftering
+//
+// FFT (f64, f32): FFTPlan - in-place split-format complex FFT of any size (radix-4 core over ButterflyComplexStage4, radix-3/5 stages, Bluestein fallback), shared by STFTPlan and the c64/c128 FFTPlan; RealFFTPlan (Forward, Inverse, InverseScaled) - complete real-input FFT and its inverse (numpy rfft/irfft) for any even size, built on FFTPlan and RealFFTUnpack; DCTPlan (DCT2, DCT3) - scipy.fft.dct types 2 and 3 (DCTBackward/DCTOrtho) for any size via Makhoul's mapping onto FFTPlan
+//
+// FIR (f64, f32, i32): FIRFilter (NewFIRFilter, Process, Reset) - streaming causal FIR filter owning its delay line, one output per input sample, on the ConvolveValid kernels; i32.FIRFilterQ15 is the Q15 counterpart on FIRValidQ15
+//
+// Resampling (f64, f32): Resampler (NewResampler, Process, MaxOutput, Reset) - streaming rational L/M polyphase sample-rate converter over interleaved channels (scipy resample_poly prototype, exact phase table or CubicInterpDot-interpolated phases), with the KaiserSinc (firwin) and KaiserBeta designers
+//
+// IIR (f64, f32): BiquadCascade (NewBiquadCascade, Process, Reset) - stateful direct form II transposed biquad cascade over interleaved channels, with RBJ cookbook designers LowpassBiquad, HighpassBiquad, BandpassBiquad, NotchBiquad, PeakingBiquad, LowShelfBiquad, HighShelfBiquad
+//
+// LPC (f64, i32): LagWindow, LevinsonDurbin (predictors, reflection coefficients and errors of every order) and QuantizeLPC - libFLAC's coefficient solve and quantizer, bit-exact; i32.LPCResidual and i32.LPCRestore apply the quantized predictor (int64 sum, orders 1..32)
+//
+// FLAC residuals (i32): FixedResidual (fixed predictor orders 0..4), FixedAbsSums and BestFixedOrder (libFLAC's best fixed order search, exact int64 residuals), PartitionAbsSums (Rice partition abs-sum table for a range of partition orders, libFLAC layout)
+//
+// MDCT (f32, cint): MDCTPlan (NewMDCTPlan, Forward, Inverse, Synthesize, Reset) - MDCT/IMDCT of 2n-sample blocks via an n/2-point FFT with streaming TDAC overlap-add, and the SineWindow, VorbisWindow and KBDWindow Princen-Bradley windows; the cint plan is fixed-point (int32 samples, Q15 windows) on a kiss_fft-structured core, bit-identical on every backend
 //
 // FFT primitives (f64, f32): ButterflyComplex (radix-2 butterfly with twiddle multiply, split-complex), RealFFTUnpack (real-FFT even/odd unpack step), RealFFTPower (the fused power-writing counterpart of RealFFTUnpack that emits the |X_k|^2 power spectrum in one pass); f64 additionally has ButterflyComplexStage, one whole radix-2 decimation-in-time stage at any span, which picks its vectorization axis from the span
 //
@@ -102,11 +131,11 @@
 //
 // Integer DSP (i8): AddSaturate, SubSaturate, AddScalarSaturate, SubScalarSaturate, Min, Max, Clamp, Abs, Neg, AbsDiff, MaxAbs, SumAbs, SAD, ToInt16, ToInt32, Sum, MinMax, DotProduct (int32-accumulated; ARM64 SDOT / amd64 VPMADDWD)
 //
-// Complex (c64/c128): Add, Sub, Mul, MulConj, DotProduct, DotProductConj, Conj, Abs, AbsSq, Scale
+// Complex (c64/c128): Add, Sub, Mul, MulConj, DotProduct, DotProductConj, Conj, Abs, AbsSq, Scale, FFTPlan (forward/inverse complex FFT, any size)
 //
-// Fixed-point complex (cint): Add, Sub, Mul, MulConj, MulByScalar (int32 data x int16 Q15 twiddle, truncating C_MUL; for integer FFT butterflies)
+// Fixed-point complex (cint): Add, Sub, Mul, MulConj, MulByScalar (int32 data x int16 Q15 twiddle, truncating C_MUL; for integer FFT butterflies), FFTPlan (Forward, Inverse, Twiddles - libopus kiss_fft opus_fft/opus_ifft, bit-exact, any 2^a*3^b*5^c size), MDCTPlan (fixed-point MDCT, see MDCT)
 //
-// CRC (crc): Checksum16 (CRC-16, poly 0x8005, MSB-first, no reflection; used by FLAC among others, PCLMULQDQ/PMULL carry-less-multiply fold)
+// CRC (crc): Checksum16 (CRC-16, poly 0x8005, MSB-first, no reflection; used by FLAC among others), Checksum8 (CRC-8/SMBUS, the FLAC frame-header CRC), ChecksumIEEE, ChecksumCastagnoli, ChecksumMPEG2, ChecksumOgg (CRC-32 variants); Model (NewModel, Checksum, Update, Combine, New) - any Rocksoft-model CRC of width 1..64 with a catalogue of Params and a streaming hash.Hash64 Digest; all on a PCLMULQDQ/PMULL carry-less-multiply fold
 //
 // # Design Principles
 //
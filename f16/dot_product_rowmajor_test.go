@@ -0,0 +1,173 @@
+package f16
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+)
+
+// rowMajorF16Vector returns n deterministic halves in [-1, 1), exact in FP16.
+func rowMajorF16Vector(seed, n int) []Float16 {
+	out := make([]Float16, n)
+	x := uint32(seed)*747796405 + 2891336453
+	for i := range out {
+		x = x*1664525 + 1013904223
+		out[i] = FromFloat32(float32(int32(x>>9)%1024) / 1024)
+	}
+	return out
+}
+
+func TestDotProductIndexedRowMajorParity(t *testing.T) {
+	for _, dims := range []int{1, 7, 8, 9, 16, 31, 64, 65, 384} {
+		for _, rows := range []int{0, 1, 4, 5, 13} {
+			baseRows := rows + 11
+			base := rowMajorF16Vector(100+dims+rows, baseRows*dims)
+			query := rowMajorF16Vector(200+dims, dims)
+			rowIDs := make([]uint32, rows)
+			for i := range rowIDs {
+				rowIDs[i] = uint32((i*7 + 3) % baseRows)
+			}
+			got := make([]float32, rows)
+			DotProductIndexed(got, base, query, rowIDs, dims)
+			assertRowMajorClose(t, dims, got, scalarIndexedOracle(base, query, rowIDs, dims))
+		}
+	}
+}
+
+func TestDotProductStridedRowMajorParity(t *testing.T) {
+	for _, dims := range []int{1, 7, 8, 9, 16, 31, 64, 65, 384} {
+		for _, rows := range []int{0, 1, 4, 5, 13} {
+			stride := dims + 3
+			base := rowMajorF16Vector(300+dims+rows, rows*stride)
+			query := rowMajorF16Vector(400+dims, dims)
+			got := make([]float32, rows)
+			DotProductStrided(got, base, query, rows, dims, stride)
+			assertRowMajorClose(t, dims, got, scalarStridedOracle(base, query, rows, dims, stride))
+		}
+	}
+}
+
+func TestDotProductRowMajorRaggedAndTails(t *testing.T) {
+	h := func(v ...float32) []Float16 {
+		out := make([]Float16, len(v))
+		for i, f := range v {
+			out[i] = FromFloat32(f)
+		}
+		return out
+	}
+	base := h(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, // truncated row 2
+	)
+	query := h(1, 10, 100)
+	want := []float32{321, 765, 109, 0, 123}
+
+	got := []float32{-1, -1, -1, -1, 123}
+	if DotProductIndexed(got[:4], base, query, []uint32{0, 1, 2, 99}, 4) {
+		t.Error("ragged indexed shape should use the Go path")
+	}
+	assertRowMajorClose(t, 4, got, want)
+
+	got = []float32{-1, -1, -1, -1, 123}
+	if DotProductStrided(got[:4], base, query, 4, 4, 4) {
+		t.Error("ragged strided shape should use the Go path")
+	}
+	assertRowMajorClose(t, 4, got, want)
+
+	got = []float32{7, 8, 9}
+	DotProductStrided(got, base, query, 3, 0, 4)
+	assertRowMajorClose(t, 4, got, []float32{0, 0, 0})
+
+	// Products past the FP16 maximum must not saturate.
+	big := h(300, 300, 300, 300, 300, 300, 300, 300)
+	got = []float32{0}
+	DotProductStrided(got, big, big, 1, len(big), len(big))
+	assertRowMajorClose(t, len(big), got, []float32{8 * 300 * 300})
+}
+
+func TestDotProductRowMajorOptimizedStatus(t *testing.T) {
+	const dims, rows = 64, 8
+	base := rowMajorF16Vector(501, rows*dims)
+	query := rowMajorF16Vector(502, dims)
+	rowIDs := []uint32{7, 0, 5, 2, 6, 1, 4, 3}
+	dst := make([]float32, rows)
+
+	want := runtime.GOARCH == "arm64" && cpu.HasNEON()
+	if got := DotProductIndexed(dst, base, query, rowIDs, dims); got != want {
+		t.Errorf("indexed optimized status = %v, want %v (cpu=%s)", got, want, cpu.Info())
+	}
+	if got := DotProductStrided(dst, base, query, rows, dims, dims); got != want {
+		t.Errorf("strided optimized status = %v, want %v (cpu=%s)", got, want, cpu.Info())
+	}
+	if DotProductStrided(dst, base, query[:32], rows, dims, dims) {
+		t.Error("query shorter than dims should not report optimized")
+	}
+}
+
+func TestDotProductRowMajorAllocs(t *testing.T) {
+	const dims, rows = 64, 8
+	base := rowMajorF16Vector(601, rows*dims)
+	query := rowMajorF16Vector(602, dims)
+	rowIDs := []uint32{7, 0, 5, 2, 6, 1, 4, 3}
+	dst := make([]float32, rows)
+	if got := testing.AllocsPerRun(100, func() {
+		DotProductIndexed(dst, base, query, rowIDs, dims)
+		DotProductStrided(dst, base, query, rows, dims, dims)
+	}); got != 0 {
+		t.Errorf("row-major dot allocations = %v, want 0", got)
+	}
+}
+
+// assertRowMajorClose allows for the SIMD kernel summing in a different order
+// than the scalar oracle: every term is at most 1 in magnitude, so the error is
+// bounded by a few float32 ulps of dims.
+func assertRowMajorClose(t *testing.T, dims int, got, want []float32) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("len(got)=%d len(want)=%d", len(got), len(want))
+	}
+	tol := 1e-6 * float64(dims)
+	for i := range got {
+		if math.Abs(float64(got[i]-want[i])) > tol*(1+math.Abs(float64(want[i]))) {
+			t.Fatalf("dims=%d [%d] got=%g want=%g", dims, i, got[i], want[i])
+		}
+	}
+}
+
+// scalarIndexedOracle and scalarStridedOracle recompute the expected scores
+// with the scalar dotProductGo, mirroring the public APIs' row selection,
+// ragged-input clamping, and out-of-range zeroing.
+func scalarIndexedOracle(base, query []Float16, rowIDs []uint32, dims int) []float32 {
+	dst := make([]float32, len(rowIDs))
+	if dims <= 0 || len(query) == 0 {
+		return dst
+	}
+	for i, id := range rowIDs {
+		off := int(id) * dims
+		if off >= len(base) {
+			continue
+		}
+		n := min(dims, len(query), len(base)-off)
+		dst[i] = dotProductGo(base[off:off+n], query[:n])
+	}
+	return dst
+}
+
+func scalarStridedOracle(base, query []Float16, rowCount, dims, stride int) []float32 {
+	dst := make([]float32, rowCount)
+	if dims <= 0 || stride <= 0 || len(query) == 0 {
+		return dst
+	}
+	for i := range rowCount {
+		off := i * stride
+		if off >= len(base) {
+			continue
+		}
+		n := min(dims, len(query), len(base)-off)
+		dst[i] = dotProductGo(base[off:off+n], query[:n])
+	}
+	return dst
+}
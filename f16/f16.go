@@ -43,8 +43,8 @@
 // or half). ConvolveValid writes a valid-convolution output shorter than its
 // signal and reads a sliding window, so its dst must be distinct from the signal.
 // ToFloat32Slice and FromFloat32Slice convert between Float16 and float32 (distinct
-// element types that cannot alias in safe Go), as does DotProductBatch (float32
-// results). The reductions (Sum, Min, Max, Mean, MinIdx, MaxIdx, DotProduct,
+// element types that cannot alias in safe Go), as do DotProductBatch,
+// DotProductIndexed and DotProductStrided (float32 results). The reductions (Sum, Min, Max, Mean, MinIdx, MaxIdx, DotProduct,
 // DotProductF32, EuclideanDistance, Variance, StdDev) write no output slice, so
 // aliasing does not apply to them.
 package f16
@@ -420,6 +420,44 @@ func DotProductBatch(results []float32, rows [][]Float16, vec []Float16) {
 	dotProductBatch16(results[:n], rows[:n], vec)
 }
 
+// DotProductIndexed computes dot products between query and selected rows in a
+// flat row-major base slice. For each processed row i:
+//
+//	dst[i] = dot(base[rowIDs[i]*dims : rowIDs[i]*dims+dims], query[:dims])
+//
+// Products are widened to float32 before multiplying and accumulated in
+// float32, as in DotProductF32, so they never saturate at the FP16 maximum.
+// The number of processed rows is min(len(dst), len(rowIDs)). Ragged inputs are
+// safe: if query is shorter than dims or a row extends past base, the dot uses
+// the available common prefix; out-of-range row IDs, non-positive dims, or an
+// empty query produce a zero score for that row. The function returns true when
+// the platform SIMD kernel (NEON on ARM64) scored at least one row; false means
+// the pure-Go path handled the call, as it always does on AMD64.
+func DotProductIndexed(dst []float32, base, query []Float16, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	return dotProductIndexed(dst[:n], base, query, rowIDs[:n], dims)
+}
+
+// DotProductStrided computes dot products between query and rowCount rows in a
+// flat base slice where row i starts at base[i*stride]. dims and stride are in
+// Float16 elements, not bytes; use stride >= dims for non-overlapping rows.
+// Accumulation is in float32 as in DotProductIndexed. The number of processed
+// rows is min(len(dst), rowCount). Ragged inputs are safe: if query is shorter
+// than dims or a row extends past base, the dot uses the available common
+// prefix; non-positive rowCount/dims/stride or an empty query produce zero
+// scores for processed rows. The function returns true when the platform SIMD
+// kernel scored at least one row.
+func DotProductStrided(dst []float32, base, query []Float16, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	return dotProductStrided(dst[:n], base, query, n, dims, stride)
+}
+
 // AccumulateAdd adds src to dst: dst[i] += src[i].
 // This is useful for overlap-add operations.
 func AccumulateAdd(dst, src []Float16, offset int) {
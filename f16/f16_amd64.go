@@ -10,9 +10,21 @@ const f16cWidth = 8
 
 // hasF16C caches the F16C conversion capability at package init. amd64 has no
 // half-precision arithmetic outside AVX512-FP16 (Sapphire Rapids and newer), so
-// only the slice conversions are accelerated; every other op stays pure Go,
-// mirroring the storage-type design (Float16 is an alias for uint16).
-var hasF16C = cpu.X86.F16C
+// only the slice conversions and the softmax family's fused exponential (which
+// widens with F16C and computes in float32 with AVX2, cached in hasAVX2) are
+// accelerated; every other op stays pure Go, mirroring the storage-type design
+// (Float16 is an alias for uint16).
+var (
+	hasF16C = cpu.X86.F16C
+	hasAVX2 = cpu.X86.AVX2
+)
+
+// bindKernels re-reads the feature flags cached above from cpu.X86.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasF16C = cpu.X86.F16C
+	hasAVX2 = cpu.X86.AVX2
+}
 
 func toFloat32(h Float16) float32 {
 	return toFloat32Go(h)
@@ -45,9 +57,18 @@ func fromFloat32Slice(dst []Float16, src []float32) {
 	fromFloat32SliceGo(dst, src)
 }
 
-// Every operation other than the two slice conversions stays pure Go on amd64:
-// there is no F16C arithmetic, and the compute ops are not yet implemented as
-// convert-to-f32 + f32 SIMD + convert-back. These delegate to the references.
+func expSum16(dst []float32, src []Float16, shift float32) float32 {
+	n := len(dst)
+	if hasF16C && hasAVX2 && n >= f16cWidth {
+		nVec := (n / f16cWidth) * f16cWidth
+		return expSumF16CAVX2(dst[:nVec], src[:nVec], shift) + expSum16Go(dst[nVec:], src[nVec:], shift)
+	}
+	return expSum16Go(dst, src, shift)
+}
+
+// Every other operation stays pure Go on amd64: there is no F16C arithmetic,
+// and the compute ops are not yet implemented as convert-to-f32 + f32 SIMD +
+// convert-back. These delegate to the references.
 
 func dotProduct(a, b []Float16) float32 {
 	return dotProductGo(a, b)
@@ -161,6 +182,16 @@ func dotProductBatch16(results []float32, rows [][]Float16, vec []Float16) {
 	dotProductBatchGo(results, rows, vec)
 }
 
+func dotProductIndexed(dst []float32, base, query []Float16, rowIDs []uint32, dims int) bool {
+	dotProductIndexedGo(dst, base, query, rowIDs, dims)
+	return false
+}
+
+func dotProductStrided(dst []float32, base, query []Float16, rowCount, dims, stride int) bool {
+	dotProductStridedGo(dst, base, query, rowCount, dims, stride)
+	return false
+}
+
 func accumulateAdd16(dst, src []Float16) {
 	accumulateAddGo(dst, src)
 }
@@ -189,3 +220,11 @@ func toFloat32SliceF16C(dst []float32, src []Float16)
 
 //go:noescape
 func fromFloat32SliceF16C(dst []Float16, src []float32)
+
+// expSumF16CAVX2 is expSum16Go on 8 halves per iteration: VCVTPH2PS widens
+// them and f32's expSumAVX core, constants included, evaluates the
+// exponentials. Called only with a non-zero multiple of f16cWidth elements;
+// the dispatch handles the tail.
+//
+//go:noescape
+func expSumF16CAVX2(dst []float32, src []Float16, shift float32) float32
@@ -52,3 +52,183 @@ from_f16c_loop:
 from_f16c_done:
 	VZEROUPPER
 	RET
+
+// The exp core's constants: the values of f32's exp_* tables, so the softmax
+// family computes the same exponentials as f32's expAVX.
+DATA exp_log2e<>+0x00(SB)/4, $0x3fb8aa3b  // log2(e)
+DATA exp_log2e<>+0x04(SB)/4, $0x3fb8aa3b
+DATA exp_log2e<>+0x08(SB)/4, $0x3fb8aa3b
+DATA exp_log2e<>+0x0c(SB)/4, $0x3fb8aa3b
+DATA exp_log2e<>+0x10(SB)/4, $0x3fb8aa3b
+DATA exp_log2e<>+0x14(SB)/4, $0x3fb8aa3b
+DATA exp_log2e<>+0x18(SB)/4, $0x3fb8aa3b
+DATA exp_log2e<>+0x1c(SB)/4, $0x3fb8aa3b
+GLOBL exp_log2e<>(SB), RODATA|NOPTR, $32
+
+DATA exp_ln2<>+0x00(SB)/4, $0x3f317218  // ln(2)
+DATA exp_ln2<>+0x04(SB)/4, $0x3f317218
+DATA exp_ln2<>+0x08(SB)/4, $0x3f317218
+DATA exp_ln2<>+0x0c(SB)/4, $0x3f317218
+DATA exp_ln2<>+0x10(SB)/4, $0x3f317218
+DATA exp_ln2<>+0x14(SB)/4, $0x3f317218
+DATA exp_ln2<>+0x18(SB)/4, $0x3f317218
+DATA exp_ln2<>+0x1c(SB)/4, $0x3f317218
+GLOBL exp_ln2<>(SB), RODATA|NOPTR, $32
+
+DATA exp_one<>+0x00(SB)/4, $0x3f800000  // 1.0
+DATA exp_one<>+0x04(SB)/4, $0x3f800000
+DATA exp_one<>+0x08(SB)/4, $0x3f800000
+DATA exp_one<>+0x0c(SB)/4, $0x3f800000
+DATA exp_one<>+0x10(SB)/4, $0x3f800000
+DATA exp_one<>+0x14(SB)/4, $0x3f800000
+DATA exp_one<>+0x18(SB)/4, $0x3f800000
+DATA exp_one<>+0x1c(SB)/4, $0x3f800000
+GLOBL exp_one<>(SB), RODATA|NOPTR, $32
+
+DATA exp_c2<>+0x00(SB)/4, $0x3f000000  // 1/2
+DATA exp_c2<>+0x04(SB)/4, $0x3f000000
+DATA exp_c2<>+0x08(SB)/4, $0x3f000000
+DATA exp_c2<>+0x0c(SB)/4, $0x3f000000
+DATA exp_c2<>+0x10(SB)/4, $0x3f000000
+DATA exp_c2<>+0x14(SB)/4, $0x3f000000
+DATA exp_c2<>+0x18(SB)/4, $0x3f000000
+DATA exp_c2<>+0x1c(SB)/4, $0x3f000000
+GLOBL exp_c2<>(SB), RODATA|NOPTR, $32
+
+DATA exp_c3<>+0x00(SB)/4, $0x3e2aaaab  // 1/6
+DATA exp_c3<>+0x04(SB)/4, $0x3e2aaaab
+DATA exp_c3<>+0x08(SB)/4, $0x3e2aaaab
+DATA exp_c3<>+0x0c(SB)/4, $0x3e2aaaab
+DATA exp_c3<>+0x10(SB)/4, $0x3e2aaaab
+DATA exp_c3<>+0x14(SB)/4, $0x3e2aaaab
+DATA exp_c3<>+0x18(SB)/4, $0x3e2aaaab
+DATA exp_c3<>+0x1c(SB)/4, $0x3e2aaaab
+GLOBL exp_c3<>(SB), RODATA|NOPTR, $32
+
+DATA exp_c4<>+0x00(SB)/4, $0x3d2aaaab  // 1/24
+DATA exp_c4<>+0x04(SB)/4, $0x3d2aaaab
+DATA exp_c4<>+0x08(SB)/4, $0x3d2aaaab
+DATA exp_c4<>+0x0c(SB)/4, $0x3d2aaaab
+DATA exp_c4<>+0x10(SB)/4, $0x3d2aaaab
+DATA exp_c4<>+0x14(SB)/4, $0x3d2aaaab
+DATA exp_c4<>+0x18(SB)/4, $0x3d2aaaab
+DATA exp_c4<>+0x1c(SB)/4, $0x3d2aaaab
+GLOBL exp_c4<>(SB), RODATA|NOPTR, $32
+
+DATA exp_c5<>+0x00(SB)/4, $0x3c088889  // 1/120
+DATA exp_c5<>+0x04(SB)/4, $0x3c088889
+DATA exp_c5<>+0x08(SB)/4, $0x3c088889
+DATA exp_c5<>+0x0c(SB)/4, $0x3c088889
+DATA exp_c5<>+0x10(SB)/4, $0x3c088889
+DATA exp_c5<>+0x14(SB)/4, $0x3c088889
+DATA exp_c5<>+0x18(SB)/4, $0x3c088889
+DATA exp_c5<>+0x1c(SB)/4, $0x3c088889
+GLOBL exp_c5<>(SB), RODATA|NOPTR, $32
+
+DATA exp_magic<>+0x00(SB)/4, $0x4b400000  // 1.5 * 2^23
+DATA exp_magic<>+0x04(SB)/4, $0x4b400000
+DATA exp_magic<>+0x08(SB)/4, $0x4b400000
+DATA exp_magic<>+0x0c(SB)/4, $0x4b400000
+DATA exp_magic<>+0x10(SB)/4, $0x4b400000
+DATA exp_magic<>+0x14(SB)/4, $0x4b400000
+DATA exp_magic<>+0x18(SB)/4, $0x4b400000
+DATA exp_magic<>+0x1c(SB)/4, $0x4b400000
+GLOBL exp_magic<>(SB), RODATA|NOPTR, $32
+
+DATA exp_clamp_hi<>+0x00(SB)/4, $0x42b00000  // 88.0
+DATA exp_clamp_hi<>+0x04(SB)/4, $0x42b00000
+DATA exp_clamp_hi<>+0x08(SB)/4, $0x42b00000
+DATA exp_clamp_hi<>+0x0c(SB)/4, $0x42b00000
+DATA exp_clamp_hi<>+0x10(SB)/4, $0x42b00000
+DATA exp_clamp_hi<>+0x14(SB)/4, $0x42b00000
+DATA exp_clamp_hi<>+0x18(SB)/4, $0x42b00000
+DATA exp_clamp_hi<>+0x1c(SB)/4, $0x42b00000
+GLOBL exp_clamp_hi<>(SB), RODATA|NOPTR, $32
+
+DATA exp_clamp_lo<>+0x00(SB)/4, $0xc2b00000  // -88.0
+DATA exp_clamp_lo<>+0x04(SB)/4, $0xc2b00000
+DATA exp_clamp_lo<>+0x08(SB)/4, $0xc2b00000
+DATA exp_clamp_lo<>+0x0c(SB)/4, $0xc2b00000
+DATA exp_clamp_lo<>+0x10(SB)/4, $0xc2b00000
+DATA exp_clamp_lo<>+0x14(SB)/4, $0xc2b00000
+DATA exp_clamp_lo<>+0x18(SB)/4, $0xc2b00000
+DATA exp_clamp_lo<>+0x1c(SB)/4, $0xc2b00000
+GLOBL exp_clamp_lo<>(SB), RODATA|NOPTR, $32
+// func expSumF16CAVX2(dst []float32, src []Float16, shift float32) float32
+// The fused exp-and-sum pass of the softmax family, expSum16Go 8 halves at a
+// time: VCVTPH2PS widens them, then f32's expSumAVX core runs unchanged (clamp
+// to [-88, 88], k = round(x*log2e), r = x - k*ln2, degree-5 polynomial, 2^k
+// through the exponent bits). The clamp takes its operands in the order that
+// passes a NaN x through, so NaN propagates as in expSum16Go. The sum stays in
+// Y6 and is reduced once at the end. The dispatch in f16_amd64.go calls this
+// only with len(dst) == len(src) a non-zero multiple of 8; any sub-8 tail is
+// handled in Go.
+TEXT ·expSumF16CAVX2(SB), NOSPLIT, $0-60
+	MOVQ dst_base+0(FP), DI
+	MOVQ dst_len+8(FP), CX
+	MOVQ src_base+24(FP), SI
+
+	VMOVUPS exp_log2e<>(SB), Y8      // Y8 = log2(e)
+	VMOVUPS exp_ln2<>(SB), Y9        // Y9 = ln(2)
+	VMOVUPS exp_one<>(SB), Y10       // Y10 = 1.0
+	VMOVUPS exp_c2<>(SB), Y11        // Y11 = c2 = 1/2
+	VMOVUPS exp_c3<>(SB), Y12        // Y12 = c3 = 1/6
+	VMOVUPS exp_c4<>(SB), Y13        // Y13 = c4 = 1/24
+	VMOVUPS exp_c5<>(SB), Y14        // Y14 = c5 = 1/120
+	VMOVUPS exp_magic<>(SB), Y15     // Y15 = magic for rounding
+	VMOVUPS exp_clamp_hi<>(SB), Y7   // Y7 = 88.0
+	VBROADCASTSS shift+48(FP), Y5    // Y5 = shift
+	VXORPS Y6, Y6, Y6                // Y6 = running sum
+
+	SHRQ $3, CX                      // CX = number of 8-element blocks
+	JZ   expsum16_reduce
+
+expsum16_loop:
+	VCVTPH2PS (SI), Y0               // widen 8 packed Float16
+	VSUBPS Y5, Y0, Y0                // Y0 = x = v - shift
+
+	// Clamp x to [-88, 88]; a NaN x is the operand returned.
+	VMOVUPS exp_clamp_lo<>(SB), Y1
+	VMINPS Y0, Y7, Y0
+	VMAXPS Y0, Y1, Y0
+
+	// Range reduction: k = round(x * log2e), r = x - k * ln2
+	VMULPS Y8, Y0, Y1
+	VADDPS Y15, Y1, Y2
+	VSUBPS Y15, Y2, Y3               // Y3 = k
+	VMULPS Y9, Y3, Y4
+	VSUBPS Y4, Y0, Y0                // Y0 = r
+
+	// Polynomial: exp(r) ~= 1 + r*(1 + r*(c2 + r*(c3 + r*(c4 + r*c5))))
+	VMULPS Y0, Y14, Y1
+	VADDPS Y13, Y1, Y1
+	VMULPS Y0, Y1, Y1
+	VADDPS Y12, Y1, Y1
+	VMULPS Y0, Y1, Y1
+	VADDPS Y11, Y1, Y1
+	VMULPS Y0, Y1, Y1
+	VADDPS Y10, Y1, Y1
+	VMULPS Y0, Y1, Y1
+	VADDPS Y10, Y1, Y1               // Y1 = exp(r)
+
+	// Reconstruct: exp(x) = exp(r) * 2^k
+	VCVTPS2DQ Y3, Y4
+	VPSLLD $23, Y4, Y4
+	VPADDD Y10, Y4, Y4
+	VMULPS Y4, Y1, Y1                // Y1 = exp(x)
+
+	VMOVUPS Y1, (DI)
+	VADDPS Y1, Y6, Y6                // sum += exp(x)
+	ADDQ $16, SI                     // 8 * 2 bytes consumed
+	ADDQ $32, DI                     // 8 * 4 bytes written
+	DECQ CX
+	JNZ  expsum16_loop
+
+expsum16_reduce:
+	VEXTRACTF128 $1, Y6, X0
+	VADDPS X0, X6, X6
+	VHADDPS X6, X6, X6
+	VHADDPS X6, X6, X6
+	VMOVSS X6, ret+56(FP)
+	VZEROUPPER
+	RET
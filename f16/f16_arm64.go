@@ -16,6 +16,13 @@ var (
 	hasNEON = cpu.ARM64.NEON
 )
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasFP16 = cpu.ARM64.FP16
+	hasNEON = cpu.ARM64.NEON
+}
+
 func toFloat32(h Float16) float32 {
 	return toFloat32Go(h)
 }
@@ -337,6 +344,58 @@ func dotProductBatch16(results []float32, rows [][]Float16, vec []Float16) {
 	}
 }
 
+// dotProductIndexed scores every full in-range row with the float32-widening
+// NEON kernel behind dotProductF32 (f16 has no batch-of-4 kernel, so the query
+// is reloaded per row) and leaves rows past the end of base to the ragged-safe
+// Go path. Without NEON, or when a row is shorter than one vector or the query
+// shorter than dims, the whole call takes the Go path.
+func dotProductIndexed(dst []float32, base, query []Float16, rowIDs []uint32, dims int) bool {
+	maxRow := fullRowMaxIndex(len(base), dims, dims)
+	if !hasNEON || dims < neonWidth || len(query) < dims || maxRow < 0 {
+		dotProductIndexedGo(dst, base, query, rowIDs, dims)
+		return false
+	}
+	queryFull := query[:dims]
+	usedSIMD := false
+	for i, id := range rowIDs {
+		if uint64(id) <= uint64(maxRow) {
+			off := int(id) * dims
+			dst[i] = dotProductF32(base[off:off+dims], queryFull)
+			usedSIMD = true
+			continue
+		}
+		dst[i] = dotProductIndexedOneGo(base, query, id, dims)
+	}
+	return usedSIMD
+}
+
+func dotProductStrided(dst []float32, base, query []Float16, rowCount, dims, stride int) bool {
+	maxRow := fullRowMaxIndex(len(base), dims, stride)
+	if !hasNEON || dims < neonWidth || len(query) < dims || maxRow < 0 {
+		dotProductStridedGo(dst, base, query, rowCount, dims, stride)
+		return false
+	}
+	queryFull := query[:dims]
+	for i := range dst[:rowCount] {
+		if i > maxRow {
+			dst[i] = dotProductStridedOneGo(base, query, i, dims, stride)
+			continue
+		}
+		off := i * stride
+		dst[i] = dotProductF32(base[off:off+dims], queryFull)
+	}
+	return true
+}
+
+// fullRowMaxIndex returns the last row index whose dims elements, at the given
+// stride, lie entirely within base, or -1 when none does.
+func fullRowMaxIndex(baseLen, dims, stride int) int {
+	if dims <= 0 || stride <= 0 || baseLen < dims {
+		return -1
+	}
+	return (baseLen - dims) / stride
+}
+
 func accumulateAdd16(dst, src []Float16) {
 	n := len(src)
 	if hasFP16 && n >= neonWidth {
@@ -389,6 +448,16 @@ func clampScale16(dst, src []Float16, minVal, maxVal, scale Float16) {
 	clampScaleGo(dst, src, minVal, maxVal, scale)
 }
 
+func expSum16(dst []float32, src []Float16, shift float32) float32 {
+	n := len(dst)
+	// FP32-widened kernel: base NEON only (FCVTL + FP32 arithmetic).
+	if hasNEON && n >= neonWidth {
+		nVec := (n / neonWidth) * neonWidth
+		return expSumNEON(dst[:nVec], src[:nVec], shift) + expSum16Go(dst[nVec:], src[nVec:], shift)
+	}
+	return expSum16Go(dst, src, shift)
+}
+
 //go:noescape
 func toFloat32SliceNEON(dst []float32, src []Float16)
 
@@ -469,3 +538,6 @@ func deinterleave2NEON(a, b, src []Float16)
 
 //go:noescape
 func clampScaleNEON(dst, src []Float16, minF, maxF, scaleF float32)
+
+//go:noescape
+func expSumNEON(dst []float32, src []Float16, shift float32) float32
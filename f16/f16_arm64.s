@@ -930,3 +930,92 @@ clampscale16_loop8:
 
 clampscale16_done:
     RET
+
+// func expSumNEON(dst []float32, src []Float16, shift float32) float32
+// The fused exp-and-sum pass of the softmax family, expSum16Go 4 halves at a
+// time: FCVTL widens them, then f32's expSumNEON core runs unchanged (clamp to
+// [-88, 88], k = round(x*log2e), r = x - k*ln2, degree-5 polynomial, 2^k
+// through the exponent bits, with the same constants). FMIN/FMAX propagate a
+// NaN x, as expSum16Go does. Base NEON only. Length must be a multiple of 8
+// (caller guarantees via dispatch).
+TEXT ·expSumNEON(SB), NOSPLIT, $0-60
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD src_base+24(FP), R1
+
+    MOVW $0x3fb8aa3b, R10
+    VMOV R10, V20.S[0]
+    VDUP V20.S[0], V20.S4         // V20 = log2(e)
+    MOVW $0x3f317218, R10
+    VMOV R10, V21.S[0]
+    VDUP V21.S[0], V21.S4         // V21 = ln(2)
+    FMOVS $1.0, F22
+    VDUP V22.S[0], V22.S4         // V22 = 1.0
+    FMOVS $0.5, F23
+    VDUP V23.S[0], V23.S4         // V23 = c2 = 0.5
+    MOVW $0x3e2aaaab, R10
+    VMOV R10, V24.S[0]
+    VDUP V24.S[0], V24.S4         // V24 = c3 = 1/6
+    MOVW $0x3d2aaaab, R10
+    VMOV R10, V25.S[0]
+    VDUP V25.S[0], V25.S4         // V25 = c4 = 1/24
+    MOVW $0x3c088889, R10
+    VMOV R10, V26.S[0]
+    VDUP V26.S[0], V26.S4         // V26 = c5 = 1/120
+    MOVW $0x42b00000, R10
+    VMOV R10, V27.S[0]
+    VDUP V27.S[0], V27.S4         // V27 = 88.0 (clamp_hi)
+    MOVW $0xc2b00000, R10
+    VMOV R10, V28.S[0]
+    VDUP V28.S[0], V28.S4         // V28 = -88.0 (clamp_lo)
+    FMOVS shift+48(FP), F29
+    VDUP V29.S[0], V29.S4         // V29 = shift
+    VEOR V30.B16, V30.B16, V30.B16 // V30 = running sum
+
+    LSR $2, R3, R4
+    CBZ R4, expsum16_neon_reduce
+
+expsum16_neon_loop4:
+    VLD1.P 8(R1), [V0.H4]         // Load 4 FP16
+    WORD $0x0E217800              // FCVTL V0.4S, V0.4H
+    WORD $0x4EBDD400              // FSUB V0.4S, V0.4S, V29.4S   V0 = x = v - shift
+
+    // Clamp x to [-88, 88]
+    WORD $0x4EBBF400              // FMIN V0.4S, V0.4S, V27.4S
+    WORD $0x4E3CF400              // FMAX V0.4S, V0.4S, V28.4S
+
+    // Range reduction: k = round(x * log2e), r = x - k * ln2
+    WORD $0x6E34DC01              // FMUL V1.4S, V0.4S, V20.4S
+    WORD $0x4E218822              // FRINTN V2.4S, V1.4S
+    WORD $0x6E35DC44              // FMUL V4.4S, V2.4S, V21.4S
+    WORD $0x4EA4D403              // FSUB V3.4S, V0.4S, V4.4S
+
+    // Polynomial: exp(r) ~= 1 + r*(1 + r*(c2 + r*(c3 + r*(c4 + r*c5))))
+    WORD $0x6E3ADC64              // FMUL V4.4S, V3.4S, V26.4S
+    WORD $0x4E39D484              // FADD V4.4S, V4.4S, V25.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E38D484              // FADD V4.4S, V4.4S, V24.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E37D484              // FADD V4.4S, V4.4S, V23.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E36D484              // FADD V4.4S, V4.4S, V22.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E36D484              // FADD V4.4S, V4.4S, V22.4S   V4 = exp(r)
+
+    // Reconstruct: exp(x) = exp(r) * 2^k
+    WORD $0x4EA1B841              // FCVTZS V1.4S, V2.4S
+    WORD $0x4F375421              // SHL V1.4S, V1.4S, #23
+    WORD $0x4EB68421              // ADD V1.4S, V1.4S, V22.4S
+    WORD $0x6E21DC84              // FMUL V4.4S, V4.4S, V1.4S    V4 = exp(x)
+
+    VST1.P [V4.S4], 16(R0)
+    WORD $0x4E24D7DE              // FADD V30.4S, V30.4S, V4.4S  sum += exp(x)
+
+    SUB $1, R4
+    CBNZ R4, expsum16_neon_loop4
+
+expsum16_neon_reduce:
+    WORD $0x6E3ED7DE              // FADDP V30.4S, V30.4S, V30.4S
+    WORD $0x7E30DBDE              // FADDP S30, V30.2S
+    FMOVS F30, ret+56(FP)
+    RET
@@ -419,6 +419,53 @@ func expGo(dst, src []Float16) {
 	}
 }
 
+// expSum16Go writes dst[i] = e^(src[i]-shift), widened to float32, and
+// returns the float32 sum of the written values: the fused pass of Softmax,
+// LogSoftmax and LogSumExp.
+//
+// The exponential is f32's vectorized exp core (the exp_* tables of expAVX and
+// its NEON twin), so the family agrees with f32.Softmax on the widened row: x
+// is clamped to [-88, 88], k = round(x*log2e) through the 1.5*2^23 shifter,
+// r = x - k*ln2, e^r from the degree-5 Taylor polynomial in Horner form, and
+// 2^k built in the exponent bits. NaN propagates. The float32 conversions keep
+// each product rounded as the kernels round it, where the compiler would
+// otherwise fuse the multiply-add (arm64).
+func expSum16Go(dst []float32, src []Float16, shift float32) float32 {
+	if len(dst) == 0 {
+		return 0
+	}
+	_ = src[len(dst)-1]
+	var sum float32
+	for i := range dst {
+		x := min(max(toFloat32Go(src[i])-shift, -expSumClamp), expSumClamp)
+		k := float32(x*expSumLog2E) + expSumShifter - expSumShifter
+		r := x - float32(k*expSumLn2)
+		p := float32(r*expSumC5) + expSumC4
+		p = float32(p*r) + expSumC3
+		p = float32(p*r) + expSumC2
+		p = float32(p*r) + 1
+		p = float32(p*r) + 1
+		e := p * math.Float32frombits(uint32(int32(k)+fp32ExpBias)<<fp32ExpShift)
+		dst[i] = e
+		sum += e
+	}
+	return sum
+}
+
+// Constants of the exp core in expSum16Go, the values of f32's exp_* tables:
+// the clamp that keeps 2^k a finite float32 (and flushes x <= -88 to 0), and
+// 1.5*2^23, whose addition rounds a float32 to an integer.
+const (
+	expSumLog2E   float32 = 1.44269504088896341
+	expSumLn2     float32 = 0.693147180559945309
+	expSumC2      float32 = 1.0 / 2
+	expSumC3      float32 = 1.0 / 6
+	expSumC4      float32 = 1.0 / 24
+	expSumC5      float32 = 1.0 / 120
+	expSumClamp   float32 = 88
+	expSumShifter float32 = 12582912
+)
+
 // tanhGo computes element-wise hyperbolic tangent.
 func tanhGo(dst, src []Float16) {
 	if len(dst) == 0 {
@@ -523,6 +570,92 @@ func dotProductBatchGo(results []float32, rows [][]Float16, vec []Float16) {
 	}
 }
 
+// dotProductIndexedGo scores each selected row with the float32-widening
+// dotProductF32, clamped to the common prefix of query and the row.
+func dotProductIndexedGo(dst []float32, base, query []Float16, rowIDs []uint32, dims int) {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return
+	}
+	if dims <= 0 || len(query) == 0 {
+		clear(dst[:n])
+		return
+	}
+	for i := range n {
+		dst[i] = dotProductIndexedOneGo(base, query, rowIDs[i], dims)
+	}
+}
+
+func dotProductIndexedOneGo(base, query []Float16, rowID uint32, dims int) float32 {
+	if dims <= 0 || len(query) == 0 {
+		return 0
+	}
+	offset, ok := rowOffsetUint32InBase(rowID, dims, len(base))
+	if !ok {
+		return 0
+	}
+	n := min(dims, len(query), len(base)-offset)
+	return dotProductF32(base[offset:offset+n], query[:n])
+}
+
+// dotProductStridedGo scores rows starting every stride elements of base.
+func dotProductStridedGo(dst []float32, base, query []Float16, rowCount, dims, stride int) {
+	if rowCount <= 0 || len(dst) == 0 {
+		return
+	}
+	n := min(len(dst), rowCount)
+	if dims <= 0 || stride <= 0 || len(query) == 0 {
+		clear(dst[:n])
+		return
+	}
+	for i := range n {
+		dst[i] = dotProductStridedOneGo(base, query, i, dims, stride)
+	}
+}
+
+func dotProductStridedOneGo(base, query []Float16, row, dims, stride int) float32 {
+	if row < 0 || dims <= 0 || stride <= 0 || len(query) == 0 {
+		return 0
+	}
+	offset, ok := rowOffsetStrideInBase(row, stride, len(base))
+	if !ok {
+		return 0
+	}
+	n := min(dims, len(query), len(base)-offset)
+	return dotProductF32(base[offset:offset+n], query[:n])
+}
+
+// rowOffsetUint32InBase returns rowID*stride if it indexes into base, guarding
+// the multiply against overflow.
+func rowOffsetUint32InBase(rowID uint32, stride, baseLen int) (int, bool) {
+	if stride <= 0 || baseLen <= 0 {
+		return 0, false
+	}
+	offset, ok := mulUint64(uint64(rowID), uint64(stride))
+	if !ok || offset >= uint64(baseLen) {
+		return 0, false
+	}
+	return int(offset), true
+}
+
+func rowOffsetStrideInBase(row, stride, baseLen int) (int, bool) {
+	if row < 0 || stride <= 0 || baseLen <= 0 {
+		return 0, false
+	}
+	offset, ok := mulUint64(uint64(row), uint64(stride))
+	if !ok || offset >= uint64(baseLen) {
+		return 0, false
+	}
+	return int(offset), true
+}
+
+func mulUint64(a, b uint64) (uint64, bool) {
+	if a != 0 && b > ^uint64(0)/a {
+		return 0, false
+	}
+	return a * b, true
+}
+
 // accumulateAddGo adds src to dst[offset:].
 func accumulateAddGo(dst, src []Float16) {
 	for i := range src {
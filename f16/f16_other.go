@@ -102,6 +102,10 @@ func tanh16(dst, src []Float16) {
 	tanhGo(dst, src)
 }
 
+func expSum16(dst []float32, src []Float16, shift float32) float32 {
+	return expSum16Go(dst, src, shift)
+}
+
 func minIdx16(a []Float16) int {
 	return minIdxGo(a)
 }
@@ -130,6 +134,16 @@ func dotProductBatch16(results []float32, rows [][]Float16, vec []Float16) {
 	dotProductBatchGo(results, rows, vec)
 }
 
+func dotProductIndexed(dst []float32, base, query []Float16, rowIDs []uint32, dims int) bool {
+	dotProductIndexedGo(dst, base, query, rowIDs, dims)
+	return false
+}
+
+func dotProductStrided(dst []float32, base, query []Float16, rowCount, dims, stride int) bool {
+	dotProductStridedGo(dst, base, query, rowCount, dims, stride)
+	return false
+}
+
 func accumulateAdd16(dst, src []Float16) {
 	accumulateAddGo(dst, src)
 }
@@ -149,3 +163,6 @@ func deinterleave2_16(a, b, src []Float16) {
 func clampScale16(dst, src []Float16, minVal, maxVal, scale Float16) {
 	clampScaleGo(dst, src, minVal, maxVal, scale)
 }
+
+// bindKernels has no flags to re-read: every operation runs pure Go here.
+func bindKernels() {}
@@ -0,0 +1,64 @@
+package f16
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":               absGo,
+	"AccumulateAdd":     accumulateAddGo,
+	"Add":               addGo,
+	"AddScalar":         addScalarGo,
+	"AddScaled":         addScaledGo,
+	"Clamp":             clampGo,
+	"ClampScale":        clampScaleGo,
+	"ConvolveValid":     convolveValidGo,
+	"CumulativeSum":     cumulativeSumGo,
+	"Deinterleave2":     deinterleave2Go,
+	"Div":               divGo,
+	"DotProduct":        dotProductGo,
+	"DotProductBatch":   dotProductBatchGo,
+	"DotProductF32":     dotProductGo,
+	"DotProductIndexed": dotProductIndexedGo,
+	"DotProductStrided": dotProductStridedGo,
+	"DotProductUnsafe":  dotProductGo,
+	"EuclideanDistance": euclideanDistanceGo,
+	"Exp":               expGo,
+	"ExpInPlace":        expGo,
+	"FMA":               fmaGo,
+	"FromFloat32":       fromFloat32Go,
+	"FromFloat32Slice":  fromFloat32SliceGo,
+	"Interleave2":       interleave2Go,
+	"LogSoftmax":        expSum16Go,
+	"LogSoftmaxRows":    expSum16Go,
+	"LogSumExp":         expSum16Go,
+	"LogSumExpRows":     expSum16Go,
+	"Max":               maxGo,
+	"MaxIdx":            maxIdxGo,
+	"Min":               minGo,
+	"MinIdx":            minIdxGo,
+	"Mul":               mulGo,
+	"Neg":               negGo,
+	"ReLU":              reluGo,
+	"ReLUInPlace":       reluGo,
+	"Reciprocal":        reciprocalGo,
+	"Scale":             scaleGo,
+	"Sigmoid":           sigmoidGo,
+	"SigmoidInPlace":    sigmoidGo,
+	"Softmax":           expSum16Go,
+	"SoftmaxRows":       expSum16Go,
+	"Sqrt":              sqrtGo,
+	"Sub":               subGo,
+	"Sum":               sumGo,
+	"Tanh":              tanhGo,
+	"TanhInPlace":       tanhGo,
+	"ToFloat32":         toFloat32Go,
+	"ToFloat32Slice":    toFloat32SliceGo,
+	"Variance":          varianceGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "f16", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
@@ -0,0 +1,26 @@
+//go:build amd64
+
+package f16
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelBinding reports the kernel op is bound to: F16C for the slice
+// conversions, F16C+AVX2 for the softmax family's fused exponential, the Go
+// reference for everything else.
+func kernelBinding(op string) dispatch.Binding {
+	switch op {
+	case "ToFloat32Slice":
+		if hasF16C {
+			return dispatch.Bind(dispatch.F16C, toFloat32SliceF16C)
+		}
+	case "FromFloat32Slice":
+		if hasF16C {
+			return dispatch.Bind(dispatch.F16C, fromFloat32SliceF16C)
+		}
+	case "Softmax", "SoftmaxRows", "LogSoftmax", "LogSoftmaxRows", "LogSumExp", "LogSumExpRows":
+		if hasF16C && hasAVX2 {
+			return dispatch.Bind(dispatch.F16CAVX2, expSumF16CAVX2)
+		}
+	}
+	return dispatch.Binding{}
+}
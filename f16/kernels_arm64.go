@@ -0,0 +1,63 @@
+//go:build arm64
+
+package f16
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// fp16Kernels maps each operation computed in half precision to its NEON+FP16
+// kernel.
+var fp16Kernels = map[string]any{
+	"ToFloat32Slice":   toFloat32SliceNEON,
+	"FromFloat32Slice": fromFloat32SliceNEON,
+	"DotProduct":       dotProductNEON,
+	"DotProductUnsafe": dotProductNEON,
+	"DotProductBatch":  dotProductNEON,
+	"Add":              addNEON,
+	"Sub":              subNEON,
+	"Mul":              mulNEON,
+	"Div":              divNEON,
+	"Scale":            scaleNEON,
+	"AddScalar":        addScalarNEON,
+	"AddScaled":        addScaledNEON,
+	"FMA":              fmaNEON,
+	"AccumulateAdd":    accumulateAddNEON,
+	"Sum":              sumNEON,
+	"Min":              minNEON,
+	"Max":              maxNEON,
+	"Abs":              absNEON,
+	"Neg":              negNEON,
+	"ReLU":             reluNEON,
+	"ReLUInPlace":      reluNEON,
+	"Clamp":            clampNEON,
+	"Sqrt":             sqrtNEON,
+	"Reciprocal":       reciprocalNEON,
+}
+
+// neonKernels maps each operation that widens to float32 (or only moves
+// halves) to its plain NEON kernel.
+var neonKernels = map[string]any{
+	"DotProductF32":     dotProductWideNEON,
+	"DotProductIndexed": dotProductWideNEON,
+	"DotProductStrided": dotProductWideNEON,
+	"EuclideanDistance": sumSqDiffNEON,
+	"Variance":          sumSqDevNEON,
+	"Interleave2":       interleave2NEON,
+	"Deinterleave2":     deinterleave2NEON,
+	"ClampScale":        clampScaleNEON,
+	"Softmax":           expSumNEON,
+	"SoftmaxRows":       expSumNEON,
+	"LogSoftmax":        expSumNEON,
+	"LogSoftmaxRows":    expSumNEON,
+	"LogSumExp":         expSumNEON,
+	"LogSumExpRows":     expSumNEON,
+}
+
+// kernelBinding reports the kernel op is bound to. The transcendental maps,
+// MinIdx, MaxIdx, CumulativeSum and ConvolveValid are Go on every host.
+func kernelBinding(op string) dispatch.Binding {
+	if fn, ok := fp16Kernels[op]; ok {
+		return dispatch.When(hasFP16, dispatch.NEONFP16, fn)
+	}
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
@@ -0,0 +1,181 @@
+package f16
+
+import "math"
+
+// Row-wise softmax family, computed in float32. Each row is processed a chunk
+// at a time through a float32 buffer on the stack: one fused pass (expSum16)
+// widens the chunk into the buffer as its shifted exponentials, from f32's
+// vectorized exp core, and returns their sum, so the work stays cache-hot and
+// every exponential is evaluated once. Softmax keeps the exponentials for its scale pass: in the buffer when
+// the row fits in one chunk, so each output is rounded to Float16 once, and
+// otherwise in dst as Float16, which rounds the longer rows twice (within one
+// Float16 ulp).
+
+// softmaxChunk is how many elements one pass converts into its float32
+// buffer.
+const softmaxChunk = 256
+
+// Softmax writes the softmax of src to dst:
+//
+//	dst[i] = e^(src[i]-m) / sum_j e^(src[j]-m),  m = max_j src[j]
+//
+// for i in [0, n), n = min(len(dst), len(src)), with the shift, the
+// exponentials and the sum in float32. Subtracting the maximum keeps every
+// exponent at or below zero, so the sum cannot overflow. dst may alias src
+// exactly.
+//
+// A row whose maximum is not finite (all -Inf, or any +Inf) yields NaN in every
+// element; -Inf elements of any other row map to 0. NaN handling otherwise
+// follows [Max]. Allocation-free.
+func Softmax(dst, src []Float16) {
+	n := min(len(dst), len(src))
+	if n == 0 {
+		return
+	}
+	softmaxRow(dst[:n], src[:n])
+}
+
+// LogSoftmax writes dst[i] = (src[i] - m) - log(sum_j e^(src[j]-m)),
+// m = max_j src[j], for i in [0, n), n = min(len(dst), len(src)), computed in
+// float32 and rounded once. dst may alias src exactly. Non-finite rows behave as
+// in [Softmax].
+func LogSoftmax(dst, src []Float16) {
+	n := min(len(dst), len(src))
+	if n == 0 {
+		return
+	}
+	logSoftmaxRow(dst[:n], src[:n])
+}
+
+// LogSumExp returns log(sum_i e^a[i]) as a float32, computed as
+// m + log(sum_i e^(a[i]-m)), m = max_i a[i], with float32 accumulation. Returns
+// -Inf for an empty slice or one of all -Inf, and +Inf if any element is +Inf.
+func LogSumExp(a []Float16) float32 {
+	if len(a) == 0 {
+		return float32(math.Inf(-1))
+	}
+	return logSumExpRow(a)
+}
+
+// SoftmaxRows applies [Softmax] to each row of a row-major matrix: row r is
+// src[r*cols : (r+1)*cols] and its softmax goes to the same range of dst, for
+// r in [0, min(len(dst), len(src))/cols). A trailing partial row is left
+// untouched, as is all of dst when cols <= 0. dst may alias src exactly.
+func SoftmaxRows(dst, src []Float16, cols int) {
+	if cols <= 0 {
+		return
+	}
+	rows := min(len(dst), len(src)) / cols
+	for r := range rows {
+		off := r * cols
+		softmaxRow(dst[off:off+cols], src[off:off+cols])
+	}
+}
+
+// LogSoftmaxRows applies [LogSoftmax] to each row of a row-major matrix, with
+// the row layout of [SoftmaxRows].
+func LogSoftmaxRows(dst, src []Float16, cols int) {
+	if cols <= 0 {
+		return
+	}
+	rows := min(len(dst), len(src)) / cols
+	for r := range rows {
+		off := r * cols
+		logSoftmaxRow(dst[off:off+cols], src[off:off+cols])
+	}
+}
+
+// LogSumExpRows writes dst[r] = LogSumExp(src[r*cols : (r+1)*cols]) for r in
+// [0, min(len(dst), len(src)/cols)). Nothing is written when cols <= 0.
+func LogSumExpRows(dst []float32, src []Float16, cols int) {
+	if cols <= 0 {
+		return
+	}
+	rows := min(len(dst), len(src)/cols)
+	for r := range rows {
+		dst[r] = logSumExpRow(src[r*cols : (r+1)*cols])
+	}
+}
+
+// softmaxRow is Softmax on a non-empty row with len(dst) == len(src).
+func softmaxRow(dst, src []Float16) {
+	m := toFloat32(max16(src))
+	if m-m != 0 { // ±Inf or NaN
+		fillNaN16(dst)
+		return
+	}
+	var buf [softmaxChunk]float32
+	if len(src) <= softmaxChunk {
+		b := buf[:len(src)]
+		scaleChunk(b, 1/expSum16(b, src, m))
+		fromFloat32Slice(dst, b)
+		return
+	}
+	var sum float32
+	for off := 0; off < len(src); off += softmaxChunk {
+		b := buf[:min(softmaxChunk, len(src)-off)]
+		sum += expSum16(b, src[off:off+len(b)], m)
+		fromFloat32Slice(dst[off:off+len(b)], b)
+	}
+	inv := 1 / sum
+	for off := 0; off < len(dst); off += softmaxChunk {
+		b := buf[:min(softmaxChunk, len(dst)-off)]
+		toFloat32Slice(b, dst[off:off+len(b)])
+		scaleChunk(b, inv)
+		fromFloat32Slice(dst[off:off+len(b)], b)
+	}
+}
+
+// logSoftmaxRow is LogSoftmax on a non-empty row with len(dst) == len(src).
+func logSoftmaxRow(dst, src []Float16) {
+	m := toFloat32(max16(src))
+	if m-m != 0 {
+		fillNaN16(dst)
+		return
+	}
+	var buf [softmaxChunk]float32
+	logS := float32(math.Log(float64(sumExpShifted(&buf, src, m))))
+	for off := 0; off < len(src); off += softmaxChunk {
+		b := buf[:min(softmaxChunk, len(src)-off)]
+		toFloat32Slice(b, src[off:off+len(b)])
+		for i := range b {
+			b[i] = b[i] - m - logS
+		}
+		fromFloat32Slice(dst[off:off+len(b)], b)
+	}
+}
+
+// logSumExpRow is LogSumExp on a non-empty row.
+func logSumExpRow(a []Float16) float32 {
+	m := toFloat32(max16(a))
+	if m-m != 0 {
+		return m
+	}
+	var buf [softmaxChunk]float32
+	return m + float32(math.Log(float64(sumExpShifted(&buf, a, m))))
+}
+
+// sumExpShifted returns the float32 sum of e^(src[i]-shift), using buf as
+// scratch.
+func sumExpShifted(buf *[softmaxChunk]float32, src []Float16, shift float32) float32 {
+	var s float32
+	for off := 0; off < len(src); off += softmaxChunk {
+		b := buf[:min(softmaxChunk, len(src)-off)]
+		s += expSum16(b, src[off:off+len(b)], shift)
+	}
+	return s
+}
+
+// scaleChunk multiplies every element of b by s.
+func scaleChunk(b []float32, s float32) {
+	for i := range b {
+		b[i] *= s
+	}
+}
+
+func fillNaN16(dst []Float16) {
+	nan := fromFloat32(float32(math.NaN()))
+	for i := range dst {
+		dst[i] = nan
+	}
+}
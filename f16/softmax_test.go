@@ -0,0 +1,219 @@
+package f16
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tphakala/simd/f32"
+)
+
+// softmaxInput16 returns n Float16 logits and their float64 values.
+func softmaxInput16(n, seed int) ([]Float16, []float64) {
+	h := make([]Float16, n)
+	f := make([]float64, n)
+	for i := range h {
+		h[i] = FromFloat32(float32((i*37+seed*11)%101-50) * 0.37)
+		f[i] = float64(ToFloat32(h[i]))
+	}
+	return h, f
+}
+
+// softmaxOracle returns the float64 log-sum-exp of a.
+func softmaxOracle(a []float64) float64 {
+	m := math.Inf(-1)
+	for _, v := range a {
+		m = math.Max(m, v)
+	}
+	var s float64
+	for _, v := range a {
+		s += math.Exp(v - m)
+	}
+	return m + math.Log(s)
+}
+
+func TestSoftmax_MatchesReference(t *testing.T) {
+	// One Float16 rounding of the output (2^-11 relative) on top of the float32
+	// exponentials.
+	const tol = 1e-3
+	for _, n := range []int{1, 2, 7, 8, 9, 16, 100, 256, 257, 600} {
+		src, ref := softmaxInput16(n, n)
+		lse := softmaxOracle(ref)
+		if got := LogSumExp(src); math.Abs(float64(got)-lse) > 1e-5*(1+math.Abs(lse)) {
+			t.Fatalf("n=%d: LogSumExp = %g, want %g", n, got, lse)
+		}
+
+		dst := make([]Float16, n)
+		Softmax(dst, src)
+		for i, v := range dst {
+			want := math.Exp(ref[i] - lse)
+			if got := float64(ToFloat32(v)); math.Abs(got-want) > tol*want+1e-7 {
+				t.Fatalf("n=%d: Softmax[%d] = %g, want %g", n, i, got, want)
+			}
+		}
+
+		LogSoftmax(dst, src)
+		for i, v := range dst {
+			want := ref[i] - lse
+			if got := float64(ToFloat32(v)); math.Abs(got-want) > tol*(1+math.Abs(want)) {
+				t.Fatalf("n=%d: LogSoftmax[%d] = %g, want %g", n, i, got, want)
+			}
+		}
+
+		out := make([]Float16, n)
+		Softmax(out, src)
+		inPlace := append([]Float16(nil), src...)
+		Softmax(inPlace, inPlace)
+		for i := range out {
+			if inPlace[i] != out[i] {
+				t.Fatalf("n=%d: in-place Softmax[%d] = %v, want %v", n, i, inPlace[i], out[i])
+			}
+		}
+	}
+}
+
+func TestSoftmax_EdgeCases(t *testing.T) {
+	negInf := FromFloat32(float32(math.Inf(-1)))
+	posInf := FromFloat32(float32(math.Inf(1)))
+	one := FromFloat32(1)
+
+	src := []Float16{one, negInf, one, negInf}
+	dst := make([]Float16, len(src))
+	Softmax(dst, src)
+	for i, want := range []float32{0.5, 0, 0.5, 0} {
+		if got := ToFloat32(dst[i]); got != want {
+			t.Errorf("Softmax[%d] = %g, want %g", i, got, want)
+		}
+	}
+
+	if got := LogSumExp(nil); !math.IsInf(float64(got), -1) {
+		t.Errorf("LogSumExp(nil) = %g, want -Inf", got)
+	}
+	if got := LogSumExp([]Float16{negInf, negInf}); !math.IsInf(float64(got), -1) {
+		t.Errorf("LogSumExp(all -Inf) = %g, want -Inf", got)
+	}
+	if got := LogSumExp([]Float16{one, posInf}); !math.IsInf(float64(got), 1) {
+		t.Errorf("LogSumExp(+Inf) = %g, want +Inf", got)
+	}
+	Softmax(dst[:2], []Float16{negInf, negInf})
+	if !math.IsNaN(float64(ToFloat32(dst[0]))) {
+		t.Errorf("Softmax(all -Inf) = %v, want NaN", dst[:2])
+	}
+}
+
+func TestSoftmaxRows(t *testing.T) {
+	const rows, cols = 4, 300
+	src, _ := softmaxInput16(rows*cols+5, 2)
+	dst := make([]Float16, len(src))
+	lse := make([]float32, rows)
+	SoftmaxRows(dst, src, cols)
+	LogSumExpRows(lse, src, cols)
+	want := make([]Float16, cols)
+	for r := range rows {
+		row := src[r*cols : (r+1)*cols]
+		Softmax(want, row)
+		for i, w := range want {
+			if dst[r*cols+i] != w {
+				t.Fatalf("SoftmaxRows row %d [%d] = %v, want %v", r, i, dst[r*cols+i], w)
+			}
+		}
+		if got := LogSumExp(row); lse[r] != got {
+			t.Errorf("LogSumExpRows[%d] = %g, want %g", r, lse[r], got)
+		}
+	}
+	for i := rows * cols; i < len(dst); i++ {
+		if dst[i] != 0 {
+			t.Fatalf("SoftmaxRows wrote the partial row at %d", i)
+		}
+	}
+
+	LogSoftmaxRows(dst, src, cols)
+	for r := range rows {
+		LogSoftmax(want, src[r*cols:(r+1)*cols])
+		for i, w := range want {
+			if dst[r*cols+i] != w {
+				t.Fatalf("LogSoftmaxRows row %d [%d] = %v, want %v", r, i, dst[r*cols+i], w)
+			}
+		}
+	}
+}
+
+// TestSoftmax_MatchesF32 checks the family against f32's on the widened row:
+// both run the same exp core, so each Softmax and LogSoftmax output is within
+// one Float16 ulp of f32's result rounded to Float16 (the sums may differ in
+// order, and rows past one chunk round twice), and LogSumExp agrees to float32
+// rounding.
+func TestSoftmax_MatchesF32(t *testing.T) {
+	for _, n := range []int{1, 7, 8, 100, 256, 257, 1000} {
+		src, _ := softmaxInput16(n, n)
+		wide := make([]float32, n)
+		ToFloat32Slice(wide, src)
+
+		if got, want := LogSumExp(src), f32.LogSumExp(wide); math.Abs(float64(got-want)) > 1e-6*(1+math.Abs(float64(want))) {
+			t.Fatalf("n=%d: LogSumExp = %g, f32 %g", n, got, want)
+		}
+
+		dst := make([]Float16, n)
+		ref := make([]float32, n)
+		for _, c := range []struct {
+			name string
+			f16  func(dst, src []Float16)
+			f32  func(dst, src []float32)
+		}{
+			{"Softmax", Softmax, f32.Softmax},
+			{"LogSoftmax", LogSoftmax, f32.LogSoftmax},
+		} {
+			c.f16(dst, src)
+			c.f32(ref, wide)
+			for i, v := range dst {
+				want := FromFloat32(ref[i])
+				if d := int(v) - int(want); d < -1 || d > 1 {
+					t.Fatalf("n=%d: %s[%d] = %g, f32 %g", n, c.name, i, ToFloat32(v), ref[i])
+				}
+			}
+		}
+	}
+}
+
+// TestExpSum16 checks the dispatched fused exponential against its Go
+// reference across kernel-width tails, the -88 clamp and the special values.
+func TestExpSum16(t *testing.T) {
+	special := []float32{0, -1e-3, -0.5, -87.5, -88, -88.5, -200, float32(math.Inf(-1)), float32(math.NaN())}
+	for _, n := range []int{1, 7, 8, 9, 15, 16, 31, 100, 256} {
+		src, _ := softmaxInput16(n, n)
+		for i := range min(n, len(special)) {
+			src[i*n/len(special)] = FromFloat32(special[i] + 20)
+		}
+		got := make([]float32, n)
+		want := make([]float32, n)
+		gs, ws := expSum16(got, src, 20), expSum16Go(want, src, 20)
+		for i := range want {
+			if want[i] != want[i] {
+				if got[i] == got[i] {
+					t.Fatalf("n=%d: [%d] = %g, want NaN", n, i, got[i])
+				}
+				continue
+			}
+			if math.Abs(float64(got[i]-want[i])) > 1e-6*float64(want[i]) {
+				t.Fatalf("n=%d: [%d] = %g, want %g", n, i, got[i], want[i])
+			}
+		}
+		if ws == ws && math.Abs(float64(gs-ws)) > 1e-6*float64(ws) {
+			t.Errorf("n=%d: sum = %g, want %g", n, gs, ws)
+		}
+	}
+}
+
+func TestSoftmax_Allocs(t *testing.T) {
+	src, _ := softmaxInput16(1000, 1)
+	dst := make([]Float16, len(src))
+	lse := make([]float32, 10)
+	allocs := testing.AllocsPerRun(100, func() {
+		Softmax(dst, src)
+		LogSoftmax(dst, src)
+		_ = LogSumExp(src)
+		LogSumExpRows(lse, src, 100)
+	})
+	if allocs != 0 {
+		t.Fatalf("softmax family allocations = %v, want 0", allocs)
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"github.com/tphakala/simd/internal/aliastest"
 )
 
-// forTiers runs the aliasing sweep on both the Go and NEON arm64 paths by
-// flipping the package hasNEON gate, so the sweep sees the two kernels that #215
-// showed can disagree. On a NEON-less arm64 host the NEON tier is skipped and the
-// Go path runs once.
+// forTiers runs the aliasing sweep on the SVE, NEON and Go arm64 paths by
+// flipping the package hasSVE and hasNEON gates, so the sweep sees the kernels
+// that #215 showed can disagree. Tiers the host lacks are skipped.
 func forTiers(t *testing.T, run func(t *testing.T)) {
 	t.Helper()
-	aliastest.ForGate(t, &hasNEON, "NEON", run)
+	neon, sve := hasNEON, hasSVE
+	aliastest.ForTiers(t, []aliastest.Tier{
+		{Name: "SVE", Bind: func() { hasNEON, hasSVE = neon, sve }, Supported: sve},
+		{Name: "NEON", Bind: func() { hasNEON, hasSVE = neon, false }, Supported: neon},
+		{Name: "Go", Bind: func() { hasNEON, hasSVE = false, false }, Supported: true},
+	}, run)
 }
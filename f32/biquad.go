@@ -0,0 +1,236 @@
+package f32
+
+import (
+	"errors"
+	"math"
+)
+
+// Recursive (IIR) filtering: a cascade of second-order sections in direct form
+// II transposed, with RBJ Audio EQ Cookbook coefficient designers. A recursion
+// is sequential in time, so the vector lanes run across channels instead: the
+// state is stored structure-of-arrays (each section's s1 for every channel, then
+// its s2), and each section makes one pass over the block with a group of
+// channels' state held in registers (AVX+FMA on amd64, NEON on arm64; see
+// biquadSection). Mono input runs sample by sample through all sections in Go
+// while the sample stays in registers.
+
+// ErrBiquadConfig is returned by NewBiquadCascade for an empty cascade, a channel
+// count below 1, or a non-finite coefficient.
+var ErrBiquadConfig = errors.New("f32: biquad cascade needs >= 1 section with finite coefficients and >= 1 channel")
+
+// Biquad is one second-order section normalized so a0 == 1:
+//
+//	H(z) = (B0 + B1*z^-1 + B2*z^-2) / (1 + A1*z^-1 + A2*z^-2)
+//
+// The designers below fill it from the RBJ Audio EQ Cookbook for a sample rate
+// sr, a center or corner frequency 0 < freq < sr/2 and a quality factor q > 0
+// (1/sqrt(2) is the Butterworth response); outside those ranges the section is
+// degenerate or not finite. The design runs in float64 and is rounded once.
+type Biquad struct {
+	B0, B1, B2 float32
+	A1, A2     float32
+}
+
+// biquadDesign holds the RBJ intermediates for one design.
+type biquadDesign struct {
+	cos, alpha float64
+}
+
+func newBiquadDesign(sr, freq, q float64) biquadDesign {
+	s, c := math.Sincos(2 * math.Pi * freq / sr)
+	return biquadDesign{cos: c, alpha: s / (2 * q)}
+}
+
+// biquadNorm divides the raw RBJ coefficients by a0.
+func biquadNorm(b0, b1, b2, a0, a1, a2 float64) Biquad {
+	return Biquad{
+		B0: float32(b0 / a0), B1: float32(b1 / a0), B2: float32(b2 / a0),
+		A1: float32(a1 / a0), A2: float32(a2 / a0),
+	}
+}
+
+// biquadShelfGain is the amplitude A = 10^(gainDB/40) of the peaking and shelf
+// designs.
+func biquadShelfGain(gainDB float64) float64 {
+	const dbPerDecade = 40
+	return math.Pow(10, gainDB/dbPerDecade)
+}
+
+// LowpassBiquad designs a second-order lowpass section.
+func LowpassBiquad(sr, freq, q float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	b := (1 - d.cos) / 2
+	return biquadNorm(b, 2*b, b, 1+d.alpha, -2*d.cos, 1-d.alpha)
+}
+
+// HighpassBiquad designs a second-order highpass section.
+func HighpassBiquad(sr, freq, q float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	b := (1 + d.cos) / 2
+	return biquadNorm(b, -2*b, b, 1+d.alpha, -2*d.cos, 1-d.alpha)
+}
+
+// BandpassBiquad designs a bandpass section with 0 dB gain at freq (the
+// cookbook's constant 0 dB peak gain form).
+func BandpassBiquad(sr, freq, q float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	return biquadNorm(d.alpha, 0, -d.alpha, 1+d.alpha, -2*d.cos, 1-d.alpha)
+}
+
+// NotchBiquad designs a notch (band-reject) section with a zero at freq.
+func NotchBiquad(sr, freq, q float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	return biquadNorm(1, -2*d.cos, 1, 1+d.alpha, -2*d.cos, 1-d.alpha)
+}
+
+// PeakingBiquad designs a peaking EQ section with gainDB of boost (or cut, when
+// negative) at freq.
+func PeakingBiquad(sr, freq, q, gainDB float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	a := biquadShelfGain(gainDB)
+	return biquadNorm(1+d.alpha*a, -2*d.cos, 1-d.alpha*a, 1+d.alpha/a, -2*d.cos, 1-d.alpha/a)
+}
+
+// LowShelfBiquad designs a low shelf with gainDB below freq and 0 dB above; q
+// sets the slope at the corner (1/sqrt(2) is the steepest without overshoot).
+func LowShelfBiquad(sr, freq, q, gainDB float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	a := biquadShelfGain(gainDB)
+	k := 2 * math.Sqrt(a) * d.alpha
+	return biquadNorm(
+		a*((a+1)-(a-1)*d.cos+k),
+		2*a*((a-1)-(a+1)*d.cos),
+		a*((a+1)-(a-1)*d.cos-k),
+		(a+1)+(a-1)*d.cos+k,
+		-2*((a-1)+(a+1)*d.cos),
+		(a+1)+(a-1)*d.cos-k,
+	)
+}
+
+// HighShelfBiquad designs a high shelf with gainDB above freq and 0 dB below; q
+// is as for LowShelfBiquad.
+func HighShelfBiquad(sr, freq, q, gainDB float64) Biquad {
+	d := newBiquadDesign(sr, freq, q)
+	a := biquadShelfGain(gainDB)
+	k := 2 * math.Sqrt(a) * d.alpha
+	return biquadNorm(
+		a*((a+1)+(a-1)*d.cos+k),
+		-2*a*((a-1)+(a+1)*d.cos),
+		a*((a+1)+(a-1)*d.cos-k),
+		(a+1)-(a-1)*d.cos+k,
+		2*((a-1)-(a+1)*d.cos),
+		(a+1)-(a-1)*d.cos-k,
+	)
+}
+
+// BiquadCascade filters interleaved multichannel audio through a series of
+// biquad sections, each in direct form II transposed:
+//
+//	y    = B0*x + s1
+//	s1   = B1*x - A1*y + s2
+//	s2   = B2*x - A2*y
+//
+// with separate state (s1, s2) per section and channel, carried across Process
+// calls, so a stream filtered in blocks matches one call over the whole stream.
+// Every channel runs the same sections.
+//
+// A cascade holds filter state, so its methods are NOT safe for concurrent use
+// on the same cascade; use one per stream.
+type BiquadCascade struct {
+	sections []Biquad
+	channels int
+	// state holds section s at [2*s*channels : 2*(s+1)*channels]: s1 of every
+	// channel, then s2 of every channel.
+	state []float32
+}
+
+// NewBiquadCascade builds a cascade of sections (applied in order, copied) over
+// channels interleaved channels, with zeroed state. It returns ErrBiquadConfig for
+// no sections, channels < 1, or a coefficient that is NaN or infinite.
+func NewBiquadCascade(sections []Biquad, channels int) (*BiquadCascade, error) {
+	if len(sections) == 0 || channels < 1 {
+		return nil, ErrBiquadConfig
+	}
+	for _, s := range sections {
+		for _, v := range [...]float32{s.B0, s.B1, s.B2, s.A1, s.A2} {
+			if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+				return nil, ErrBiquadConfig
+			}
+		}
+	}
+	return &BiquadCascade{
+		sections: append([]Biquad(nil), sections...),
+		channels: channels,
+		state:    make([]float32, 2*len(sections)*channels),
+	}, nil
+}
+
+// NumSections returns the number of sections in the cascade.
+func (c *BiquadCascade) NumSections() int { return len(c.sections) }
+
+// Channels returns the number of interleaved channels per frame.
+func (c *BiquadCascade) Channels() int { return c.channels }
+
+// Reset clears the filter state, so the next Process starts from silence.
+func (c *BiquadCascade) Reset() { clear(c.state) }
+
+// Process filters the interleaved frames of src into dst and returns the number
+// of frames processed: min(len(dst), len(src)) / Channels() whole frames, with
+// any trailing partial frame left untouched. dst may alias src exactly (in-place
+// filtering); it must not otherwise overlap it. Allocation-free.
+func (c *BiquadCascade) Process(dst, src []float32) int {
+	ch := c.channels
+	frames := min(len(dst), len(src)) / ch
+	if frames == 0 {
+		return 0
+	}
+	if ch == 1 {
+		biquadMono(dst[:frames], src[:frames], c.sections, c.state)
+		return frames
+	}
+	n := frames * ch
+	if &dst[0] != &src[0] {
+		copy(dst[:n], src[:n])
+	}
+	for s := range c.sections {
+		st := c.state[2*s*ch : 2*(s+1)*ch]
+		biquadSection(dst[:n], ch, &c.sections[s], st[:ch], st[ch:])
+	}
+	return frames
+}
+
+// biquadMono runs each sample through every section in turn.
+func biquadMono(dst, src []float32, sections []Biquad, state []float32) {
+	state = state[:2*len(sections)]
+	for i, x := range src {
+		for s := range sections {
+			b := &sections[s]
+			st := state[2*s : 2*s+2]
+			y := b.B0*x + st[0]
+			st[0] = b.B1*x - b.A1*y + st[1]
+			st[1] = b.B2*x - b.A2*y
+			x = y
+		}
+		dst[i] = x
+	}
+}
+
+// biquadSectionGo runs one section in place over channels [lo, ch) of every
+// frame of the interleaved data; s1 and s2 hold the section's state per channel.
+// A section filters each channel independently, so running it over the whole
+// block before the next section gives the same result as running every section
+// per frame.
+func biquadSectionGo(data []float32, ch, lo int, b *Biquad, s1, s2 []float32) {
+	b0, b1, b2, a1, a2 := b.B0, b.B1, b.B2, b.A1, b.A2
+	s1, s2 = s1[:ch], s2[:ch]
+	for f := 0; f+ch <= len(data); f += ch {
+		frame := data[f : f+ch]
+		for j := lo; j < ch; j++ {
+			x := frame[j]
+			y := b0*x + s1[j]
+			s1[j] = b1*x - a1*y + s2[j]
+			s2[j] = b2*x - a2*y
+			frame[j] = y
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package f32
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// biquadResponse evaluates H(exp(i*2*pi*freq/sr)) of one section in float64.
+func biquadResponse(b Biquad, sr, freq float64) complex128 {
+	z := cmplx.Exp(complex(0, -2*math.Pi*freq/sr)) // z^-1
+	num := complex(float64(b.B0), 0) + complex(float64(b.B1), 0)*z + complex(float64(b.B2), 0)*z*z
+	den := 1 + complex(float64(b.A1), 0)*z + complex(float64(b.A2), 0)*z*z
+	return num / den
+}
+
+func biquadGainDB(b Biquad, sr, freq float64) float64 {
+	return 20 * math.Log10(cmplx.Abs(biquadResponse(b, sr, freq)))
+}
+
+// biquadRefF32 filters one channel of interleaved src through the sections with
+// the direct form I difference equation in float64.
+func biquadRefF32(src []float32, ch, c int, sections []Biquad) []float64 {
+	frames := len(src) / ch
+	x := make([]float64, frames)
+	for f := range frames {
+		x[f] = float64(src[f*ch+c])
+	}
+	for _, b := range sections {
+		y := make([]float64, frames)
+		for n := range frames {
+			y[n] = float64(b.B0) * x[n]
+			if n >= 1 {
+				y[n] += float64(b.B1)*x[n-1] - float64(b.A1)*y[n-1]
+			}
+			if n >= 2 {
+				y[n] += float64(b.B2)*x[n-2] - float64(b.A2)*y[n-2]
+			}
+		}
+		x = y
+	}
+	return x
+}
+
+func TestBiquadDesignersF32(t *testing.T) {
+	const sr, f0 = 48000.0, 1000.0
+	q := 1 / math.Sqrt2
+	for _, c := range []struct {
+		name       string
+		b          Biquad
+		freq, want float64
+	}{
+		{"lowpass DC", LowpassBiquad(sr, f0, q), 1, 0},
+		{"lowpass f0", LowpassBiquad(sr, f0, q), f0, -3.0103},
+		{"highpass f0", HighpassBiquad(sr, f0, q), f0, -3.0103},
+		{"highpass pass", HighpassBiquad(sr, f0, q), 20000, 0},
+		{"bandpass f0", BandpassBiquad(sr, f0, 2), f0, 0},
+		{"peaking f0", PeakingBiquad(sr, f0, 1, 6), f0, 6},
+		{"peaking DC", PeakingBiquad(sr, f0, 1, -9), 1, 0},
+		{"lowshelf DC", LowShelfBiquad(sr, f0, q, 6), 1, 6},
+		{"lowshelf f0", LowShelfBiquad(sr, f0, q, 6), f0, 3},
+		{"lowshelf high", LowShelfBiquad(sr, f0, q, 6), 23000, 0},
+		{"highshelf high", HighShelfBiquad(sr, f0, q, -12), 23999, -12},
+		{"highshelf DC", HighShelfBiquad(sr, f0, q, -12), 1, 0},
+	} {
+		if got := biquadGainDB(c.b, sr, c.freq); math.Abs(got-c.want) > 0.02 {
+			t.Errorf("%s: %.4f dB, want %.4f", c.name, got, c.want)
+		}
+	}
+	if g := cmplx.Abs(biquadResponse(NotchBiquad(sr, f0, 10), sr, f0)); g > 1e-3 {
+		t.Errorf("notch at f0: |H| = %g, want 0", g)
+	}
+	if g := biquadGainDB(NotchBiquad(sr, f0, 10), sr, 10*f0); math.Abs(g) > 0.01 {
+		t.Errorf("notch away from f0: %g dB, want 0", g)
+	}
+}
+
+func TestNewBiquadCascadeErrorsF32(t *testing.T) {
+	ok := LowpassBiquad(48000, 1000, 0.7)
+	nan := ok
+	nan.A1 = float32(math.NaN())
+	inf := ok
+	inf.B2 = float32(math.Inf(1))
+	for _, c := range []struct {
+		s  []Biquad
+		ch int
+	}{{nil, 1}, {[]Biquad{ok}, 0}, {[]Biquad{ok, nan}, 2}, {[]Biquad{inf}, 1}} {
+		if _, err := NewBiquadCascade(c.s, c.ch); !errors.Is(err, ErrBiquadConfig) {
+			t.Errorf("NewBiquadCascade(%d sections, %d ch) error = %v, want ErrBiquadConfig", len(c.s), c.ch, err)
+		}
+	}
+	bc, err := NewBiquadCascade([]Biquad{ok, ok}, 3)
+	if err != nil || bc.NumSections() != 2 || bc.Channels() != 3 {
+		t.Fatalf("NewBiquadCascade = %v, %v", bc, err)
+	}
+}
+
+// TestBiquadCascadeAgainstRefF32 checks Process against the float64 direct form I
+// reference per channel, streamed in uneven blocks, for mono and multichannel
+// cascades.
+func TestBiquadCascadeAgainstRefF32(t *testing.T) {
+	const sr = 48000.0
+	sections := []Biquad{
+		HighpassBiquad(sr, 40, 0.7071),
+		PeakingBiquad(sr, 2500, 1.5, 4),
+		NotchBiquad(sr, 50, 8),
+		LowShelfBiquad(sr, 200, 0.7071, -6),
+		LowpassBiquad(sr, 12000, 0.9),
+	}
+	for _, ch := range []int{1, 2, 3, 4, 5, 6, 8, 11, 16} {
+		for _, secs := range [][]Biquad{sections[:1], sections} {
+			bc, _ := NewBiquadCascade(secs, ch)
+			const frames = 1500
+			src := testSignalF32(frames * ch)
+			dst := make([]float32, len(src))
+			for pos, step := 0, 1; pos < frames; step = step*3 + 1 {
+				end := min(frames, pos+step)
+				if got := bc.Process(dst[pos*ch:end*ch], src[pos*ch:end*ch]); got != end-pos {
+					t.Fatalf("Process returned %d frames, want %d", got, end-pos)
+				}
+				pos = end
+			}
+			// The 40 Hz and 50 Hz poles sit within 0.01 of the unit circle, where
+			// float32 state rounding is strongly amplified.
+			const tol = 1e-3
+			for c := range ch {
+				want := biquadRefF32(src, ch, c, secs)
+				for f := range frames {
+					if d := math.Abs(float64(dst[f*ch+c]) - want[f]); d > tol {
+						t.Fatalf("ch=%d/%d sections=%d frame %d: %g want %g", c, ch, len(secs), f, dst[f*ch+c], want[f])
+					}
+				}
+			}
+
+			// In place from a reset matches the out-of-place run.
+			bc.Reset()
+			inPlace := append([]float32(nil), src...)
+			bc.Process(inPlace, inPlace)
+			for i := range inPlace {
+				if inPlace[i] != dst[i] {
+					t.Fatalf("ch=%d: in-place lane %d = %g, want %g", ch, i, inPlace[i], dst[i])
+				}
+			}
+		}
+	}
+}
+
+// TestBiquadSectionKernelF32 checks the bound section kernel against the Go
+// reference over every channel-group split: full vector groups, a partial group
+// and the scalar tail.
+func TestBiquadSectionKernelF32(t *testing.T) {
+	b := PeakingBiquad(48000, 2500, 1.5, 4)
+	for ch := 2; ch <= 19; ch++ {
+		const frames = 257
+		data := testSignalF32(frames * ch)
+		want := append([]float32(nil), data...)
+		s1, s2 := make([]float32, ch), make([]float32, ch)
+		w1, w2 := make([]float32, ch), make([]float32, ch)
+		for i := range ch {
+			s1[i], s2[i] = float32(i)*0.01, -float32(i)*0.02
+		}
+		copy(w1, s1)
+		copy(w2, s2)
+		biquadSection(data, ch, &b, s1, s2)
+		biquadSectionGo(want, ch, 0, &b, w1, w2)
+		for i := range data {
+			if d := math.Abs(float64(data[i] - want[i])); d > 1e-5 {
+				t.Fatalf("ch=%d lane %d: %g, want %g", ch, i, data[i], want[i])
+			}
+		}
+		for i := range ch {
+			if math.Abs(float64(s1[i]-w1[i])) > 1e-5 || math.Abs(float64(s2[i]-w2[i])) > 1e-5 {
+				t.Fatalf("ch=%d state %d: (%g, %g), want (%g, %g)", ch, i, s1[i], s2[i], w1[i], w2[i])
+			}
+		}
+	}
+}
+
+// TestBiquadCascadeGuardsF32 checks the whole-frame clamp.
+func TestBiquadCascadeGuardsF32(t *testing.T) {
+	bc, _ := NewBiquadCascade([]Biquad{LowpassBiquad(48000, 1000, 0.7)}, 2)
+	dst := []float32{9, 9, 9, 9, 9}
+	if got := bc.Process(dst, testSignalF32(7)); got != 2 {
+		t.Fatalf("Process returned %d frames, want 2", got)
+	}
+	if dst[4] != 9 {
+		t.Fatalf("partial frame modified")
+	}
+	if got := bc.Process(dst[:1], testSignalF32(4)); got != 0 || dst[0] == 9 {
+		t.Fatalf("short Process returned %d", got)
+	}
+}
+
+func TestBiquadCascadeAllocFreeF32(t *testing.T) {
+	secs := []Biquad{LowpassBiquad(48000, 1000, 0.7), PeakingBiquad(48000, 300, 1, 3)}
+	for _, ch := range []int{1, 2} {
+		bc, _ := NewBiquadCascade(secs, ch)
+		buf := testSignalF32(512 * ch)
+		if a := testing.AllocsPerRun(5, func() { bc.Process(buf, buf) }); a != 0 {
+			t.Errorf("ch=%d: Process allocated %v times per run, want 0", ch, a)
+		}
+	}
+}
+
+func BenchmarkBiquadCascade(b *testing.B) {
+	secs := []Biquad{
+		HighpassBiquad(48000, 40, 0.7071),
+		PeakingBiquad(48000, 2500, 1.5, 4),
+		LowpassBiquad(48000, 12000, 0.9),
+		NotchBiquad(48000, 50, 8),
+	}
+	for _, ch := range []int{1, 2, 8} {
+		b.Run(fmt.Sprintf("ch=%d", ch), func(b *testing.B) {
+			bc, _ := NewBiquadCascade(secs, ch)
+			buf := testSignalF32(1024 * ch)
+			b.SetBytes(int64(4 * len(buf)))
+			b.ReportAllocs()
+			for b.Loop() {
+				bc.Process(buf, buf)
+			}
+		})
+	}
+}
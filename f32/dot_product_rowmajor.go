@@ -1,10 +1,16 @@
 package f32
 
+import "math"
+
 // Shared scaffolding for the row-major batch dot APIs (DotProductIndexed /
 // DotProductStrided). The per-architecture dispatch (f32_amd64.go, f32_arm64.go)
 // owns the SIMD kernel selection and the batch-of-4 loop; everything here is the
 // portable glue both arches lean on: the SIMD-vs-fallback eligibility gate, the
 // full-row range math, and the ragged-safe scalar fallback/tail paths.
+//
+// The distance APIs (L2SquaredIndexed/Strided, CosineIndexed/Strided) run their
+// batch-of-4 loop here too, on top of the per-arch dotNorm4Batch hook, which
+// takes each row's dot with the query and its squared norm from one pass.
 
 const (
 	batchDotRows    = 4
@@ -135,3 +141,201 @@ func batchDotRowsWithinGate(rows, dims int) bool {
 		return true
 	}
 }
+
+// rowMetric selects the score the fused distance loops derive from a row's dot
+// product with the query and the squared norms of both.
+type rowMetric int
+
+const (
+	rowL2Squared rowMetric = iota
+	rowCosine
+)
+
+// score combines dot = row·query with rowNorm = ||row||² and queryNorm =
+// ||query||². The squared distance is expanded as rowNorm - 2·dot + queryNorm
+// in float64 and clamped at zero, since cancellation can leave it slightly
+// negative for near-identical vectors.
+func (m rowMetric) score(dot, rowNorm, queryNorm float32) float32 {
+	if m == rowCosine {
+		return cosineFromDot(dot, rowNorm, queryNorm)
+	}
+	return float32(max(float64(rowNorm)-2*float64(dot)+float64(queryNorm), 0))
+}
+
+// cosineFromDot returns dot / sqrt(normA * normB) from squared norms, or 0
+// when either norm is zero.
+func cosineFromDot(dot, normA, normB float32) float32 {
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(float64(dot) / math.Sqrt(float64(normA)*float64(normB)))
+}
+
+// rowAt scores the row starting at base[off]. A row lying wholly within base,
+// against a query covering dims, takes its norm from rowNorms[r] when rowNorms
+// covers r; anything else is scored over the common prefix of row and query.
+func (m rowMetric) rowAt(base []float32, off int, query []float32, queryNorm float32, rowNorms []float32, r, dims int) float32 {
+	if len(query) >= dims && len(base)-off >= dims {
+		row := base[off : off+dims]
+		return m.score(dotProduct(row, query[:dims]), rowNormAt(rowNorms, r, row), queryNorm)
+	}
+	n := min(len(base)-off, dims, len(query))
+	row, q := base[off:off+n], query[:n]
+	return m.score(dotProduct(row, q), dotProduct(row, row), dotProduct(q, q))
+}
+
+// rowNormAt returns the precomputed squared norm of row r, or computes it from
+// row when rowNorms does not cover r.
+func rowNormAt(rowNorms []float32, r int, row []float32) float32 {
+	if r < len(rowNorms) {
+		return rowNorms[r]
+	}
+	return dotProduct(row, row)
+}
+
+// fullQueryNorm returns ||query[:dims]||², or 0 when query is shorter than dims
+// and every row is scored over a prefix instead.
+func fullQueryNorm(query []float32, dims int) float32 {
+	if len(query) < dims {
+		return 0
+	}
+	q := query[:dims]
+	return dotProduct(q, q)
+}
+
+func rowMetricIndexedTail(m rowMetric, base, query []float32, queryNorm float32, rowNorms []float32, rowID uint32, dims int) float32 {
+	off, ok := rowOffsetUint32InBase(rowID, dims, len(base))
+	if !ok {
+		return 0
+	}
+	return m.rowAt(base, off, query, queryNorm, rowNorms, int(rowID), dims)
+}
+
+func rowMetricStridedTail(m rowMetric, base, query []float32, queryNorm float32, rowNorms []float32, row, dims, stride int) float32 {
+	off, ok := rowOffsetStrideInBase(row, stride, len(base))
+	if !ok {
+		return 0
+	}
+	return m.rowAt(base, off, query, queryNorm, rowNorms, row, dims)
+}
+
+func rowMetricIndexedFallback(m rowMetric, dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return
+	}
+	if dims <= 0 || len(query) == 0 {
+		clear(dst[:n])
+		return
+	}
+	queryNorm := fullQueryNorm(query, dims)
+	for i := range n {
+		dst[i] = rowMetricIndexedTail(m, base, query, queryNorm, rowNorms, rowIDs[i], dims)
+	}
+}
+
+func rowMetricStridedFallback(m rowMetric, dst, base, query, rowNorms []float32, rowCount, dims, stride int) {
+	if rowCount <= 0 || len(dst) == 0 {
+		return
+	}
+	n := min(len(dst), rowCount)
+	if dims <= 0 || stride <= 0 || len(query) == 0 {
+		clear(dst[:n])
+		return
+	}
+	queryNorm := fullQueryNorm(query, dims)
+	for i := range n {
+		dst[i] = rowMetricStridedTail(m, base, query, queryNorm, rowNorms, i, dims, stride)
+	}
+}
+
+// rowMetric4 scores four full rows (row indices rows, starting at offs) into
+// dst[di:di+4]. The kernel computes the row norms alongside the dots unless
+// rowNorms covers all four rows; a precomputed norm is preferred wherever one
+// exists, so a row scores the same whichever batch it lands in.
+func rowMetric4(m rowMetric, dst []float32, di int, base []float32, rows, offs [batchDotRows]int, query []float32, queryNorm float32, rowNorms []float32, dims int) {
+	var dots, computed [batchDotRows]float32
+	var norms *[batchDotRows]float32
+	for _, r := range rows {
+		if r >= len(rowNorms) {
+			norms = &computed
+			break
+		}
+	}
+	dotNorm4Batch(&dots, norms, base, offs[0], offs[1], offs[2], offs[3], query, dims)
+	for j, r := range rows {
+		rowNorm := computed[j]
+		if r < len(rowNorms) {
+			rowNorm = rowNorms[r]
+		}
+		dst[di+j] = m.score(dots[j], rowNorm, queryNorm)
+	}
+}
+
+func rowMetricIndexed(m rowMetric, dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	maxRow := fullRowMaxIndex(len(base), dims, dims)
+	if !batchDotNormSIMD() || !batchDotIndexedSIMDEligible(n, dims, len(query)) || maxRow < 0 {
+		rowMetricIndexedFallback(m, dst[:n], base, query, rowNorms, rowIDs[:n], dims)
+		return false
+	}
+
+	queryFull := query[:dims]
+	queryNorm := dotProduct(queryFull, queryFull)
+	usedSIMD := false
+	i := 0
+	for ; i+batchDotRows-1 < n; i += batchDotRows {
+		id0, id1, id2, id3 := rowIDs[i], rowIDs[i+1], rowIDs[i+2], rowIDs[i+3]
+		if rowIDInFullRange(id0, maxRow) && rowIDInFullRange(id1, maxRow) && rowIDInFullRange(id2, maxRow) && rowIDInFullRange(id3, maxRow) {
+			rows := [batchDotRows]int{int(id0), int(id1), int(id2), int(id3)}
+			offs := [batchDotRows]int{rows[0] * dims, rows[1] * dims, rows[2] * dims, rows[3] * dims}
+			rowMetric4(m, dst, i, base, rows, offs, queryFull, queryNorm, rowNorms, dims)
+			usedSIMD = true
+			continue
+		}
+		for j := range batchDotRows {
+			dst[i+j] = rowMetricIndexedTail(m, base, query, queryNorm, rowNorms, rowIDs[i+j], dims)
+		}
+	}
+	for ; i < n; i++ {
+		dst[i] = rowMetricIndexedTail(m, base, query, queryNorm, rowNorms, rowIDs[i], dims)
+	}
+	return usedSIMD
+}
+
+func rowMetricStrided(m rowMetric, dst, base, query, rowNorms []float32, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	maxRow := fullRowMaxIndex(len(base), dims, stride)
+	if !batchDotNormSIMD() || !batchDotStridedSIMDEligible(n, dims, stride, len(query)) || maxRow < 0 {
+		rowMetricStridedFallback(m, dst[:n], base, query, rowNorms, n, dims, stride)
+		return false
+	}
+
+	queryFull := query[:dims]
+	queryNorm := dotProduct(queryFull, queryFull)
+	usedSIMD := false
+	i := 0
+	for ; i+batchDotRows-1 < n; i += batchDotRows {
+		if i+batchDotRows-1 <= maxRow {
+			rows := [batchDotRows]int{i, i + 1, i + 2, i + 3}
+			off0 := i * stride
+			offs := [batchDotRows]int{off0, off0 + stride, off0 + 2*stride, off0 + 3*stride}
+			rowMetric4(m, dst, i, base, rows, offs, queryFull, queryNorm, rowNorms, dims)
+			usedSIMD = true
+			continue
+		}
+		for j := range batchDotRows {
+			dst[i+j] = rowMetricStridedTail(m, base, query, queryNorm, rowNorms, i+j, dims, stride)
+		}
+	}
+	for ; i < n; i++ {
+		dst[i] = rowMetricStridedTail(m, base, query, queryNorm, rowNorms, i, dims, stride)
+	}
+	return usedSIMD
+}
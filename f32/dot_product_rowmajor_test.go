@@ -1,6 +1,7 @@
 package f32
 
 import (
+	"math"
 	"runtime"
 	"testing"
 
@@ -207,3 +208,144 @@ func scalarStridedOracle(base, query []float32, rowCount, dims, stride int) []fl
 	}
 	return dst
 }
+
+// rowDistanceOracle measures row against query over their common prefix in
+// float64, directly as sum((r-q)^2) or dot/(|r||q|), without the norm expansion
+// the fused loops use.
+func rowDistanceOracle(m rowMetric, row, query []float32) float32 {
+	n := min(len(row), len(query))
+	var d2, dot, rn, qn float64
+	for j := range n {
+		r, q := float64(row[j]), float64(query[j])
+		d2 += (r - q) * (r - q)
+		dot += r * q
+		rn += r * r
+		qn += q * q
+	}
+	if m == rowL2Squared {
+		return float32(d2)
+	}
+	if rn == 0 || qn == 0 {
+		return 0
+	}
+	return float32(dot / math.Sqrt(rn*qn))
+}
+
+func stridedRowNorms(base []float32, rows, dims, stride int) []float32 {
+	norms := make([]float32, rows)
+	for i := range norms {
+		row := base[i*stride : i*stride+dims]
+		norms[i] = SumOfSquares(row)
+	}
+	return norms
+}
+
+func TestRowDistanceRowMajorParity(t *testing.T) {
+	metrics := []struct {
+		name     string
+		metric   rowMetric
+		indexed  func(dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) bool
+		strided  func(dst, base, query, rowNorms []float32, rowCount, dims, stride int) bool
+		goRefIdx func(dst, base, query, rowNorms []float32, rowIDs []uint32, dims int)
+	}{
+		{"L2Squared", rowL2Squared, L2SquaredIndexed, L2SquaredStrided, l2SquaredIndexedGo},
+		{"Cosine", rowCosine, CosineIndexed, CosineStrided, cosineIndexedGo},
+	}
+	for _, m := range metrics {
+		for _, dims := range []int{1, 7, 16, 64, 65, 128, 768} {
+			for _, rows := range []int{1, 4, 5, 13, 16} {
+				stride := dims + 3
+				baseRows := rows + 11
+				base := deterministicF32Vector(700+dims+rows, baseRows*stride)
+				query := deterministicF32Vector(800+dims, dims)
+				allNorms := stridedRowNorms(base, baseRows, dims, stride)
+				rowIDs := make([]uint32, rows)
+				for i := range rowIDs {
+					rowIDs[i] = uint32((i*7 + 3) % baseRows)
+				}
+				// Indexed rows are packed (stride == dims), so repack the store.
+				packed := make([]float32, baseRows*dims)
+				for r := range baseRows {
+					copy(packed[r*dims:], base[r*stride:r*stride+dims])
+				}
+
+				wantStrided := make([]float32, rows)
+				wantIndexed := make([]float32, rows)
+				for i := range rows {
+					wantStrided[i] = rowDistanceOracle(m.metric, base[i*stride:i*stride+dims], query)
+					id := int(rowIDs[i])
+					wantIndexed[i] = rowDistanceOracle(m.metric, packed[id*dims:id*dims+dims], query)
+				}
+
+				// nil, complete and partial (every row past baseRows/2 computed) norms.
+				for _, norms := range [][]float32{nil, allNorms, allNorms[:baseRows/2]} {
+					got := make([]float32, rows)
+					m.strided(got, base, query, norms, rows, dims, stride)
+					assertCloseSlice(t, got, wantStrided)
+
+					got = make([]float32, rows)
+					m.indexed(got, packed, query, norms, rowIDs, dims)
+					assertCloseSlice(t, got, wantIndexed)
+
+					m.goRefIdx(got, packed, query, norms, rowIDs, dims)
+					assertCloseSlice(t, got, wantIndexed)
+				}
+			}
+		}
+	}
+}
+
+func TestRowDistanceRowMajorRagged(t *testing.T) {
+	base := []float32{
+		1, 2, 3, 4,
+		0, 0, 0, 0,
+		9, 10, // truncated row 2
+	}
+	query := []float32{1, 1, 1, 1}
+	// The bogus norms prove full rows use them and ragged rows do not.
+	norms := []float32{30, 0, 1e6}
+
+	got := []float32{-1, -1, -1, -1, 123}
+	L2SquaredIndexed(got[:4], base, query, norms, []uint32{0, 1, 2, 99}, 4)
+	// Row 0: 30 - 2*10 + 4; row 1: 0 - 0 + 4; row 2 over its prefix 9, 10.
+	assertCloseSlice(t, got, []float32{14, 4, 8*8 + 9*9, 0, 123})
+
+	got = []float32{-1, -1, -1, -1, 123}
+	CosineStrided(got[:4], base, query[:2], nil, 4, 4, 4)
+	want := []float32{3 / float32(math.Sqrt(5*2)), 0, 19 / float32(math.Sqrt(181*2)), 0, 123}
+	assertCloseSlice(t, got, want)
+
+	got = []float32{7, 8}
+	L2SquaredStrided(got, base, query, nil, 2, 0, 4)
+	assertCloseSlice(t, got, []float32{0, 0})
+}
+
+func TestRowDistanceRowMajorOptimizedStatus(t *testing.T) {
+	const dims = 64
+	const rows = 8
+	base := deterministicF32Vector(901, rows*dims)
+	query := deterministicF32Vector(902, dims)
+	norms := stridedRowNorms(base, rows, dims, dims)
+	rowIDs := []uint32{7, 0, 5, 2, 6, 1, 4, 3}
+	dst := make([]float32, rows)
+
+	wantOptimized := (runtime.GOARCH == "amd64" && ((cpu.X86.AVX512F && cpu.X86.AVX512VL) || (cpu.X86.AVX && cpu.X86.FMA))) ||
+		(runtime.GOARCH == "arm64" && cpu.HasNEON())
+	if used := L2SquaredIndexed(dst, base, query, nil, rowIDs, dims); used != wantOptimized {
+		t.Fatalf("L2SquaredIndexed optimized status = %v, want %v (cpu=%s)", used, wantOptimized, cpu.Info())
+	}
+	if used := CosineStrided(dst, base, query, norms, rows, dims, dims); used != wantOptimized {
+		t.Fatalf("CosineStrided optimized status = %v, want %v (cpu=%s)", used, wantOptimized, cpu.Info())
+	}
+	if CosineIndexed(dst, base, query[:32], nil, rowIDs, dims) {
+		t.Fatalf("query shorter than dims should not report optimized")
+	}
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		L2SquaredStrided(dst, base, query, nil, rows, dims, dims)
+		CosineIndexed(dst, base, query, norms, rowIDs, dims)
+	})
+	if allocs != 0 {
+		t.Fatalf("row distance allocations = %v, want 0", allocs)
+	}
+}
@@ -55,11 +55,13 @@
 //     window, except that AddScaled also permits s==dst exactly.
 //   - ButterflyComplex, ButterflyComplexStage and ButterflyComplexStage4 update
 //     their data slices in place; those slices must not overlap one another, and
-//     the twiddles must not overlap them.
+//     the twiddles must not overlap them. FFTPlan.Forward and FFTPlan.Inverse
+//     likewise transform (re, im) in place, and re and im must not overlap.
 //   - The mirror, window, stride, interleave, batch and resample operations
 //     (Interleave2/N, Deinterleave2/N, ConvolveValid and ConvolveValidMulti,
 //     ConvolveDecimate, DotProductBatch, DotProductIndexed, DotProductStrided,
-//     MinIdxOfSumRows and RealFFTUnpack) index inputs and
+//     L2SquaredIndexed/Strided, CosineIndexed/Strided, MinIdxOfSumRows and
+//     RealFFTUnpack) index inputs and
 //     outputs at different positions, so their outputs must not overlap any input.
 //
 // The float-to-fixed and fixed-to-float conversions (Float32ToInt16Scale,
@@ -361,6 +363,71 @@ func DotProductStrided(dst, base, query []float32, rowCount, dims, stride int) b
 	return dotProductStrided(dst[:n], base, query, n, dims, stride)
 }
 
+// L2SquaredIndexed computes squared Euclidean distances between query and
+// selected rows of a flat row-major base slice laid out as for
+// [DotProductIndexed]. For each processed row i, with r = rowIDs[i]:
+//
+//	dst[i] = sum((base[r*dims+j] - query[j])^2) for j in 0..dims-1
+//
+// rowNorms is optional: where len(rowNorms) > r, rowNorms[r] must hold the
+// row's squared norm ([SumOfSquares] of the row) and is used as is; any other
+// row has its norm computed in the same pass as its dot product. Each distance
+// is formed as ||row||² - 2·dot + ||query||² and clamped at zero, so its
+// rounding error scales with the norms rather than the distance; use
+// [EuclideanDistance] where near-duplicate rows must be told apart.
+//
+// Ragged inputs follow DotProductIndexed: a row extending past base, or a query
+// shorter than dims, is measured over the common prefix (ignoring rowNorms),
+// and an out-of-range row, non-positive dims or an empty query scores zero. The
+// function returns true when at least one optimized platform SIMD batch kernel
+// was used.
+func L2SquaredIndexed(dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	return rowMetricIndexed(rowL2Squared, dst[:n], base, query, rowNorms, rowIDs[:n], dims)
+}
+
+// L2SquaredStrided computes squared Euclidean distances between query and
+// rowCount rows of a flat base slice laid out as for [DotProductStrided], with
+// rowNorms[i] the optional squared norm of row i. Norms, rounding and ragged
+// inputs are handled as by [L2SquaredIndexed].
+func L2SquaredStrided(dst, base, query, rowNorms []float32, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	return rowMetricStrided(rowL2Squared, dst[:n], base, query, rowNorms, n, dims, stride)
+}
+
+// CosineIndexed computes cosine similarities between query and selected rows
+// of a flat row-major base slice laid out as for [DotProductIndexed]:
+//
+//	dst[i] = dot(row, query) / (||row|| * ||query||)
+//
+// A zero-norm row or query has similarity 0. rowNorms optionally holds the
+// squared row norms, indexed by row ID, and ragged inputs are handled as by
+// [L2SquaredIndexed].
+func CosineIndexed(dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	return rowMetricIndexed(rowCosine, dst[:n], base, query, rowNorms, rowIDs[:n], dims)
+}
+
+// CosineStrided computes cosine similarities between query and rowCount rows
+// of a flat base slice laid out as for [DotProductStrided], with rowNorms[i]
+// the optional squared norm of row i. It otherwise behaves as [CosineIndexed].
+func CosineStrided(dst, base, query, rowNorms []float32, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	return rowMetricStrided(rowCosine, dst[:n], base, query, rowNorms, n, dims, stride)
+}
+
 // ConvolveValid computes valid convolution of signal with kernel.
 // dst[i] = sum(signal[i+j] * kernel[j]) for j in 0..len(kernel)-1.
 // Output length is len(signal) - len(kernel) + 1.
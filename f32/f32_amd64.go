@@ -17,10 +17,18 @@ const (
 	minAVX512Elements = 16
 )
 
-// minSIMDElements is set at init time based on which SIMD implementation is selected.
+// minSIMDElements is set by bindKernels based on which SIMD implementation is selected.
 // Used by min32/max32 to determine when to fall back to scalar code.
 var minSIMDElements = minAVXElements
 
+// hasAVXFMA and hasAVX512 gate the kernels dispatched per call rather than
+// through the function pointers below (the GEMM microkernel and the biquad
+// section); bindKernels re-reads them.
+var (
+	hasAVXFMA = cpu.X86.AVX && cpu.X86.FMA
+	hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
+)
+
 // Function pointer types for SIMD operations
 type (
 	dotProductFunc          func(a, b []float32) float32
@@ -72,6 +80,14 @@ var (
 )
 
 func init() {
+	bindKernels()
+}
+
+// bindKernels selects the implementations from the current cpu.X86 flags. It
+// runs from init, and again from cpu.Override.
+func bindKernels() {
+	hasAVXFMA = cpu.X86.AVX && cpu.X86.FMA
+	hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
 	// Select optimal implementation based on CPU features
 	// Priority: AVX-512 > AVX+FMA > SSE2 > Go
 	switch {
@@ -127,6 +143,7 @@ func initAVX512() {
 }
 
 func initAVX() {
+	minSIMDElements = minAVXElements
 	dotProductImpl = dotProductAVX
 	addImpl = addAVX
 	subImpl = subAVX
@@ -156,6 +173,7 @@ func initAVX() {
 }
 
 func initSSE() {
+	minSIMDElements = minAVXElements
 	dotProductImpl = dotProductSSE
 	addImpl = addSSE
 	subImpl = subSSE
@@ -187,6 +205,7 @@ func initSSE() {
 }
 
 func initGo() {
+	minSIMDElements = minAVXElements
 	dotProductImpl = dotProductGo
 	addImpl = addGo
 	subImpl = subGo
@@ -534,6 +553,45 @@ func dotProduct4Batch(useAVX512 bool, dst []float32, di int, base []float32, off
 	}
 }
 
+// batchDotNormSIMD reports whether dotNorm4Batch has a batch-of-4 kernel on
+// this CPU, gating the fused L2Squared and Cosine row-major loops.
+func batchDotNormSIMD() bool {
+	return (cpu.X86.AVX512F && cpu.X86.AVX512VL) || (cpu.X86.AVX && cpu.X86.FMA)
+}
+
+// dotNorm4Batch scores four full rows like dotProduct4Batch, writing the dots
+// to dots and, when norms is non-nil, each row's sum of squares to norms from
+// the same pass over the rows. The caller has checked batchDotNormSIMD.
+func dotNorm4Batch(dots, norms *[batchDotRows]float32, base []float32, off0, off1, off2, off3 int, query []float32, dims int) {
+	r0 := (*float32)(unsafe.Pointer(&base[off0]))
+	r1 := (*float32)(unsafe.Pointer(&base[off1]))
+	r2 := (*float32)(unsafe.Pointer(&base[off2]))
+	r3 := (*float32)(unsafe.Pointer(&base[off3]))
+	q := (*float32)(unsafe.Pointer(&query[0]))
+	switch {
+	case cpu.X86.AVX512F && cpu.X86.AVX512VL && norms == nil:
+		dotProduct4AVX512(&dots[0], r0, r1, r2, r3, q, dims)
+	case cpu.X86.AVX512F && cpu.X86.AVX512VL:
+		dotNorm4AVX512(&dots[0], &norms[0], r0, r1, r2, r3, q, dims)
+	case cpu.X86.AVX && cpu.X86.FMA && norms == nil:
+		dotProduct4AVX(&dots[0], r0, r1, r2, r3, q, dims)
+	case cpu.X86.AVX && cpu.X86.FMA:
+		dotNorm4AVX(&dots[0], &norms[0], r0, r1, r2, r3, q, dims)
+	}
+}
+
+// gemmMicroKernel returns the GEMM microkernel of the widest tier this CPU
+// supports, or false when GEMM should take the pure-Go loop.
+func gemmMicroKernel() (gemmMicro, bool) {
+	switch {
+	case hasAVX512:
+		return gemmMicro{mr: 6, nr: 32, kernel: gemmKernel6x32AVX512}, true
+	case hasAVXFMA:
+		return gemmMicro{mr: 6, nr: 16, kernel: gemmKernel6x16AVX}, true
+	}
+	return gemmMicro{}, false
+}
+
 func dotProductIndexed(dst, base, query []float32, rowIDs []uint32, dims int) bool {
 	n := min(len(dst), len(rowIDs))
 	if n == 0 {
@@ -834,6 +892,12 @@ func convolveValidMaxAbsSSE(signal, kernel []float32) float32
 //go:noescape
 func dotProduct4AVX(results, row0, row1, row2, row3, vec *float32, n int)
 
+//go:noescape
+func dotNorm4AVX(dots, norms, row0, row1, row2, row3, vec *float32, n int)
+
+//go:noescape
+func gemmKernel6x16AVX(k int, a, b, c *float32, ldc int)
+
 //go:noescape
 func addAVX(dst, a, b []float32)
 
@@ -920,6 +984,12 @@ func dotProductAVX512(a, b []float32) float32
 //go:noescape
 func dotProduct4AVX512(results, row0, row1, row2, row3, vec *float32, n int)
 
+//go:noescape
+func dotNorm4AVX512(dots, norms, row0, row1, row2, row3, vec *float32, n int)
+
+//go:noescape
+func gemmKernel6x32AVX512(k int, a, b, c *float32, ldc int)
+
 //go:noescape
 func addAVX512(dst, a, b []float32)
 
@@ -1148,6 +1218,18 @@ func exp32(dst, src []float32) {
 //go:noescape
 func expAVX(dst, src []float32)
 
+// expSum32 writes dst[i] = e^(src[i]-shift) and returns the sum of the written
+// values. Same AVX2 requirement as exp32.
+func expSum32(dst, src []float32, shift float32) float32 {
+	if cpu.X86.AVX2 && len(dst) >= minAVXElements {
+		return expSumAVX(dst, src, shift)
+	}
+	return expSum32Go(dst, src, shift)
+}
+
+//go:noescape
+func expSumAVX(dst, src []float32, shift float32) float32
+
 // logSIMDOK32 reports whether the AVX log/pow kernels can run: they need
 // AVX2 (YMM integer ops for the exponent extraction) and FMA (polynomial
 // evaluation). AVX1-only or FMA-less CPUs use the accurate Go path.
@@ -1468,3 +1550,27 @@ func reverseAVX(dst, src []float32)
 
 //go:noescape
 func addSubAVX(sumDst, diffDst, a, b []float32)
+
+// biquadSection runs one section over every frame of the interleaved data in
+// place: groups of 8 channels per biquadAVX call, then one group each of 4, 2
+// and 1 for the remainder, so stereo and other narrow layouts stay on the
+// vector path without masked moves.
+func biquadSection(data []float32, ch int, b *Biquad, s1, s2 []float32) {
+	if !hasAVXFMA {
+		biquadSectionGo(data, ch, 0, b, s1, s2)
+		return
+	}
+	frames := len(data) / ch
+	for c, lanes := 0, 8; c < ch; lanes >>= 1 {
+		for ; ch-c >= lanes; c += lanes {
+			biquadAVX(&data[c], frames, ch, b, &s1[c], &s2[c], lanes)
+		}
+	}
+}
+
+// biquadAVX runs one section over frames frames of lanes (8, 4, 2 or 1)
+// channels starting at x, stride floats apart, with the channels' state in s1
+// and s2.
+//
+//go:noescape
+func biquadAVX(x *float32, frames, stride int, b *Biquad, s1, s2 *float32, lanes int)
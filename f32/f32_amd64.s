@@ -4378,6 +4378,139 @@ exp32_done:
     VZEROUPPER
     RET
 
+// func expSumAVX(dst, src []float32, shift float32) float32
+// Computes dst[i] = e^(src[i]-shift) with the expAVX core and returns the sum
+// of the stored values, the fused exp-and-sum pass of Softmax, LogSoftmax and
+// LogSumExp. The shifted inputs are clamped to [-88, 88] exactly as in expAVX.
+// The vector sum stays in Y6 and is reduced into X6 before the scalar tail,
+// which reads the clamp bounds from memory because X6/X7 are taken.
+TEXT ·expSumAVX(SB), NOSPLIT, $0-60
+    MOVQ dst_base+0(FP), DI
+    MOVQ dst_len+8(FP), CX
+    MOVQ src_base+24(FP), SI
+
+    VMOVUPS exp_log2e<>(SB), Y8         // Y8 = log2(e)
+    VMOVUPS exp_ln2<>(SB), Y9           // Y9 = ln(2)
+    VMOVUPS exp_one<>(SB), Y10          // Y10 = 1.0
+    VMOVUPS exp_c2<>(SB), Y11           // Y11 = c2 = 0.5
+    VMOVUPS exp_c3<>(SB), Y12           // Y12 = c3 = 1/6
+    VMOVUPS exp_c4<>(SB), Y13           // Y13 = c4 = 1/24
+    VMOVUPS exp_c5<>(SB), Y14           // Y14 = c5 = 1/120
+    VMOVUPS exp_magic<>(SB), Y15        // Y15 = magic for rounding
+    VBROADCASTSS shift+48(FP), Y5       // Y5 = shift
+    VXORPS Y6, Y6, Y6                   // Y6 = running sum
+
+    MOVQ CX, AX
+    SHRQ $3, AX
+    JZ   expsum32_reduce
+
+expsum32_loop8:
+    VMOVUPS (SI), Y0
+    VSUBPS Y5, Y0, Y0                   // Y0 = x = src - shift
+
+    // Clamp x to [-88, 88]
+    VMOVUPS exp_clamp_hi<>(SB), Y1
+    VMOVUPS exp_clamp_lo<>(SB), Y2
+    VMINPS Y1, Y0, Y0
+    VMAXPS Y2, Y0, Y0
+
+    // Range reduction: k = round(x * log2e), r = x - k * ln2
+    VMULPS Y8, Y0, Y1
+    VADDPS Y15, Y1, Y2
+    VSUBPS Y15, Y2, Y3                  // Y3 = k
+    VMULPS Y9, Y3, Y4
+    VSUBPS Y4, Y0, Y0                   // Y0 = r
+
+    // Polynomial: exp(r) ~= 1 + r*(1 + r*(c2 + r*(c3 + r*(c4 + r*c5))))
+    VMULPS Y0, Y14, Y1
+    VADDPS Y13, Y1, Y1
+    VMULPS Y0, Y1, Y1
+    VADDPS Y12, Y1, Y1
+    VMULPS Y0, Y1, Y1
+    VADDPS Y11, Y1, Y1
+    VMULPS Y0, Y1, Y1
+    VADDPS Y10, Y1, Y1
+    VMULPS Y0, Y1, Y1
+    VADDPS Y10, Y1, Y1                  // Y1 = exp(r)
+
+    // Reconstruct: exp(x) = exp(r) * 2^k
+    VCVTPS2DQ Y3, Y4
+    VPSLLD $23, Y4, Y4
+    VPADDD Y10, Y4, Y4
+    VMULPS Y4, Y1, Y1                   // Y1 = exp(x)
+
+    VMOVUPS Y1, (DI)
+    VADDPS Y1, Y6, Y6                   // sum += exp(x)
+
+    ADDQ $32, SI
+    ADDQ $32, DI
+    DECQ AX
+    JNZ  expsum32_loop8
+
+expsum32_reduce:
+    VEXTRACTF128 $1, Y6, X7
+    VADDPS X7, X6, X6
+    VHADDPS X6, X6, X6
+    VHADDPS X6, X6, X6                  // X6 = vector sum
+
+    ANDQ $7, CX
+    JZ   expsum32_done
+
+    VMOVSS exp_log2e<>(SB), X8
+    VMOVSS exp_ln2<>(SB), X9
+    VMOVSS exp_magic<>(SB), X15
+    VMOVSS exp_one<>(SB), X10
+    VMOVSS exp_c2<>(SB), X11
+    VMOVSS exp_c3<>(SB), X12
+    VMOVSS exp_c4<>(SB), X13
+    VMOVSS exp_c5<>(SB), X14
+
+expsum32_scalar:
+    VMOVSS (SI), X0
+    VSUBSS X5, X0, X0                   // X0 = x = src - shift
+
+    // Clamp
+    VMINSS exp_clamp_hi<>(SB), X0, X0
+    VMAXSS exp_clamp_lo<>(SB), X0, X0
+
+    // Range reduction
+    VMULSS X8, X0, X1
+    VADDSS X15, X1, X2
+    VSUBSS X15, X2, X3                  // X3 = k
+    VMULSS X9, X3, X4
+    VSUBSS X4, X0, X0                   // X0 = r
+
+    // Polynomial
+    VMULSS X0, X14, X1
+    VADDSS X13, X1, X1
+    VMULSS X0, X1, X1
+    VADDSS X12, X1, X1
+    VMULSS X0, X1, X1
+    VADDSS X11, X1, X1
+    VMULSS X0, X1, X1
+    VADDSS X10, X1, X1
+    VMULSS X0, X1, X1
+    VADDSS X10, X1, X1                  // X1 = exp(r)
+
+    // Reconstruct 2^k
+    VCVTSS2SI X3, AX
+    SHLL $23, AX
+    ADDL $0x3f800000, AX
+    VMOVD AX, X4
+    VMULSS X4, X1, X1                   // X1 = exp(x)
+    VMOVSS X1, (DI)
+    VADDSS X1, X6, X6                   // sum += exp(x)
+
+    ADDQ $4, SI
+    ADDQ $4, DI
+    DECQ CX
+    JNZ  expsum32_scalar
+
+expsum32_done:
+    VMOVSS X6, ret+56(FP)
+    VZEROUPPER
+    RET
+
 // func clampScaleAVX(dst, src []float32, minVal, maxVal, scale float32)
 // Performs fused clamp and scale: dst[i] = (clamp(src[i], minVal, maxVal) - minVal) * scale
 TEXT ·clampScaleAVX(SB), NOSPLIT, $0-60
@@ -7678,6 +7811,263 @@ dot4_512_done:
     VZEROUPPER
     RET
 
+// func dotNorm4AVX(dots, norms, row0, row1, row2, row3, vec *float32, n int)
+// dotProduct4AVX plus each row's sum of squares, taken from the same row
+// loads: Y0-Y3 accumulate the dots and Y4-Y7 the norms, one bank each,
+// so the eight independent FMA chains cover the FMA latency without unrolling.
+TEXT ·dotNorm4AVX(SB), NOSPLIT, $0-64
+    MOVQ dots+0(FP), DX
+    MOVQ norms+8(FP), BX
+    MOVQ row0+16(FP), SI
+    MOVQ row1+24(FP), R8
+    MOVQ row2+32(FP), R9
+    MOVQ row3+40(FP), R10
+    MOVQ vec+48(FP), DI
+    MOVQ n+56(FP), CX
+
+    VXORPS Y0, Y0, Y0          // dot0
+    VXORPS Y1, Y1, Y1          // dot1
+    VXORPS Y2, Y2, Y2          // dot2
+    VXORPS Y3, Y3, Y3          // dot3
+    VXORPS Y4, Y4, Y4          // norm0
+    VXORPS Y5, Y5, Y5          // norm1
+    VXORPS Y6, Y6, Y6          // norm2
+    VXORPS Y7, Y7, Y7          // norm3
+
+    MOVQ CX, AX
+    SHRQ $3, AX                // n / 8
+    JZ   dotnorm4_avx_reduce
+
+dotnorm4_avx_loop:
+    VMOVUPS (DI), Y8
+    VMOVUPS (SI), Y9
+    VFMADD231PS Y8, Y9, Y0
+    VFMADD231PS Y9, Y9, Y4
+    VMOVUPS (R8), Y9
+    VFMADD231PS Y8, Y9, Y1
+    VFMADD231PS Y9, Y9, Y5
+    VMOVUPS (R9), Y9
+    VFMADD231PS Y8, Y9, Y2
+    VFMADD231PS Y9, Y9, Y6
+    VMOVUPS (R10), Y9
+    VFMADD231PS Y8, Y9, Y3
+    VFMADD231PS Y9, Y9, Y7
+    ADDQ $32, DI
+    ADDQ $32, SI
+    ADDQ $32, R8
+    ADDQ $32, R9
+    ADDQ $32, R10
+    DECQ AX
+    JNZ  dotnorm4_avx_loop
+
+dotnorm4_avx_reduce:
+    VEXTRACTF128 $1, Y0, X9
+    VADDPS X9, X0, X0
+    VHADDPS X0, X0, X0
+    VHADDPS X0, X0, X0
+    VEXTRACTF128 $1, Y1, X9
+    VADDPS X9, X1, X1
+    VHADDPS X1, X1, X1
+    VHADDPS X1, X1, X1
+    VEXTRACTF128 $1, Y2, X9
+    VADDPS X9, X2, X2
+    VHADDPS X2, X2, X2
+    VHADDPS X2, X2, X2
+    VEXTRACTF128 $1, Y3, X9
+    VADDPS X9, X3, X3
+    VHADDPS X3, X3, X3
+    VHADDPS X3, X3, X3
+    VEXTRACTF128 $1, Y4, X9
+    VADDPS X9, X4, X4
+    VHADDPS X4, X4, X4
+    VHADDPS X4, X4, X4
+    VEXTRACTF128 $1, Y5, X9
+    VADDPS X9, X5, X5
+    VHADDPS X5, X5, X5
+    VHADDPS X5, X5, X5
+    VEXTRACTF128 $1, Y6, X9
+    VADDPS X9, X6, X6
+    VHADDPS X6, X6, X6
+    VHADDPS X6, X6, X6
+    VEXTRACTF128 $1, Y7, X9
+    VADDPS X9, X7, X7
+    VHADDPS X7, X7, X7
+    VHADDPS X7, X7, X7
+
+    ANDQ $7, CX
+    JZ   dotnorm4_avx_done
+
+dotnorm4_avx_scalar:
+    VMOVSS (DI), X8
+    VMOVSS (SI), X9
+    VFMADD231SS X8, X9, X0
+    VFMADD231SS X9, X9, X4
+    VMOVSS (R8), X9
+    VFMADD231SS X8, X9, X1
+    VFMADD231SS X9, X9, X5
+    VMOVSS (R9), X9
+    VFMADD231SS X8, X9, X2
+    VFMADD231SS X9, X9, X6
+    VMOVSS (R10), X9
+    VFMADD231SS X8, X9, X3
+    VFMADD231SS X9, X9, X7
+    ADDQ $4, DI
+    ADDQ $4, SI
+    ADDQ $4, R8
+    ADDQ $4, R9
+    ADDQ $4, R10
+    DECQ CX
+    JNZ  dotnorm4_avx_scalar
+
+dotnorm4_avx_done:
+    VMOVSS X0, (DX)
+    VMOVSS X1, 4(DX)
+    VMOVSS X2, 8(DX)
+    VMOVSS X3, 12(DX)
+    VMOVSS X4, (BX)
+    VMOVSS X5, 4(BX)
+    VMOVSS X6, 8(BX)
+    VMOVSS X7, 12(BX)
+    VZEROUPPER
+    RET
+
+// func dotNorm4AVX512(dots, norms, row0, row1, row2, row3, vec *float32, n int)
+// dotProduct4AVX512 plus each row's sum of squares, taken from the same row
+// loads: Z0-Z3 accumulate the dots and Z4-Z7 the norms, one bank each,
+// so the eight independent FMA chains cover the FMA latency without unrolling.
+TEXT ·dotNorm4AVX512(SB), NOSPLIT, $0-64
+    MOVQ dots+0(FP), DX
+    MOVQ norms+8(FP), BX
+    MOVQ row0+16(FP), SI
+    MOVQ row1+24(FP), R8
+    MOVQ row2+32(FP), R9
+    MOVQ row3+40(FP), R10
+    MOVQ vec+48(FP), DI
+    MOVQ n+56(FP), CX
+
+    VPXORD Z0, Z0, Z0          // dot0
+    VPXORD Z1, Z1, Z1          // dot1
+    VPXORD Z2, Z2, Z2          // dot2
+    VPXORD Z3, Z3, Z3          // dot3
+    VPXORD Z4, Z4, Z4          // norm0
+    VPXORD Z5, Z5, Z5          // norm1
+    VPXORD Z6, Z6, Z6          // norm2
+    VPXORD Z7, Z7, Z7          // norm3
+
+    MOVQ CX, AX
+    SHRQ $4, AX                // n / 16
+    JZ   dotnorm4_512_reduce
+
+dotnorm4_512_loop:
+    VMOVUPS (DI), Z8
+    VMOVUPS (SI), Z9
+    VFMADD231PS Z8, Z9, Z0
+    VFMADD231PS Z9, Z9, Z4
+    VMOVUPS (R8), Z9
+    VFMADD231PS Z8, Z9, Z1
+    VFMADD231PS Z9, Z9, Z5
+    VMOVUPS (R9), Z9
+    VFMADD231PS Z8, Z9, Z2
+    VFMADD231PS Z9, Z9, Z6
+    VMOVUPS (R10), Z9
+    VFMADD231PS Z8, Z9, Z3
+    VFMADD231PS Z9, Z9, Z7
+    ADDQ $64, DI
+    ADDQ $64, SI
+    ADDQ $64, R8
+    ADDQ $64, R9
+    ADDQ $64, R10
+    DECQ AX
+    JNZ  dotnorm4_512_loop
+
+dotnorm4_512_reduce:
+    // VEXTRACTF64X4 (AVX512F) folds the upper 256 bits without requiring DQ.
+    VEXTRACTF64X4 $1, Z0, Y9
+    VADDPS Y9, Y0, Y0
+    VEXTRACTF128 $1, Y0, X9
+    VADDPS X9, X0, X0
+    VHADDPS X0, X0, X0
+    VHADDPS X0, X0, X0
+    VEXTRACTF64X4 $1, Z1, Y9
+    VADDPS Y9, Y1, Y1
+    VEXTRACTF128 $1, Y1, X9
+    VADDPS X9, X1, X1
+    VHADDPS X1, X1, X1
+    VHADDPS X1, X1, X1
+    VEXTRACTF64X4 $1, Z2, Y9
+    VADDPS Y9, Y2, Y2
+    VEXTRACTF128 $1, Y2, X9
+    VADDPS X9, X2, X2
+    VHADDPS X2, X2, X2
+    VHADDPS X2, X2, X2
+    VEXTRACTF64X4 $1, Z3, Y9
+    VADDPS Y9, Y3, Y3
+    VEXTRACTF128 $1, Y3, X9
+    VADDPS X9, X3, X3
+    VHADDPS X3, X3, X3
+    VHADDPS X3, X3, X3
+    VEXTRACTF64X4 $1, Z4, Y9
+    VADDPS Y9, Y4, Y4
+    VEXTRACTF128 $1, Y4, X9
+    VADDPS X9, X4, X4
+    VHADDPS X4, X4, X4
+    VHADDPS X4, X4, X4
+    VEXTRACTF64X4 $1, Z5, Y9
+    VADDPS Y9, Y5, Y5
+    VEXTRACTF128 $1, Y5, X9
+    VADDPS X9, X5, X5
+    VHADDPS X5, X5, X5
+    VHADDPS X5, X5, X5
+    VEXTRACTF64X4 $1, Z6, Y9
+    VADDPS Y9, Y6, Y6
+    VEXTRACTF128 $1, Y6, X9
+    VADDPS X9, X6, X6
+    VHADDPS X6, X6, X6
+    VHADDPS X6, X6, X6
+    VEXTRACTF64X4 $1, Z7, Y9
+    VADDPS Y9, Y7, Y7
+    VEXTRACTF128 $1, Y7, X9
+    VADDPS X9, X7, X7
+    VHADDPS X7, X7, X7
+    VHADDPS X7, X7, X7
+
+    ANDQ $15, CX
+    JZ   dotnorm4_512_done
+
+dotnorm4_512_scalar:
+    VMOVSS (DI), X8
+    VMOVSS (SI), X9
+    VFMADD231SS X8, X9, X0
+    VFMADD231SS X9, X9, X4
+    VMOVSS (R8), X9
+    VFMADD231SS X8, X9, X1
+    VFMADD231SS X9, X9, X5
+    VMOVSS (R9), X9
+    VFMADD231SS X8, X9, X2
+    VFMADD231SS X9, X9, X6
+    VMOVSS (R10), X9
+    VFMADD231SS X8, X9, X3
+    VFMADD231SS X9, X9, X7
+    ADDQ $4, DI
+    ADDQ $4, SI
+    ADDQ $4, R8
+    ADDQ $4, R9
+    ADDQ $4, R10
+    DECQ CX
+    JNZ  dotnorm4_512_scalar
+
+dotnorm4_512_done:
+    VMOVSS X0, (DX)
+    VMOVSS X1, 4(DX)
+    VMOVSS X2, 8(DX)
+    VMOVSS X3, 12(DX)
+    VMOVSS X4, (BX)
+    VMOVSS X5, 4(BX)
+    VMOVSS X6, 8(BX)
+    VMOVSS X7, 12(BX)
+    VZEROUPPER
+    RET
+
 // ============================================================================
 // VARIANCE / EUCLIDEAN DISTANCE REDUCTIONS
 // Ported from the f64 kernels (f64/f64_amd64.s). float32 doubles the lane count
@@ -9013,3 +9403,321 @@ minidxofsumrows4_avx2_write:
     VMOVUPS X0, (AX)               // vals[0:4]
     VMOVUPS X1, (BX)               // idxs[0:4]
     RET
+
+// ============================================================================
+// GEMM MICROKERNELS
+// Register-blocked tiles for the packed GEMM driver in gemm.go; the caller
+// handles blocking, packing, alpha/beta and partial tiles.
+// ============================================================================
+
+// func gemmKernel6x16AVX(k int, a, b, c *float32, ldc int)
+// AVX+FMA GEMM microkernel: c[i*ldc+j] += sum over p < k of a[p*6+i] * b[p*16+j]
+// for the 6x16 tile, from A packed 6 rows and B packed 16 columns per
+// step of p. Y4-Y15 hold the tile, 2 registers per row; Y0-Y1 hold the B row
+// and Y2-Y3 alternate as the broadcast A element.
+TEXT ·gemmKernel6x16AVX(SB), NOSPLIT, $0-40
+    MOVQ k+0(FP), CX
+    MOVQ a+8(FP), SI
+    MOVQ b+16(FP), DI
+    MOVQ c+24(FP), DX
+    MOVQ ldc+32(FP), R8
+    SHLQ $2, R8                // ldc in bytes
+
+    VXORPS Y4, Y4, Y4
+    VXORPS Y5, Y5, Y5
+    VXORPS Y6, Y6, Y6
+    VXORPS Y7, Y7, Y7
+    VXORPS Y8, Y8, Y8
+    VXORPS Y9, Y9, Y9
+    VXORPS Y10, Y10, Y10
+    VXORPS Y11, Y11, Y11
+    VXORPS Y12, Y12, Y12
+    VXORPS Y13, Y13, Y13
+    VXORPS Y14, Y14, Y14
+    VXORPS Y15, Y15, Y15
+
+    TESTQ CX, CX
+    JZ    gemmkernel6x16avx_store
+
+gemmkernel6x16avx_loop:
+    VMOVUPS (DI), Y0
+    VMOVUPS 32(DI), Y1
+    VBROADCASTSS (SI), Y2
+    VFMADD231PS Y0, Y2, Y4
+    VFMADD231PS Y1, Y2, Y5
+    VBROADCASTSS 4(SI), Y3
+    VFMADD231PS Y0, Y3, Y6
+    VFMADD231PS Y1, Y3, Y7
+    VBROADCASTSS 8(SI), Y2
+    VFMADD231PS Y0, Y2, Y8
+    VFMADD231PS Y1, Y2, Y9
+    VBROADCASTSS 12(SI), Y3
+    VFMADD231PS Y0, Y3, Y10
+    VFMADD231PS Y1, Y3, Y11
+    VBROADCASTSS 16(SI), Y2
+    VFMADD231PS Y0, Y2, Y12
+    VFMADD231PS Y1, Y2, Y13
+    VBROADCASTSS 20(SI), Y3
+    VFMADD231PS Y0, Y3, Y14
+    VFMADD231PS Y1, Y3, Y15
+    ADDQ $24, SI
+    ADDQ $64, DI
+    DECQ CX
+    JNZ  gemmkernel6x16avx_loop
+
+gemmkernel6x16avx_store:
+    VADDPS (DX), Y4, Y4
+    VMOVUPS Y4, (DX)
+    VADDPS 32(DX), Y5, Y5
+    VMOVUPS Y5, 32(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Y6, Y6
+    VMOVUPS Y6, (DX)
+    VADDPS 32(DX), Y7, Y7
+    VMOVUPS Y7, 32(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Y8, Y8
+    VMOVUPS Y8, (DX)
+    VADDPS 32(DX), Y9, Y9
+    VMOVUPS Y9, 32(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Y10, Y10
+    VMOVUPS Y10, (DX)
+    VADDPS 32(DX), Y11, Y11
+    VMOVUPS Y11, 32(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Y12, Y12
+    VMOVUPS Y12, (DX)
+    VADDPS 32(DX), Y13, Y13
+    VMOVUPS Y13, 32(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Y14, Y14
+    VMOVUPS Y14, (DX)
+    VADDPS 32(DX), Y15, Y15
+    VMOVUPS Y15, 32(DX)
+    VZEROUPPER
+    RET
+
+// func gemmKernel6x32AVX512(k int, a, b, c *float32, ldc int)
+// AVX-512 GEMM microkernel: c[i*ldc+j] += sum over p < k of a[p*6+i] * b[p*32+j]
+// for the 6x32 tile, from A packed 6 rows and B packed 32 columns per
+// step of p. Z4-Z15 hold the tile, 2 registers per row; Z0-Z1 hold the B row
+// and Z2-Z3 alternate as the broadcast A element.
+TEXT ·gemmKernel6x32AVX512(SB), NOSPLIT, $0-40
+    MOVQ k+0(FP), CX
+    MOVQ a+8(FP), SI
+    MOVQ b+16(FP), DI
+    MOVQ c+24(FP), DX
+    MOVQ ldc+32(FP), R8
+    SHLQ $2, R8                // ldc in bytes
+
+    VPXORD Z4, Z4, Z4
+    VPXORD Z5, Z5, Z5
+    VPXORD Z6, Z6, Z6
+    VPXORD Z7, Z7, Z7
+    VPXORD Z8, Z8, Z8
+    VPXORD Z9, Z9, Z9
+    VPXORD Z10, Z10, Z10
+    VPXORD Z11, Z11, Z11
+    VPXORD Z12, Z12, Z12
+    VPXORD Z13, Z13, Z13
+    VPXORD Z14, Z14, Z14
+    VPXORD Z15, Z15, Z15
+
+    TESTQ CX, CX
+    JZ    gemmkernel6x32avx512_store
+
+gemmkernel6x32avx512_loop:
+    VMOVUPS (DI), Z0
+    VMOVUPS 64(DI), Z1
+    VBROADCASTSS (SI), Z2
+    VFMADD231PS Z0, Z2, Z4
+    VFMADD231PS Z1, Z2, Z5
+    VBROADCASTSS 4(SI), Z3
+    VFMADD231PS Z0, Z3, Z6
+    VFMADD231PS Z1, Z3, Z7
+    VBROADCASTSS 8(SI), Z2
+    VFMADD231PS Z0, Z2, Z8
+    VFMADD231PS Z1, Z2, Z9
+    VBROADCASTSS 12(SI), Z3
+    VFMADD231PS Z0, Z3, Z10
+    VFMADD231PS Z1, Z3, Z11
+    VBROADCASTSS 16(SI), Z2
+    VFMADD231PS Z0, Z2, Z12
+    VFMADD231PS Z1, Z2, Z13
+    VBROADCASTSS 20(SI), Z3
+    VFMADD231PS Z0, Z3, Z14
+    VFMADD231PS Z1, Z3, Z15
+    ADDQ $24, SI
+    ADDQ $128, DI
+    DECQ CX
+    JNZ  gemmkernel6x32avx512_loop
+
+gemmkernel6x32avx512_store:
+    VADDPS (DX), Z4, Z4
+    VMOVUPS Z4, (DX)
+    VADDPS 64(DX), Z5, Z5
+    VMOVUPS Z5, 64(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Z6, Z6
+    VMOVUPS Z6, (DX)
+    VADDPS 64(DX), Z7, Z7
+    VMOVUPS Z7, 64(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Z8, Z8
+    VMOVUPS Z8, (DX)
+    VADDPS 64(DX), Z9, Z9
+    VMOVUPS Z9, 64(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Z10, Z10
+    VMOVUPS Z10, (DX)
+    VADDPS 64(DX), Z11, Z11
+    VMOVUPS Z11, 64(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Z12, Z12
+    VMOVUPS Z12, (DX)
+    VADDPS 64(DX), Z13, Z13
+    VMOVUPS Z13, 64(DX)
+    ADDQ R8, DX
+    VADDPS (DX), Z14, Z14
+    VMOVUPS Z14, (DX)
+    VADDPS 64(DX), Z15, Z15
+    VMOVUPS Z15, 64(DX)
+    VZEROUPPER
+    RET
+
+// func biquadAVX(x *float32, frames, stride int, b *Biquad, s1, s2 *float32, lanes int)
+// One direct form II transposed section across lanes (8, 4, 2 or 1) channels,
+// frame by frame:
+//
+//	y = B0*x + s1;  s1 = B1*x - A1*y + s2;  s2 = B2*x - A2*y
+//
+// Y0/Y1 carry s1/s2 through the loop (Y0 turns into y, which is stored before
+// the new state rotates in) and Y8-Y12 hold B0, B1, B2, A1, A2. Narrower groups
+// run the same sequence on the low XMM lanes with 16-, 8- or 4-byte moves,
+// never touching memory past the group.
+TEXT ·biquadAVX(SB), NOSPLIT, $0-56
+    MOVQ x+0(FP), DI
+    MOVQ frames+8(FP), CX
+    MOVQ stride+16(FP), DX
+    MOVQ b+24(FP), SI
+    MOVQ s1+32(FP), R8
+    MOVQ s2+40(FP), R9
+    MOVQ lanes+48(FP), R10
+    SHLQ $2, DX                // stride in bytes
+
+    VBROADCASTSS 0(SI), Y8     // B0
+    VBROADCASTSS 4(SI), Y9     // B1
+    VBROADCASTSS 8(SI), Y10    // B2
+    VBROADCASTSS 12(SI), Y11   // A1
+    VBROADCASTSS 16(SI), Y12   // A2
+
+    CMPQ R10, $8
+    JNE  biquad32_avx_x4
+
+    VMOVUPS (R8), Y0
+    VMOVUPS (R9), Y1
+    TESTQ CX, CX
+    JZ    biquad32_avx_y8_store
+
+biquad32_avx_y8_loop:
+    VMOVUPS (DI), Y2           // x
+    VFMADD231PS Y2, Y8, Y0     // y = B0*x + s1
+    VFMADD231PS Y2, Y9, Y1     // s2 + B1*x
+    VFNMADD231PS Y0, Y11, Y1   // s1 = s2 + B1*x - A1*y
+    VMULPS Y2, Y10, Y4         // B2*x
+    VFNMADD231PS Y0, Y12, Y4   // s2 = B2*x - A2*y
+    VMOVUPS Y0, (DI)
+    VMOVAPS Y1, Y0
+    VMOVAPS Y4, Y1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad32_avx_y8_loop
+
+biquad32_avx_y8_store:
+    VMOVUPS Y0, (R8)
+    VMOVUPS Y1, (R9)
+    VZEROUPPER
+    RET
+
+biquad32_avx_x4:
+    CMPQ R10, $4
+    JNE  biquad32_avx_x2
+    VMOVUPS (R8), X0
+    VMOVUPS (R9), X1
+    TESTQ CX, CX
+    JZ    biquad32_avx_x4_store
+
+biquad32_avx_x4_loop:
+    VMOVUPS (DI), X2           // x
+    VFMADD231PS X2, X8, X0     // y = B0*x + s1
+    VFMADD231PS X2, X9, X1     // s2 + B1*x
+    VFNMADD231PS X0, X11, X1   // s1 = s2 + B1*x - A1*y
+    VMULPS X2, X10, X4         // B2*x
+    VFNMADD231PS X0, X12, X4   // s2 = B2*x - A2*y
+    VMOVUPS X0, (DI)
+    VMOVAPS X1, X0
+    VMOVAPS X4, X1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad32_avx_x4_loop
+
+biquad32_avx_x4_store:
+    VMOVUPS X0, (R8)
+    VMOVUPS X1, (R9)
+    VZEROUPPER
+    RET
+
+biquad32_avx_x2:
+    CMPQ R10, $2
+    JNE  biquad32_avx_x1
+    VMOVSD (R8), X0
+    VMOVSD (R9), X1
+    TESTQ CX, CX
+    JZ    biquad32_avx_x2_store
+
+biquad32_avx_x2_loop:
+    VMOVSD (DI), X2            // x
+    VFMADD231PS X2, X8, X0     // y = B0*x + s1
+    VFMADD231PS X2, X9, X1     // s2 + B1*x
+    VFNMADD231PS X0, X11, X1   // s1 = s2 + B1*x - A1*y
+    VMULPS X2, X10, X4         // B2*x
+    VFNMADD231PS X0, X12, X4   // s2 = B2*x - A2*y
+    VMOVSD X0, (DI)
+    VMOVAPS X1, X0
+    VMOVAPS X4, X1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad32_avx_x2_loop
+
+biquad32_avx_x2_store:
+    VMOVSD X0, (R8)
+    VMOVSD X1, (R9)
+    VZEROUPPER
+    RET
+
+biquad32_avx_x1:
+    VMOVSS (R8), X0
+    VMOVSS (R9), X1
+    TESTQ CX, CX
+    JZ    biquad32_avx_x1_store
+
+biquad32_avx_x1_loop:
+    VMOVSS (DI), X2            // x
+    VFMADD231PS X2, X8, X0     // y = B0*x + s1
+    VFMADD231PS X2, X9, X1     // s2 + B1*x
+    VFNMADD231PS X0, X11, X1   // s1 = s2 + B1*x - A1*y
+    VMULPS X2, X10, X4         // B2*x
+    VFNMADD231PS X0, X12, X4   // s2 = B2*x - A2*y
+    VMOVSS X0, (DI)
+    VMOVAPS X1, X0
+    VMOVAPS X4, X1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad32_avx_x1_loop
+
+biquad32_avx_x1_store:
+    VMOVSS X0, (R8)
+    VMOVSS X1, (R9)
+    VZEROUPPER
+    RET
@@ -11,9 +11,20 @@ import (
 
 var (
 	hasNEON = cpu.ARM64.NEON
+	hasSVE  = cpu.ARM64.SVE
 )
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+	hasSVE = cpu.ARM64.SVE
+}
+
 func dotProduct(a, b []float32) float32 {
+	if hasSVE && len(a) >= 4 {
+		return dotProductSVE(a, b)
+	}
 	if hasNEON && len(a) >= 4 {
 		return dotProductNEON(a, b)
 	}
@@ -21,6 +32,10 @@ func dotProduct(a, b []float32) float32 {
 }
 
 func add(dst, a, b []float32) {
+	if hasSVE && len(dst) >= 4 {
+		addSVE(dst, a, b)
+		return
+	}
 	if hasNEON && len(dst) >= 4 {
 		addNEON(dst, a, b)
 		return
@@ -37,6 +52,10 @@ func sub(dst, a, b []float32) {
 }
 
 func mul(dst, a, b []float32) {
+	if hasSVE && len(dst) >= 4 {
+		mulSVE(dst, a, b)
+		return
+	}
 	if hasNEON && len(dst) >= 4 {
 		mulNEON(dst, a, b)
 		return
@@ -69,6 +88,9 @@ func addScalar(dst, a []float32, s float32) {
 }
 
 func sum(a []float32) float32 {
+	if hasSVE && len(a) >= 4 {
+		return sumSVE(a)
+	}
 	if hasNEON && len(a) >= 4 {
 		return sumNEON(a)
 	}
@@ -129,6 +151,10 @@ func subFromScalar32(dst, a []float32, s float32) {
 }
 
 func fma32(dst, a, b, c []float32) {
+	if hasSVE && len(dst) >= 4 {
+		fmaSVE(dst, a, b, c)
+		return
+	}
 	if hasNEON && len(dst) >= 4 {
 		fmaNEON(dst, a, b, c)
 		return
@@ -170,7 +196,7 @@ func dotProductBatch32(results []float32, rows [][]float32, vec []float32) {
 }
 
 // dotProductBatchKernel scores rows against vec in groups of four, keeping the
-// query vector resident across each group via dotProduct4NEON instead of
+// query vector resident across each group via dotProduct4 instead of
 // reloading it per row. Rows shorter than vecLen (and any tail past the last
 // full group of four) fall back to the per-row dotProduct, so results stay
 // anchored to the scalar contract regardless of row shape.
@@ -185,7 +211,7 @@ func dotProductBatchKernel(results []float32, rows [][]float32, vec []float32, v
 			r2 := (*float32)(unsafe.Pointer(&row2[0]))
 			r3 := (*float32)(unsafe.Pointer(&row3[0]))
 			q := (*float32)(unsafe.Pointer(&vec[0]))
-			dotProduct4NEON(res, r0, r1, r2, r3, q, vecLen)
+			dotProduct4(res, r0, r1, r2, r3, q, vecLen)
 			i += 4
 			continue
 		}
@@ -298,7 +324,49 @@ func dotProduct4Batch(dst []float32, di int, base []float32, off0, off1, off2, o
 	r2 := (*float32)(unsafe.Pointer(&base[off2]))
 	r3 := (*float32)(unsafe.Pointer(&base[off3]))
 	q := (*float32)(unsafe.Pointer(&query[0]))
-	dotProduct4NEON(results, r0, r1, r2, r3, q, dims)
+	dotProduct4(results, r0, r1, r2, r3, q, dims)
+}
+
+// batchDotNormSIMD reports whether dotNorm4Batch has a batch-of-4 kernel on
+// this CPU, gating the fused L2Squared and Cosine row-major loops.
+func batchDotNormSIMD() bool { return hasNEON }
+
+// dotNorm4Batch scores four full rows like dotProduct4Batch, writing the dots
+// to dots and, when norms is non-nil, each row's sum of squares to norms from
+// the same pass over the rows. The fused dot+norm kernel is NEON only; the
+// dot-only form still takes dotProduct4's SVE tier.
+func dotNorm4Batch(dots, norms *[batchDotRows]float32, base []float32, off0, off1, off2, off3 int, query []float32, dims int) {
+	if norms == nil {
+		dotProduct4Batch(dots[:], 0, base, off0, off1, off2, off3, query, dims)
+		return
+	}
+	r0 := (*float32)(unsafe.Pointer(&base[off0]))
+	r1 := (*float32)(unsafe.Pointer(&base[off1]))
+	r2 := (*float32)(unsafe.Pointer(&base[off2]))
+	r3 := (*float32)(unsafe.Pointer(&base[off3]))
+	q := (*float32)(unsafe.Pointer(&query[0]))
+	dotNorm4NEON(&dots[0], &norms[0], r0, r1, r2, r3, q, dims)
+}
+
+// gemmMicroKernel returns the NEON GEMM microkernel, or false when GEMM should
+// take the pure-Go loop. There is no SVE tier: the 8x8 tile is sized to the
+// NEON register file.
+func gemmMicroKernel() (gemmMicro, bool) {
+	if !hasNEON {
+		return gemmMicro{}, false
+	}
+	return gemmMicro{mr: 8, nr: 8, kernel: gemmKernel8x8NEON}, true
+}
+
+// dotProduct4 runs the batch-of-4 kernel of the widest tier: SVE when present,
+// otherwise NEON. Callers have checked hasNEON, which every SVE host has (and
+// SIMD_DISABLE=neon clears SVE with it).
+func dotProduct4(results, row0, row1, row2, row3, vec *float32, n int) {
+	if hasSVE {
+		dotProduct4SVE(results, row0, row1, row2, row3, vec, n)
+		return
+	}
+	dotProduct4NEON(results, row0, row1, row2, row3, vec, n)
 }
 
 func convolveValid32(dst, signal, kernel []float32) {
@@ -311,6 +379,16 @@ func convolveValid32(dst, signal, kernel []float32) {
 func convolveDecimate32(dst, signal, kernel []float32, factor, phase int) {
 	// Mirror dotProduct's NEON length threshold (>= 4) so the fused kernel and a
 	// per-window DotProductUnsafe pick the same backend, keeping results identical.
+	// SVE has no fused kernel: run its per-window dot directly, like AVX-512 does.
+	if hasSVE && len(kernel) >= 4 {
+		kLen := len(kernel)
+		pos := phase
+		for k := range dst {
+			dst[k] = dotProductSVE(signal[pos:pos+kLen], kernel)
+			pos += factor
+		}
+		return
+	}
 	if hasNEON && len(kernel) >= 4 {
 		convolveDecimateNEON(dst, signal, kernel, factor, phase)
 		return
@@ -321,7 +399,10 @@ func convolveDecimate32(dst, signal, kernel []float32, factor, phase int) {
 func convolveValidMaxAbs32(signal, kernel []float32) float32 {
 	// Mirror dotProduct's NEON threshold (>= 4) so the fused kernel and the
 	// per-window dotProduct in ConvolveValid pick the same backend, keeping the
-	// peak bit-identical.
+	// peak bit-identical. Under SVE the Go loop runs that dotProduct itself.
+	if hasSVE {
+		return convolveValidMaxAbsGo(signal, kernel)
+	}
 	if hasNEON && len(kernel) >= 4 {
 		return convolveValidMaxAbsNEON(signal, kernel)
 	}
@@ -336,6 +417,10 @@ func convolveDecimateNEON(dst, signal, kernel []float32, factor, phase int)
 
 func accumulateAdd32(dst, src []float32) {
 	// AccumulateAdd is dst += src, use add with dst as both operands
+	if hasSVE && len(dst) >= 4 {
+		addSVE(dst, dst, src)
+		return
+	}
 	if hasNEON && len(dst) >= 4 {
 		addNEON(dst, dst, src)
 		return
@@ -349,6 +434,33 @@ func dotProductNEON(a, b []float32) float32
 //go:noescape
 func dotProduct4NEON(results, row0, row1, row2, row3, vec *float32, n int)
 
+//go:noescape
+func dotNorm4NEON(dots, norms, row0, row1, row2, row3, vec *float32, n int)
+
+//go:noescape
+func gemmKernel8x8NEON(k int, a, b, c *float32, ldc int)
+
+// SVE kernels (f32_sve_arm64.s): vector-length agnostic, same contracts as
+// their NEON counterparts.
+//
+//go:noescape
+func dotProductSVE(a, b []float32) float32
+
+//go:noescape
+func dotProduct4SVE(results, row0, row1, row2, row3, vec *float32, n int)
+
+//go:noescape
+func sumSVE(a []float32) float32
+
+//go:noescape
+func addSVE(dst, a, b []float32)
+
+//go:noescape
+func mulSVE(dst, a, b []float32)
+
+//go:noescape
+func fmaSVE(dst, a, b, c []float32)
+
 //go:noescape
 func addNEON(dst, a, b []float32)
 
@@ -737,6 +849,18 @@ func exp32(dst, src []float32) {
 //go:noescape
 func expNEON(dst, src []float32)
 
+// expSum32 writes dst[i] = e^(src[i]-shift) and returns the sum of the written
+// values.
+func expSum32(dst, src []float32, shift float32) float32 {
+	if hasNEON && len(dst) >= 4 {
+		return expSumNEON(dst, src, shift)
+	}
+	return expSum32Go(dst, src, shift)
+}
+
+//go:noescape
+func expSumNEON(dst, src []float32, shift float32) float32
+
 func log32(dst, src []float32) {
 	// Assumes len(src) >= len(dst); caller ensures this via public API
 	if hasNEON && len(dst) >= 4 {
@@ -1005,3 +1129,35 @@ func reverseNEON(dst, src []float32)
 
 //go:noescape
 func addSubNEON(sumDst, diffDst, a, b []float32)
+
+// biquadSection runs one section over every frame of the interleaved data in
+// place: four channels per biquad4NEON call, then a pair per biquad2NEON, and a
+// last odd channel in Go.
+func biquadSection(data []float32, ch int, b *Biquad, s1, s2 []float32) {
+	c := 0
+	if hasNEON {
+		frames := len(data) / ch
+		for ; c+4 <= ch; c += 4 {
+			biquad4NEON(&data[c], frames, ch, b, &s1[c], &s2[c])
+		}
+		if c+2 <= ch {
+			biquad2NEON(&data[c], frames, ch, b, &s1[c], &s2[c])
+			c += 2
+		}
+	}
+	if c < ch {
+		biquadSectionGo(data, ch, c, b, s1, s2)
+	}
+}
+
+// biquad4NEON runs one section over frames frames of the four channels starting
+// at x, stride floats apart, with their state in s1 and s2.
+//
+//go:noescape
+func biquad4NEON(x *float32, frames, stride int, b *Biquad, s1, s2 *float32)
+
+// biquad2NEON is biquad4NEON for two channels, on the low halves of the
+// vectors.
+//
+//go:noescape
+func biquad2NEON(x *float32, frames, stride int, b *Biquad, s1, s2 *float32)
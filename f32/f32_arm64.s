@@ -201,6 +201,105 @@ dot4n_store:
     FMOVS F3, 12(R0)
     RET
 
+// func dotNorm4NEON(dots, norms, row0, row1, row2, row3, vec *float32, n int)
+// dotProduct4NEON plus each row's sum of squares, taken from the same row
+// loads. V0-V3 accumulate the dots and V4-V7 the norms over a 4-element loop;
+// V16 holds the query chunk, V18-V21 the four row chunks.
+TEXT ·dotNorm4NEON(SB), NOSPLIT, $0-64
+    MOVD dots+0(FP), R0
+    MOVD norms+8(FP), R10
+    MOVD row0+16(FP), R1
+    MOVD row1+24(FP), R2
+    MOVD row2+32(FP), R3
+    MOVD row3+40(FP), R4
+    MOVD vec+48(FP), R5
+    MOVD n+56(FP), R6
+
+    VEOR V0.B16, V0.B16, V0.B16
+    VEOR V1.B16, V1.B16, V1.B16
+    VEOR V2.B16, V2.B16, V2.B16
+    VEOR V3.B16, V3.B16, V3.B16
+    VEOR V4.B16, V4.B16, V4.B16
+    VEOR V5.B16, V5.B16, V5.B16
+    VEOR V6.B16, V6.B16, V6.B16
+    VEOR V7.B16, V7.B16, V7.B16
+
+    LSR $2, R6, R7             // R7 = n / 4
+    CBZ R7, dotnorm4n_reduce
+
+dotnorm4n_loop4:
+    VLD1.P 16(R5), [V16.S4]
+    VLD1.P 16(R1), [V18.S4]
+    WORD $0x4E30CE40           // FMLA V0.4S, V18.4S, V16.4S
+    WORD $0x4E32CE44           // FMLA V4.4S, V18.4S, V18.4S
+    VLD1.P 16(R2), [V19.S4]
+    WORD $0x4E30CE61           // FMLA V1.4S, V19.4S, V16.4S
+    WORD $0x4E33CE65           // FMLA V5.4S, V19.4S, V19.4S
+    VLD1.P 16(R3), [V20.S4]
+    WORD $0x4E30CE82           // FMLA V2.4S, V20.4S, V16.4S
+    WORD $0x4E34CE86           // FMLA V6.4S, V20.4S, V20.4S
+    VLD1.P 16(R4), [V21.S4]
+    WORD $0x4E30CEA3           // FMLA V3.4S, V21.4S, V16.4S
+    WORD $0x4E35CEA7           // FMLA V7.4S, V21.4S, V21.4S
+    SUB $1, R7
+    CBNZ R7, dotnorm4n_loop4
+
+dotnorm4n_reduce:
+    // Reduce every accumulator to a scalar (S0..S7) BEFORE any scalar FMA,
+    // since scalar ops zero the upper lanes of the V register.
+    WORD $0x6E20D400           // FADDP V0.4S, V0.4S, V0.4S
+    WORD $0x7E30D800           // FADDP S0, V0.2S
+    WORD $0x6E21D421           // FADDP V1.4S, V1.4S, V1.4S
+    WORD $0x7E30D821           // FADDP S1, V1.2S
+    WORD $0x6E22D442           // FADDP V2.4S, V2.4S, V2.4S
+    WORD $0x7E30D842           // FADDP S2, V2.2S
+    WORD $0x6E23D463           // FADDP V3.4S, V3.4S, V3.4S
+    WORD $0x7E30D863           // FADDP S3, V3.2S
+    WORD $0x6E24D484           // FADDP V4.4S, V4.4S, V4.4S
+    WORD $0x7E30D884           // FADDP S4, V4.2S
+    WORD $0x6E25D4A5           // FADDP V5.4S, V5.4S, V5.4S
+    WORD $0x7E30D8A5           // FADDP S5, V5.2S
+    WORD $0x6E26D4C6           // FADDP V6.4S, V6.4S, V6.4S
+    WORD $0x7E30D8C6           // FADDP S6, V6.2S
+    WORD $0x6E27D4E7           // FADDP V7.4S, V7.4S, V7.4S
+    WORD $0x7E30D8E7           // FADDP S7, V7.2S
+
+    AND $3, R6, R8             // R8 = n & 3 (scalar tail count)
+    CBZ R8, dotnorm4n_store
+
+dotnorm4n_scalar:
+    FMOVS (R5), F16
+    FMOVS (R1), F18
+    FMADDS F16, F0, F18, F0    // F0 = F18 * F16 + F0
+    FMADDS F18, F4, F18, F4    // F4 = F18 * F18 + F4
+    FMOVS (R2), F18
+    FMADDS F16, F1, F18, F1    // F1 = F18 * F16 + F1
+    FMADDS F18, F5, F18, F5    // F5 = F18 * F18 + F5
+    FMOVS (R3), F18
+    FMADDS F16, F2, F18, F2    // F2 = F18 * F16 + F2
+    FMADDS F18, F6, F18, F6    // F6 = F18 * F18 + F6
+    FMOVS (R4), F18
+    FMADDS F16, F3, F18, F3    // F3 = F18 * F16 + F3
+    FMADDS F18, F7, F18, F7    // F7 = F18 * F18 + F7
+    ADD $4, R5
+    ADD $4, R1
+    ADD $4, R2
+    ADD $4, R3
+    ADD $4, R4
+    SUB $1, R8
+    CBNZ R8, dotnorm4n_scalar
+
+dotnorm4n_store:
+    FMOVS F0, (R0)
+    FMOVS F1, 4(R0)
+    FMOVS F2, 8(R0)
+    FMOVS F3, 12(R0)
+    FMOVS F4, (R10)
+    FMOVS F5, 4(R10)
+    FMOVS F6, 8(R10)
+    FMOVS F7, 12(R10)
+    RET
+
 // func addNEON(dst, a, b []float32)
 TEXT ·addNEON(SB), NOSPLIT, $0-72
     MOVD dst_base+0(FP), R0
@@ -1795,6 +1894,152 @@ exp32_neon_scalar_loop:
 exp32_neon_done:
     RET
 
+// func expSumNEON(dst, src []float32, shift float32) float32
+// Computes dst[i] = e^(src[i]-shift) with the expNEON core and returns the sum
+// of the stored values, the fused exp-and-sum pass of Softmax, LogSoftmax and
+// LogSumExp. The shifted inputs are clamped to [-88, 88] exactly as in expNEON.
+// V29 holds the broadcast shift and V30 the running sum, reduced into S30
+// before the scalar tail.
+TEXT ·expSumNEON(SB), NOSPLIT, $0-60
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD src_base+24(FP), R1
+
+    MOVW $0x3fb8aa3b, R10
+    VMOV R10, V20.S[0]
+    VDUP V20.S[0], V20.S4         // V20 = log2(e)
+    MOVW $0x3f317218, R10
+    VMOV R10, V21.S[0]
+    VDUP V21.S[0], V21.S4         // V21 = ln(2)
+    FMOVS $1.0, F22
+    VDUP V22.S[0], V22.S4         // V22 = 1.0
+    FMOVS $0.5, F23
+    VDUP V23.S[0], V23.S4         // V23 = c2 = 0.5
+    MOVW $0x3e2aaaab, R10
+    VMOV R10, V24.S[0]
+    VDUP V24.S[0], V24.S4         // V24 = c3 = 1/6
+    MOVW $0x3d2aaaab, R10
+    VMOV R10, V25.S[0]
+    VDUP V25.S[0], V25.S4         // V25 = c4 = 1/24
+    MOVW $0x3c088889, R10
+    VMOV R10, V26.S[0]
+    VDUP V26.S[0], V26.S4         // V26 = c5 = 1/120
+    MOVW $0x42b00000, R10
+    VMOV R10, V27.S[0]
+    VDUP V27.S[0], V27.S4         // V27 = 88.0 (clamp_hi)
+    MOVW $0xc2b00000, R10
+    VMOV R10, V28.S[0]
+    VDUP V28.S[0], V28.S4         // V28 = -88.0 (clamp_lo)
+    FMOVS shift+48(FP), F29
+    VDUP V29.S[0], V29.S4         // V29 = shift
+    VEOR V30.B16, V30.B16, V30.B16 // V30 = running sum
+
+    LSR $2, R3, R4
+    CBZ R4, expsum32_neon_reduce
+
+expsum32_neon_loop4:
+    VLD1.P 16(R1), [V0.S4]
+    WORD $0x4EBDD400              // FSUB V0.4S, V0.4S, V29.4S   V0 = x = src - shift
+
+    // Clamp x to [-88, 88]
+    WORD $0x4EBBF400              // FMIN V0.4S, V0.4S, V27.4S
+    WORD $0x4E3CF400              // FMAX V0.4S, V0.4S, V28.4S
+
+    // Range reduction: k = round(x * log2e), r = x - k * ln2
+    WORD $0x6E34DC01              // FMUL V1.4S, V0.4S, V20.4S
+    WORD $0x4E218822              // FRINTN V2.4S, V1.4S
+    WORD $0x6E35DC44              // FMUL V4.4S, V2.4S, V21.4S
+    WORD $0x4EA4D403              // FSUB V3.4S, V0.4S, V4.4S
+
+    // Polynomial: exp(r) ~= 1 + r*(1 + r*(c2 + r*(c3 + r*(c4 + r*c5))))
+    WORD $0x6E3ADC64              // FMUL V4.4S, V3.4S, V26.4S
+    WORD $0x4E39D484              // FADD V4.4S, V4.4S, V25.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E38D484              // FADD V4.4S, V4.4S, V24.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E37D484              // FADD V4.4S, V4.4S, V23.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E36D484              // FADD V4.4S, V4.4S, V22.4S
+    WORD $0x6E23DC84              // FMUL V4.4S, V4.4S, V3.4S
+    WORD $0x4E36D484              // FADD V4.4S, V4.4S, V22.4S   V4 = exp(r)
+
+    // Reconstruct: exp(x) = exp(r) * 2^k
+    WORD $0x4EA1B841              // FCVTZS V1.4S, V2.4S
+    WORD $0x4F375421              // SHL V1.4S, V1.4S, #23
+    WORD $0x4EB68421              // ADD V1.4S, V1.4S, V22.4S
+    WORD $0x6E21DC84              // FMUL V4.4S, V4.4S, V1.4S    V4 = exp(x)
+
+    VST1.P [V4.S4], 16(R0)
+    WORD $0x4E24D7DE              // FADD V30.4S, V30.4S, V4.4S  sum += exp(x)
+
+    SUB $1, R4
+    CBNZ R4, expsum32_neon_loop4
+
+expsum32_neon_reduce:
+    WORD $0x6E3ED7DE              // FADDP V30.4S, V30.4S, V30.4S
+    WORD $0x7E30DBDE              // FADDP S30, V30.2S
+
+    AND $3, R3
+    CBZ R3, expsum32_neon_done
+
+    MOVW $0x42b00000, R10
+    FMOVS R10, F6                 // F6 = 88.0 (clamp_hi)
+    MOVW $0xc2b00000, R10
+    FMOVS R10, F7                 // F7 = -88.0 (clamp_lo)
+    MOVW $0x3fb8aa3b, R10
+    FMOVS R10, F8                 // F8 = log2(e)
+    MOVW $0x3f317218, R10
+    FMOVS R10, F9                 // F9 = ln(2)
+    FMOVS $1.0, F10               // c1 = 1.0
+    FMOVS $0.5, F11               // c2 = 0.5
+    MOVW $0x3e2aaaab, R10
+    FMOVS R10, F12                // c3 = 1/6
+    MOVW $0x3d2aaaab, R10
+    FMOVS R10, F13                // c4 = 1/24
+    MOVW $0x3c088889, R10
+    FMOVS R10, F14                // c5 = 1/120
+    MOVW $0x3f800000, R11         // R11 = 1.0's bits (exponent bias)
+
+expsum32_neon_scalar_loop:
+    FMOVS (R1), F0
+    FSUBS F29, F0, F0             // F0 = x = src - shift
+
+    FMINS F6, F0, F0
+    FMAXS F7, F0, F0
+
+    FMULS F8, F0, F1
+    FRINTNS F1, F2                // F2 = k
+    FMULS F9, F2, F3
+    FSUBS F3, F0, F0              // F0 = r
+
+    FMULS F0, F14, F4
+    FADDS F13, F4, F4
+    FMULS F0, F4, F4
+    FADDS F12, F4, F4
+    FMULS F0, F4, F4
+    FADDS F11, F4, F4
+    FMULS F0, F4, F4
+    FADDS F10, F4, F4
+    FMULS F0, F4, F4
+    FADDS F10, F4, F4             // F4 = exp(r)
+
+    FCVTZSS F2, R10
+    LSL $23, R10, R10
+    ADD R11, R10, R10
+    FMOVS R10, F5
+    FMULS F5, F4, F4              // F4 = exp(x)
+    FMOVS F4, (R0)
+    FADDS F4, F30, F30            // sum += exp(x)
+
+    ADD $4, R0
+    ADD $4, R1
+    SUB $1, R3
+    CBNZ R3, expsum32_neon_scalar_loop
+
+expsum32_neon_done:
+    FMOVS F30, ret+56(FP)
+    RET
+
 // func reluNEON(dst, src []float32)
 // Computes ReLU: dst[i] = max(0, src[i])
 TEXT ·reluNEON(SB), NOSPLIT, $0-48
@@ -5241,3 +5486,200 @@ minidxrows4_write:
     VST1 [V0.S4], (R0)             // vals[0:4]
     VST1 [V1.S4], (R1)             // idxs[0:4]
     RET
+
+// ============================================================================
+// GEMM MICROKERNELS
+// Register-blocked tiles for the packed GEMM driver in gemm.go; the caller
+// handles blocking, packing, alpha/beta and partial tiles.
+// ============================================================================
+
+// func gemmKernel8x8NEON(k int, a, b, c *float32, ldc int)
+// NEON GEMM microkernel: c[i*ldc+j] += sum over p < k of a[p*8+i] * b[p*8+j]
+// for the 8x8 tile. Each step loads 8 packed A elements into V0-V1 and the
+// 8-wide B row into V2-V3, then FMLA by element accumulates row i of the tile
+// into V16-V31, 2 registers per row.
+TEXT ·gemmKernel8x8NEON(SB), NOSPLIT, $0-40
+    MOVD k+0(FP), R0
+    MOVD a+8(FP), R1
+    MOVD b+16(FP), R2
+    MOVD c+24(FP), R3
+    MOVD ldc+32(FP), R4
+    LSL $2, R4, R4              // ldc in bytes
+
+    VEOR V16.B16, V16.B16, V16.B16
+    VEOR V17.B16, V17.B16, V17.B16
+    VEOR V18.B16, V18.B16, V18.B16
+    VEOR V19.B16, V19.B16, V19.B16
+    VEOR V20.B16, V20.B16, V20.B16
+    VEOR V21.B16, V21.B16, V21.B16
+    VEOR V22.B16, V22.B16, V22.B16
+    VEOR V23.B16, V23.B16, V23.B16
+    VEOR V24.B16, V24.B16, V24.B16
+    VEOR V25.B16, V25.B16, V25.B16
+    VEOR V26.B16, V26.B16, V26.B16
+    VEOR V27.B16, V27.B16, V27.B16
+    VEOR V28.B16, V28.B16, V28.B16
+    VEOR V29.B16, V29.B16, V29.B16
+    VEOR V30.B16, V30.B16, V30.B16
+    VEOR V31.B16, V31.B16, V31.B16
+
+    CBZ R0, gemmkernel8x8neon_store
+
+gemmkernel8x8neon_loop:
+    VLD1.P 32(R1), [V0.S4, V1.S4]
+    VLD1.P 32(R2), [V2.S4, V3.S4]
+    WORD $0x4F801050           // FMLA V16.4S, V2.4S, V0.S[0]
+    WORD $0x4F801071           // FMLA V17.4S, V3.4S, V0.S[0]
+    WORD $0x4FA01052           // FMLA V18.4S, V2.4S, V0.S[1]
+    WORD $0x4FA01073           // FMLA V19.4S, V3.4S, V0.S[1]
+    WORD $0x4F801854           // FMLA V20.4S, V2.4S, V0.S[2]
+    WORD $0x4F801875           // FMLA V21.4S, V3.4S, V0.S[2]
+    WORD $0x4FA01856           // FMLA V22.4S, V2.4S, V0.S[3]
+    WORD $0x4FA01877           // FMLA V23.4S, V3.4S, V0.S[3]
+    WORD $0x4F811058           // FMLA V24.4S, V2.4S, V1.S[0]
+    WORD $0x4F811079           // FMLA V25.4S, V3.4S, V1.S[0]
+    WORD $0x4FA1105A           // FMLA V26.4S, V2.4S, V1.S[1]
+    WORD $0x4FA1107B           // FMLA V27.4S, V3.4S, V1.S[1]
+    WORD $0x4F81185C           // FMLA V28.4S, V2.4S, V1.S[2]
+    WORD $0x4F81187D           // FMLA V29.4S, V3.4S, V1.S[2]
+    WORD $0x4FA1185E           // FMLA V30.4S, V2.4S, V1.S[3]
+    WORD $0x4FA1187F           // FMLA V31.4S, V3.4S, V1.S[3]
+    SUB $1, R0
+    CBNZ R0, gemmkernel8x8neon_loop
+
+gemmkernel8x8neon_store:
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E30D484           // FADD V4.4S, V4.4S, V16.4S
+    WORD $0x4E31D4A5           // FADD V5.4S, V5.4S, V17.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E32D484           // FADD V4.4S, V4.4S, V18.4S
+    WORD $0x4E33D4A5           // FADD V5.4S, V5.4S, V19.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E34D484           // FADD V4.4S, V4.4S, V20.4S
+    WORD $0x4E35D4A5           // FADD V5.4S, V5.4S, V21.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E36D484           // FADD V4.4S, V4.4S, V22.4S
+    WORD $0x4E37D4A5           // FADD V5.4S, V5.4S, V23.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E38D484           // FADD V4.4S, V4.4S, V24.4S
+    WORD $0x4E39D4A5           // FADD V5.4S, V5.4S, V25.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E3AD484           // FADD V4.4S, V4.4S, V26.4S
+    WORD $0x4E3BD4A5           // FADD V5.4S, V5.4S, V27.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E3CD484           // FADD V4.4S, V4.4S, V28.4S
+    WORD $0x4E3DD4A5           // FADD V5.4S, V5.4S, V29.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V4.S4, V5.S4]
+    WORD $0x4E3ED484           // FADD V4.4S, V4.4S, V30.4S
+    WORD $0x4E3FD4A5           // FADD V5.4S, V5.4S, V31.4S
+    VST1 [V4.S4, V5.S4], (R3)
+    RET
+
+// func biquad4NEON(x *float32, frames, stride int, b *Biquad, s1, s2 *float32)
+// One direct form II transposed section across 4 channels, frame by frame:
+//
+//	y = B0*x + s1;  s1 = B1*x - A1*y + s2;  s2 = B2*x - A2*y
+//
+// V0/V1 carry s1/s2 through the loop (V0 turns into y, which is stored before
+// the new state rotates in) and V16-V20 hold B0, B1, B2, A1, A2.
+TEXT ·biquad4NEON(SB), NOSPLIT, $0-48
+    MOVD x+0(FP), R0
+    MOVD frames+8(FP), R1
+    MOVD stride+16(FP), R2
+    MOVD b+24(FP), R3
+    MOVD s1+32(FP), R4
+    MOVD s2+40(FP), R5
+    LSL $2, R2                 // stride in bytes
+
+    FMOVS 0(R3), F16
+    FMOVS 4(R3), F17
+    FMOVS 8(R3), F18
+    FMOVS 12(R3), F19
+    FMOVS 16(R3), F20
+    WORD $0x4E040610           // DUP V16.4S, V16.S[0]
+    WORD $0x4E040631           // DUP V17.4S, V17.S[0]
+    WORD $0x4E040652           // DUP V18.4S, V18.S[0]
+    WORD $0x4E040673           // DUP V19.4S, V19.S[0]
+    WORD $0x4E040694           // DUP V20.4S, V20.S[0]
+
+    VLD1 (R4), [V0.S4]
+    VLD1 (R5), [V1.S4]
+    CBZ R1, biquad4_neon_store
+
+biquad4_neon_loop:
+    VLD1 (R0), [V2.S4]         // x
+    WORD $0x4E30CC40           // FMLA V0.4S, V2.4S, V16.4S  (y = s1 + x*B0)
+    WORD $0x4E31CC41           // FMLA V1.4S, V2.4S, V17.4S  (s2 + x*B1)
+    WORD $0x4EB3CC01           // FMLS V1.4S, V0.4S, V19.4S  (s1 = s2 + x*B1 - y*A1)
+    WORD $0x6E32DC44           // FMUL V4.4S, V2.4S, V18.4S  (x*B2)
+    WORD $0x4EB4CC04           // FMLS V4.4S, V0.4S, V20.4S  (s2 = x*B2 - y*A2)
+    VST1 [V0.S4], (R0)
+    WORD $0x4EA11C20           // MOV V0.16B, V1.16B
+    WORD $0x4EA41C81           // MOV V1.16B, V4.16B
+    ADD R2, R0
+    SUB $1, R1
+    CBNZ R1, biquad4_neon_loop
+
+biquad4_neon_store:
+    VST1 [V0.S4], (R4)
+    VST1 [V1.S4], (R5)
+    RET
+
+// func biquad2NEON(x *float32, frames, stride int, b *Biquad, s1, s2 *float32)
+// biquad4NEON for two channels, on the 64-bit halves of the vectors.
+TEXT ·biquad2NEON(SB), NOSPLIT, $0-48
+    MOVD x+0(FP), R0
+    MOVD frames+8(FP), R1
+    MOVD stride+16(FP), R2
+    MOVD b+24(FP), R3
+    MOVD s1+32(FP), R4
+    MOVD s2+40(FP), R5
+    LSL $2, R2                 // stride in bytes
+
+    FMOVS 0(R3), F16
+    FMOVS 4(R3), F17
+    FMOVS 8(R3), F18
+    FMOVS 12(R3), F19
+    FMOVS 16(R3), F20
+    WORD $0x4E040610           // DUP V16.4S, V16.S[0]
+    WORD $0x4E040631           // DUP V17.4S, V17.S[0]
+    WORD $0x4E040652           // DUP V18.4S, V18.S[0]
+    WORD $0x4E040673           // DUP V19.4S, V19.S[0]
+    WORD $0x4E040694           // DUP V20.4S, V20.S[0]
+
+    VLD1 (R4), [V0.S2]
+    VLD1 (R5), [V1.S2]
+    CBZ R1, biquad2_neon_store
+
+biquad2_neon_loop:
+    VLD1 (R0), [V2.S2]         // x
+    WORD $0x0E30CC40           // FMLA V0.2S, V2.2S, V16.2S  (y = s1 + x*B0)
+    WORD $0x0E31CC41           // FMLA V1.2S, V2.2S, V17.2S  (s2 + x*B1)
+    WORD $0x0EB3CC01           // FMLS V1.2S, V0.2S, V19.2S  (s1 = s2 + x*B1 - y*A1)
+    WORD $0x2E32DC44           // FMUL V4.2S, V2.2S, V18.2S  (x*B2)
+    WORD $0x0EB4CC04           // FMLS V4.2S, V0.2S, V20.2S  (s2 = x*B2 - y*A2)
+    VST1 [V0.S2], (R0)
+    WORD $0x0EA11C20           // MOV V0.8B, V1.8B
+    WORD $0x0EA41C81           // MOV V1.8B, V4.8B
+    ADD R2, R0
+    SUB $1, R1
+    CBNZ R1, biquad2_neon_loop
+
+biquad2_neon_store:
+    VST1 [V0.S2], (R4)
+    VST1 [V1.S2], (R5)
+    RET
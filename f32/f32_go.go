@@ -307,6 +307,22 @@ func dotProductStridedOneGo(base, query []float32, row, dims, stride int) float3
 	return dotProduct(base[offset:offset+n], query[:n])
 }
 
+func l2SquaredIndexedGo(dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) {
+	rowMetricIndexedFallback(rowL2Squared, dst, base, query, rowNorms, rowIDs, dims)
+}
+
+func l2SquaredStridedGo(dst, base, query, rowNorms []float32, rowCount, dims, stride int) {
+	rowMetricStridedFallback(rowL2Squared, dst, base, query, rowNorms, rowCount, dims, stride)
+}
+
+func cosineIndexedGo(dst, base, query, rowNorms []float32, rowIDs []uint32, dims int) {
+	rowMetricIndexedFallback(rowCosine, dst, base, query, rowNorms, rowIDs, dims)
+}
+
+func cosineStridedGo(dst, base, query, rowNorms []float32, rowCount, dims, stride int) {
+	rowMetricStridedFallback(rowCosine, dst, base, query, rowNorms, rowCount, dims, stride)
+}
+
 func rowOffsetUint32InBase(rowID uint32, stride, baseLen int) (int, bool) {
 	if stride <= 0 || baseLen <= 0 {
 		return 0, false
@@ -669,6 +685,31 @@ func exp32Go(dst, src []float32) {
 	}
 }
 
+// expSum32Go writes dst[i] = e^(src[i]-shift), clamped like exp32Go, and
+// returns the float32 sum of the written values.
+func expSum32Go(dst, src []float32, shift float32) float32 {
+	if len(dst) == 0 {
+		return 0
+	}
+	_ = src[len(dst)-1]
+	var sum float32
+	for i := range dst {
+		x := src[i] - shift
+		var e float32
+		switch {
+		case x > expOverflowThreshold:
+			e = float32(math.Exp(expOverflowThreshold))
+		case x < -expOverflowThreshold:
+			e = 0
+		default:
+			e = float32(math.Exp(float64(x)))
+		}
+		dst[i] = e
+		sum += e
+	}
+	return sum
+}
+
 // logGo computes the natural logarithm: dst[i] = ln(src[i]).
 // Edge cases follow math.Log: ln(0) = -Inf, ln(x<0) = NaN, ln(+Inf) = +Inf,
 // ln(NaN) = NaN. This is the scalar reference and the fallback when no SIMD log
@@ -1192,3 +1233,40 @@ func minIdxOfSumRowsGo(vals []float32, idxs []int32, a, k []float32, base, slide
 		idxs[r] = int32(i)
 	}
 }
+
+// gemmGo is the pure-Go GEMM reference: row i of C is scaled by beta, then
+// alpha*op(A)[i][p] times row p of op(B) is added for each p. The caller has
+// validated the shapes.
+func gemmGo(transA, transB bool, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	for i := range m {
+		ci := c[i*ldc : i*ldc+n]
+		switch beta {
+		case 1:
+		case 0:
+			clear(ci)
+		default:
+			for j := range ci {
+				ci[j] *= beta
+			}
+		}
+		if alpha == 0 {
+			continue
+		}
+		for p := range k {
+			ai := i*lda + p
+			if transA {
+				ai = p*lda + i
+			}
+			aip := alpha * a[ai]
+			if transB {
+				for j := range ci {
+					ci[j] += aip * b[j*ldb+p]
+				}
+				continue
+			}
+			for j, v := range b[p*ldb : p*ldb+n] {
+				ci[j] += aip * v
+			}
+		}
+	}
+}
@@ -30,6 +30,17 @@ func dotProductStrided(dst, base, query []float32, rowCount, dims, stride int) b
 	dotProductStridedGo(dst, base, query, rowCount, dims, stride)
 	return false
 }
+func batchDotNormSIMD() bool             { return false }
+func gemmMicroKernel() (gemmMicro, bool) { return gemmMicro{}, false }
+func dotNorm4Batch(dots, norms *[batchDotRows]float32, base []float32, off0, off1, off2, off3 int, query []float32, dims int) {
+	for j, off := range [batchDotRows]int{off0, off1, off2, off3} {
+		row := base[off : off+dims]
+		dots[j] = dotProductGo(row, query[:dims])
+		if norms != nil {
+			norms[j] = dotProductGo(row, row)
+		}
+	}
+}
 func convolveValid32(dst, signal, kernel []float32) { convolveValid32Go(dst, signal, kernel) }
 func convolveValidMaxAbs32(signal, kernel []float32) float32 {
 	return convolveValidMaxAbsGo(signal, kernel)
@@ -65,8 +76,11 @@ func relu32(dst, src []float32)    { relu32Go(dst, src) }
 func clampScale32(dst, src []float32, minVal, maxVal, scale float32) {
 	clampScale32Go(dst, src, minVal, maxVal, scale)
 }
-func tanh32(dst, src []float32)                                 { tanh32Go(dst, src) }
-func exp32(dst, src []float32)                                  { exp32Go(dst, src) }
+func tanh32(dst, src []float32) { tanh32Go(dst, src) }
+func exp32(dst, src []float32)  { exp32Go(dst, src) }
+func expSum32(dst, src []float32, shift float32) float32 {
+	return expSum32Go(dst, src, shift)
+}
 func int32ToFloat32Scale(dst []float32, src []int32, s float32) { int32ToFloat32ScaleGo(dst, src, s) }
 func int32ToFloat32ScaleAdd(dst, a []float32, src []int32, s float32) {
 	int32ToFloat32ScaleAddGo(dst, a, src, s)
@@ -119,3 +133,10 @@ func powElem32(dst, base, exp []float32)    { powElemGo(dst, base, exp) }
 func minIdxOfSumRows32(vals []float32, idxs []int32, a, k []float32, base, slide int) {
 	minIdxOfSumRowsGo(vals, idxs, a, k, base, slide)
 }
+
+// bindKernels has no flags to re-read: every operation runs pure Go here.
+func bindKernels() {}
+
+func biquadSection(data []float32, ch int, b *Biquad, s1, s2 []float32) {
+	biquadSectionGo(data, ch, 0, b, s1, s2)
+}
@@ -0,0 +1,232 @@
+//go:build arm64
+
+#include "textflag.h"
+
+// ARM64 SVE for float32: vector-length agnostic, each Z register holds VL/32
+// elements (4 at 128 bits, 8 at 256 bits). Go's assembler has no SVE
+// mnemonics, so every SVE instruction is a WORD opcode; asmcheck decodes them.
+//
+// Tails need no scalar loop: WHILELO sets the lanes still in range, predicated
+// loads zero the rest and predicated stores skip them. WHILELO sets the flags
+// like PTEST, so B.NONE (no lane active) is BEQ.
+//
+// Registers: P1 is all-true, P0 the WHILELO tail predicate. Z0-Z3 accumulate,
+// Z4-Z7 and Z16-Z20 hold loaded operands.
+
+// func dotProductSVE(a, b []float32) float32
+// Handles mismatched slice lengths: uses min(len(a), len(b)). The main loop
+// runs four independent accumulators over 4*VL elements to hide FMLA latency.
+TEXT ·dotProductSVE(SB), NOSPLIT, $0-52
+    MOVD a_base+0(FP), R0
+    MOVD a_len+8(FP), R2
+    MOVD b_len+32(FP), R3
+    CMP R3, R2
+    CSEL LT, R2, R3, R2        // R2 = min(len(a), len(b))
+    MOVD b_base+24(FP), R1
+
+    WORD $0x2598E3E1           // PTRUE P1.S
+    WORD $0x25B8C000           // MOV Z0.S, #0
+    WORD $0x25B8C001           // MOV Z1.S, #0
+    WORD $0x25B8C002           // MOV Z2.S, #0
+    WORD $0x25B8C003           // MOV Z3.S, #0
+    MOVD $0, R4                // R4 = i
+    WORD $0x04A3E3E6           // CNTW X6, ALL, MUL #4
+    SUB R6, R2, R7             // R7 = n - 4*VL, the last i a full step fits
+
+dotsve32_loop4:
+    CMP R7, R4
+    BGT dotsve32_tail
+    ADD R4<<2, R0, R8
+    ADD R4<<2, R1, R9
+    WORD $0xA540A504           // LD1W {Z4.S}, P1/Z, [X8]
+    WORD $0xA541A505           // LD1W {Z5.S}, P1/Z, [X8, #1, MUL VL]
+    WORD $0xA542A506           // LD1W {Z6.S}, P1/Z, [X8, #2, MUL VL]
+    WORD $0xA543A507           // LD1W {Z7.S}, P1/Z, [X8, #3, MUL VL]
+    WORD $0xA540A530           // LD1W {Z16.S}, P1/Z, [X9]
+    WORD $0xA541A531           // LD1W {Z17.S}, P1/Z, [X9, #1, MUL VL]
+    WORD $0xA542A532           // LD1W {Z18.S}, P1/Z, [X9, #2, MUL VL]
+    WORD $0xA543A533           // LD1W {Z19.S}, P1/Z, [X9, #3, MUL VL]
+    WORD $0x65B00480           // FMLA Z0.S, P1/M, Z4.S, Z16.S
+    WORD $0x65B104A1           // FMLA Z1.S, P1/M, Z5.S, Z17.S
+    WORD $0x65B204C2           // FMLA Z2.S, P1/M, Z6.S, Z18.S
+    WORD $0x65B304E3           // FMLA Z3.S, P1/M, Z7.S, Z19.S
+    ADD R6, R4
+    B dotsve32_loop4
+
+dotsve32_tail:
+    WORD $0x25A21C80           // WHILELO P0.S, X4, X2
+    BEQ dotsve32_reduce
+    WORD $0xA5444004           // LD1W {Z4.S}, P0/Z, [X0, X4, LSL #2]
+    WORD $0xA5444030           // LD1W {Z16.S}, P0/Z, [X1, X4, LSL #2]
+    WORD $0x65B00080           // FMLA Z0.S, P0/M, Z4.S, Z16.S
+    WORD $0x04B0E3E4           // INCW X4
+    B dotsve32_tail
+
+dotsve32_reduce:
+    WORD $0x65810000           // FADD Z0.S, Z0.S, Z1.S
+    WORD $0x65830042           // FADD Z2.S, Z2.S, Z3.S
+    WORD $0x65820000           // FADD Z0.S, Z0.S, Z2.S
+    WORD $0x65802400           // FADDV S0, P1, Z0.S
+    FMOVS F0, ret+48(FP)
+    RET
+
+// func dotProduct4SVE(results, row0, row1, row2, row3, vec *float32, n int)
+// Scores four rows against the same vec, loading each vec chunk once for the
+// group. Z0-Z3 accumulate rows 0-3; Z16 holds the vec chunk, Z17-Z20 the rows.
+TEXT ·dotProduct4SVE(SB), NOSPLIT, $0-56
+    MOVD results+0(FP), R0
+    MOVD row0+8(FP), R1
+    MOVD row1+16(FP), R2
+    MOVD row2+24(FP), R3
+    MOVD row3+32(FP), R4
+    MOVD vec+40(FP), R5
+    MOVD n+48(FP), R6
+
+    WORD $0x25B8C000           // MOV Z0.S, #0
+    WORD $0x25B8C001           // MOV Z1.S, #0
+    WORD $0x25B8C002           // MOV Z2.S, #0
+    WORD $0x25B8C003           // MOV Z3.S, #0
+    MOVD $0, R7                // R7 = i
+
+dot4sve32_loop:
+    WORD $0x25A61CE0           // WHILELO P0.S, X7, X6
+    BEQ dot4sve32_reduce
+    WORD $0xA54740B0           // LD1W {Z16.S}, P0/Z, [X5, X7, LSL #2]
+    WORD $0xA5474031           // LD1W {Z17.S}, P0/Z, [X1, X7, LSL #2]
+    WORD $0xA5474052           // LD1W {Z18.S}, P0/Z, [X2, X7, LSL #2]
+    WORD $0xA5474073           // LD1W {Z19.S}, P0/Z, [X3, X7, LSL #2]
+    WORD $0xA5474094           // LD1W {Z20.S}, P0/Z, [X4, X7, LSL #2]
+    WORD $0x65B00220           // FMLA Z0.S, P0/M, Z17.S, Z16.S
+    WORD $0x65B00241           // FMLA Z1.S, P0/M, Z18.S, Z16.S
+    WORD $0x65B00262           // FMLA Z2.S, P0/M, Z19.S, Z16.S
+    WORD $0x65B00283           // FMLA Z3.S, P0/M, Z20.S, Z16.S
+    WORD $0x04B0E3E7           // INCW X7
+    B dot4sve32_loop
+
+dot4sve32_reduce:
+    WORD $0x2598E3E1           // PTRUE P1.S
+    WORD $0x65802400           // FADDV S0, P1, Z0.S
+    WORD $0x65802421           // FADDV S1, P1, Z1.S
+    WORD $0x65802442           // FADDV S2, P1, Z2.S
+    WORD $0x65802463           // FADDV S3, P1, Z3.S
+    FMOVS F0, 0(R0)
+    FMOVS F1, 4(R0)
+    FMOVS F2, 8(R0)
+    FMOVS F3, 12(R0)
+    RET
+
+// func sumSVE(a []float32) float32
+// Four accumulators over 4*VL elements, like dotProductSVE. The tail adds
+// under P0 so inactive lanes keep their sign of zero.
+TEXT ·sumSVE(SB), NOSPLIT, $0-28
+    MOVD a_base+0(FP), R0
+    MOVD a_len+8(FP), R2
+
+    WORD $0x2598E3E1           // PTRUE P1.S
+    WORD $0x25B8C000           // MOV Z0.S, #0
+    WORD $0x25B8C001           // MOV Z1.S, #0
+    WORD $0x25B8C002           // MOV Z2.S, #0
+    WORD $0x25B8C003           // MOV Z3.S, #0
+    MOVD $0, R4                // R4 = i
+    WORD $0x04A3E3E6           // CNTW X6, ALL, MUL #4
+    SUB R6, R2, R7             // R7 = n - 4*VL, the last i a full step fits
+
+sumsve32_loop4:
+    CMP R7, R4
+    BGT sumsve32_tail
+    ADD R4<<2, R0, R8
+    WORD $0xA540A504           // LD1W {Z4.S}, P1/Z, [X8]
+    WORD $0xA541A505           // LD1W {Z5.S}, P1/Z, [X8, #1, MUL VL]
+    WORD $0xA542A506           // LD1W {Z6.S}, P1/Z, [X8, #2, MUL VL]
+    WORD $0xA543A507           // LD1W {Z7.S}, P1/Z, [X8, #3, MUL VL]
+    WORD $0x65840000           // FADD Z0.S, Z0.S, Z4.S
+    WORD $0x65850021           // FADD Z1.S, Z1.S, Z5.S
+    WORD $0x65860042           // FADD Z2.S, Z2.S, Z6.S
+    WORD $0x65870063           // FADD Z3.S, Z3.S, Z7.S
+    ADD R6, R4
+    B sumsve32_loop4
+
+sumsve32_tail:
+    WORD $0x25A21C80           // WHILELO P0.S, X4, X2
+    BEQ sumsve32_reduce
+    WORD $0xA5444004           // LD1W {Z4.S}, P0/Z, [X0, X4, LSL #2]
+    WORD $0x65808080           // FADD Z0.S, P0/M, Z0.S, Z4.S
+    WORD $0x04B0E3E4           // INCW X4
+    B sumsve32_tail
+
+sumsve32_reduce:
+    WORD $0x65810000           // FADD Z0.S, Z0.S, Z1.S
+    WORD $0x65830042           // FADD Z2.S, Z2.S, Z3.S
+    WORD $0x65820000           // FADD Z0.S, Z0.S, Z2.S
+    WORD $0x65802400           // FADDV S0, P1, Z0.S
+    FMOVS F0, ret+24(FP)
+    RET
+
+// func addSVE(dst, a, b []float32)
+// dst[i] = a[i] + b[i] for i < len(dst); the wrapper bounds a and b. Each
+// lane is loaded before it is stored, so dst may alias a or b.
+TEXT ·addSVE(SB), NOSPLIT, $0-72
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD a_base+24(FP), R1
+    MOVD b_base+48(FP), R2
+    MOVD $0, R4                // R4 = i
+
+addsve32_loop:
+    WORD $0x25A31C80           // WHILELO P0.S, X4, X3
+    BEQ addsve32_done
+    WORD $0xA5444020           // LD1W {Z0.S}, P0/Z, [X1, X4, LSL #2]
+    WORD $0xA5444041           // LD1W {Z1.S}, P0/Z, [X2, X4, LSL #2]
+    WORD $0x65810000           // FADD Z0.S, Z0.S, Z1.S
+    WORD $0xE5444000           // ST1W {Z0.S}, P0, [X0, X4, LSL #2]
+    WORD $0x04B0E3E4           // INCW X4
+    B addsve32_loop
+
+addsve32_done:
+    RET
+
+// func mulSVE(dst, a, b []float32)
+// dst[i] = a[i] * b[i] for i < len(dst); same contract as addSVE.
+TEXT ·mulSVE(SB), NOSPLIT, $0-72
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD a_base+24(FP), R1
+    MOVD b_base+48(FP), R2
+    MOVD $0, R4                // R4 = i
+
+mulsve32_loop:
+    WORD $0x25A31C80           // WHILELO P0.S, X4, X3
+    BEQ mulsve32_done
+    WORD $0xA5444020           // LD1W {Z0.S}, P0/Z, [X1, X4, LSL #2]
+    WORD $0xA5444041           // LD1W {Z1.S}, P0/Z, [X2, X4, LSL #2]
+    WORD $0x65810800           // FMUL Z0.S, Z0.S, Z1.S
+    WORD $0xE5444000           // ST1W {Z0.S}, P0, [X0, X4, LSL #2]
+    WORD $0x04B0E3E4           // INCW X4
+    B mulsve32_loop
+
+mulsve32_done:
+    RET
+
+// func fmaSVE(dst, a, b, c []float32)
+// dst[i] = a[i]*b[i] + c[i] with a single rounding (FMLA), for i < len(dst).
+TEXT ·fmaSVE(SB), NOSPLIT, $0-96
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R4
+    MOVD a_base+24(FP), R1
+    MOVD b_base+48(FP), R2
+    MOVD c_base+72(FP), R3
+    MOVD $0, R5                // R5 = i
+
+fmasve32_loop:
+    WORD $0x25A41CA0           // WHILELO P0.S, X5, X4
+    BEQ fmasve32_done
+    WORD $0xA5454020           // LD1W {Z0.S}, P0/Z, [X1, X5, LSL #2]
+    WORD $0xA5454041           // LD1W {Z1.S}, P0/Z, [X2, X5, LSL #2]
+    WORD $0xA5454062           // LD1W {Z2.S}, P0/Z, [X3, X5, LSL #2]
+    WORD $0x65A10002           // FMLA Z2.S, P0/M, Z0.S, Z1.S
+    WORD $0xE5454002           // ST1W {Z2.S}, P0, [X0, X5, LSL #2]
+    WORD $0x04B0E3E5           // INCW X5
+    B fmasve32_loop
+
+fmasve32_done:
+    RET
@@ -0,0 +1,541 @@
+package f32
+
+import (
+	"errors"
+	"math"
+)
+
+// This file implements the complex FFT core the spectral code is built on: an
+// in-place, decimation-in-time transform over split-format (separate real and
+// imaginary) float32 data. STFTPlan runs its half-length rfft on one, and
+// c64.FFTPlan wraps one for interleaved []complex64 data, so every transform in
+// the library shares the same twiddle layout and vector paths.
+//
+// The plan picks one of three schedules from n:
+//
+//   - Power of two: stages driven through ButterflyComplexStage4 (a radix-4
+//     core, two radix-2 stages per pass) with a single trailing
+//     ButterflyComplexStage when log2(n) is odd, after a bit-reversal reorder.
+//   - 2^a * 3^b * 5^c (mixed radix): scalar radix-5 and radix-3 stages at the
+//     short spans, then the same radix-4/radix-2 stages at the long spans, after
+//     the matching digit-reversal reorder. This covers the common audio sizes
+//     (nfft = 400, 480, 960, ...) without padding.
+//   - Anything else: Bluestein's chirp-z algorithm, which rewrites the size-n DFT
+//     as a circular convolution evaluated with a power-of-two plan of size
+//     m >= 2n-1.
+//
+// The twiddle and chirp tables are computed in float64 and rounded once to
+// float32, as in STFTPlan.
+
+// ErrFFTSize is returned by NewFFTPlan when n < 1.
+var ErrFFTSize = errors.New("f32: FFT size must be >= 1")
+
+// stage4Tw3Power is the twiddle power of the radix-4 stage's third factor: tw3 =
+// w^(3j) (tw1 = w^(2j) and tw2 = w^(1j) are sliced from the radix-2 tables).
+const stage4Tw3Power = 3
+
+// Radices of the scalar mixed-radix stages; the radix-2 and radix-4 stages reuse
+// butterflyStageRadix and butterflyStage4Radix.
+const (
+	fftRadix3 = 3
+	fftRadix5 = 5
+)
+
+// Twiddle-free DFT constants of the radix-3 and radix-5 butterflies.
+const (
+	fftSin60  = 0.86602540378443864676  // sin(2*pi/3)
+	fftCos72  = 0.30901699437494742410  // cos(2*pi/5)
+	fftCos144 = -0.80901699437494742410 // cos(4*pi/5)
+	fftSin72  = 0.95105651629515357212  // sin(2*pi/5)
+	fftSin144 = 0.58778525229247312917  // sin(4*pi/5)
+)
+
+// stage4Order is the sub-vector order ButterflyComplexStage4 expects: position q
+// of a radix-4 block holds the input residue class stage4Order[q] (positions 1
+// and 2 swapped, the radix-2 bit-reversed layout).
+var stage4Order = [butterflyStage4Radix]int{0, 2, 1, 3}
+
+// fftStage is one decimation-in-time pass of a mixed-radix schedule: it combines
+// radix sub-transforms of length span into transforms of length radix*span.
+// twRe[q-1]/twIm[q-1] hold the span twiddles applied to block position q.
+type fftStage struct {
+	radix, span int
+	twRe, twIm  [fftRadix5 - 1][]float32
+}
+
+// FFTPlan holds the resident permutation and per-stage twiddle tables for an
+// n-point complex FFT over split-format data. Build one with NewFFTPlan and
+// reuse it; Forward and Inverse are allocation-free.
+//
+// Power-of-two and 2^a*3^b*5^c plans hold only read-only tables, so one plan may
+// be shared by any number of goroutines, each transforming its own data. A
+// Bluestein plan (any other n) holds convolution scratch, so its methods are NOT
+// safe for concurrent use on the same plan; use one plan per goroutine.
+type FFTPlan struct {
+	n int // transform size
+
+	bitrev []int // bit-reversal permutation for a power-of-two n
+
+	// Per-stage contiguous twiddles, so each stage can be driven through
+	// ButterflyComplexStage (which reads its twiddles contiguous in j over
+	// [0, span)). Stage m in {2,4,...,n} uses span = m/2 factors
+	// W_m^j = exp(-i*2*pi*j/m) for j in [0, span); the stage with span s occupies
+	// stageTwRe[s-1 : 2*s-1], and the tables total n-1 entries. Power-of-two n only.
+	stageTwRe, stageTwIm []float32
+
+	// Extra twiddle for the radix-4 core: the w^(3j) power ButterflyComplexStage4
+	// needs beyond the two it can slice from stageTwRe/stageTwIm (tw1 = w^(2j) is the
+	// span-s radix-2 table, tw2 = w^j is the first s entries of the span-2s table).
+	// The radix-4 stage with span s (s in {1,4,16,...}) occupies stage4Tw3Re[(s-1)/3 :
+	// (s-1)/3 + s]; the offset (s-1)/3 is exact because s is a power of four. Empty
+	// when n < 4 (no radix-4 stage runs) or n is not a power of two.
+	stage4Tw3Re, stage4Tw3Im []float32
+
+	// Mixed-radix schedule (2^a*3^b*5^c n that is not a power of two): the
+	// digit-reversal permutation perm (position i loads input perm[i]), one
+	// leader index per non-trivial cycle of it so the reorder runs in place, and
+	// the stages in execution order (span increasing).
+	perm   []int
+	cycles []int
+	stages []fftStage
+	blue   *bluestein // Bluestein fallback for every other n; nil otherwise
+}
+
+// bluestein holds the chirp-z tables and scratch for one size-n transform:
+// X[k] = w[k] * sum_t (x[t]*w[t]) * conj(w[k-t]) with w[t] = exp(-i*pi*t^2/n),
+// the sum evaluated as a size-m circular convolution through a power-of-two plan.
+type bluestein struct {
+	inner            *FFTPlan  // power-of-two convolution plan, m >= 2n-1
+	chirpRe, chirpIm []float32 // w[t] for t in [0, n)
+	kernRe, kernIm   []float32 // size-m FFT of the wrapped conj(w), pre-scaled by 1/m
+	re, im           []float32 // size-m convolution scratch
+}
+
+// NewFFTPlan builds a reusable plan for n-point complex FFTs. Any n >= 1 is
+// accepted; otherwise ErrFFTSize is returned. Sizes of the form 2^a*3^b*5^c run
+// a direct mixed-radix schedule, every other size (large primes, say) the
+// slower Bluestein fallback.
+func NewFFTPlan(n int) (*FFTPlan, error) {
+	if n < 1 {
+		return nil, ErrFFTSize
+	}
+	if n&(n-1) == 0 {
+		return newPow2FFTPlan(n), nil
+	}
+	if radices := fftRadices(n); radices != nil {
+		return newMixedFFTPlan(n, radices), nil
+	}
+	return &FFTPlan{n: n, blue: newBluestein(n)}, nil
+}
+
+// newPow2FFTPlan builds the radix-4/radix-2 plan for a power-of-two n.
+func newPow2FFTPlan(n int) *FFTPlan {
+	// Size the radix-4 tw3 table: the radix-4 core runs stages at spans 1, 4, 16, ...
+	// while 4*span <= n, and stage span s holds s entries, so they sum to
+	// (4^numStages - 1)/3. Zero when n < 4.
+	stage4Tw3Len := 0
+	for s := 1; butterflyStage4Radix*s <= n; s *= butterflyStage4Radix {
+		stage4Tw3Len += s
+	}
+
+	p := &FFTPlan{
+		n:           n,
+		bitrev:      make([]int, n),
+		stageTwRe:   make([]float32, n-1),
+		stageTwIm:   make([]float32, n-1),
+		stage4Tw3Re: make([]float32, stage4Tw3Len),
+		stage4Tw3Im: make([]float32, stage4Tw3Len),
+	}
+
+	// Bit-reversal permutation for a size-n FFT.
+	logN := 0
+	for (1 << logN) < n {
+		logN++
+	}
+	for i := range p.bitrev {
+		r := 0
+		for b := range logN {
+			r |= ((i >> b) & 1) << (logN - 1 - b)
+		}
+		p.bitrev[i] = r
+	}
+
+	// Per-stage contiguous FFT twiddles (computed in float64, stored as float32):
+	// stage m in {2,4,...,n} writes its span = m/2 factors
+	// W_m^j = exp(-i*2*pi*j/m) starting at offset span-1.
+	for m := 2; m <= n; m <<= 1 {
+		span := m >> 1
+		off := span - 1
+		for j := range span {
+			ang := 2 * math.Pi * float64(j) / float64(m)
+			s, c := math.Sincos(ang)
+			p.stageTwRe[off+j] = float32(c)
+			p.stageTwIm[off+j] = float32(-s)
+		}
+	}
+
+	// Radix-4 tw3 = w^(3j) with w = exp(-i*2*pi/(4*span)), for each radix-4 stage
+	// span s in {1,4,16,...}. tw1 = w^(2j) and tw2 = w^j are the span-s and span-2s
+	// radix-2 tables above, sliced in transform; only w^(3j) is not already present.
+	// The offset (s-1)/(radix4-1) is the exact running sum of the earlier stage
+	// lengths because each s is a power of butterflyStage4Radix.
+	for s := 1; butterflyStage4Radix*s <= n; s *= butterflyStage4Radix {
+		off := (s - 1) / (butterflyStage4Radix - 1)
+		for j := range s {
+			ang := 2 * math.Pi * float64(stage4Tw3Power*j) / float64(butterflyStage4Radix*s)
+			sin, cos := math.Sincos(ang)
+			p.stage4Tw3Re[off+j] = float32(cos)
+			p.stage4Tw3Im[off+j] = float32(-sin)
+		}
+	}
+
+	return p
+}
+
+// fftRadices factors n into the mixed-radix stage schedule, in execution order:
+// the scalar radix-5 and radix-3 stages first, where spans are short, then
+// radix-4 stages and at most one radix-2 stage, where the long spans take the
+// vector paths. It returns nil when n has a prime factor other than 2, 3, or 5.
+func fftRadices(n int) []int {
+	var radices []int
+	for n%fftRadix5 == 0 {
+		radices = append(radices, fftRadix5)
+		n /= fftRadix5
+	}
+	for n%fftRadix3 == 0 {
+		radices = append(radices, fftRadix3)
+		n /= fftRadix3
+	}
+	for n%butterflyStage4Radix == 0 {
+		radices = append(radices, butterflyStage4Radix)
+		n /= butterflyStage4Radix
+	}
+	if n%butterflyStageRadix == 0 {
+		radices = append(radices, butterflyStageRadix)
+		n /= butterflyStageRadix
+	}
+	if n != 1 {
+		return nil
+	}
+	return radices
+}
+
+// stageDigit maps block position q of a radix-r stage to the input residue class
+// it holds: the identity except for the radix-4 stage's swapped layout.
+func stageDigit(radix, q int) int {
+	if radix == butterflyStage4Radix {
+		return stage4Order[q]
+	}
+	return q
+}
+
+// newMixedFFTPlan builds the digit-reversal permutation and per-stage twiddles
+// for the given radix schedule, whose product is n.
+func newMixedFFTPlan(n int, radices []int) *FFTPlan {
+	p := &FFTPlan{
+		n:      n,
+		perm:   make([]int, n),
+		stages: make([]fftStage, len(radices)),
+	}
+
+	span := 1
+	for i, r := range radices {
+		st := &p.stages[i]
+		st.radix, st.span = r, span
+		// Block position q takes w^(d*j), w = exp(-i*2*pi/(r*span)), where d is the
+		// residue class stageDigit(r, q) the position holds. d*j < r*span, so the
+		// angle needs no reduction.
+		for q := 1; q < r; q++ {
+			d := stageDigit(r, q)
+			st.twRe[q-1] = make([]float32, span)
+			st.twIm[q-1] = make([]float32, span)
+			for j := range span {
+				ang := 2 * math.Pi * float64(d*j) / float64(r*span)
+				s, c := math.Sincos(ang)
+				st.twRe[q-1][j] = float32(c)
+				st.twIm[q-1][j] = float32(-s)
+			}
+		}
+		span *= r
+	}
+
+	// Digit reversal: the last stage splits the transform into r sub-transforms of
+	// the inputs congruent to stageDigit(r, pos/span) mod r, and so on down the
+	// stages, so the input index is built from the outermost stage inwards.
+	for pos := range n {
+		rem, idx, mult := pos, 0, 1
+		for i := len(p.stages) - 1; i >= 0; i-- {
+			st := &p.stages[i]
+			idx += mult * stageDigit(st.radix, rem/st.span)
+			rem %= st.span
+			mult *= st.radix
+		}
+		p.perm[pos] = idx
+	}
+
+	// One leader per non-trivial cycle, so transform can permute in place.
+	seen := make([]bool, n)
+	for i := range n {
+		if seen[i] || p.perm[i] == i {
+			continue
+		}
+		p.cycles = append(p.cycles, i)
+		for j := i; !seen[j]; j = p.perm[j] {
+			seen[j] = true
+		}
+	}
+	return p
+}
+
+// newBluestein builds the chirp-z tables for a size-n transform.
+func newBluestein(n int) *bluestein {
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	b := &bluestein{
+		inner:   newPow2FFTPlan(m),
+		chirpRe: make([]float32, n),
+		chirpIm: make([]float32, n),
+		kernRe:  make([]float32, m),
+		kernIm:  make([]float32, m),
+		re:      make([]float32, m),
+		im:      make([]float32, m),
+	}
+	for t := range n {
+		// t^2 mod 2n keeps the angle small and exact for large t.
+		ang := math.Pi * float64((t*t)%(2*n)) / float64(n)
+		s, c := math.Sincos(ang)
+		b.chirpRe[t], b.chirpIm[t] = float32(c), float32(-s)
+	}
+	// The convolution kernel conj(w[d]) for |d| < n, wrapped circularly into m.
+	b.kernRe[0] = b.chirpRe[0]
+	b.kernIm[0] = -b.chirpIm[0]
+	for t := 1; t < n; t++ {
+		b.kernRe[t], b.kernIm[t] = b.chirpRe[t], -b.chirpIm[t]
+		b.kernRe[m-t], b.kernIm[m-t] = b.chirpRe[t], -b.chirpIm[t]
+	}
+	b.inner.transform(b.kernRe, b.kernIm)
+	inv := 1 / float32(m)
+	Scale(b.kernRe, b.kernRe, inv)
+	Scale(b.kernIm, b.kernIm, inv)
+	return b
+}
+
+// Len returns the transform size the plan was built for.
+func (p *FFTPlan) Len() int { return p.n }
+
+// Forward computes the unnormalized forward DFT of the split-format complex
+// vector (re, im) in place:
+//
+//	X[k] = sum_{t=0}^{n-1} x[t] * exp(-i*2*pi*k*t/n)
+//
+// It transforms re[:n] and im[:n], where n is Len(), and is a no-op when either
+// slice is shorter than n. re and im must not overlap each other.
+// Allocation-free.
+//
+// The radix-2 and radix-4 stages run through ButterflyComplexStage4 and
+// ButterflyComplexStage, so they take the AVX+FMA / NEON vector paths where the
+// span and block count justify them and fall back to scalar Go otherwise; the
+// radix-3 and radix-5 stages are scalar Go.
+func (p *FFTPlan) Forward(re, im []float32) {
+	if len(re) < p.n || len(im) < p.n {
+		return
+	}
+	p.transform(re[:p.n], im[:p.n])
+}
+
+// Inverse computes the unnormalized inverse DFT of the split-format complex
+// vector (re, im) in place:
+//
+//	x[t] = sum_{k=0}^{n-1} X[k] * exp(+i*2*pi*k*t/n)
+//
+// No 1/n factor is applied, so Forward followed by Inverse scales the input by
+// Len(); scale by 1/Len() (for example with Scale) for the normalized inverse.
+// Length handling and aliasing follow Forward. Allocation-free.
+func (p *FFTPlan) Inverse(re, im []float32) {
+	if len(re) < p.n || len(im) < p.n {
+		return
+	}
+	re, im = re[:p.n], im[:p.n]
+	// ifft(x) = conj(fft(conj(x))): conjugation only flips the imaginary part.
+	Neg(im, im)
+	p.transform(re, im)
+	Neg(im, im)
+}
+
+// transform runs the in-place forward FFT on re and im, which must both have
+// length exactly p.n.
+func (p *FFTPlan) transform(re, im []float32) {
+	switch {
+	case p.blue != nil:
+		p.blue.transform(re, im)
+	case p.stages != nil:
+		p.transformMixed(re, im)
+	default:
+		p.transformPow2(re, im)
+	}
+}
+
+// transformPow2 is transform for a power-of-two n.
+func (p *FFTPlan) transformPow2(re, im []float32) {
+	// Bit-reversal reorder.
+	for i, j := range p.bitrev {
+		if j > i {
+			re[i], re[j] = re[j], re[i]
+			im[i], im[j] = im[j], im[i]
+		}
+	}
+	// Butterfly stages via the radix-4 core: a radix-4 stage at span s advances the
+	// transform two radix-2 stages at once (span s then span 2s), so it runs spans
+	// 1, 4, 16, ... while a full radix-4 block fits (4*s <= n). tw1 = w^(2j) is the
+	// span-s radix-2 table at stageTw[s-1 : 2s-1], tw2 = w^j is the first s entries of
+	// the span-2s table at stageTw[2s-1 : 3s-1], and tw3 = w^(3j) is the dedicated
+	// stage4Tw3 table at [(s-1)/3 : (s-1)/3 + s]. A single trailing radix-2 stage
+	// finishes the transform when n is not a power of four (odd log2(n)).
+	s := 1
+	for butterflyStage4Radix*s <= p.n {
+		o1 := s - 1                                // span-s radix-2 table
+		o2 := butterflyStageRadix*s - 1            // span-2s radix-2 table, first s taken
+		o3 := (s - 1) / (butterflyStage4Radix - 1) // dedicated w^(3j) table
+		ButterflyComplexStage4(re, im, s,
+			p.stageTwRe[o1:o1+s], p.stageTwIm[o1:o1+s],
+			p.stageTwRe[o2:o2+s], p.stageTwIm[o2:o2+s],
+			p.stage4Tw3Re[o3:o3+s], p.stage4Tw3Im[o3:o3+s])
+		s *= butterflyStage4Radix
+	}
+	if s < p.n {
+		off := s - 1
+		ButterflyComplexStage(re, im, s, p.stageTwRe[off:off+s], p.stageTwIm[off:off+s])
+	}
+}
+
+// transformMixed is transform for a 2^a*3^b*5^c n that is not a power of two.
+func (p *FFTPlan) transformMixed(re, im []float32) {
+	// Digit-reversal reorder, one cycle at a time: position i takes the value at
+	// perm[i], walking the cycle until it returns to its leader.
+	for _, start := range p.cycles {
+		tr, ti := re[start], im[start]
+		i := start
+		for {
+			src := p.perm[i]
+			if src == start {
+				re[i], im[i] = tr, ti
+				break
+			}
+			re[i], im[i] = re[src], im[src]
+			i = src
+		}
+	}
+	for k := range p.stages {
+		st := &p.stages[k]
+		switch st.radix {
+		case butterflyStageRadix:
+			ButterflyComplexStage(re, im, st.span, st.twRe[0], st.twIm[0])
+		case butterflyStage4Radix:
+			ButterflyComplexStage4(re, im, st.span,
+				st.twRe[0], st.twIm[0], st.twRe[1], st.twIm[1], st.twRe[2], st.twIm[2])
+		case fftRadix3:
+			butterflyStage3(re, im, st)
+		case fftRadix5:
+			butterflyStage5(re, im, st)
+		}
+	}
+}
+
+// butterflyStage3 applies one radix-3 decimation-in-time stage in place: for each
+// block of 3*span elements it twiddles positions 1 and 2 and takes the 3-point
+// DFT of every (j, span+j, 2*span+j) triple.
+func butterflyStage3(re, im []float32, st *fftStage) {
+	span := st.span
+	t1r, t1i := st.twRe[0][:span], st.twIm[0][:span]
+	t2r, t2i := st.twRe[1][:span], st.twIm[1][:span]
+	for k := 0; k+fftRadix3*span <= len(re); k += fftRadix3 * span {
+		r0, i0 := re[k:k+span], im[k:k+span]
+		r1, i1 := re[k+span:k+2*span], im[k+span:k+2*span]
+		r2, i2 := re[k+2*span:k+3*span], im[k+2*span:k+3*span]
+		for j := range span {
+			x1r := r1[j]*t1r[j] - i1[j]*t1i[j]
+			x1i := r1[j]*t1i[j] + i1[j]*t1r[j]
+			x2r := r2[j]*t2r[j] - i2[j]*t2i[j]
+			x2i := r2[j]*t2i[j] + i2[j]*t2r[j]
+
+			sr, si := x1r+x2r, x1i+x2i
+			dr, di := fftSin60*(x1r-x2r), fftSin60*(x1i-x2i)
+			mr, mi := r0[j]-rfftHalf*sr, i0[j]-rfftHalf*si
+
+			r0[j], i0[j] = r0[j]+sr, i0[j]+si
+			r1[j], i1[j] = mr+di, mi-dr
+			r2[j], i2[j] = mr-di, mi+dr
+		}
+	}
+}
+
+// butterflyStage5 applies one radix-5 decimation-in-time stage in place: for each
+// block of 5*span elements it twiddles positions 1..4 and takes the 5-point DFT
+// of every (j, span+j, ..., 4*span+j) quintuple.
+func butterflyStage5(re, im []float32, st *fftStage) {
+	span := st.span
+	t1r, t1i := st.twRe[0][:span], st.twIm[0][:span]
+	t2r, t2i := st.twRe[1][:span], st.twIm[1][:span]
+	t3r, t3i := st.twRe[2][:span], st.twIm[2][:span]
+	t4r, t4i := st.twRe[3][:span], st.twIm[3][:span]
+	for k := 0; k+fftRadix5*span <= len(re); k += fftRadix5 * span {
+		r0, i0 := re[k:k+span], im[k:k+span]
+		r1, i1 := re[k+span:k+2*span], im[k+span:k+2*span]
+		r2, i2 := re[k+2*span:k+3*span], im[k+2*span:k+3*span]
+		r3, i3 := re[k+3*span:k+4*span], im[k+3*span:k+4*span]
+		r4, i4 := re[k+4*span:k+5*span], im[k+4*span:k+5*span]
+		for j := range span {
+			x1r := r1[j]*t1r[j] - i1[j]*t1i[j]
+			x1i := r1[j]*t1i[j] + i1[j]*t1r[j]
+			x2r := r2[j]*t2r[j] - i2[j]*t2i[j]
+			x2i := r2[j]*t2i[j] + i2[j]*t2r[j]
+			x3r := r3[j]*t3r[j] - i3[j]*t3i[j]
+			x3i := r3[j]*t3i[j] + i3[j]*t3r[j]
+			x4r := r4[j]*t4r[j] - i4[j]*t4i[j]
+			x4i := r4[j]*t4i[j] + i4[j]*t4r[j]
+
+			a1r, a1i := x1r+x4r, x1i+x4i
+			b1r, b1i := x1r-x4r, x1i-x4i
+			a2r, a2i := x2r+x3r, x2i+x3i
+			b2r, b2i := x2r-x3r, x2i-x3i
+			x0r, x0i := r0[j], i0[j]
+
+			m1r := x0r + fftCos72*a1r + fftCos144*a2r
+			m1i := x0i + fftCos72*a1i + fftCos144*a2i
+			m2r := x0r + fftCos144*a1r + fftCos72*a2r
+			m2i := x0i + fftCos144*a1i + fftCos72*a2i
+			n1r := fftSin72*b1r + fftSin144*b2r
+			n1i := fftSin72*b1i + fftSin144*b2i
+			n2r := fftSin144*b1r - fftSin72*b2r
+			n2i := fftSin144*b1i - fftSin72*b2i
+
+			// y1 = m1 - i*n1, y4 = m1 + i*n1, y2 = m2 - i*n2, y3 = m2 + i*n2.
+			r0[j], i0[j] = x0r+a1r+a2r, x0i+a1i+a2i
+			r1[j], i1[j] = m1r+n1i, m1i-n1r
+			r4[j], i4[j] = m1r-n1i, m1i+n1r
+			r2[j], i2[j] = m2r+n2i, m2i-n2r
+			r3[j], i3[j] = m2r-n2i, m2i+n2r
+		}
+	}
+}
+
+// transform runs the size-n chirp-z transform in place on re and im, which must
+// both have length exactly n.
+func (b *bluestein) transform(re, im []float32) {
+	n := len(re)
+	// a[t] = x[t]*w[t], zero-padded to m.
+	MulComplex(b.re[:n], b.im[:n], re, im, b.chirpRe, b.chirpIm)
+	clear(b.re[n:])
+	clear(b.im[n:])
+	// Circular convolution with conj(w): FFT, pointwise product with the
+	// pre-transformed kernel, and an inverse FFT (the 1/m is folded into kern).
+	b.inner.transform(b.re, b.im)
+	MulComplex(b.re, b.im, b.re, b.im, b.kernRe, b.kernIm)
+	Neg(b.im, b.im)
+	b.inner.transform(b.re, b.im)
+	Neg(b.im[:n], b.im[:n])
+	// X[k] = w[k] * conv[k].
+	MulComplex(re, im, b.re[:n], b.im[:n], b.chirpRe, b.chirpIm)
+}
@@ -0,0 +1,131 @@
+package f32
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// firRefF32 is the direct causal convolution y[n] = sum_j taps[j]*x[n-j] in
+// float64.
+func firRefF32(x, taps []float32) []float64 {
+	y := make([]float64, len(x))
+	for n := range x {
+		for j := range taps {
+			if n >= j {
+				y[n] += float64(taps[j]) * float64(x[n-j])
+			}
+		}
+	}
+	return y
+}
+
+func TestNewFIRFilterErrorsF32(t *testing.T) {
+	if _, err := NewFIRFilter(nil); !errors.Is(err, ErrFIRTaps) {
+		t.Fatalf("NewFIRFilter(nil) error = %v, want ErrFIRTaps", err)
+	}
+	f, err := NewFIRFilter(make([]float32, 31))
+	if err != nil || f.Len() != 31 {
+		t.Fatalf("NewFIRFilter(31 taps) = %v, %v", f, err)
+	}
+}
+
+// TestFIRFilterStreamingF32 checks Process against the direct convolution when
+// the signal arrives in uneven blocks (including empty ones and blocks longer
+// than the internal chunk), and that in-place filtering after Reset matches.
+//
+// Every output is one dotProduct over its own window of the delay line, so its
+// summation order depends only on the tap count, never on where the block
+// boundaries fell: the stream must equal a one-shot ConvolveValid over the
+// zero-padded signal bit for bit, on every tier. Against the float64 oracle
+// the standard dot-product bound applies, |err| <= gamma_N * sum_j |taps[j]*x[n-j]|
+// with gamma_N = N*u/(1-N*u) and u = 2^-24, whatever the kernel's accumulation
+// order.
+func TestFIRFilterStreamingF32(t *testing.T) {
+	const u = 1.0 / (1 << 24)
+	for _, nTaps := range []int{1, 2, 7, 64, 1500} {
+		taps := testSignalF32(nTaps + 3)[3:]
+		x := testSignalF32(5000)
+		want := firRefF32(x, taps)
+		f, _ := NewFIRFilter(taps)
+		got := make([]float32, len(x))
+		for pos, step := 0, 0; pos < len(x); step = step*2 + 1 {
+			end := min(len(x), pos+step)
+			if n := f.Process(got[pos:end], x[pos:end]); n != end-pos {
+				t.Fatalf("taps=%d: Process returned %d, want %d", nTaps, n, end-pos)
+			}
+			pos = end
+		}
+
+		padded := make([]float32, nTaps-1+len(x))
+		copy(padded[nTaps-1:], x)
+		rev := make([]float32, nTaps)
+		for j, v := range taps {
+			rev[nTaps-1-j] = v
+		}
+		oneShot := make([]float32, len(x))
+		ConvolveValid(oneShot, padded, rev)
+
+		gamma := float64(nTaps) * u / (1 - float64(nTaps)*u)
+		for i := range x {
+			if got[i] != oneShot[i] {
+				t.Fatalf("taps=%d: y[%d] = %g, one-shot ConvolveValid %g", nTaps, i, got[i], oneShot[i])
+			}
+			var mag float64
+			for j := range taps {
+				if i >= j {
+					mag += math.Abs(float64(taps[j]) * float64(x[i-j]))
+				}
+			}
+			if d := math.Abs(float64(got[i]) - want[i]); d > gamma*mag {
+				t.Fatalf("taps=%d: y[%d] = %g want %g (|err| %g > bound %g)", nTaps, i, got[i], want[i], d, gamma*mag)
+			}
+		}
+
+		f.Reset()
+		inPlace := append([]float32(nil), x...)
+		f.Process(inPlace[:777], inPlace[:777])
+		f.Process(inPlace[777:], inPlace[777:])
+		for i := range x {
+			if inPlace[i] != got[i] {
+				t.Fatalf("taps=%d: in-place y[%d] = %g, want %g", nTaps, i, inPlace[i], got[i])
+			}
+		}
+	}
+}
+
+// TestFIRFilterClampF32 checks that only min(len(dst), len(src)) samples are
+// processed and that the tail of dst is untouched.
+func TestFIRFilterClampF32(t *testing.T) {
+	f, _ := NewFIRFilter([]float32{1, 0.5})
+	dst := []float32{9, 9, 9, 9}
+	if n := f.Process(dst, []float32{1, 2}); n != 2 || dst[0] != 1 || dst[1] != 2.5 || dst[2] != 9 {
+		t.Fatalf("Process = %d, dst %v", n, dst)
+	}
+	if n := f.Process(dst[:1], []float32{4, 4, 4}); n != 1 || dst[0] != 5 {
+		t.Fatalf("Process = %d, dst %v (history not carried)", n, dst)
+	}
+}
+
+func TestFIRFilterAllocFreeF32(t *testing.T) {
+	f, _ := NewFIRFilter(testSignalF32(63))
+	buf := testSignalF32(4096)
+	if a := testing.AllocsPerRun(5, func() { f.Process(buf, buf) }); a != 0 {
+		t.Errorf("Process allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkFIRFilter(b *testing.B) {
+	for _, nTaps := range []int{16, 64, 256} {
+		b.Run(fmt.Sprintf("taps=%d", nTaps), func(b *testing.B) {
+			f, _ := NewFIRFilter(testSignalF32(nTaps))
+			buf := testSignalF32(480)
+			b.SetBytes(int64(4 * len(buf)))
+			b.ReportAllocs()
+			for b.Loop() {
+				f.Process(buf, buf)
+			}
+		})
+	}
+}
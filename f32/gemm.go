@@ -0,0 +1,269 @@
+package f32
+
+import "sync"
+
+// Dense matrix products over row-major storage. GEMM is a packed, cache-blocked
+// driver around one register-blocked microkernel per tier (gemmMicroKernel in
+// the per-arch files): op(B) is packed a gemmKC x gemmNC block at a time into
+// NR-column panels, op(A) a gemmMC x gemmKC block at a time into MR-row panels
+// with alpha folded in, and the microkernel accumulates each MR x NR tile of C
+// straight from the two panels. GEMV needs no packing: it runs the strided
+// batch dot kernel (op(A) = A) or AddScaled row sweeps (op(A) = A^T).
+
+// Blocking parameters. gemmMC is a multiple of every tier's MR and gemmNC of
+// every tier's NR, so only the last block in each dimension is ragged.
+const (
+	gemmKC = 256
+	gemmMC = 96
+	gemmNC = 512
+
+	gemmMaxTile = 8 * 32 // largest MR*NR of any tier
+
+	// gemvChunkRows is how many rows of A one strided batch dot call scores
+	// into a stack buffer; like topKChunkRows it keeps every chunk eligible for
+	// the batched kernel.
+	gemvChunkRows = 32
+)
+
+// gemmMicro is one tier's microkernel and its tile shape. kernel adds the
+// mr x nr product of k packed A and B steps into c, whose rows are ldc apart.
+type gemmMicro struct {
+	mr, nr int
+	kernel func(k int, a, b, c *float32, ldc int)
+}
+
+// gemmWorkspace holds the packed panels and the scratch tile for partial edge
+// tiles. Workspaces are pooled so GEMM stays allocation-free in steady state.
+type gemmWorkspace struct {
+	a    [gemmMC * gemmKC]float32
+	b    [gemmKC * gemmNC]float32
+	tile [gemmMaxTile]float32
+}
+
+var gemmPool = sync.Pool{New: func() any { return new(gemmWorkspace) }}
+
+// GEMM computes the general matrix product
+//
+//	C = alpha*op(A)*op(B) + beta*C
+//
+// over row-major matrices, where op(X) is X, or its transpose when the matching
+// trans flag is set. op(A) is m x k, op(B) is k x n and C is m x n; element
+// (i, j) of a matrix X with leading dimension ldx is x[i*ldx+j], so A is stored
+// m x k (k x m when transA) and B k x n (n x k when transB). As in BLAS, C is not
+// read when beta is 0, so it may hold NaNs, and A and B are not read when alpha
+// or k is 0.
+//
+// GEMM panics if m, n or k is negative, or if a leading dimension is shorter
+// than its matrix's row or a slice too short for its matrix. C must not overlap
+// A or B. Accumulation order differs from a naive triple loop, so results agree
+// with it to rounding, not bit for bit. Uses AVX-512 (6x32 tiles), AVX+FMA (6x16)
+// or NEON (8x8) microkernels, and a pure-Go loop elsewhere.
+func GEMM(transA, transB bool, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	if m < 0 || n < 0 || k < 0 {
+		panic("f32.GEMM: negative dimension")
+	}
+	if transA {
+		gemmCheckMatrix("f32.GEMM: A", len(a), k, m, lda)
+	} else {
+		gemmCheckMatrix("f32.GEMM: A", len(a), m, k, lda)
+	}
+	if transB {
+		gemmCheckMatrix("f32.GEMM: B", len(b), n, k, ldb)
+	} else {
+		gemmCheckMatrix("f32.GEMM: B", len(b), k, n, ldb)
+	}
+	gemmCheckMatrix("f32.GEMM: C", len(c), m, n, ldc)
+	if m == 0 || n == 0 {
+		return
+	}
+	mk, ok := gemmMicroKernel()
+	if !ok {
+		gemmGo(transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
+	gemmScaleC(m, n, beta, c, ldc)
+	if alpha == 0 || k == 0 {
+		return
+	}
+	gemmPacked(mk, transA, transB, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+}
+
+// GEMV computes the matrix-vector product
+//
+//	y = alpha*op(A)*x + beta*y
+//
+// where A is an m x n row-major matrix with leading dimension lda and op(A) is
+// A or, when transA, its transpose. x has n elements and y m (the other way
+// round when transA); as in BLAS, y is not read when beta is 0.
+//
+// GEMV panics if m or n is negative, lda is shorter than a row, or a, x or y is
+// too short. y must not overlap A or x. The product runs on the batched
+// DotProductStrided kernel, or AddScaled sweeps over the rows of A when transA,
+// and is allocation-free.
+func GEMV(transA bool, m, n int, alpha float32, a []float32, lda int, x []float32, beta float32, y []float32) {
+	if m < 0 || n < 0 {
+		panic("f32.GEMV: negative dimension")
+	}
+	gemmCheckMatrix("f32.GEMV: A", len(a), m, n, lda)
+	xLen, yLen := n, m
+	if transA {
+		xLen, yLen = m, n
+	}
+	if len(x) < xLen || len(y) < yLen {
+		panic("f32.GEMV: x or y too short")
+	}
+	y = y[:yLen]
+	if transA {
+		gemvScaleY(y, beta)
+		if alpha == 0 || n == 0 {
+			return
+		}
+		for i := range m {
+			addScaled32(y, alpha*x[i], a[i*lda:i*lda+n])
+		}
+		return
+	}
+	if alpha == 0 || n == 0 {
+		gemvScaleY(y, beta)
+		return
+	}
+	var buf [gemvChunkRows]float32
+	for start := 0; start < m; start += gemvChunkRows {
+		rows := min(gemvChunkRows, m-start)
+		off := start * lda
+		dots := buf[:rows]
+		dotProductStrided(dots, a[off:off+(rows-1)*lda+n], x[:n], rows, n, lda)
+		yc := y[start : start+rows]
+		if beta == 0 {
+			for i, d := range dots {
+				yc[i] = alpha * d
+			}
+			continue
+		}
+		for i, d := range dots {
+			yc[i] = alpha*d + beta*yc[i]
+		}
+	}
+}
+
+// gemmCheckMatrix panics unless a rows x cols matrix with leading dimension ld
+// fits in a slice of length n.
+func gemmCheckMatrix(what string, n, rows, cols, ld int) {
+	if ld < max(1, cols) {
+		panic(what + " leading dimension shorter than a row")
+	}
+	if rows > 0 && cols > 0 && n < (rows-1)*ld+cols {
+		panic(what + " slice too short")
+	}
+}
+
+// gemmScaleC applies C = beta*C, clearing C without reading it when beta is 0.
+func gemmScaleC(m, n int, beta float32, c []float32, ldc int) {
+	if beta == 1 {
+		return
+	}
+	for i := range m {
+		row := c[i*ldc : i*ldc+n]
+		if beta == 0 {
+			clear(row)
+		} else {
+			scale(row, row, beta)
+		}
+	}
+}
+
+// gemvScaleY applies y = beta*y, clearing y without reading it when beta is 0.
+func gemvScaleY(y []float32, beta float32) {
+	switch beta {
+	case 1:
+	case 0:
+		clear(y)
+	default:
+		scale(y, y, beta)
+	}
+}
+
+// gemmPacked accumulates alpha*op(A)*op(B) into C through mk's microkernel.
+func gemmPacked(mk gemmMicro, transA, transB bool, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, c []float32, ldc int) {
+	ws := gemmPool.Get().(*gemmWorkspace)
+	defer gemmPool.Put(ws)
+	mr, nr := mk.mr, mk.nr
+	for jc := 0; jc < n; jc += gemmNC {
+		nc := min(gemmNC, n-jc)
+		for pc := 0; pc < k; pc += gemmKC {
+			kc := min(gemmKC, k-pc)
+			gemmPackB(ws.b[:], transB, b, ldb, pc, jc, kc, nc, nr)
+			for ic := 0; ic < m; ic += gemmMC {
+				mc := min(gemmMC, m-ic)
+				gemmPackA(ws.a[:], transA, a, lda, ic, pc, mc, kc, mr, alpha)
+				for jr := 0; jr < nc; jr += nr {
+					bp := &ws.b[jr*kc]
+					cols := min(nr, nc-jr)
+					for ir := 0; ir < mc; ir += mr {
+						ap := &ws.a[ir*kc]
+						rows := min(mr, mc-ir)
+						ci := (ic+ir)*ldc + jc + jr
+						if rows == mr && cols == nr {
+							mk.kernel(kc, ap, bp, &c[ci], ldc)
+							continue
+						}
+						tile := ws.tile[:mr*nr]
+						clear(tile)
+						mk.kernel(kc, ap, bp, &tile[0], nr)
+						for i := range rows {
+							dst := c[ci+i*ldc : ci+i*ldc+cols]
+							for j, v := range tile[i*nr : i*nr+cols] {
+								dst[j] += v
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// gemmPackA packs op(A)[ic:ic+mc, pc:pc+kc], scaled by alpha, into mr-row
+// panels: panel r holds, for each p, the mr elements of column p, zero-padded
+// past the last row.
+func gemmPackA(dst []float32, transA bool, a []float32, lda, ic, pc, mc, kc, mr int, alpha float32) {
+	for i0 := 0; i0 < mc; i0 += mr {
+		rows := min(mr, mc-i0)
+		panel := dst[i0*kc : i0*kc+mr*kc]
+		for p := range kc {
+			col := panel[p*mr : p*mr+mr]
+			if transA {
+				src := a[(pc+p)*lda+ic+i0:]
+				for i := range rows {
+					col[i] = alpha * src[i]
+				}
+			} else {
+				for i := range rows {
+					col[i] = alpha * a[(ic+i0+i)*lda+pc+p]
+				}
+			}
+			clear(col[rows:])
+		}
+	}
+}
+
+// gemmPackB packs op(B)[pc:pc+kc, jc:jc+nc] into nr-column panels: panel j
+// holds, for each p, the nr elements of row p, zero-padded past the last column.
+func gemmPackB(dst []float32, transB bool, b []float32, ldb, pc, jc, kc, nc, nr int) {
+	for j0 := 0; j0 < nc; j0 += nr {
+		cols := min(nr, nc-j0)
+		panel := dst[j0*kc : j0*kc+nr*kc]
+		for p := range kc {
+			row := panel[p*nr : p*nr+nr]
+			if transB {
+				for j := range cols {
+					row[j] = b[(jc+j0+j)*ldb+pc+p]
+				}
+			} else {
+				off := (pc+p)*ldb + jc + j0
+				copy(row[:cols], b[off:off+cols])
+			}
+			clear(row[cols:])
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package f32
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// istftRefF32 is the float64 reference ISTFT: a direct inverse DFT of each
+// Hermitian half-spectrum row (numpy.fft.irfft semantics: the imaginary parts of
+// the DC and Nyquist bins are dropped), windowed, overlap-added, and divided by
+// the window-sum-square envelope where it exceeds the float32 tiny threshold.
+func istftRefF32(spec [][]complex64, window []float32, nfft, hop int, pad PadMode, n int) []float64 {
+	half := nfft / 2
+	off := 0
+	if pad != NoPad {
+		off = half
+	}
+	out := make([]float64, n)
+	wss := make([]float64, n)
+	frame := make([]float64, nfft)
+	for f, row := range spec {
+		bin := func(k int) complex128 {
+			if k >= len(row) {
+				return 0
+			}
+			c := complex128(row[k])
+			if k == 0 || (k == half && nfft%2 == 0) {
+				c = complex(real(c), 0)
+			}
+			return c
+		}
+		// An even nfft has a lone Nyquist bin; an odd one mirrors every bin
+		// through half.
+		top := half
+		if nfft%2 != 0 {
+			top = half + 1
+		}
+		for t := range nfft {
+			v := real(bin(0))
+			if nfft%2 == 0 {
+				v += real(bin(half)) * math.Cos(math.Pi*float64(t))
+			}
+			for k := 1; k < top; k++ {
+				ang := 2 * math.Pi * float64(k) * float64(t) / float64(nfft)
+				s, c := math.Sincos(ang)
+				b := bin(k)
+				v += 2 * (real(b)*c - imag(b)*s)
+			}
+			frame[t] = v / float64(nfft)
+		}
+		for t := range nfft {
+			idx := f*hop - off + t
+			if idx < 0 || idx >= n {
+				continue
+			}
+			w := 1.0
+			if window != nil {
+				w = float64(window[t])
+			}
+			out[idx] += frame[t] * w
+			wss[idx] += w * w
+		}
+	}
+	for i := range out {
+		if wss[i] > istftWSSFloor {
+			out[i] /= wss[i]
+		}
+	}
+	return out
+}
+
+func TestNumSamplesF32(t *testing.T) {
+	p, _ := NewSTFTPlan(16)
+	cases := []struct {
+		frames, hop int
+		pad         PadMode
+		want        int
+	}{
+		{0, 4, NoPad, 0},
+		{3, 0, NoPad, 0},
+		{1, 4, NoPad, 16},
+		{5, 4, NoPad, 32},
+		{1, 4, PadZero, 8},
+		{5, 4, PadReflect, 24},
+	}
+	for _, c := range cases {
+		if got := p.NumSamples(c.frames, c.hop, c.pad); got != c.want {
+			t.Errorf("NumSamples(%d, %d, %d) = %d, want %d", c.frames, c.hop, c.pad, got, c.want)
+		}
+	}
+	// An odd nfft trims nfft/2 from the front and nfft/2+1 from the back.
+	odd, _ := NewSTFTPlan(9)
+	for _, c := range []struct{ frames, hop, want int }{{1, 4, 5}, {3, 4, 13}} {
+		if got := odd.NumSamples(c.frames, c.hop, PadZero); got != c.want {
+			t.Errorf("nfft=9: NumSamples(%d, %d, PadZero) = %d, want %d", c.frames, c.hop, got, c.want)
+		}
+	}
+}
+
+// TestISTFTRoundTripF32 is the core inverse gate: a centered STFT followed by
+// ISTFT with the same window, hop, and pad must reproduce every sample of the
+// original signal, across power-of-two and mixed-radix nfft sizes, hops, and
+// both centering pad modes.
+func TestISTFTRoundTripF32(t *testing.T) {
+	signal := testSignalF32(3001)
+	for _, nfft := range []int{4, 16, 64, 512, 400, 480, 45, 401} {
+		p, _ := NewSTFTPlan(nfft)
+		window := hannF32(nfft)
+		for _, hop := range []int{max(nfft/4, 1), nfft / 2} {
+			for _, pad := range []PadMode{PadZero, PadReflect} {
+				nf := p.NumFrames(len(signal), hop, pad)
+				spec := make([][]complex64, nf)
+				for f := range spec {
+					spec[f] = make([]complex64, p.NumBins())
+				}
+				p.STFT(spec, signal, window, hop, pad)
+
+				out := make([]float32, len(signal))
+				if n := p.ISTFT(out, spec, window, hop, pad); n != len(signal) {
+					t.Fatalf("nfft=%d hop=%d pad=%d: ISTFT wrote %d samples, want %d", nfft, hop, pad, n, len(signal))
+				}
+				tol := 8 * math.Log2(float64(nfft)) * 1.1920928955078125e-07 * 4
+				for i := range out {
+					if d := math.Abs(float64(out[i] - signal[i])); d > tol {
+						t.Fatalf("nfft=%d hop=%d pad=%d: out[%d]=%v want %v (|diff|=%g tol=%g)",
+							nfft, hop, pad, i, out[i], signal[i], d, tol)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestISTFTRoundTripNoPadF32 round-trips the uncentered framing with a
+// rectangular window, whose envelope is non-zero at every covered sample.
+func TestISTFTRoundTripNoPadF32(t *testing.T) {
+	signal := testSignalF32(1000)
+	p, _ := NewSTFTPlan(64)
+	hop := 16
+	nf := p.NumFrames(len(signal), hop, NoPad)
+	spec := make([][]complex64, nf)
+	for f := range spec {
+		spec[f] = make([]complex64, p.NumBins())
+	}
+	p.STFT(spec, signal, nil, hop, NoPad)
+	out := make([]float32, len(signal))
+	n := p.ISTFT(out, spec, nil, hop, NoPad)
+	if want := p.NumSamples(nf, hop, NoPad); n != want {
+		t.Fatalf("ISTFT wrote %d samples, want %d", n, want)
+	}
+	for i := range n {
+		if d := math.Abs(float64(out[i] - signal[i])); d > 1e-5 {
+			t.Fatalf("out[%d]=%v want %v (|diff|=%g)", i, out[i], signal[i], d)
+		}
+	}
+}
+
+// TestISTFTAgainstRefF32 checks ISTFT against a direct float64 inverse DFT
+// overlap-add on spectra that are not the STFT of any signal (a modified
+// spectrogram, as a masking pipeline produces), including non-zero imaginary
+// parts on the DC and Nyquist bins that the inverse must ignore.
+func TestISTFTAgainstRefF32(t *testing.T) {
+	for _, nfft := range []int{2, 8, 32, 256, 6, 400, 194, 3, 9, 401} {
+		for _, pad := range []PadMode{NoPad, PadZero, PadReflect} {
+			p, _ := NewSTFTPlan(nfft)
+			window := hannF32(nfft)
+			hop := max(nfft/4, 1)
+			frames := 7
+			spec := make([][]complex64, frames)
+			for f := range spec {
+				spec[f] = make([]complex64, p.NumBins())
+				for k := range spec[f] {
+					x := float64(f*p.NumBins() + k)
+					spec[f][k] = complex(float32(math.Sin(0.7*x)), float32(math.Cos(1.3*x)))
+				}
+			}
+			n := p.NumSamples(frames, hop, pad)
+			out := make([]float32, n)
+			if got := p.ISTFT(out, spec, window, hop, pad); got != n {
+				t.Fatalf("nfft=%d pad=%d: ISTFT wrote %d samples, want %d", nfft, pad, got, n)
+			}
+			want := istftRefF32(spec, window, nfft, hop, pad, n)
+			for i := range out {
+				ctx := fmt.Sprintf("nfft=%d pad=%d sample=%d", nfft, pad, i)
+				if d := math.Abs(float64(out[i]) - want[i]); d > 1e-4*(1+math.Abs(want[i])) {
+					t.Fatalf("%s: got %v want %v (|diff|=%g)", ctx, out[i], want[i], d)
+				}
+			}
+		}
+	}
+}
+
+// TestISTFTGuardsF32 covers the empty, short-destination, short-row, and
+// short-window paths.
+func TestISTFTGuardsF32(t *testing.T) {
+	p, _ := NewSTFTPlan(16)
+	spec := make([][]complex64, 4)
+	for f := range spec {
+		spec[f] = make([]complex64, p.NumBins())
+		spec[f][1] = 1
+	}
+	dst := make([]float32, 64)
+	if n := p.ISTFT(dst, nil, nil, 4, PadZero); n != 0 {
+		t.Errorf("empty spec: wrote %d samples, want 0", n)
+	}
+	if n := p.ISTFT(dst, spec, nil, 0, PadZero); n != 0 {
+		t.Errorf("hop=0: wrote %d samples, want 0", n)
+	}
+
+	// A short dst is a prefix of the full reconstruction.
+	full := make([]float32, p.NumSamples(len(spec), 4, PadZero))
+	p.ISTFT(full, spec, nil, 4, PadZero)
+	short := make([]float32, 5)
+	if n := p.ISTFT(short, spec, nil, 4, PadZero); n != len(short) {
+		t.Fatalf("short dst: wrote %d samples, want %d", n, len(short))
+	}
+	for i := range short {
+		if short[i] != full[i] {
+			t.Fatalf("short dst[%d]=%v, want prefix value %v", i, short[i], full[i])
+		}
+	}
+
+	// A short window is rectangular, as in STFT.
+	rect := make([]float32, len(full))
+	p.ISTFT(rect, spec, hannF32(8), 4, PadZero)
+	for i := range rect {
+		if rect[i] != full[i] {
+			t.Fatalf("short window not treated as rectangular at %d", i)
+		}
+	}
+
+	// Short rows are zero-extended: truncating after bin 1 changes nothing here.
+	trunc := make([][]complex64, len(spec))
+	for f := range trunc {
+		trunc[f] = spec[f][:2]
+	}
+	got := make([]float32, len(full))
+	p.ISTFT(got, trunc, nil, 4, PadZero)
+	for i := range got {
+		if got[i] != full[i] {
+			t.Fatalf("short rows: got[%d]=%v want %v", i, got[i], full[i])
+		}
+	}
+}
+
+func TestISTFTAllocFreeF32(t *testing.T) {
+	p, _ := NewSTFTPlan(512)
+	signal := testSignalF32(8192)
+	window := hannF32(512)
+	hop := 128
+	nf := p.NumFrames(len(signal), hop, PadReflect)
+	spec := make([][]complex64, nf)
+	for f := range spec {
+		spec[f] = make([]complex64, p.NumBins())
+	}
+	p.STFT(spec, signal, window, hop, PadReflect)
+	out := make([]float32, len(signal))
+	if a := testing.AllocsPerRun(5, func() { p.ISTFT(out, spec, window, hop, PadReflect) }); a != 0 {
+		t.Errorf("ISTFT allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkISTFT(b *testing.B) {
+	p, _ := NewSTFTPlan(1024)
+	signal := testSignalF32(16000)
+	window := hannF32(1024)
+	hop := 256
+	nf := p.NumFrames(len(signal), hop, PadZero)
+	spec := make([][]complex64, nf)
+	for f := range spec {
+		spec[f] = make([]complex64, p.NumBins())
+	}
+	p.STFT(spec, signal, window, hop, PadZero)
+	out := make([]float32, len(signal))
+	b.ReportAllocs()
+	for b.Loop() {
+		p.ISTFT(out, spec, window, hop, PadZero)
+	}
+}
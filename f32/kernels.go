@@ -0,0 +1,109 @@
+package f32
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":                                  absGo,
+	"AbsPow34":                             absPow34Go,
+	"AbsSqComplex":                         absSqComplex32Go,
+	"AccumulateAdd":                        accumulateAdd32Go,
+	"Add":                                  addGo,
+	"AddScalar":                            addScalarGo,
+	"AddScaled":                            addScaledGo,
+	"AddSub":                               addSub32Go,
+	"BiquadCascade.Process":                biquadSectionGo,
+	"ButterflyComplex":                     butterflyComplex32Go,
+	"ButterflyComplexStage":                butterflyComplexStage32Go,
+	"ButterflyComplexStage4":               butterflyComplexStage4x32Go,
+	"Clamp":                                clampGo,
+	"ClampScale":                           clampScale32Go,
+	"ConvolveDecimate":                     convolveDecimate32Go,
+	"ConvolveValid":                        convolveValid32Go,
+	"ConvolveValidMaxAbs":                  convolveValidMaxAbsGo,
+	"ConvolveValidMaxAbsMulti":             convolveValidMaxAbsGo,
+	"ConvolveValidMulti":                   convolveValidMultiGo,
+	"CopySign":                             copySign32Go,
+	"CosineIndexed":                        cosineIndexedGo,
+	"CosineStrided":                        cosineStridedGo,
+	"CubicInterpDot":                       cubicInterpDotGo,
+	"CubicInterpDotUnsafe":                 cubicInterpDotGo,
+	"CumulativeSum":                        cumulativeSum32Go,
+	"Deinterleave2":                        deinterleave2Go,
+	"DeinterleaveN":                        deinterleaveNGo,
+	"Div":                                  divGo,
+	"DotProduct":                           dotProductGo,
+	"DotProductBatch":                      dotProductBatch32Go,
+	"DotProductIndexed":                    dotProductIndexedGo,
+	"DotProductStrided":                    dotProductStridedGo,
+	"DotProductUnsafe":                     dotProductGo,
+	"EuclideanDistance":                    euclideanDistance32Go,
+	"Exp":                                  exp32Go,
+	"ExpInPlace":                           exp32Go,
+	"FMA":                                  fmaGo,
+	"Float32ToInt16Scale":                  float32ToInt16ScaleGo,
+	"Float32ToInt16ScaleUnsafe":            float32ToInt16ScaleGo,
+	"Float32ToInt32ScaleClamp":             float32ToInt32ScaleClampGo,
+	"Float32ToInt32ScaleClampSigned":       float32ToInt32ScaleClampSignedGo,
+	"Float32ToInt32ScaleClampSignedUnsafe": float32ToInt32ScaleClampSignedGo,
+	"Float32ToInt32ScaleClampUnsafe":       float32ToInt32ScaleClampGo,
+	"GEMM":                                 gemmGo,
+	"Int16ToFloat32Scale":                  int16ToFloat32ScaleGo,
+	"Int16ToFloat32ScaleUnsafe":            int16ToFloat32ScaleGo,
+	"Int32ToFloat32Scale":                  int32ToFloat32ScaleGo,
+	"Int32ToFloat32ScaleAdd":               int32ToFloat32ScaleAddGo,
+	"Int32ToFloat32ScaleUnsafe":            int32ToFloat32ScaleGo,
+	"Interleave2":                          interleave2Go,
+	"InterleaveN":                          interleaveNGo,
+	"L2SquaredIndexed":                     l2SquaredIndexedGo,
+	"L2SquaredStrided":                     l2SquaredStridedGo,
+	"Log":                                  logGo,
+	"Log10":                                log10Go,
+	"Log2":                                 log2Go,
+	"LogInPlace":                           logGo,
+	"LogSoftmax":                           expSum32Go,
+	"LogSoftmaxRows":                       expSum32Go,
+	"LogSumExp":                            expSum32Go,
+	"LogSumExpRows":                        expSum32Go,
+	"Max":                                  maxGo,
+	"MaxAbs":                               maxAbsGo,
+	"MaxIdx":                               maxIdxGo,
+	"Min":                                  minGo,
+	"MinIdx":                               minIdxGo,
+	"MinIdxOfSumRows":                      minIdxOfSumRowsGo,
+	"Mul":                                  mulGo,
+	"MulComplex":                           mulComplex32Go,
+	"MulConjComplex":                       mulConjComplex32Go,
+	"Neg":                                  negGo,
+	"Pow":                                  powGo,
+	"PowElem":                              powElemGo,
+	"PowInPlace":                           powGo,
+	"ReLU":                                 relu32Go,
+	"ReLUInPlace":                          relu32Go,
+	"RealFFTPower":                         realFFTPower32Go,
+	"RealFFTUnpack":                        realFFTUnpack32Go,
+	"Reciprocal":                           reciprocal32Go,
+	"Reverse":                              reverse32Go,
+	"Round":                                round32Go,
+	"Scale":                                scaleGo,
+	"Sigmoid":                              sigmoid32Go,
+	"SigmoidInPlace":                       sigmoid32Go,
+	"Softmax":                              expSum32Go,
+	"SoftmaxRows":                          expSum32Go,
+	"Sqrt":                                 sqrt32Go,
+	"Sub":                                  subGo,
+	"SubFromScalar":                        subFromScalarGo,
+	"Sum":                                  sumGo,
+	"SumOfSquares":                         dotProductGo,
+	"Tanh":                                 tanh32Go,
+	"TanhInPlace":                          tanh32Go,
+	"Variance":                             variance32Go,
+	"WeightedSum":                          dotProductGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "f32", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
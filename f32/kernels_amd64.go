@@ -0,0 +1,284 @@
+//go:build amd64
+
+package f32
+
+import (
+	"github.com/tphakala/simd/cpu"
+	"github.com/tphakala/simd/internal/dispatch"
+)
+
+// kernelSuffixes names the tier of each kernel initAVX512, initAVX and initSSE
+// install in the function pointers. The AVX tier requires FMA as well, and the
+// kernels it shares with AVX-512 (maxAbsAVX, varianceAVX, roundAVX, ...) report
+// it accordingly.
+var kernelSuffixes = []dispatch.Suffix{
+	{Suffix: "AVX512", Tier: dispatch.AVX512},
+	{Suffix: "AVX", Tier: dispatch.AVXFMA},
+	{Suffix: "SSE", Tier: dispatch.SSE2},
+}
+
+// kernelPointer returns the function pointer op dispatches through, or nil for
+// the operations dispatched on feature flags directly.
+func kernelPointer(op string) any {
+	switch op {
+	case "DotProduct", "DotProductUnsafe", "SumOfSquares", "WeightedSum", "ConvolveValid", "ConvolveValidMulti":
+		return dotProductImpl
+	case "Add", "AccumulateAdd":
+		return addImpl
+	case "Sub":
+		return subImpl
+	case "Mul":
+		return mulImpl
+	case "Div":
+		return divImpl
+	case "Scale":
+		return scaleImpl
+	case "AddScalar":
+		return addScalarImpl
+	case "Sum":
+		return sumImpl
+	case "Min":
+		return minImpl
+	case "Max":
+		return maxImpl
+	case "MaxAbs":
+		return maxAbsImpl
+	case "Abs":
+		return absImpl
+	case "Neg", "SubFromScalar":
+		return negImpl
+	case "Sqrt":
+		return sqrtImpl
+	case "Reciprocal":
+		return reciprocalImpl
+	case "Round":
+		return roundImpl
+	case "FMA":
+		return fmaImpl
+	case "Clamp":
+		return clampImpl
+	case "Variance":
+		return varianceImpl
+	case "EuclideanDistance":
+		return euclideanDistanceImpl
+	case "MinIdx":
+		return minIdxImpl
+	case "MaxIdx":
+		return maxIdxImpl
+	case "AddScaled":
+		return addScaledImpl
+	case "ConvolveDecimate":
+		return convolveDecimateImpl
+	case "ConvolveValidMaxAbs", "ConvolveValidMaxAbsMulti":
+		return convolveValidMaxAbsImpl
+	case "Interleave2":
+		return interleave2Impl
+	case "Deinterleave2":
+		return deinterleave2Impl
+	}
+	return nil
+}
+
+// kernelBinding reports the kernel op is bound to, from the same function
+// pointers and cpu.X86 flags its dispatcher reads.
+func kernelBinding(op string) dispatch.Binding {
+	if fn := kernelPointer(op); fn != nil {
+		return dispatch.Bound(fn, kernelSuffixes)
+	}
+	if b := complexBinding(op); b.Func != "" {
+		return b
+	}
+	if b := activationBinding(op); b.Func != "" {
+		return b
+	}
+	if b := conversionBinding(op); b.Func != "" {
+		return b
+	}
+	x := &cpu.X86
+	switch op {
+	case "CopySign":
+		return sseOrAVX(x.AVX, copySignAVX, x.SSE2, copySignSSE)
+	case "AbsPow34":
+		return sseOrAVX(x.AVX, absPow34AVX, x.SSE2, absPow34SSE)
+	case "DotProductBatch", "DotProductIndexed", "DotProductStrided":
+		if x.AVX512F && x.AVX512VL {
+			return dispatch.Bind(dispatch.AVX512, dotProduct4AVX512)
+		}
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, dotProduct4AVX)
+		}
+	case "GEMM":
+		if x.AVX512F && x.AVX512VL {
+			return dispatch.Bind(dispatch.AVX512, gemmKernel6x32AVX512)
+		}
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, gemmKernel6x16AVX)
+		}
+	case "L2SquaredIndexed", "L2SquaredStrided", "CosineIndexed", "CosineStrided":
+		if x.AVX512F && x.AVX512VL {
+			return dispatch.Bind(dispatch.AVX512, dotNorm4AVX512)
+		}
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, dotNorm4AVX)
+		}
+	case "InterleaveN":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, interleave3AVX)
+		}
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, interleave4AVX)
+		}
+	case "DeinterleaveN":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, deinterleave3AVX)
+		}
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, deinterleave4AVX)
+		}
+	case "MinIdxOfSumRows":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, minIdxOfSumRows8AVX2)
+		}
+	case "CubicInterpDot", "CubicInterpDotUnsafe":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, cubicInterpDotAVX)
+		}
+	case "BiquadCascade.Process":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, biquadAVX)
+		}
+	}
+	return dispatch.Binding{} // below every kernel tier, or CumulativeSum (scalar by design)
+}
+
+// complexBinding reports the split-complex arithmetic, FFT butterfly and real-FFT kernels.
+func complexBinding(op string) dispatch.Binding {
+	x := &cpu.X86
+	switch op {
+	case "MulComplex":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, mulComplexAVX)
+		}
+	case "MulConjComplex":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, mulConjComplexAVX)
+		}
+	case "AbsSqComplex":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, absSqComplexAVX)
+		}
+	case "ButterflyComplex":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, butterflyComplexAVX)
+		}
+	case "ButterflyComplexStage":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, butterflyComplexStageAVX)
+		}
+	case "ButterflyComplexStage4":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, butterflyComplexStage4AVX)
+		}
+	case "RealFFTUnpack":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, realFFTUnpackAVX)
+		}
+	case "RealFFTPower":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, realFFTPowerAVX)
+		}
+	}
+	return dispatch.Binding{}
+}
+
+// activationBinding reports the activation and transcendental kernels.
+func activationBinding(op string) dispatch.Binding {
+	x := &cpu.X86
+	switch op {
+	case "Sigmoid", "SigmoidInPlace":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, sigmoidAVX)
+		}
+	case "Tanh", "TanhInPlace":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, tanhAVX)
+		}
+	case "Exp", "ExpInPlace":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, expAVX)
+		}
+	case "Softmax", "SoftmaxRows", "LogSoftmax", "LogSoftmaxRows", "LogSumExp", "LogSumExpRows":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, expSumAVX)
+		}
+	case "ReLU", "ReLUInPlace":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, reluAVX)
+		}
+	case "ClampScale":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, clampScaleAVX)
+		}
+	case "Log", "LogInPlace", "Log2", "Log10":
+		if x.AVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, logAVX)
+		}
+	case "Pow", "PowInPlace":
+		if x.AVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, powAVX)
+		}
+	case "PowElem":
+		if x.AVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, powElemAVX)
+		}
+	}
+	return dispatch.Binding{}
+}
+
+// conversionBinding reports the int/float conversion kernels and the AVX-only shuffles.
+func conversionBinding(op string) dispatch.Binding {
+	x := &cpu.X86
+	switch op {
+	case "Int32ToFloat32Scale", "Int32ToFloat32ScaleUnsafe":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, int32ToFloat32ScaleAVX)
+		}
+	case "Int32ToFloat32ScaleAdd":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, int32ToFloat32ScaleAddAVX)
+		}
+	case "Int16ToFloat32Scale", "Int16ToFloat32ScaleUnsafe":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, int16ToFloat32ScaleAVX)
+		}
+	case "Float32ToInt16Scale", "Float32ToInt16ScaleUnsafe":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, float32ToInt16ScaleAVX)
+		}
+	case "Float32ToInt32ScaleClamp", "Float32ToInt32ScaleClampUnsafe":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, float32ToInt32ScaleClampAVX)
+		}
+	case "Float32ToInt32ScaleClampSigned", "Float32ToInt32ScaleClampSignedUnsafe":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, float32ToInt32ScaleClampSignedAVX)
+		}
+	case "Reverse":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, reverseAVX)
+		}
+	case "AddSub":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, addSubAVX)
+		}
+	}
+	return dispatch.Binding{}
+}
+
+// sseOrAVX reports the two-tier AVX > SSE2 dispatch of the direct-call kernels.
+func sseOrAVX(avx bool, avxFn any, sse2 bool, sseFn any) dispatch.Binding {
+	if avx {
+		return dispatch.Bind(dispatch.AVX, avxFn)
+	}
+	return dispatch.When(sse2, dispatch.SSE2, sseFn)
+}
@@ -0,0 +1,135 @@
+//go:build arm64
+
+package f32
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation with a NEON path to its kernel. MinIdx,
+// MaxIdx and CumulativeSum are scalar by design and absent.
+var neonKernels = map[string]any{
+	"DotProduct":                           dotProductNEON,
+	"DotProductUnsafe":                     dotProductNEON,
+	"SumOfSquares":                         dotProductNEON,
+	"WeightedSum":                          dotProductNEON,
+	"ConvolveValid":                        dotProductNEON,
+	"ConvolveValidMulti":                   dotProductNEON,
+	"DotProductBatch":                      dotProduct4NEON,
+	"DotProductIndexed":                    dotProduct4NEON,
+	"DotProductStrided":                    dotProduct4NEON,
+	"GEMM":                                 gemmKernel8x8NEON,
+	"L2SquaredIndexed":                     dotNorm4NEON,
+	"L2SquaredStrided":                     dotNorm4NEON,
+	"CosineIndexed":                        dotNorm4NEON,
+	"CosineStrided":                        dotNorm4NEON,
+	"Add":                                  addNEON,
+	"AccumulateAdd":                        addNEON,
+	"Sub":                                  subNEON,
+	"Mul":                                  mulNEON,
+	"Div":                                  divNEON,
+	"Scale":                                scaleNEON,
+	"AddScalar":                            addScalarNEON,
+	"AddScaled":                            addScaledNEON,
+	"FMA":                                  fmaNEON,
+	"Sum":                                  sumNEON,
+	"Min":                                  minNEON,
+	"Max":                                  maxNEON,
+	"MaxAbs":                               maxAbsNEON,
+	"Abs":                                  absNEON,
+	"Neg":                                  negNEON,
+	"SubFromScalar":                        negNEON,
+	"CopySign":                             copySignNEON,
+	"Clamp":                                clampNEON,
+	"Sqrt":                                 sqrtNEON,
+	"AbsPow34":                             absPow34NEON,
+	"Round":                                roundNEON,
+	"Reciprocal":                           reciprocalNEON,
+	"Variance":                             varianceNEON32,
+	"EuclideanDistance":                    euclideanDistanceNEON32,
+	"MinIdxOfSumRows":                      minIdxOfSumRows4NEON,
+	"ConvolveDecimate":                     convolveDecimateNEON,
+	"ConvolveValidMaxAbs":                  convolveValidMaxAbsNEON,
+	"ConvolveValidMaxAbsMulti":             convolveValidMaxAbsNEON,
+	"Interleave2":                          interleave2NEON,
+	"Deinterleave2":                        deinterleave2NEON,
+	"InterleaveN":                          interleave4NEON,
+	"DeinterleaveN":                        deinterleave4NEON,
+	"CubicInterpDot":                       cubicInterpDotNEON,
+	"CubicInterpDotUnsafe":                 cubicInterpDotNEON,
+	"Sigmoid":                              sigmoidNEON,
+	"SigmoidInPlace":                       sigmoidNEON,
+	"ReLU":                                 reluNEON,
+	"ReLUInPlace":                          reluNEON,
+	"ClampScale":                           clampScaleNEON,
+	"Tanh":                                 tanhNEON,
+	"TanhInPlace":                          tanhNEON,
+	"Exp":                                  expNEON,
+	"ExpInPlace":                           expNEON,
+	"LogSoftmax":                           expSumNEON,
+	"LogSoftmaxRows":                       expSumNEON,
+	"LogSumExp":                            expSumNEON,
+	"LogSumExpRows":                        expSumNEON,
+	"Softmax":                              expSumNEON,
+	"SoftmaxRows":                          expSumNEON,
+	"Log":                                  logNEON32,
+	"LogInPlace":                           logNEON32,
+	"Log2":                                 logNEON32,
+	"Log10":                                logNEON32,
+	"Pow":                                  powNEON32,
+	"PowInPlace":                           powNEON32,
+	"PowElem":                              powElemNEON32,
+	"Int32ToFloat32Scale":                  int32ToFloat32ScaleNEON,
+	"Int32ToFloat32ScaleUnsafe":            int32ToFloat32ScaleNEON,
+	"Int32ToFloat32ScaleAdd":               int32ToFloat32ScaleAddNEON,
+	"Int16ToFloat32Scale":                  int16ToFloat32ScaleNEON,
+	"Int16ToFloat32ScaleUnsafe":            int16ToFloat32ScaleNEON,
+	"Float32ToInt16Scale":                  float32ToInt16ScaleNEON,
+	"Float32ToInt16ScaleUnsafe":            float32ToInt16ScaleNEON,
+	"Float32ToInt32ScaleClamp":             float32ToInt32ScaleClampNEON,
+	"Float32ToInt32ScaleClampUnsafe":       float32ToInt32ScaleClampNEON,
+	"Float32ToInt32ScaleClampSigned":       float32ToInt32ScaleClampSignedNEON,
+	"Float32ToInt32ScaleClampSignedUnsafe": float32ToInt32ScaleClampSignedNEON,
+	"MulComplex":                           mulComplexNEON,
+	"MulConjComplex":                       mulConjComplexNEON,
+	"AbsSqComplex":                         absSqComplexNEON,
+	"ButterflyComplex":                     butterflyComplexNEON,
+	"ButterflyComplexStage":                butterflyComplexStageNEON,
+	"ButterflyComplexStage4":               butterflyComplexStage4NEON,
+	"RealFFTUnpack":                        realFFTUnpackNEON,
+	"RealFFTPower":                         realFFTPowerNEON,
+	"Reverse":                              reverseNEON,
+	"AddSub":                               addSubNEON,
+	"BiquadCascade.Process":                biquad4NEON,
+}
+
+// sveKernels maps the operations with an SVE path to their kernel, which the
+// SVE tier runs in place of the NEON one. ConvolveDecimate and
+// ConvolveValidMaxAbs run the SVE dot per window instead of a fused kernel.
+var sveKernels = map[string]any{
+	"DotProduct":               dotProductSVE,
+	"DotProductUnsafe":         dotProductSVE,
+	"SumOfSquares":             dotProductSVE,
+	"WeightedSum":              dotProductSVE,
+	"ConvolveValid":            dotProductSVE,
+	"ConvolveValidMulti":       dotProductSVE,
+	"ConvolveDecimate":         dotProductSVE,
+	"ConvolveValidMaxAbs":      dotProductSVE,
+	"ConvolveValidMaxAbsMulti": dotProductSVE,
+	"DotProductBatch":          dotProduct4SVE,
+	"DotProductIndexed":        dotProduct4SVE,
+	"DotProductStrided":        dotProduct4SVE,
+	"Add":                      addSVE,
+	"AccumulateAdd":            addSVE,
+	"Mul":                      mulSVE,
+	"FMA":                      fmaSVE,
+	"Sum":                      sumSVE,
+}
+
+// kernelBinding reports the kernel op is bound to: its SVE kernel when the
+// host has SVE, else its NEON kernel when the host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	if fn, ok := sveKernels[op]; ok && hasSVE {
+		return dispatch.Bind(dispatch.SVE, fn)
+	}
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
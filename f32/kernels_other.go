@@ -0,0 +1,9 @@
+//go:build !amd64 && !arm64
+
+package f32
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelBinding reports the Go reference for every operation: there are no
+// kernels on this architecture.
+func kernelBinding(string) dispatch.Binding { return dispatch.Binding{} }
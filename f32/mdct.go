@@ -0,0 +1,267 @@
+package f32
+
+import (
+	"errors"
+	"math"
+)
+
+// This file adds the modified discrete cosine transform the transform codecs
+// (Opus CELT, Vorbis, AAC) are built on: an MDCTPlan mapping 2N windowed samples
+// to N coefficients and back, a streaming TDAC (time-domain aliasing
+// cancellation) overlap-add, and the sine, Vorbis and Kaiser-Bessel-derived
+// windows those codecs use.
+//
+// The transform folds the 2N windowed samples into N (the TDAC rotation), then
+// computes that sequence's DCT-IV with an N/2-point complex FFT between a pre-
+// and a post-twiddle: both twiddle passes are MulComplex and the FFT is the
+// resident FFTPlan, so a power-of-two half runs on the ButterflyComplexStage4
+// kernels. The inverse is the same DCT-IV (it is its own inverse up to 2/N)
+// followed by the unfold.
+
+// ErrMDCTConfig is returned by NewMDCTPlan when n is not even or is less than 2,
+// or when a non-nil window is not 2n samples long.
+var ErrMDCTConfig = errors.New("f32: MDCT size must be even and >= 2 with a 2*size window")
+
+// MDCTPlan is a reusable MDCT of size n: Forward maps a 2n-sample block to n
+// coefficients,
+//
+//	X[k] = sum_{t=0}^{2n-1} w[t]*x[t] * cos(pi/n * (t + 1/2 + n/2) * (k + 1/2))
+//
+// and Inverse maps n coefficients back to a 2n-sample windowed block,
+//
+//	y[t] = 2*w[t]/n * sum_{k=0}^{n-1} X[k] * cos(pi/n * (t + 1/2 + n/2) * (k + 1/2))
+//
+// so that overlap-adding consecutive inverse blocks at hop n cancels the time
+// aliasing and reconstructs the input exactly whenever the window satisfies the
+// Princen-Bradley condition w[t]^2 + w[t+n]^2 = 1 (SineWindow, VorbisWindow and
+// KBDWindow all do). Build one with NewMDCTPlan and reuse it to stay
+// allocation-free.
+//
+// A plan holds transform scratch and the Synthesize overlap, so its methods are
+// NOT safe for concurrent use on the same plan; use one plan per stream.
+type MDCTPlan struct {
+	n, half int
+
+	fft *FFTPlan // size-half complex FFT core
+
+	// DCT-IV twiddles: pre exp(-i*pi*(4j+1)/(4n)) and post exp(-i*pi*k/n) for
+	// j, k in [0, half).
+	preRe, preIm   []float32
+	postRe, postIm []float32
+
+	window []float32 // 2n analysis/synthesis window, nil for none
+
+	u      []float32 // folded block (n)
+	re, im []float32 // FFT scratch (half)
+	block  []float32 // windowed input / Synthesize's inverse block (2n)
+
+	overlap []float32 // second half of the previous Synthesize block (n)
+}
+
+// NewMDCTPlan builds a reusable MDCT plan producing n coefficients per 2n-sample
+// block. window, if non-nil, must hold 2n samples; it is copied, and applied both
+// before Forward and after Inverse. A nil window leaves both transforms
+// unwindowed, for codecs that window (or use asymmetric low-overlap windows)
+// themselves. n must be even and at least 2; otherwise, or on a window of the
+// wrong length, ErrMDCTConfig is returned.
+func NewMDCTPlan(n int, window []float32) (*MDCTPlan, error) {
+	if n < 2 || n%2 != 0 || (window != nil && len(window) != 2*n) {
+		return nil, ErrMDCTConfig
+	}
+	half := n >> 1
+	fft, err := NewFFTPlan(half)
+	if err != nil {
+		return nil, err
+	}
+	p := &MDCTPlan{
+		n:       n,
+		half:    half,
+		fft:     fft,
+		preRe:   make([]float32, half),
+		preIm:   make([]float32, half),
+		postRe:  make([]float32, half),
+		postIm:  make([]float32, half),
+		u:       make([]float32, n),
+		re:      make([]float32, half),
+		im:      make([]float32, half),
+		block:   make([]float32, 2*n),
+		overlap: make([]float32, n),
+	}
+	if window != nil {
+		p.window = append([]float32(nil), window...)
+	}
+	for j := range half {
+		s, c := math.Sincos(math.Pi * float64(4*j+1) / float64(4*n))
+		p.preRe[j], p.preIm[j] = float32(c), float32(-s)
+		s, c = math.Sincos(math.Pi * float64(j) / float64(n))
+		p.postRe[j], p.postIm[j] = float32(c), float32(-s)
+	}
+	return p, nil
+}
+
+// Len returns the number of coefficients per block, n (the hop); blocks are 2n
+// samples.
+func (p *MDCTPlan) Len() int { return p.n }
+
+// Forward computes the MDCT of the 2n-sample block src into the n coefficients
+// dst, applying the plan's window first. It reads src[:2n] and writes dst[:n],
+// and is a no-op when either slice is shorter. dst must not overlap src.
+// Allocation-free.
+func (p *MDCTPlan) Forward(dst, src []float32) {
+	n, h := p.n, p.half
+	if len(dst) < n || len(src) < 2*n {
+		return
+	}
+	x := src[:2*n]
+	if p.window != nil {
+		Mul(p.block, x, p.window)
+		x = p.block
+	}
+	// Fold the quarters (a, b, c, d) into (-c_r - d, a - b_r).
+	u := p.u
+	for t := range h {
+		u[t] = -x[3*h-1-t] - x[3*h+t]
+		u[h+t] = x[t] - x[n-1-t]
+	}
+	p.dct4(dst, u, 1)
+}
+
+// Inverse computes the windowed IMDCT of the n coefficients src into the 2n
+// samples dst, including the 2/n factor, so overlap-adding consecutive blocks at
+// hop n reconstructs the signal (see MDCTPlan). It reads src[:n] and writes
+// dst[:2n], and is a no-op when either slice is shorter. dst must not overlap
+// src. Allocation-free.
+func (p *MDCTPlan) Inverse(dst, src []float32) {
+	n, h := p.n, p.half
+	if len(dst) < 2*n || len(src) < n {
+		return
+	}
+	u := p.u
+	p.dct4(u, src, imdctGain/float32(n))
+	// Unfold: (q, -q_r, -p_r, -p) from u = (p, q).
+	for t := range h {
+		dst[t] = u[h+t]
+		dst[h+t] = -u[n-1-t]
+		dst[n+t] = -u[h-1-t]
+		dst[3*h+t] = -u[t]
+	}
+	if p.window != nil {
+		Mul(dst[:2*n], dst[:2*n], p.window)
+	}
+}
+
+// Synthesize is the streaming TDAC overlap-add: it runs Inverse on the n
+// coefficients src, adds the first half of the block to the second half of the
+// previous block, writes the n finished samples to dst, and keeps the new second
+// half for the next call. When the calls are fed the Forward coefficients of
+// consecutive 2n-sample blocks x[f*n : f*n+2n], call f writes x[f*n : f*n+n],
+// exact from the second call on (the first has no preceding block to cancel its
+// aliasing). Length handling follows Inverse with dst n samples long.
+// Allocation-free.
+func (p *MDCTPlan) Synthesize(dst, src []float32) {
+	n := p.n
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	p.Inverse(p.block, src)
+	Add(dst[:n], p.overlap, p.block[:n])
+	copy(p.overlap, p.block[n:])
+}
+
+// Reset clears the Synthesize overlap, so the next call starts a new stream.
+func (p *MDCTPlan) Reset() { clear(p.overlap) }
+
+// dct4 writes scale times the DCT-IV of u (n values) into dst:
+//
+//	dst[k] = scale * sum_{t=0}^{n-1} u[t] * cos(pi/n * (t + 1/2) * (k + 1/2))
+//
+// via z[j] = (u[2j] + i*u[n-1-2j]) * pre[j], Z = FFT(z), Y[k] = Z[k] * post[k],
+// dst[2k] = Re(Y[k]) and dst[n-1-2k] = -Im(Y[k]).
+func (p *MDCTPlan) dct4(dst, u []float32, scale float32) {
+	n, h := p.n, p.half
+	re, im := p.re, p.im
+	for j := range h {
+		re[j] = u[2*j]
+		im[j] = u[n-1-2*j]
+	}
+	MulComplex(re, im, re, im, p.preRe, p.preIm)
+	p.fft.transform(re, im)
+	MulComplex(re, im, re, im, p.postRe, p.postIm)
+	ns := -scale
+	for k := range h {
+		dst[2*k] = scale * re[k]
+		dst[n-1-2*k] = ns * im[k]
+	}
+}
+
+// imdctGain is the 2 in the IMDCT's 2/n normalization, which makes windowed
+// TDAC overlap-add reconstruct the input exactly.
+const imdctGain = 2
+
+// mdctPhase is the half-sample offset of the MDCT window and basis phases.
+const mdctPhase = 0.5
+
+// SineWindow fills dst with the MDCT sine window w[t] = sin(pi*(t + 1/2)/L),
+// L = len(dst), the long-block window of MP3 and AAC. For even L it
+// satisfies the Princen-Bradley condition at hop L/2.
+func SineWindow(dst []float32) {
+	l := float64(len(dst))
+	for t := range dst {
+		dst[t] = float32(math.Sin(math.Pi * (float64(t) + mdctPhase) / l))
+	}
+}
+
+// VorbisWindow fills dst with the Vorbis power-complementary window
+// w[t] = sin(pi/2 * sin^2(pi*(t + 1/2)/L)), L = len(dst), also the shape of the
+// Opus CELT overlap. For even L it
+// satisfies the Princen-Bradley condition at hop L/2.
+func VorbisWindow(dst []float32) {
+	l := float64(len(dst))
+	for t := range dst {
+		s := math.Sin(math.Pi * (float64(t) + mdctPhase) / l)
+		dst[t] = float32(math.Sin(math.Pi / 2 * s * s))
+	}
+}
+
+// KBDWindow fills dst with the Kaiser-Bessel-derived window of AAC and AC-3 for
+// shape parameter alpha (AAC uses 4 for long blocks and 6 for short ones): with
+// M = L/2 and a Kaiser kernel v[j] = I0(pi*alpha*sqrt(1 - (2j/M - 1)^2)),
+// j in [0, M],
+//
+//	w[t] = sqrt(sum_{j<=t} v[j] / sum_{j<=M} v[j]),  w[L-1-t] = w[t],  t < M
+//
+// It satisfies the Princen-Bradley condition at hop M. L = len(dst) must be even;
+// an odd-length dst is left untouched.
+func KBDWindow(dst []float32, alpha float64) {
+	l := len(dst)
+	if l%2 != 0 {
+		return
+	}
+	m := l / 2
+	kernel := func(j int) float64 {
+		r := 2*float64(j)/float64(m) - 1
+		return besselI0(math.Pi * alpha * math.Sqrt(1-r*r))
+	}
+	var total float64
+	for j := range m + 1 {
+		total += kernel(j)
+	}
+	var acc float64
+	for t := range m {
+		acc += kernel(t)
+		w := float32(math.Sqrt(acc / total))
+		dst[t], dst[l-1-t] = w, w
+	}
+}
+
+// besselI0 is the zeroth-order modified Bessel function of the first kind,
+// I0(x) = sum_k ((x/2)^k / k!)^2, summed until the terms stop contributing.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	q := x * x / 4
+	for k := 1; term > sum*1e-17; k++ {
+		term *= q / float64(k*k)
+		sum += term
+	}
+	return sum
+}
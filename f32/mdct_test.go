@@ -0,0 +1,240 @@
+package f32
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// mdctSizesF32 covers power-of-two, mixed-radix (CELT's 120/240/480/960) and
+// Bluestein halves, plus the smallest plan.
+var mdctSizesF32 = []int{2, 4, 8, 16, 64, 256, 120, 480, 960, 194}
+
+// mdctRefF32 evaluates the windowed MDCT definition directly in float64.
+func mdctRefF32(src, w []float32, n int) []float64 {
+	out := make([]float64, n)
+	for k := range n {
+		var sum float64
+		for t := range 2 * n {
+			x := float64(src[t])
+			if w != nil {
+				x *= float64(w[t])
+			}
+			sum += x * math.Cos(math.Pi/float64(n)*(float64(t)+0.5+float64(n)/2)*(float64(k)+0.5))
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// imdctRefF32 evaluates the windowed, 2/n-normalized IMDCT definition directly in
+// float64.
+func imdctRefF32(src, w []float32, n int) []float64 {
+	out := make([]float64, 2*n)
+	for t := range 2 * n {
+		var sum float64
+		for k := range n {
+			sum += float64(src[k]) * math.Cos(math.Pi/float64(n)*(float64(t)+0.5+float64(n)/2)*(float64(k)+0.5))
+		}
+		sum *= 2 / float64(n)
+		if w != nil {
+			sum *= float64(w[t])
+		}
+		out[t] = sum
+	}
+	return out
+}
+
+func TestNewMDCTPlanErrorsF32(t *testing.T) {
+	for _, c := range []struct {
+		n int
+		w []float32
+	}{{0, nil}, {1, nil}, {3, nil}, {-2, nil}, {8, make([]float32, 8)}, {8, make([]float32, 17)}} {
+		if _, err := NewMDCTPlan(c.n, c.w); !errors.Is(err, ErrMDCTConfig) {
+			t.Errorf("NewMDCTPlan(%d, len %d) error = %v, want ErrMDCTConfig", c.n, len(c.w), err)
+		}
+	}
+	p, err := NewMDCTPlan(960, make([]float32, 1920))
+	if err != nil || p.Len() != 960 {
+		t.Fatalf("NewMDCTPlan(960) = %v, %v", p, err)
+	}
+}
+
+// TestMDCTAgainstRefF32 checks Forward and Inverse against the direct float64
+// definitions, with and without a window.
+func TestMDCTAgainstRefF32(t *testing.T) {
+	for _, n := range mdctSizesF32 {
+		win := make([]float32, 2*n)
+		SineWindow(win)
+		for _, w := range [][]float32{nil, win} {
+			p, err := NewMDCTPlan(n, w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src := testSignalF32(2 * n)
+			var scale float64
+			for _, v := range src {
+				scale += math.Abs(float64(v))
+			}
+			want := mdctRefF32(src, w, n)
+			got := make([]float32, n)
+			p.Forward(got, src)
+			tol := 2 * stftTolF32(n, scale)
+			for k := range n {
+				if d := math.Abs(float64(got[k]) - want[k]); d > tol {
+					t.Fatalf("n=%d windowed=%v k=%d: Forward %g want %g (|diff|=%g tol=%g)", n, w != nil, k, got[k], want[k], d, tol)
+				}
+			}
+
+			coef := testSignalF32(n)
+			wantInv := imdctRefF32(coef, w, n)
+			gotInv := make([]float32, 2*n)
+			p.Inverse(gotInv, coef)
+			for i := range 2 * n {
+				if d := math.Abs(float64(gotInv[i]) - wantInv[i]); d > 2*tol/float64(n) {
+					t.Fatalf("n=%d windowed=%v t=%d: Inverse %g want %g", n, w != nil, i, gotInv[i], wantInv[i])
+				}
+			}
+		}
+	}
+}
+
+// TestMDCTWindowsF32 checks the Princen-Bradley condition and symmetry of every
+// window, and the closed forms of the sine window and the KBD endpoints.
+func TestMDCTWindowsF32(t *testing.T) {
+	for _, l := range []int{4, 16, 256, 1920} {
+		sine, vorbis, kbd4, kbd6 := make([]float32, l), make([]float32, l), make([]float32, l), make([]float32, l)
+		SineWindow(sine)
+		VorbisWindow(vorbis)
+		KBDWindow(kbd4, 4)
+		KBDWindow(kbd6, 6)
+		for name, w := range map[string][]float32{"sine": sine, "vorbis": vorbis, "kbd4": kbd4, "kbd6": kbd6} {
+			h := l / 2
+			for i := range h {
+				pb := float64(w[i])*float64(w[i]) + float64(w[i+h])*float64(w[i+h])
+				if math.Abs(pb-1) > 1e-6 {
+					t.Fatalf("%s L=%d: w[%d]^2 + w[%d]^2 = %g, want 1", name, l, i, i+h, pb)
+				}
+				if w[i] != w[l-1-i] {
+					t.Fatalf("%s L=%d: not symmetric at %d", name, l, i)
+				}
+			}
+		}
+		if got, want := float64(sine[0]), math.Sin(math.Pi/(2*float64(l))); math.Abs(got-want) > 1e-7 {
+			t.Errorf("sine L=%d: w[0] = %g want %g", l, got, want)
+		}
+		if kbd6[0] >= kbd4[0] {
+			t.Errorf("L=%d: larger alpha should taper harder: kbd6[0]=%g kbd4[0]=%g", l, kbd6[0], kbd4[0])
+		}
+	}
+	odd := []float32{7, 7, 7}
+	KBDWindow(odd, 4)
+	if odd[0] != 7 {
+		t.Errorf("KBDWindow modified an odd-length dst")
+	}
+	if got := besselI0(1); math.Abs(got-1.2660658777520082) > 1e-15 {
+		t.Errorf("besselI0(1) = %.17g", got)
+	}
+}
+
+// TestMDCTTDACF32 streams a signal through Forward and Synthesize block by block
+// and checks perfect reconstruction for every window.
+func TestMDCTTDACF32(t *testing.T) {
+	for _, n := range []int{16, 120, 256, 194} {
+		windows := map[string]func([]float32){
+			"sine":   SineWindow,
+			"vorbis": VorbisWindow,
+			"kbd":    func(w []float32) { KBDWindow(w, 4) },
+		}
+		for name, fill := range windows {
+			win := make([]float32, 2*n)
+			fill(win)
+			p, _ := NewMDCTPlan(n, win)
+			const blocks = 8
+			sig := testSignalF32((blocks + 1) * n)
+			coef := make([]float32, n)
+			out := make([]float32, n)
+			for f := range blocks {
+				p.Forward(coef, sig[f*n:f*n+2*n])
+				p.Synthesize(out, coef)
+				if f == 0 {
+					continue
+				}
+				for i := range n {
+					if d := math.Abs(float64(out[i] - sig[f*n+i])); d > 1e-5 {
+						t.Fatalf("%s n=%d block %d: out[%d] = %g want %g", name, n, f, i, out[i], sig[f*n+i])
+					}
+				}
+			}
+
+			// After Reset the first block again lacks its predecessor: the output
+			// is exactly the fade-in half of a fresh Inverse.
+			p.Reset()
+			block := make([]float32, 2*n)
+			p.Forward(coef, sig[:2*n])
+			p.Inverse(block, coef)
+			p.Synthesize(out, coef)
+			for i := range n {
+				if out[i] != block[i] {
+					t.Fatalf("%s n=%d: after Reset out[%d] = %g want %g", name, n, i, out[i], block[i])
+				}
+			}
+		}
+	}
+}
+
+// TestMDCTGuardsF32 checks that short slices are a no-op and that only the
+// documented prefix is touched.
+func TestMDCTGuardsF32(t *testing.T) {
+	p, _ := NewMDCTPlan(4, nil)
+	short := []float32{1, 2, 3}
+	p.Forward(short, testSignalF32(8))
+	p.Forward(make([]float32, 4), testSignalF32(7))
+	p.Synthesize(short, testSignalF32(4))
+	long := make([]float32, 9)
+	long[8] = 42
+	p.Inverse(long[:7], testSignalF32(4))
+	p.Inverse(long, testSignalF32(4))
+	for i, v := range short {
+		if v != float32(i+1) {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+	if long[8] != 42 {
+		t.Fatalf("sample past 2n modified")
+	}
+}
+
+func TestMDCTAllocFreeF32(t *testing.T) {
+	for _, n := range []int{256, 480, 194} {
+		win := make([]float32, 2*n)
+		VorbisWindow(win)
+		p, _ := NewMDCTPlan(n, win)
+		src := testSignalF32(2 * n)
+		coef := make([]float32, n)
+		out := make([]float32, n)
+		if a := testing.AllocsPerRun(5, func() { p.Forward(coef, src) }); a != 0 {
+			t.Errorf("n=%d: Forward allocated %v times per run, want 0", n, a)
+		}
+		if a := testing.AllocsPerRun(5, func() { p.Synthesize(out, coef) }); a != 0 {
+			t.Errorf("n=%d: Synthesize allocated %v times per run, want 0", n, a)
+		}
+	}
+}
+
+func BenchmarkMDCTForward(b *testing.B) {
+	for _, n := range []int{256, 480, 1024} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			win := make([]float32, 2*n)
+			SineWindow(win)
+			p, _ := NewMDCTPlan(n, win)
+			src := testSignalF32(2 * n)
+			dst := make([]float32, n)
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(dst, src)
+			}
+		})
+	}
+}
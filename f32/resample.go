@@ -0,0 +1,304 @@
+package f32
+
+import (
+	"errors"
+	"math"
+)
+
+// Rational sample-rate conversion by L/M as a polyphase FIR: the input is
+// (conceptually) upsampled by L, lowpass filtered at the lower of the two Nyquist
+// rates, and downsampled by M, so each output is one dot product of the input
+// history with one of the L phases of the prototype filter. When the phase table
+// is small it is stored exactly and each output is a DotProduct; otherwise (an L
+// in the thousands, as for 48000 -> 44099) the prototype is sampled at a fixed
+// number of phases with a cubic in the fractional phase between them, and each
+// output is a CubicInterpDot.
+
+// ErrResamplerConfig is returned by NewResampler for a ratio, channel count or
+// filter length below 1, or a negative or non-finite beta.
+var ErrResamplerConfig = errors.New("f32: resampler needs up, down, channels and zeroCrossings >= 1 and a finite beta >= 0")
+
+const (
+	// resamplerMaxTable is the largest exact phase table (L * taps per phase);
+	// larger designs switch to the interpolated table.
+	resamplerMaxTable = 1 << 16
+
+	// resamplerTablePhases is the number of cubic segments per unit of phase in
+	// the interpolated table.
+	resamplerTablePhases = 128
+
+	// resamplerChunk is the number of input frames deinterleaved per pass.
+	resamplerChunk = 1024
+)
+
+// cubicThirds maps samples of a function at x = 0, 1/3, 2/3 and 1 to the
+// coefficients (a, b, c, d) of the cubic a + x*(b + x*(c + x*d)) through them.
+var cubicThirds = [4][4]float64{
+	{1, 0, 0, 0},
+	{-5.5, 9, -4.5, 1},
+	{9, -22.5, 18, -4.5},
+	{-4.5, 13.5, -13.5, 4.5},
+}
+
+// kaiserSinc is the Kaiser-windowed sinc lowpass of KaiserSinc as a function of
+// the continuous offset u from the filter center, before normalization: half is
+// (numTaps-1)/2 and i0Beta is I0(beta). It is zero outside the window.
+func kaiserSinc(u, cutoff, beta, half, i0Beta float64) float64 {
+	v := cutoff
+	if x := math.Pi * cutoff * u; x != 0 {
+		v = math.Sin(x) / (math.Pi * u)
+	}
+	if half == 0 {
+		return v
+	}
+	r := u / half
+	if r*r > 1 {
+		return 0
+	}
+	return v * besselI0(beta*math.Sqrt(1-r*r)) / i0Beta
+}
+
+// kaiserSincSum is the DC gain of the numTaps-tap KaiserSinc design before
+// normalization.
+func kaiserSincSum(numTaps int, cutoff, beta float64) float64 {
+	half, i0Beta := float64(numTaps-1)/2, besselI0(beta)
+	var sum float64
+	for j := range numTaps {
+		sum += kaiserSinc(float64(j)-half, cutoff, beta, half, i0Beta)
+	}
+	return sum
+}
+
+// KaiserSinc fills dst with a len(dst)-tap linear-phase lowpass FIR, the
+// Kaiser-windowed sinc of scipy.signal.firwin(len(dst), cutoff,
+// window=("kaiser", beta)): cutoff is a fraction of the Nyquist rate in (0, 1],
+// and the taps are scaled to unit DC gain. KaiserBeta picks beta for a stopband
+// attenuation. The design runs in float64 and is rounded once.
+func KaiserSinc(dst []float32, cutoff, beta float64) {
+	n := len(dst)
+	if n == 0 {
+		return
+	}
+	half, i0Beta := float64(n-1)/2, besselI0(beta)
+	scale := 1 / kaiserSincSum(n, cutoff, beta)
+	for j := range dst {
+		dst[j] = float32(scale * kaiserSinc(float64(j)-half, cutoff, beta, half, i0Beta))
+	}
+}
+
+// Kaiser's empirical fit of the window shape to the stopband attenuation.
+const (
+	kaiserHighA     = 50
+	kaiserLowA      = 21
+	kaiserHighSlope = 0.1102
+	kaiserHighShift = 8.7
+	kaiserLowScale  = 0.5842
+	kaiserLowPow    = 0.4
+	kaiserLowSlope  = 0.07886
+)
+
+// KaiserBeta returns the Kaiser window beta that gives a stopband attenuation of
+// attenuationDB (a positive number of dB), as scipy.signal.kaiser_beta.
+func KaiserBeta(attenuationDB float64) float64 {
+	a := attenuationDB
+	switch {
+	case a > kaiserHighA:
+		return kaiserHighSlope * (a - kaiserHighShift)
+	case a > kaiserLowA:
+		return kaiserLowScale*math.Pow(a-kaiserLowA, kaiserLowPow) + kaiserLowSlope*(a-kaiserLowA)
+	default:
+		return 0
+	}
+}
+
+// Resampler converts interleaved multichannel audio by the rational ratio
+// up/down (say 160/147 for 44.1 kHz -> 48 kHz) with a polyphase Kaiser-windowed
+// sinc filter, carrying its input history across Process calls so a stream
+// converted in blocks matches one call over the whole stream.
+//
+// The output is scipy.signal.upfirdn(h, x, up, down) for the prototype
+// h = up * firwin(2*zeroCrossings*max(up, down) + 1, 1/max(up, down),
+// window=("kaiser", beta)), the filter scipy.signal.resample_poly designs: its
+// passband ends at the lower of the two Nyquist rates, and it spans
+// zeroCrossings sinc lobes on each side of its center. Unlike resample_poly, the
+// output is not shifted to compensate the filter's group delay of
+// zeroCrossings*max(up, down) upsampled samples (that many divided by down output
+// samples); feed zeroCrossings*max(up, down)/up frames of zeros, rounded up,
+// after the last block to flush the tail. Large phase tables are replaced by a
+// cubic interpolation of the same prototype, accurate to well below float32
+// rounding.
+//
+// A resampler holds stream state, so its methods are NOT safe for concurrent use
+// on the same resampler; use one per stream.
+type Resampler struct {
+	up, down int
+	channels int
+	taps     int // prototype taps per phase
+
+	// Exact table: phase p's taps, reversed to run against ascending history,
+	// at phases[p*taps : (p+1)*taps].
+	phases []float32
+
+	// Interpolated table: segment q's cubic coefficients, reversed the same way,
+	// at [q*taps : (q+1)*taps] of each array, for q in [0, resamplerTablePhases).
+	ca, cb, cc, cd []float32
+
+	hist  [][]float32 // per channel: taps-1 history frames, then up to resamplerChunk new ones
+	split [][]float32 // DeinterleaveN destinations into hist
+
+	phase int // upsampled index of the next output, mod up
+	pos   int // input index of the next output's newest sample, relative to hist[c][taps-1]
+}
+
+// NewResampler builds a resampler from up/down (reduced by their greatest common
+// divisor) for channels interleaved channels, with a prototype spanning
+// zeroCrossings lobes per side (16 is transparent for audio; resample_poly uses
+// 10) and Kaiser window parameter beta (resample_poly uses 5; see KaiserBeta).
+// It returns ErrResamplerConfig for parameters out of range.
+func NewResampler(up, down, channels, zeroCrossings int, beta float64) (*Resampler, error) {
+	if up < 1 || down < 1 || channels < 1 || zeroCrossings < 1 || !(beta >= 0) || math.IsInf(beta, 0) {
+		return nil, ErrResamplerConfig
+	}
+	g := resamplerGCD(up, down)
+	up, down = up/g, down/g
+	maxRate := max(up, down)
+	numTaps := 2*zeroCrossings*maxRate + 1
+	taps := (numTaps + up - 1) / up
+	r := &Resampler{
+		up:       up,
+		down:     down,
+		channels: channels,
+		taps:     taps,
+		hist:     make([][]float32, channels),
+		split:    make([][]float32, channels),
+	}
+	for c := range r.hist {
+		r.hist[c] = make([]float32, taps-1+resamplerChunk)
+	}
+
+	cutoff := 1 / float64(maxRate)
+	half, i0Beta := float64(numTaps-1)/2, besselI0(beta)
+	gain := float64(up) / kaiserSincSum(numTaps, cutoff, beta)
+	// coef is the prototype tap of phase phi (in [0, 1], in units of 1/up of an
+	// input sample) at history distance t (0 = newest sample).
+	coef := func(phi float64, t int) float64 {
+		j := (phi + float64(t)) * float64(up)
+		return gain * kaiserSinc(j-half, cutoff, beta, half, i0Beta)
+	}
+
+	if up*taps <= resamplerMaxTable {
+		r.phases = make([]float32, up*taps)
+		for p := range up {
+			for t := range taps {
+				r.phases[p*taps+taps-1-t] = float32(coef(float64(p)/float64(up), t))
+			}
+		}
+		return r, nil
+	}
+	const segs = resamplerTablePhases
+	r.ca, r.cb, r.cc = make([]float32, segs*taps), make([]float32, segs*taps), make([]float32, segs*taps)
+	r.cd = make([]float32, segs*taps)
+	for q := range segs {
+		for t := range taps {
+			var f [4]float64
+			for s := range f {
+				f[s] = coef((float64(q)+float64(s)/3)/segs, t)
+			}
+			var k [4]float64
+			for i := range k {
+				for s := range f {
+					k[i] += cubicThirds[i][s] * f[s]
+				}
+			}
+			at := q*taps + taps - 1 - t
+			r.ca[at], r.cb[at], r.cc[at], r.cd[at] = float32(k[0]), float32(k[1]), float32(k[2]), float32(k[3])
+		}
+	}
+	return r, nil
+}
+
+func resamplerGCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Ratio returns the reduced conversion ratio up/down.
+func (r *Resampler) Ratio() (up, down int) { return r.up, r.down }
+
+// Channels returns the number of interleaved channels per frame.
+func (r *Resampler) Channels() int { return r.channels }
+
+// TapsPerPhase returns the number of prototype taps applied per output sample.
+func (r *Resampler) TapsPerPhase() int { return r.taps }
+
+// MaxOutput returns an upper bound on the frames Process produces from inFrames
+// input frames, ceil(inFrames*up/down); size dst with it to consume every input
+// frame in one call.
+func (r *Resampler) MaxOutput(inFrames int) int {
+	return (inFrames*r.up + r.down - 1) / r.down
+}
+
+// Reset clears the input history and phase, so the next Process starts a new
+// stream.
+func (r *Resampler) Reset() {
+	for _, h := range r.hist {
+		clear(h)
+	}
+	r.phase, r.pos = 0, 0
+}
+
+// Process converts the interleaved frames of src into dst and returns the number
+// of input frames consumed and output frames written. It consumes whole frames
+// of src until it is exhausted or dst has no room for the next output; the
+// unconsumed input (in frames onward) must be passed again in the next call.
+// dst must not overlap src. Allocation-free.
+func (r *Resampler) Process(dst, src []float32) (in, out int) {
+	nc, taps := r.channels, r.taps
+	inFrames, outCap := len(src)/nc, len(dst)/nc
+	h := taps - 1
+	for in < inFrames {
+		avail := min(resamplerChunk, inFrames-in)
+		for c := range nc {
+			r.split[c] = r.hist[c][h : h+avail]
+		}
+		DeinterleaveN(r.split, src[in*nc:(in+avail)*nc])
+
+		for r.pos < avail && out < outCap {
+			frame := dst[out*nc : (out+1)*nc]
+			for c := range frame {
+				frame[c] = r.dot(r.hist[c][r.pos : r.pos+taps])
+			}
+			out++
+			r.phase += r.down
+			r.pos += r.phase / r.up
+			r.phase %= r.up
+		}
+
+		// Keep the taps-1 frames before the next output's window start.
+		used := min(avail, r.pos)
+		for c := range nc {
+			copy(r.hist[c][:h], r.hist[c][used:used+h])
+		}
+		r.pos -= used
+		in += used
+		if used < avail {
+			break
+		}
+	}
+	return in, out
+}
+
+// dot applies the current phase's filter to the window of taps frames.
+func (r *Resampler) dot(window []float32) float32 {
+	taps := r.taps
+	if r.phases != nil {
+		p := r.phase * taps
+		return DotProduct(window, r.phases[p:p+taps])
+	}
+	x := float64(r.phase) * resamplerTablePhases / float64(r.up)
+	q := int(x)
+	s := q * taps
+	return CubicInterpDot(window, r.ca[s:s+taps], r.cb[s:s+taps], r.cc[s:s+taps], r.cd[s:s+taps], float32(x-float64(q)))
+}
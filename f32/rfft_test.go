@@ -0,0 +1,172 @@
+package f32
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// rfftSizesF32 covers the power-of-two, mixed-radix, and Bluestein schedules of
+// the packed half-length FFT.
+var rfftSizesF32 = []int{2, 4, 8, 16, 64, 1024, 6, 12, 400, 480, 14, 194}
+
+func TestNewRealFFTPlanErrorsF32(t *testing.T) {
+	for _, bad := range []int{-2, 0, 1, 3, 401} {
+		if _, err := NewRealFFTPlan(bad); err == nil {
+			t.Errorf("NewRealFFTPlan(%d) = nil error, want ErrRealFFTSize", bad)
+		}
+	}
+	for _, good := range []int{2, 4, 6, 400, 1024} {
+		p, err := NewRealFFTPlan(good)
+		if err != nil {
+			t.Errorf("NewRealFFTPlan(%d) unexpected error: %v", good, err)
+			continue
+		}
+		if p.Len() != good || p.NumBins() != good/2+1 {
+			t.Errorf("NewRealFFTPlan(%d): Len=%d NumBins=%d", good, p.Len(), p.NumBins())
+		}
+	}
+}
+
+// TestRealFFTForwardF32 checks every half-spectrum bin against a direct float64
+// DFT of the real input.
+func TestRealFFTForwardF32(t *testing.T) {
+	for _, n := range rfftSizesF32 {
+		p, err := NewRealFFTPlan(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src := testSignalF32(n)
+		var scale float64
+		for _, v := range src {
+			scale += math.Abs(float64(v))
+		}
+		wantRe, wantIm := dftSplitF32(src, make([]float32, n), -1)
+		dst := make([]complex64, p.NumBins())
+		p.Forward(dst, src)
+		tol := stftTolF32(n, scale)
+		for k := range dst {
+			ctx := fmt.Sprintf("n=%d bin=%d", n, k)
+			cmplxCloseF32(t, ctx, dst[k], complex(wantRe[k], wantIm[k]), tol)
+		}
+		if imag(dst[0]) != 0 || imag(dst[n/2]) != 0 {
+			t.Errorf("n=%d: DC/Nyquist not real: %v %v", n, dst[0], dst[n/2])
+		}
+	}
+}
+
+// TestRealFFTInverseF32 checks Inverse against a direct float64 Hermitian inverse
+// DFT on half-spectra that are not the transform of any real signal, including
+// imaginary parts on DC and Nyquist that must be ignored, and that
+// InverseScaled is Inverse/n.
+func TestRealFFTInverseF32(t *testing.T) {
+	for _, n := range rfftSizesF32 {
+		p, _ := NewRealFFTPlan(n)
+		h := n / 2
+		src := make([]complex64, h+1)
+		for k := range src {
+			x := float64(k)
+			src[k] = complex(float32(math.Sin(0.7*x+0.2)), float32(math.Cos(1.3*x)))
+		}
+		got := make([]float32, n)
+		p.Inverse(got, src)
+		scaled := make([]float32, n)
+		p.InverseScaled(scaled, src)
+		// Every bin has modulus <= sqrt(2) and enters at most twice.
+		tol := stftTolF32(n, 2*math.Sqrt2*float64(h+1))
+		for t0 := range n {
+			want := float64(real(src[0])) + float64(real(src[h]))*math.Cos(math.Pi*float64(t0))
+			for k := 1; k < h; k++ {
+				s, c := math.Sincos(2 * math.Pi * float64(k*t0%n) / float64(n))
+				want += 2 * (float64(real(src[k]))*c - float64(imag(src[k]))*s)
+			}
+			if d := math.Abs(float64(got[t0]) - want); d > tol {
+				t.Fatalf("n=%d t=%d: Inverse=%v want %v (|diff|=%g tol=%g)", n, t0, got[t0], want, d, tol)
+			}
+			if d := math.Abs(float64(scaled[t0]) - want/float64(n)); d > tol/float64(n) {
+				t.Fatalf("n=%d t=%d: InverseScaled=%v want %v", n, t0, scaled[t0], want/float64(n))
+			}
+		}
+	}
+}
+
+// TestRealFFTRoundTripF32 checks InverseScaled(Forward(x)) == x.
+func TestRealFFTRoundTripF32(t *testing.T) {
+	for _, n := range rfftSizesF32 {
+		p, _ := NewRealFFTPlan(n)
+		src := testSignalF32(n)
+		spec := make([]complex64, p.NumBins())
+		p.Forward(spec, src)
+		got := make([]float32, n)
+		p.InverseScaled(got, spec)
+		for i := range n {
+			if d := math.Abs(float64(got[i] - src[i])); d > 1e-5 {
+				t.Fatalf("n=%d: round trip[%d] = %v want %v", n, i, got[i], src[i])
+			}
+		}
+	}
+}
+
+// TestRealFFTGuardsF32 checks that short slices are a no-op and that only the
+// first Len() samples and NumBins() bins are touched.
+func TestRealFFTGuardsF32(t *testing.T) {
+	p, _ := NewRealFFTPlan(8)
+	spec := []complex64{1, 2, 3, 4}
+	p.Forward(spec, testSignalF32(8))
+	p.Forward(make([]complex64, 5), testSignalF32(7))
+	out := []float32{1, 2, 3, 4, 5, 6, 7}
+	p.Inverse(out, make([]complex64, 5))
+	p.InverseScaled(make([]float32, 8), spec)
+	for i, v := range spec {
+		if v != complex(float32(i+1), 0) {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+	for i, v := range out {
+		if v != float32(i+1) {
+			t.Fatalf("short Inverse dst modified at %d", i)
+		}
+	}
+
+	long := make([]complex64, 7)
+	long[6] = 42
+	p.Forward(long, testSignalF32(12))
+	if long[6] != 42 {
+		t.Fatalf("bin past NumBins() modified")
+	}
+	samples := make([]float32, 10)
+	samples[9] = 42
+	p.Inverse(samples, long)
+	if samples[9] != 42 {
+		t.Fatalf("sample past Len() modified")
+	}
+}
+
+func TestRealFFTAllocFreeF32(t *testing.T) {
+	for _, n := range []int{1024, 400, 194} {
+		p, _ := NewRealFFTPlan(n)
+		src := testSignalF32(n)
+		spec := make([]complex64, p.NumBins())
+		out := make([]float32, n)
+		if a := testing.AllocsPerRun(5, func() { p.Forward(spec, src) }); a != 0 {
+			t.Errorf("n=%d: Forward allocated %v times per run, want 0", n, a)
+		}
+		if a := testing.AllocsPerRun(5, func() { p.InverseScaled(out, spec) }); a != 0 {
+			t.Errorf("n=%d: InverseScaled allocated %v times per run, want 0", n, a)
+		}
+	}
+}
+
+func BenchmarkRealFFTForward(b *testing.B) {
+	for _, n := range []int{400, 512, 1024, 4096} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			p, _ := NewRealFFTPlan(n)
+			src := testSignalF32(n)
+			dst := make([]complex64, p.NumBins())
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(dst, src)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package f32
+
+import "math"
+
+// Row-wise softmax family. Each row takes three passes over cache-hot data:
+// Max for the shift that keeps every exponent at or below zero, one fused
+// exp-and-sum kernel (expSum32: the Exp polynomial with the subtraction and
+// the running sum folded in) and a final Scale or AddScalar. LogSoftmax and
+// LogSumExp need only the sum, so they run the fused kernel into a stack chunk
+// and never store the exponentials in dst.
+
+// softmaxChunk is how many exponentials LogSoftmax and LogSumExp compute into
+// a stack buffer per fused kernel call.
+const softmaxChunk = 256
+
+// Softmax writes the softmax of src to dst:
+//
+//	dst[i] = e^(src[i]-m) / sum_j e^(src[j]-m),  m = max_j src[j]
+//
+// for i in [0, n), n = min(len(dst), len(src)). Subtracting the maximum keeps
+// every exponent at or below zero, so the sum cannot overflow and is at least
+// 1. dst may alias src exactly. The exponentials share Exp's polynomial (about
+// 7e-6 relative error on the SIMD paths), so dst sums to 1 within that.
+//
+// A row whose maximum is not finite (all -Inf, or any +Inf) yields NaN in every
+// element; -Inf elements of any other row map to 0. NaN handling otherwise
+// follows [Max] and is architecture-dependent. Uses AVX2 on AMD64 and NEON on
+// ARM64; allocation-free.
+func Softmax(dst, src []float32) {
+	n := min(len(dst), len(src))
+	if n == 0 {
+		return
+	}
+	softmaxRow(dst[:n], src[:n])
+}
+
+// LogSoftmax writes the log of the softmax of src to dst:
+//
+//	dst[i] = (src[i] - m) - log(sum_j e^(src[j]-m)),  m = max_j src[j]
+//
+// for i in [0, n), n = min(len(dst), len(src)), without forming the softmax, so
+// it stays accurate where the softmax itself underflows. dst may alias src
+// exactly. Non-finite rows behave as in [Softmax].
+func LogSoftmax(dst, src []float32) {
+	n := min(len(dst), len(src))
+	if n == 0 {
+		return
+	}
+	logSoftmaxRow(dst[:n], src[:n])
+}
+
+// LogSumExp returns log(sum_i e^a[i]) computed as m + log(sum_i e^(a[i]-m)),
+// m = max_i a[i], so it neither overflows nor underflows for finite inputs.
+// Returns -Inf for an empty slice or one of all -Inf, and +Inf if any element
+// is +Inf. a is read-only; the call allocates nothing.
+func LogSumExp(a []float32) float32 {
+	if len(a) == 0 {
+		return negInf
+	}
+	return logSumExpRow(a)
+}
+
+// SoftmaxRows applies [Softmax] to each row of a row-major matrix: row r is
+// src[r*cols : (r+1)*cols] and its softmax goes to the same range of dst, for
+// r in [0, min(len(dst), len(src))/cols). A trailing partial row is left
+// untouched, as is all of dst when cols <= 0. dst may alias src exactly.
+func SoftmaxRows(dst, src []float32, cols int) {
+	if cols <= 0 {
+		return
+	}
+	rows := min(len(dst), len(src)) / cols
+	for r := range rows {
+		off := r * cols
+		softmaxRow(dst[off:off+cols], src[off:off+cols])
+	}
+}
+
+// LogSoftmaxRows applies [LogSoftmax] to each row of a row-major matrix, with
+// the row layout of [SoftmaxRows].
+func LogSoftmaxRows(dst, src []float32, cols int) {
+	if cols <= 0 {
+		return
+	}
+	rows := min(len(dst), len(src)) / cols
+	for r := range rows {
+		off := r * cols
+		logSoftmaxRow(dst[off:off+cols], src[off:off+cols])
+	}
+}
+
+// LogSumExpRows writes dst[r] = LogSumExp(src[r*cols : (r+1)*cols]) for r in
+// [0, min(len(dst), len(src)/cols)). Nothing is written when cols <= 0.
+func LogSumExpRows(dst, src []float32, cols int) {
+	if cols <= 0 {
+		return
+	}
+	rows := min(len(dst), len(src)/cols)
+	for r := range rows {
+		dst[r] = logSumExpRow(src[r*cols : (r+1)*cols])
+	}
+}
+
+// softmaxRow is Softmax on a non-empty row with len(dst) == len(src).
+func softmaxRow(dst, src []float32) {
+	m := max32(src)
+	if m-m != 0 { // ±Inf or NaN
+		fillNaN32(dst)
+		return
+	}
+	s := expSum32(dst, src, m)
+	scale(dst, dst, 1/s)
+}
+
+// logSoftmaxRow is LogSoftmax on a non-empty row with len(dst) == len(src).
+func logSoftmaxRow(dst, src []float32) {
+	m := max32(src)
+	if m-m != 0 {
+		fillNaN32(dst)
+		return
+	}
+	logS := float32(math.Log(float64(sumExpShifted(src, m))))
+	addScalar(dst, src, -m)
+	addScalar(dst, dst, -logS)
+}
+
+// logSumExpRow is LogSumExp on a non-empty row.
+func logSumExpRow(a []float32) float32 {
+	m := max32(a)
+	if m-m != 0 {
+		return m
+	}
+	return m + float32(math.Log(float64(sumExpShifted(a, m))))
+}
+
+// sumExpShifted returns sum_i e^(src[i]-shift) without writing anywhere but a
+// stack buffer, so src may be the caller's dst.
+func sumExpShifted(src []float32, shift float32) float32 {
+	var buf [softmaxChunk]float32
+	var s float32
+	for len(src) > 0 {
+		c := min(len(src), softmaxChunk)
+		s += expSum32(buf[:c], src[:c], shift)
+		src = src[c:]
+	}
+	return s
+}
+
+func fillNaN32(dst []float32) {
+	nan := float32(math.NaN())
+	for i := range dst {
+		dst[i] = nan
+	}
+}
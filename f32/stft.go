@@ -28,15 +28,25 @@ import (
 //
 // The transform runs in float32 to match the rest of the f32 package; the
 // twiddle and unravel tables are computed in float64 and rounded once to float32
-// so the resident constants carry full precision. It is a radix-2 rfft
-// (power-of-two nfft only); its butterfly stages run through ButterflyComplexStage,
-// so they take the AVX+FMA / NEON vector paths where the span and block count
-// justify them and fall back to scalar Go otherwise. See #108 and #205.
+// so the resident constants carry full precision. An even nfft is an rfft whose
+// half-length complex FFT is an FFTPlan (see fft.go): power-of-two sizes run the
+// radix-4 core through ButterflyComplexStage4 and ButterflyComplexStage (the
+// AVX+FMA / NEON vector paths), sizes such as nfft = 400 or 480 add scalar
+// radix-3/5 stages, and any other even nfft falls back to Bluestein. An odd nfft
+// has no half-length split, so it runs a full nfft-point FFTPlan on the real
+// frame. See #108 and #205.
 
 // ErrSTFT* describe invalid STFTPlan configurations.
 var (
-	// ErrNotPowerOfTwo is returned when nfft is not a power of two >= 2.
-	ErrNotPowerOfTwo = errors.New("f32: STFT nfft must be a power of two >= 2")
+	// ErrSTFTSize is returned when nfft is less than 2.
+	ErrSTFTSize = errors.New("f32: STFT nfft must be >= 2")
+
+	// ErrNotPowerOfTwo is the error NewSTFTPlan returned when nfft had to be a
+	// power of two.
+	//
+	// Deprecated: nfft no longer has to be a power of two. ErrNotPowerOfTwo is
+	// ErrSTFTSize, so existing errors.Is checks keep matching.
+	ErrNotPowerOfTwo = ErrSTFTSize
 )
 
 // rfftHalf is the 1/2 factor in the real-FFT even/odd half-spectrum split.
@@ -72,78 +82,65 @@ const (
 // use on the same plan; use one plan per goroutine (plans are cheap to create
 // and the underlying tables are small). Distinct plans share no state.
 type STFTPlan struct {
-	nfft int // transform size (power of two)
-	half int // nfft / 2: size of the packed complex FFT
-
-	bitrev []int // bit-reversal permutation for the size-half FFT
+	nfft int  // transform size
+	half int  // nfft / 2 (rounded down): the last bin, and the centering pad
+	odd  bool // odd nfft: a full nfft-point FFT of the real frame, no unravel
 
-	// Per-stage contiguous twiddles for the size-half radix-2 FFT, so each stage
-	// can be driven through ButterflyComplexStage (which reads its twiddles
-	// contiguous in j over [0, span)). Stage m in {2,4,...,half} uses span = m/2
-	// factors W_m^j = exp(-i*2*pi*j/m) for j in [0, span); the stage with span s
-	// occupies stageTwRe[s-1 : 2*s-1], and the tables total half-1 entries.
-	stageTwRe, stageTwIm []float32
+	fft *FFTPlan // size-half complex FFT core (size nfft when odd)
 
 	// Unravel twiddles W_N^k = exp(-i*2*pi*k/nfft) for k in [0, half], used to
 	// recombine the even/odd half-spectra into the real-input spectrum.
 	unRe, unIm []float32
 
-	// Per-transform scratch (the packed complex frame, FFT'd in place).
+	// Per-transform scratch (the packed complex frame, FFT'd in place; the
+	// real frame with a zero imaginary part when odd).
 	re, im []float32
+
+	// ISTFT scratch: the synthesized time-domain frame, and the squared
+	// synthesis window the overlap-add normalization reads.
+	frame, winSq []float32
 }
 
-// NumBins returns the number of output bins per frame, nfft/2 + 1 (the Hermitian
-// half-spectrum, DC through Nyquist).
+// NumBins returns the number of output bins per frame, nfft/2 + 1 rounded down
+// (the Hermitian half-spectrum, DC through Nyquist for an even nfft), as
+// librosa's 1 + n_fft//2.
 func (p *STFTPlan) NumBins() int { return p.half + 1 }
 
 // NFFT returns the transform size the plan was built for.
 func (p *STFTPlan) NFFT() int { return p.nfft }
 
 // NewSTFTPlan builds a reusable plan for nfft-point real-input STFTs. nfft must
-// be a power of two and at least 2; otherwise ErrNotPowerOfTwo is returned.
+// be at least 2; otherwise ErrSTFTSize is returned. Even sizes run as a
+// half-length complex FFT and are about twice as fast as odd ones, which
+// transform the whole real frame. Powers of two are fastest, and sizes whose
+// half is 2^a*3^b*5^c (400, 480, 960, ...) run a direct mixed-radix FFT; see
+// FFTPlan.
 func NewSTFTPlan(nfft int) (*STFTPlan, error) {
-	if nfft < 2 || nfft&(nfft-1) != 0 {
-		return nil, ErrNotPowerOfTwo
+	if nfft < 2 {
+		return nil, ErrSTFTSize
 	}
 	half := nfft >> 1
-
-	p := &STFTPlan{
-		nfft:      nfft,
-		half:      half,
-		bitrev:    make([]int, half),
-		stageTwRe: make([]float32, max(half-1, 0)),
-		stageTwIm: make([]float32, max(half-1, 0)),
-		unRe:      make([]float32, half+1),
-		unIm:      make([]float32, half+1),
-		re:        make([]float32, half),
-		im:        make([]float32, half),
+	odd := nfft%2 != 0
+	fftSize := half
+	if odd {
+		fftSize = nfft
 	}
-
-	// Bit-reversal permutation for a size-half FFT.
-	logHalf := 0
-	for (1 << logHalf) < half {
-		logHalf++
-	}
-	for i := range p.bitrev {
-		r := 0
-		for b := range logHalf {
-			r |= ((i >> b) & 1) << (logHalf - 1 - b)
-		}
-		p.bitrev[i] = r
+	fft, err := NewFFTPlan(fftSize)
+	if err != nil {
+		return nil, err
 	}
 
-	// Per-stage contiguous FFT twiddles (computed in float64, stored as float32):
-	// stage m in {2,4,...,half} writes its span = m/2 factors
-	// W_m^j = exp(-i*2*pi*j/m) starting at offset span-1.
-	for m := 2; m <= half; m <<= 1 {
-		span := m >> 1
-		off := span - 1
-		for j := range span {
-			ang := 2 * math.Pi * float64(j) / float64(m)
-			s, c := math.Sincos(ang)
-			p.stageTwRe[off+j] = float32(c)
-			p.stageTwIm[off+j] = float32(-s)
-		}
+	p := &STFTPlan{
+		nfft:  nfft,
+		half:  half,
+		odd:   odd,
+		fft:   fft,
+		unRe:  make([]float32, half+1),
+		unIm:  make([]float32, half+1),
+		re:    make([]float32, fftSize),
+		im:    make([]float32, fftSize),
+		frame: make([]float32, nfft),
+		winSq: make([]float32, nfft),
 	}
 
 	// Real-input unravel twiddles W_N^k.
@@ -157,33 +154,28 @@ func NewSTFTPlan(nfft int) (*STFTPlan, error) {
 	return p, nil
 }
 
-// fftHalf runs an in-place size-half radix-2 decimation-in-time complex FFT on
-// the plan's scratch (p.re, p.im), using the resident bit-reversal and per-stage
-// twiddles. Each stage is one ButterflyComplexStage call, so the butterflies take
-// the AVX+FMA / NEON vector paths where the span and block count justify them.
+// fftHalf runs the in-place size-half complex FFT (size nfft when odd) on the
+// plan's scratch (p.re, p.im) through the resident FFTPlan.
 func (p *STFTPlan) fftHalf() {
-	re, im := p.re, p.im
-	// Bit-reversal reorder.
-	for i, j := range p.bitrev {
-		if j > i {
-			re[i], re[j] = re[j], re[i]
-			im[i], im[j] = im[j], im[i]
-		}
-	}
-	// Butterfly stages. Stage m operates on span = m/2 with block stride m; its
-	// contiguous twiddles live at stageTwRe/stageTwIm[span-1 : 2*span-1].
-	for m := 2; m <= p.half; m <<= 1 {
-		span := m >> 1
-		off := span - 1
-		ButterflyComplexStage(re, im, span, p.stageTwRe[off:off+span], p.stageTwIm[off:off+span])
-	}
+	p.fft.transform(p.re, p.im)
 }
 
 // packFrame loads frame f (signal[base : base+nfft]) into the scratch as half
-// complex samples c[j] = x[2j] + i*x[2j+1], applying the window during the pack.
+// complex samples c[j] = x[2j] + i*x[2j+1], applying the window during the pack;
+// an odd nfft loads the nfft real samples with a zero imaginary part instead.
 // window may be nil (rectangular). The caller guarantees the frame fits.
 func (p *STFTPlan) packFrame(signal, window []float32, base int) {
 	re, im := p.re, p.im
+	if p.odd {
+		clear(im)
+		for j := range re {
+			re[j] = signal[base+j]
+			if window != nil {
+				re[j] *= window[j]
+			}
+		}
+		return
+	}
 	if window == nil {
 		for j := range p.half {
 			re[j] = signal[base+2*j]
@@ -204,8 +196,8 @@ func (p *STFTPlan) packFrame(signal, window []float32, base int) {
 //	NoPad:              1 + (signalLen-nfft)/hop, or 0 if signalLen < nfft
 //	PadZero/PadReflect: 1 + signalLen/hop,        or 0 if signalLen <= 0
 //
-// The centered count (1 + signalLen/hop for even nfft) matches librosa's
-// stft(center=True) framing.
+// The centered count pads nfft/2 (rounded down) per side, so an odd nfft gives
+// 1 + (signalLen-1)/hop; both match librosa's stft(center=True) framing.
 func (p *STFTPlan) NumFrames(signalLen, hop int, pad PadMode) int {
 	if hop <= 0 {
 		return 0
@@ -219,7 +211,7 @@ func (p *STFTPlan) NumFrames(signalLen, hop int, pad PadMode) int {
 	if signalLen <= 0 {
 		return 0
 	}
-	return 1 + signalLen/hop
+	return 1 + (signalLen+2*p.half-p.nfft)/hop
 }
 
 // reflectIndex maps an out-of-range index into [0,n) using numpy "reflect"
@@ -264,6 +256,16 @@ func (p *STFTPlan) packFrameAt(signal, window []float32, base int, pad PadMode)
 		return
 	}
 	re, im := p.re, p.im
+	if p.odd {
+		clear(im)
+		for j := range re {
+			re[j] = sampleAt(signal, base+j, pad)
+			if window != nil {
+				re[j] *= window[j]
+			}
+		}
+		return
+	}
 	for j := range p.half {
 		s0 := sampleAt(signal, base+2*j, pad)
 		s1 := sampleAt(signal, base+2*j+1, pad)
@@ -276,8 +278,12 @@ func (p *STFTPlan) packFrameAt(signal, window []float32, base int, pad PadMode)
 }
 
 // unravelBin computes the real-input spectrum bin X[k] (k in [0, half]) from the
-// half-length complex FFT result currently in p.re/p.im, returning (re, im).
+// half-length complex FFT result currently in p.re/p.im, returning (re, im). An
+// odd nfft's full transform already holds X[k].
 func (p *STFTPlan) unravelBin(k int) (re, im float32) {
+	if p.odd {
+		return p.re[k], p.im[k]
+	}
 	// k runs 0..half inclusive; the half-size spectrum C wraps at p.half,
 	// so both k == 0 and k == p.half read C[0]. Branch instead of modulo
 	// to keep integer division off this per-bin path.
@@ -3,6 +3,7 @@ package f32
 import (
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"testing"
@@ -58,12 +59,15 @@ func cmplxCloseF32(t *testing.T, ctx string, got complex64, want complex128, tol
 }
 
 func TestNewSTFTPlanErrorsF32(t *testing.T) {
-	for _, bad := range []int{0, 1, 3, 5, 6, 7, 9, 100, 1000} {
-		if _, err := NewSTFTPlan(bad); err == nil {
-			t.Errorf("NewSTFTPlan(%d) = nil error, want ErrNotPowerOfTwo", bad)
+	for _, bad := range []int{-2, 0, 1} {
+		if _, err := NewSTFTPlan(bad); !errors.Is(err, ErrSTFTSize) {
+			t.Errorf("NewSTFTPlan(%d) error = %v, want ErrSTFTSize", bad, err)
 		}
 	}
-	for _, good := range []int{2, 4, 8, 16, 1024} {
+	if _, err := NewSTFTPlan(1); !errors.Is(err, ErrNotPowerOfTwo) {
+		t.Errorf("deprecated ErrNotPowerOfTwo no longer matches: %v", err)
+	}
+	for _, good := range []int{2, 3, 4, 5, 6, 8, 9, 16, 100, 400, 401, 480, 1000, 1024} {
 		p, err := NewSTFTPlan(good)
 		if err != nil {
 			t.Errorf("NewSTFTPlan(%d) unexpected error: %v", good, err)
@@ -77,10 +81,11 @@ func TestNewSTFTPlanErrorsF32(t *testing.T) {
 
 // TestSTFTAgainstDFTF32 is the core correctness gate: every bin of every frame
 // must match a direct DFT of the windowed frame within the float32 tolerance,
-// across nfft sizes, hops, and with or without a window.
+// across nfft sizes (power-of-two, mixed-radix, and Bluestein halves), hops, and
+// with or without a window.
 func TestSTFTAgainstDFTF32(t *testing.T) {
 	signal := testSignalF32(5000)
-	for _, nfft := range []int{2, 4, 8, 16, 64, 256, 1024} {
+	for _, nfft := range []int{2, 4, 8, 16, 64, 256, 1024, 6, 12, 30, 400, 480, 14, 194, 3, 5, 9, 15, 401} {
 		for _, useWin := range []bool{false, true} {
 			plan, err := NewSTFTPlan(nfft)
 			if err != nil {
@@ -124,8 +129,8 @@ func TestSTFTAgainstDFTF32(t *testing.T) {
 	}
 }
 
-// TestSTFTStageTwiddles pins the per-stage contiguous twiddle layout that
-// fftHalf and ButterflyComplexStage share: the table holds max(half-1, 0)
+// TestSTFTStageTwiddles pins the per-stage contiguous twiddle layout that the
+// plan's FFTPlan and ButterflyComplexStage share: the table holds max(half-1, 0)
 // entries, and stage m in {2,4,...,half} keeps its span=m/2 factors
 // W_m^j = (cos(2*pi*j/m), -sin(2*pi*j/m)) at offset span-1. An off-by-one in the
 // offset or a wrong table length would put the wrong factor at [off+j] and fail
@@ -140,9 +145,9 @@ func TestSTFTStageTwiddles(t *testing.T) {
 		}
 		half := nfft >> 1
 		wantLen := max(half-1, 0)
-		if len(p.stageTwRe) != wantLen || len(p.stageTwIm) != wantLen {
+		if len(p.fft.stageTwRe) != wantLen || len(p.fft.stageTwIm) != wantLen {
 			t.Fatalf("nfft=%d: twiddle table len = (%d,%d), want %d",
-				nfft, len(p.stageTwRe), len(p.stageTwIm), wantLen)
+				nfft, len(p.fft.stageTwRe), len(p.fft.stageTwIm), wantLen)
 		}
 		for m := 2; m <= half; m <<= 1 {
 			span := m >> 1
@@ -155,11 +160,11 @@ func TestSTFTStageTwiddles(t *testing.T) {
 				// expression); an exact check catches any factor corruption a loose
 				// tolerance would let pass.
 				wantRe, wantIm := float32(c), float32(-s)
-				if math.Float32bits(p.stageTwRe[off+j]) != math.Float32bits(wantRe) {
-					t.Errorf("nfft=%d m=%d j=%d: stageTwRe=%g want %g", nfft, m, j, p.stageTwRe[off+j], wantRe)
+				if math.Float32bits(p.fft.stageTwRe[off+j]) != math.Float32bits(wantRe) {
+					t.Errorf("nfft=%d m=%d j=%d: stageTwRe=%g want %g", nfft, m, j, p.fft.stageTwRe[off+j], wantRe)
 				}
-				if math.Float32bits(p.stageTwIm[off+j]) != math.Float32bits(wantIm) {
-					t.Errorf("nfft=%d m=%d j=%d: stageTwIm=%g want %g", nfft, m, j, p.stageTwIm[off+j], wantIm)
+				if math.Float32bits(p.fft.stageTwIm[off+j]) != math.Float32bits(wantIm) {
+					t.Errorf("nfft=%d m=%d j=%d: stageTwIm=%g want %g", nfft, m, j, p.fft.stageTwIm[off+j], wantIm)
 				}
 			}
 		}
@@ -468,6 +473,14 @@ func TestNumFramesF32(t *testing.T) {
 			t.Errorf("NumFrames(%d,%d,%v)=%d want %d", c.n, c.hop, c.pad, got, c.want)
 		}
 	}
+	// An odd nfft pads nfft/2 (rounded down) per side, matching
+	// librosa's 1 + (len + 2*(n_fft//2) - n_fft)//hop.
+	odd, _ := NewSTFTPlan(9)
+	for _, c := range []struct{ n, hop, want int }{{1, 4, 1}, {8, 4, 2}, {9, 4, 3}, {16, 4, 4}} {
+		if got := odd.NumFrames(c.n, c.hop, PadZero); got != c.want {
+			t.Errorf("nfft=9: NumFrames(%d,%d,PadZero)=%d want %d", c.n, c.hop, got, c.want)
+		}
+	}
 }
 
 func TestReflectIndexF32(t *testing.T) {
@@ -529,7 +542,7 @@ func stftRefF32(signal, window []float32, nfft, hop int, pad PadMode) [][]comple
 			nf = 1 + (len(signal)-nfft)/hop
 		}
 	case len(signal) > 0:
-		nf = 1 + len(signal)/hop
+		nf = 1 + (len(signal)+2*(nfft/2)-nfft)/hop
 	}
 	out := make([][]complex128, nf)
 	frame := make([]float32, nfft)
@@ -569,7 +582,7 @@ func windowedFrameL1F32(signal, window []float32, nfft, hop, f, off int, pad Pad
 // TestSTFTCenteredAgainstRefF32 gates the centered/padded paths: every bin of
 // every frame must match the independent reference within the float32 tolerance.
 func TestSTFTCenteredAgainstRefF32(t *testing.T) {
-	for _, nfft := range []int{16, 64, 512, 1024} {
+	for _, nfft := range []int{16, 64, 512, 1024, 400, 480, 45, 401} {
 		for _, hop := range []int{nfft / 4, nfft / 2, nfft} {
 			for _, pad := range []PadMode{NoPad, PadZero, PadReflect} {
 				for _, useWin := range []bool{false, true} {
@@ -653,69 +666,82 @@ func TestSTFTPowerIntoF32(t *testing.T) {
 //go:embed testdata/stft_librosa_golden.json
 var librosaGoldenJSON []byte
 
-// TestSTFTLibrosaParityF32 pins the float32 output convention against a golden
-// vector generated by real librosa (float64). The golden is embedded so the test
+// TestSTFTLibrosaParityF32 pins the float32 output convention against golden
+// vectors for the radix-2, 400/480 mixed-radix and odd nfft paths; each entry
+// records its generator. Only the nfft 1024 entry is librosa (float64) output;
+// the 400, 480 and 401 entries come from the generator's stdlib transcription
+// of librosa.stft, so those sizes are checked against that second port, not
+// against librosa (see TestSTFTLibrosaParity). The golden is embedded so the test
 // runs from any working directory (including the cross-arch copy-the-binary flow).
 // The signal and window are regenerated with the same deterministic formulas the
 // generator used; the tolerance is looser than f64 to absorb float32 accumulation.
 func TestSTFTLibrosaParityF32(t *testing.T) {
 	var g struct {
-		LibrosaVersion string `json:"librosa_version"`
-		NFFT           int    `json:"nfft"`
-		Hop            int    `json:"hop"`
-		N              int    `json:"n"`
-		Cases          []struct {
-			GoPad  string    `json:"go_pad"`
-			Frames int       `json:"frames"`
-			Bins   int       `json:"bins"`
-			Power  []float64 `json:"power"`
-		} `json:"cases"`
+		N     int `json:"n"`
+		Sizes []struct {
+			NFFT      int    `json:"nfft"`
+			Hop       int    `json:"hop"`
+			Generator string `json:"generator"`
+			Cases     []struct {
+				GoPad  string    `json:"go_pad"`
+				Frames int       `json:"frames"`
+				Bins   int       `json:"bins"`
+				Power  []float64 `json:"power"`
+			} `json:"cases"`
+		} `json:"sizes"`
 	}
 	if err := json.Unmarshal(librosaGoldenJSON, &g); err != nil {
 		t.Fatalf("unmarshal golden: %v", err)
 	}
-	t.Logf("golden generated by librosa %s (nfft=%d hop=%d)", g.LibrosaVersion, g.NFFT, g.Hop)
 	signal := testSignalF32(g.N)
-	window := hannF32(g.NFFT)
-	p, _ := NewSTFTPlan(g.NFFT)
 	padOf := map[string]PadMode{"PadZero": PadZero, "PadReflect": PadReflect}
-	for _, c := range g.Cases {
-		pad, ok := padOf[c.GoPad]
-		if !ok {
-			t.Fatalf("unknown go_pad %q in golden", c.GoPad)
-		}
-		if nf := p.NumFrames(len(signal), g.Hop, pad); nf != c.Frames {
-			t.Fatalf("%s: NumFrames=%d but librosa produced %d frames", c.GoPad, nf, c.Frames)
-		}
-		if c.Bins != p.NumBins() {
-			t.Fatalf("%s: golden bins=%d but NumBins=%d", c.GoPad, c.Bins, p.NumBins())
+	for _, e := range g.Sizes {
+		t.Logf("nfft=%d hop=%d golden generated by %s", e.NFFT, e.Hop, e.Generator)
+		window := hannF32(e.NFFT)
+		p, err := NewSTFTPlan(e.NFFT)
+		if err != nil {
+			t.Fatalf("NewSTFTPlan(%d): %v", e.NFFT, err)
 		}
-		var peak float64
-		for _, v := range c.Power {
-			if v > peak {
-				peak = v
+		for _, c := range e.Cases {
+			ctx := fmt.Sprintf("nfft=%d %s", e.NFFT, c.GoPad)
+			pad, ok := padOf[c.GoPad]
+			if !ok {
+				t.Fatalf("unknown go_pad %q in golden", c.GoPad)
 			}
-		}
-		flat := make([]float32, c.Frames*c.Bins)
-		p.STFTPowerInto(flat, signal, window, g.Hop, pad)
-		var maxRel float64
-		for i := range flat {
-			ref := c.Power[i]
-			// Floor the denominator at a small fraction of the peak power so
-			// near-zero bins (where float32 noise dominates) do not blow up the
-			// relative error.
-			den := math.Abs(ref) + 1e-4*peak
-			rel := math.Abs(float64(flat[i])-ref) / den
-			if rel > maxRel {
-				maxRel = rel
+			if nf := p.NumFrames(len(signal), e.Hop, pad); nf != c.Frames {
+				t.Fatalf("%s: NumFrames=%d but %s produced %d frames", ctx, nf, e.Generator, c.Frames)
+			}
+			if c.Bins != p.NumBins() {
+				t.Fatalf("%s: golden bins=%d but NumBins=%d", ctx, c.Bins, p.NumBins())
+			}
+			var peak float64
+			for _, v := range c.Power {
+				if v > peak {
+					peak = v
+				}
+			}
+			flat := make([]float32, c.Frames*c.Bins)
+			p.STFTPowerInto(flat, signal, window, e.Hop, pad)
+			var maxRel float64
+			for i := range flat {
+				ref := c.Power[i]
+				// Floor the denominator at a small fraction of the peak power so
+				// near-zero bins (where float32 noise dominates) do not blow up the
+				// relative error.
+				den := math.Abs(ref) + 1e-4*peak
+				rel := math.Abs(float64(flat[i])-ref) / den
+				if rel > maxRel {
+					maxRel = rel
+				}
+			}
+			// float32 rfft vs a float64 reference: the error is dominated
+			// by float32 rounding (observed ~2e-6 relative after squaring to
+			// power). The 1e-4 bound keeps ~50x margin while a convention error
+			// (wrong centering, window, or normalization) would be orders of
+			// magnitude larger.
+			if maxRel > 1e-4 {
+				t.Errorf("%s: max relative error %g exceeds 1e-4 vs %s", ctx, maxRel, e.Generator)
 			}
-		}
-		// float32 radix-2 rfft vs librosa's float64 pocketfft: the error is
-		// dominated by float32 rounding (observed ~2e-6 relative after squaring to
-		// power). The 1e-4 bound keeps ~50x margin while a convention error (wrong
-		// centering, window, or normalization) would be orders of magnitude larger.
-		if maxRel > 1e-4 {
-			t.Errorf("%s: max relative error %g exceeds 1e-4 vs librosa", c.GoPad, maxRel)
 		}
 	}
 }
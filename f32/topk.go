@@ -0,0 +1,177 @@
+package f32
+
+// Fused top-K search over a flat row-major store. Rows are scored a chunk at a
+// time through the strided batch dot or cosine loop (or the per-row distance
+// kernel for L2) into a stack buffer, and each chunk is folded into a bounded
+// selection kept in the caller's ids/scores slices, so the store's scores are
+// never materialized and nothing is allocated.
+//
+// L2 stays on the per-row kernel, which sums (row[i] - query[i])^2 directly,
+// rather than the batched L2SquaredStrided loop: that loop expands the
+// distance as ||row||^2 - 2*row.query + ||query||^2, which cancels to rounding
+// noise for rows close to the query, and those are exactly the rows a
+// nearest-neighbor search must rank (TestTopKL2_NearDuplicates).
+
+// topKChunkRows is how many rows are scored per batch-kernel call. It is a
+// multiple of batchDotRows and below batchDotHugeMaxRows, so every chunk stays
+// eligible for the batched kernel whatever its dims.
+const topKChunkRows = 32
+
+// topKMetric selects how topK scores a row.
+type topKMetric int
+
+const (
+	topKDot topKMetric = iota
+	topKL2
+	topKCosine
+)
+
+// TopKDot finds the k rows of a flat row-major store with the largest dot
+// product with query, where k = min(len(ids), len(scores)). Row i starts at
+// base[i*stride] and is dims elements long; dims and stride are in float32
+// elements. Only rows lying entirely within base, among the first rowCount,
+// are candidates.
+//
+// The winners are written best first: ids[j] is the row index and scores[j]
+// its dot product. Equal scores rank by lowest row index, so the result is
+// deterministic. Rows scoring NaN are never selected. The return value is the
+// number of results written, min(k, candidate rows) less any NaN rows; it is
+// 0 when dims or stride is non-positive or query is shorter than dims.
+func TopKDot(ids []uint32, scores []float32, base, query []float32, rowCount, dims, stride int) int {
+	return topK(topKDot, ids, scores, base, query, rowCount, dims, stride)
+}
+
+// TopKL2 finds the k rows nearest to query in Euclidean distance, with the
+// same store layout, tie-breaking and result contract as TopKDot. scores[j] is
+// the distance sqrt(sum((row[i] - query[i])^2)), smallest first.
+func TopKL2(ids []uint32, scores []float32, base, query []float32, rowCount, dims, stride int) int {
+	return topK(topKL2, ids, scores, base, query, rowCount, dims, stride)
+}
+
+// TopKCosine finds the k rows with the largest cosine similarity to query,
+// with the same store layout, tie-breaking and result contract as TopKDot.
+// scores[j] is dot(row, query) / (||row|| * ||query||), largest first; a
+// zero-norm row or query has similarity 0.
+func TopKCosine(ids []uint32, scores []float32, base, query []float32, rowCount, dims, stride int) int {
+	return topK(topKCosine, ids, scores, base, query, rowCount, dims, stride)
+}
+
+func topK(metric topKMetric, ids []uint32, scores, base, query []float32, rowCount, dims, stride int) int {
+	k := min(len(ids), len(scores))
+	if k == 0 || rowCount <= 0 || dims <= 0 || stride <= 0 || len(query) < dims {
+		return 0
+	}
+	rows := min(rowCount, fullRowMaxIndex(len(base), dims, stride)+1)
+	if rows <= 0 {
+		return 0
+	}
+	queryFull := query[:dims]
+
+	sel := topKSelection{ids: ids[:k], scores: scores[:k], ascending: metric == topKL2}
+	var buf [topKChunkRows]float32
+	for start := 0; start < rows; start += topKChunkRows {
+		c := min(topKChunkRows, rows-start)
+		off := start * stride
+		chunk := base[off : off+(c-1)*stride+dims]
+		dst := buf[:c]
+		switch metric {
+		case topKDot:
+			dotProductStrided(dst, chunk, queryFull, c, dims, stride)
+		case topKL2:
+			for i := range dst {
+				dst[i] = euclideanDistance32(chunk[i*stride:i*stride+dims], queryFull)
+			}
+		case topKCosine:
+			rowMetricStrided(rowCosine, dst, chunk, queryFull, nil, c, dims, stride)
+		}
+		for i, s := range dst {
+			sel.push(s, uint32(start+i))
+		}
+	}
+	return sel.finish()
+}
+
+// topKSelection keeps the best n <= len(ids) (score, id) pairs seen so far as a
+// binary heap in ids/scores whose root is the worst of them, so a candidate
+// costs one comparison unless it displaces the root.
+type topKSelection struct {
+	ids       []uint32
+	scores    []float32
+	n         int
+	ascending bool // smaller scores rank first (distances)
+}
+
+// better reports whether (sa, ia) ranks ahead of (sb, ib).
+func (s *topKSelection) better(sa float32, ia uint32, sb float32, ib uint32) bool {
+	if sa != sb {
+		return (sa < sb) == s.ascending
+	}
+	return ia < ib
+}
+
+// betterAt compares the entries at heap positions i and j.
+func (s *topKSelection) betterAt(i, j int) bool {
+	return s.better(s.scores[i], s.ids[i], s.scores[j], s.ids[j])
+}
+
+func (s *topKSelection) swap(i, j int) {
+	s.ids[i], s.ids[j] = s.ids[j], s.ids[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
+
+func (s *topKSelection) push(score float32, id uint32) {
+	if score != score { // NaN
+		return
+	}
+	if s.n < len(s.ids) {
+		s.ids[s.n], s.scores[s.n] = id, score
+		s.n++
+		s.siftUp(s.n - 1)
+		return
+	}
+	if !s.better(score, id, s.scores[0], s.ids[0]) {
+		return
+	}
+	s.ids[0], s.scores[0] = id, score
+	s.siftDown(0, s.n)
+}
+
+// siftUp moves entry i toward the root while it is worse than its parent.
+func (s *topKSelection) siftUp(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !s.betterAt(p, i) {
+			return
+		}
+		s.swap(p, i)
+		i = p
+	}
+}
+
+// siftDown moves entry i toward the leaves of the heap's first n entries while
+// a child is worse than it.
+func (s *topKSelection) siftDown(i, n int) {
+	for {
+		w := 2*i + 1
+		if w >= n {
+			return
+		}
+		if r := w + 1; r < n && s.betterAt(w, r) {
+			w = r
+		}
+		if !s.betterAt(i, w) {
+			return
+		}
+		s.swap(i, w)
+		i = w
+	}
+}
+
+// finish sorts the selection best first in place and returns its size.
+func (s *topKSelection) finish() int {
+	for end := s.n - 1; end > 0; end-- {
+		s.swap(0, end)
+		s.siftDown(0, end)
+	}
+	return s.n
+}
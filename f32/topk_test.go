@@ -0,0 +1,204 @@
+package f32
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// topKStore builds rows of small integers so every dot product and squared
+// distance is exact in float32 whichever kernel sums it, and so equal scores
+// (ties) are common. Row r repeats row r%distinct, which forces ties between
+// rows far apart in the store.
+func topKStore(rows, dims, stride, distinct int) []float32 {
+	base := make([]float32, rows*stride)
+	for r := range rows {
+		src := r % distinct
+		for j := range dims {
+			base[r*stride+j] = float32((src*7+j*3)%11 - 5)
+		}
+		for j := dims; j < stride; j++ {
+			base[r*stride+j] = 1e30 // padding must never be read
+		}
+	}
+	return base
+}
+
+// topKOracle scores every row with the scalar references and sorts all of
+// them by the documented order, lowest row index first among equal scores.
+func topKOracle(metric topKMetric, base, query []float32, rows, dims, stride int) ([]uint32, []float32) {
+	ids := make([]uint32, rows)
+	scores := make([]float32, rows)
+	q := query[:dims]
+	for r := range rows {
+		row := base[r*stride : r*stride+dims]
+		ids[r] = uint32(r)
+		switch metric {
+		case topKDot:
+			scores[r] = dotProductGo(row, q)
+		case topKL2:
+			scores[r] = euclideanDistance32Go(row, q)
+		case topKCosine:
+			scores[r] = cosineFromDot(dotProductGo(row, q), dotProductGo(row, row), dotProductGo(q, q))
+		}
+	}
+	sort.SliceStable(ids, func(a, b int) bool {
+		sa, sb := scores[ids[a]], scores[ids[b]]
+		if sa != sb {
+			return (sa < sb) == (metric == topKL2)
+		}
+		return ids[a] < ids[b]
+	})
+	sorted := make([]float32, rows)
+	for i, id := range ids {
+		sorted[i] = scores[id]
+	}
+	return ids, sorted
+}
+
+var topKFuncs = []struct {
+	name   string
+	metric topKMetric
+	fn     func(ids []uint32, scores []float32, base, query []float32, rowCount, dims, stride int) int
+}{
+	{"Dot", topKDot, TopKDot},
+	{"L2", topKL2, TopKL2},
+	{"Cosine", topKCosine, TopKCosine},
+}
+
+func TestTopK_MatchesOracle(t *testing.T) {
+	for _, f := range topKFuncs {
+		for _, dims := range []int{1, 3, 16, 64, 67} {
+			for _, rows := range []int{1, 5, 31, 32, 33, 100, 257} {
+				for _, k := range []int{1, 4, 10, 300} {
+					stride := dims + 2
+					base := topKStore(rows, dims, stride, 13)
+					query := topKStore(1, dims, dims, 1)
+					for j := range query {
+						query[j] += float32(j % 3)
+					}
+					ids := make([]uint32, k)
+					scores := make([]float32, k)
+					got := f.fn(ids, scores, base, query, rows, dims, stride)
+					wantIDs, wantScores := topKOracle(f.metric, base, query, rows, dims, stride)
+					if want := min(k, rows); got != want {
+						t.Fatalf("TopK%s dims=%d rows=%d k=%d: returned %d, want %d", f.name, dims, rows, k, got, want)
+					}
+					for j := range got {
+						if ids[j] != wantIDs[j] || !topKScoreClose(scores[j], wantScores[j]) {
+							t.Fatalf("TopK%s dims=%d rows=%d k=%d [%d]: got (%d, %g), want (%d, %g)",
+								f.name, dims, rows, k, j, ids[j], scores[j], wantIDs[j], wantScores[j])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// topKScoreClose allows for the SIMD distance and cosine kernels rounding the
+// square root differently from the scalar oracle; dot scores are exact.
+func topKScoreClose(got, want float32) bool {
+	return math.Abs(float64(got-want)) <= 1e-6*(1+math.Abs(float64(want)))
+}
+
+func TestTopK_Contract(t *testing.T) {
+	const dims = 4
+	base := []float32{
+		1, 0, 0, 0,
+		0, 2, 0, 0,
+		1, 0, 0, 0, // ties row 0
+		0, 0, 0, 0, // zero norm
+		9, 9, // truncated: never a candidate
+	}
+	query := []float32{1, 1, 0, 0}
+	ids := make([]uint32, 8)
+	scores := make([]float32, 8)
+
+	if n := TopKDot(ids, scores, base, query, 5, dims, dims); n != 4 {
+		t.Fatalf("TopKDot returned %d, want 4 full rows", n)
+	}
+	if want := []uint32{1, 0, 2, 3}; !slices.Equal(ids[:4], want) {
+		t.Errorf("TopKDot ids = %v, want %v", ids[:4], want)
+	}
+
+	if n := TopKCosine(ids, scores, base, query, 5, dims, dims); n != 4 || scores[3] != 0 || ids[3] != 3 {
+		t.Errorf("TopKCosine = %d %v %v, want the zero-norm row last with similarity 0", n, ids[:n], scores[:n])
+	}
+
+	if n := TopKL2(ids[:2], scores, base, query, 5, dims, dims); n != 2 {
+		t.Fatalf("TopKL2 returned %d, want k=2", n)
+	}
+	if want := []uint32{0, 2}; !slices.Equal(ids[:2], want) {
+		t.Errorf("TopKL2 ids = %v, want %v (tie broken by lowest row)", ids[:2], want)
+	}
+
+	for name, n := range map[string]int{
+		"k=0":          TopKDot(nil, scores, base, query, 4, dims, dims),
+		"rowCount=0":   TopKDot(ids, scores, base, query, 0, dims, dims),
+		"dims=0":       TopKDot(ids, scores, base, query, 4, 0, dims),
+		"stride=0":     TopKDot(ids, scores, base, query, 4, dims, 0),
+		"short query":  TopKDot(ids, scores, base, query[:3], 4, dims, dims),
+		"empty base":   TopKDot(ids, scores, nil, query, 4, dims, dims),
+		"base < 1 row": TopKDot(ids, scores, base[:3], query, 4, dims, dims),
+	} {
+		if n != 0 {
+			t.Errorf("%s: returned %d, want 0", name, n)
+		}
+	}
+}
+
+// TestTopKL2_NearDuplicates checks TopKL2 ranks rows that differ from the
+// query by tiny offsets on large coordinates, where the expansion
+// ||row||^2 - 2*row.query + ||query||^2 cancels to noise.
+func TestTopKL2_NearDuplicates(t *testing.T) {
+	const rows, dims = 64, 64
+	query := make([]float32, dims)
+	for j := range query {
+		query[j] = 100 + float32(j)
+	}
+	base := make([]float32, rows*dims)
+	for r := range rows {
+		for j := range dims {
+			base[r*dims+j] = query[j]
+		}
+		// Row r is off by (rows-r)/1024 in one coordinate: the last row is nearest.
+		base[r*dims+r%dims] += float32(rows-r) / 1024
+	}
+	ids := make([]uint32, 4)
+	scores := make([]float32, 4)
+	if n := TopKL2(ids, scores, base, query, rows, dims, dims); n != 4 {
+		t.Fatalf("TopKL2 returned %d, want 4", n)
+	}
+	for j, id := range ids {
+		want := uint32(rows - 1 - j)
+		if id != want || scores[j] != float32(rows-int(want))/1024 {
+			t.Fatalf("TopKL2[%d] = (%d, %g), want (%d, %g)", j, id, scores[j], want, float32(rows-int(want))/1024)
+		}
+	}
+}
+
+func TestTopK_SkipsNaN(t *testing.T) {
+	base := []float32{1, float32(math.NaN()), 2, 3}
+	ids := make([]uint32, 4)
+	scores := make([]float32, 4)
+	if n := TopKDot(ids, scores, base, []float32{1}, 4, 1, 1); n != 3 || !slices.Equal(ids[:3], []uint32{3, 2, 0}) {
+		t.Errorf("TopKDot = %d %v, want the three non-NaN rows [3 2 0]", n, ids[:n])
+	}
+}
+
+func TestTopK_Allocs(t *testing.T) {
+	const rows, dims = 200, 64
+	base := topKStore(rows, dims, dims, 17)
+	query := topKStore(1, dims, dims, 1)
+	ids := make([]uint32, 10)
+	scores := make([]float32, 10)
+	for _, f := range topKFuncs {
+		if got := testing.AllocsPerRun(100, func() {
+			f.fn(ids, scores, base, query, rows, dims, dims)
+		}); got != 0 {
+			t.Errorf("TopK%s allocated %v times per run, want 0", f.name, got)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package f64
+
+import (
+	"errors"
+	"math"
+)
+
+// This file adds the discrete cosine transforms the cepstral end of the feature
+// pipeline needs: a DCT-II/DCT-III plan matching scipy.fft.dct/idct (types 2 and
+// 3, norm="backward" and norm="ortho"), and an MFCC stage that turns log-mel
+// frames into cepstral coefficients (librosa.feature.mfcc) with optional
+// liftering.
+//
+// DCTPlan uses Makhoul's mapping onto a same-length complex FFT: the input is
+// reordered even samples first, odd samples reversed, transformed by the
+// resident FFTPlan, and rotated by exp(-i*pi*k/(2n)). DCT-III runs the same steps
+// backwards. The MFCC stage keeps only the first few coefficients of every frame,
+// so it folds the orthonormal DCT-II rows and the lifter into one small matrix
+// and projects each frame with DotProduct instead.
+
+// ErrDCT* describe invalid DCT and MFCC configurations.
+var (
+	// ErrDCTSize is returned by NewDCTPlan when n is less than 1.
+	ErrDCTSize = errors.New("f64: DCT size must be >= 1")
+
+	// ErrMFCCConfig is returned by NewMFCC for a nil filterbank, a coefficient
+	// count outside [1, NumMels], or a negative lifter.
+	ErrMFCCConfig = errors.New("f64: invalid MFCC configuration")
+)
+
+// DCTNorm selects the scaling convention of DCTPlan, named after scipy.fft's norm
+// argument.
+//
+//   - DCTBackward: no normalization (scipy's default, norm="backward"); DCT-II is
+//     y[k] = 2*sum x[m]*cos(pi*k*(2m+1)/(2n)), and DCT3(DCT2(x)) is 2n*x.
+//   - DCTOrtho: the orthonormal scaling (norm="ortho"); DCT-II and DCT-III are
+//     each other's inverse.
+type DCTNorm int
+
+// DCT normalizations; see DCTNorm.
+const (
+	DCTBackward DCTNorm = iota
+	DCTOrtho
+)
+
+// dctBackwardGain is the 2 in the unnormalized DCT-II, 2*Re(rotated FFT).
+const dctBackwardGain = 2
+
+// DCTPlan is a reusable n-point DCT-II/DCT-III. Build one with NewDCTPlan and
+// reuse it across calls to stay allocation-free. Any n >= 1 is accepted; the cost
+// follows the FFTPlan schedule for n.
+//
+// A plan holds per-transform scratch, so its methods are NOT safe for concurrent
+// use on the same plan; use one plan per goroutine.
+type DCTPlan struct {
+	n   int
+	fft *FFTPlan
+
+	// Rotation cos(pi*k/(2n)) and sin(pi*k/(2n)) for k in [0, n).
+	twCos, twSin []float64
+
+	// Orthonormal scales: sqrt(1/(4n)) for bin 0 and sqrt(1/(2n)) for the rest.
+	ortho0, ortho float64
+
+	re, im []float64 // FFT scratch
+}
+
+// NewDCTPlan builds a reusable plan for n-point DCTs. n must be at least 1;
+// otherwise ErrDCTSize is returned.
+func NewDCTPlan(n int) (*DCTPlan, error) {
+	if n < 1 {
+		return nil, ErrDCTSize
+	}
+	fft, err := NewFFTPlan(n)
+	if err != nil {
+		return nil, err
+	}
+	p := &DCTPlan{
+		n:      n,
+		fft:    fft,
+		twCos:  make([]float64, n),
+		twSin:  make([]float64, n),
+		ortho0: math.Sqrt(1 / float64(4*n)),
+		ortho:  math.Sqrt(1 / float64(2*n)),
+		re:     make([]float64, n),
+		im:     make([]float64, n),
+	}
+	for k := range n {
+		s, c := math.Sincos(math.Pi * float64(k) / float64(2*n))
+		p.twCos[k] = c
+		p.twSin[k] = s
+	}
+	return p, nil
+}
+
+// Len returns the transform size the plan was built for.
+func (p *DCTPlan) Len() int { return p.n }
+
+// DCT2 computes the DCT-II of src into dst (scipy.fft.dct type 2):
+//
+//	dst[k] = 2 * sum_{m=0}^{n-1} src[m] * cos(pi*k*(2m+1)/(2n))
+//
+// with DCTBackward, or the same scaled by sqrt(1/(4n)) for k == 0 and
+// sqrt(1/(2n)) otherwise with DCTOrtho. It reads src[:n] and writes dst[:n],
+// where n is Len(), and is a no-op when either slice is shorter. dst and src may
+// be the same slice. Allocation-free.
+func (p *DCTPlan) DCT2(dst, src []float64, norm DCTNorm) {
+	n := p.n
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	// Makhoul reorder: v = [x0, x2, x4, ..., x5, x3, x1].
+	re, im := p.re, p.im
+	for m := 0; 2*m < n; m++ {
+		re[m] = src[2*m]
+	}
+	for m := 0; 2*m+1 < n; m++ {
+		re[n-1-m] = src[2*m+1]
+	}
+	clear(im)
+	p.fft.transform(re, im)
+
+	g0, g := float64(dctBackwardGain), float64(dctBackwardGain)
+	if norm == DCTOrtho {
+		g0, g = g0*p.ortho0, g*p.ortho
+	}
+	// dst[k] = g * Re(exp(-i*pi*k/(2n)) * V[k]).
+	dst[0] = g0 * re[0]
+	for k := 1; k < n; k++ {
+		dst[k] = g * (p.twCos[k]*re[k] + p.twSin[k]*im[k])
+	}
+}
+
+// DCT3 computes the DCT-III of src into dst (scipy.fft.dct type 3):
+//
+//	dst[k] = src[0] + 2 * sum_{m=1}^{n-1} src[m] * cos(pi*m*(2k+1)/(2n))
+//
+// with DCTBackward, so DCT3(DCT2(x)) is 2n*x; with DCTOrtho it is the exact
+// inverse of the orthonormal DCT2. Length handling and aliasing follow DCT2.
+// Allocation-free.
+func (p *DCTPlan) DCT3(dst, src []float64, norm DCTNorm) {
+	n := p.n
+	if len(dst) < n || len(src) < n {
+		return
+	}
+	// The orthonormal DCT-III is the unnormalized one applied to src scaled by
+	// sqrt(1/n) at m == 0 and sqrt(1/(2n)) elsewhere.
+	s0, s := 1.0, 1.0
+	if norm == DCTOrtho {
+		s0, s = dctBackwardGain*p.ortho0, p.ortho
+	}
+
+	// V[k] = exp(i*pi*k/(2n)) * (x[k] - i*x[n-k]) with x[n] = 0; the inverse FFT
+	// of V, un-reordered, is the DCT-III. Store conj(V) so the forward FFT
+	// computes the (real) inverse in its real part.
+	re, im := p.re, p.im
+	re[0], im[0] = s0*src[0], 0
+	for k := 1; k < n; k++ {
+		xa, xb := s*src[k], s*src[n-k]
+		c, sn := p.twCos[k], p.twSin[k]
+		re[k] = c*xa + sn*xb
+		im[k] = c*xb - sn*xa
+	}
+	p.fft.transform(re, im)
+
+	for m := 0; 2*m < n; m++ {
+		dst[2*m] = re[m]
+	}
+	for m := 0; 2*m+1 < n; m++ {
+		dst[2*m+1] = re[n-1-m]
+	}
+}
+
+// MFCC turns log-mel frames into mel-frequency cepstral coefficients: the
+// first NumMFCC coefficients of the orthonormal DCT-II of each frame, optionally
+// liftered, as librosa.feature.mfcc computes them from a log-mel spectrogram
+// (dct_type=2, norm="ortho"). Liftering with L > 0 scales coefficient k by
+//
+//	1 + (L/2)*sin(pi*(k+1)/L)
+//
+// The DCT rows and the lifter are folded into one NumMFCC x NumMels matrix at
+// construction. An MFCC only reads its matrix after NewMFCC, so one may be shared
+// by any number of goroutines.
+type MFCC struct {
+	nMels, nMFCC int
+	basis        []float64 // nMFCC x nMels, row-major
+}
+
+// NewMFCC builds an MFCC stage for the log-mel frames of fb, keeping nMFCC
+// coefficients per frame (librosa's default is 20) and liftering with lifter (0
+// disables it, librosa's default). It returns ErrMFCCConfig for a nil filterbank,
+// nMFCC outside [1, fb.NumMels()], or a negative lifter.
+func NewMFCC(fb *MelFilterbank, nMFCC int, lifter float64) (*MFCC, error) {
+	if fb == nil || nMFCC < 1 || nMFCC > fb.nMels || !(lifter >= 0) {
+		return nil, ErrMFCCConfig
+	}
+	nMels := fb.nMels
+	c := &MFCC{nMels: nMels, nMFCC: nMFCC, basis: make([]float64, nMFCC*nMels)}
+	for k := range nMFCC {
+		// Orthonormal DCT-II row k, with the lifter gain folded in.
+		scale := math.Sqrt(dctBackwardGain / float64(nMels))
+		if k == 0 {
+			scale = math.Sqrt(1 / float64(nMels))
+		}
+		if lifter > 0 {
+			scale *= 1 + lifter/2*math.Sin(math.Pi*float64(k+1)/lifter)
+		}
+		row := c.basis[k*nMels : (k+1)*nMels]
+		for m := range row {
+			row[m] = scale * math.Cos(math.Pi*float64(k*(2*m+1))/float64(2*nMels))
+		}
+	}
+	return c, nil
+}
+
+// NumMFCC returns the number of coefficients per output frame.
+func (c *MFCC) NumMFCC() int { return c.nMFCC }
+
+// Apply computes the cepstral coefficients of frame-contiguous log-mel frames,
+// as MelFilterbank.LogMel writes them (stride NumMels), into dst
+// (frame-contiguous, stride NumMFCC). It processes
+// min(len(logMel)/NumMels, len(dst)/NumMFCC) whole frames and returns that
+// count. dst must not overlap logMel. Allocation-free.
+func (c *MFCC) Apply(dst, logMel []float64) int {
+	frames := min(len(logMel)/c.nMels, len(dst)/c.nMFCC)
+	for f := range frames {
+		in := logMel[f*c.nMels : (f+1)*c.nMels]
+		out := dst[f*c.nMFCC : (f+1)*c.nMFCC]
+		for k := range out {
+			out[k] = DotProduct(c.basis[k*c.nMels:(k+1)*c.nMels], in)
+		}
+	}
+	return frames
+}
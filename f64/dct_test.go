@@ -0,0 +1,243 @@
+package f64
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// dctSizes covers the power-of-two, mixed-radix, and Bluestein schedules of
+// the underlying FFT, plus the odd and tiny sizes the reorder must handle.
+var dctSizes = []int{1, 2, 3, 4, 5, 8, 13, 16, 40, 64, 80, 97, 128, 400, 1024}
+
+// dctRef evaluates the scipy.fft.dct type 2 or 3 definition directly in float64.
+func dctRef(src []float64, typ int, norm DCTNorm) []float64 {
+	n := len(src)
+	out := make([]float64, n)
+	for k := range n {
+		var sum float64
+		for m := range n {
+			x := src[m]
+			if typ == 2 {
+				w := 2.0
+				if norm == DCTOrtho {
+					w = 2 * math.Sqrt(1/float64(2*n))
+					if k == 0 {
+						w = 2 * math.Sqrt(1/float64(4*n))
+					}
+				}
+				sum += w * x * math.Cos(math.Pi*float64(k*(2*m+1))/float64(2*n))
+				continue
+			}
+			w := 2.0
+			if m == 0 {
+				w = 1
+			}
+			if norm == DCTOrtho {
+				w = math.Sqrt(2 / float64(n))
+				if m == 0 {
+					w = math.Sqrt(1 / float64(n))
+				}
+			}
+			sum += w * x * math.Cos(math.Pi*float64(m*(2*k+1))/float64(2*n))
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+func TestNewDCTPlanErrors(t *testing.T) {
+	for _, bad := range []int{-1, 0} {
+		if _, err := NewDCTPlan(bad); !errors.Is(err, ErrDCTSize) {
+			t.Errorf("NewDCTPlan(%d) error = %v, want ErrDCTSize", bad, err)
+		}
+	}
+	p, err := NewDCTPlan(40)
+	if err != nil || p.Len() != 40 {
+		t.Fatalf("NewDCTPlan(40) = %v, %v", p, err)
+	}
+}
+
+// TestDCTAgainstRef checks DCT2 and DCT3 in both normalizations against the
+// direct float64 definitions.
+func TestDCTAgainstRef(t *testing.T) {
+	for _, n := range dctSizes {
+		p, err := NewDCTPlan(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src := testSignal(n)
+		var scale float64
+		for _, v := range src {
+			scale += math.Abs(v)
+		}
+		for _, typ := range []int{2, 3} {
+			for _, norm := range []DCTNorm{DCTBackward, DCTOrtho} {
+				want := dctRef(src, typ, norm)
+				got := make([]float64, n)
+				if typ == 2 {
+					p.DCT2(got, src, norm)
+				} else {
+					p.DCT3(got, src, norm)
+				}
+				tol := 1e-12*scale*float64(n) + 1e-12
+				if norm == DCTOrtho {
+					tol /= math.Sqrt(float64(n))
+				}
+				for k := range n {
+					if d := math.Abs(got[k] - want[k]); d > tol {
+						t.Fatalf("n=%d type=%d norm=%d k=%d: got %g want %g (|diff|=%g tol=%g)", n, typ, norm, k, got[k], want[k], d, tol)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestDCTRoundTrip checks that the orthonormal DCT3 inverts DCT2 and that the
+// unnormalized pair composes to 2n, with dst aliasing src.
+func TestDCTRoundTrip(t *testing.T) {
+	for _, n := range dctSizes {
+		p, _ := NewDCTPlan(n)
+		src := testSignal(n)
+		for _, norm := range []DCTNorm{DCTBackward, DCTOrtho} {
+			buf := append([]float64(nil), src...)
+			p.DCT2(buf, buf, norm)
+			p.DCT3(buf, buf, norm)
+			gain := 1.0
+			if norm == DCTBackward {
+				gain = float64(2 * n)
+			}
+			for i := range n {
+				if d := math.Abs(buf[i]/gain - src[i]); d > 1e-12 {
+					t.Fatalf("n=%d norm=%d: round trip[%d] = %v want %v", n, norm, i, buf[i]/gain, src[i])
+				}
+			}
+		}
+	}
+}
+
+// TestDCTGuards checks that short slices are a no-op and that only the first
+// Len() values are touched.
+func TestDCTGuards(t *testing.T) {
+	p, _ := NewDCTPlan(8)
+	short := []float64{1, 2, 3, 4, 5, 6, 7}
+	p.DCT2(short, testSignal(8), DCTOrtho)
+	p.DCT3(make([]float64, 8), short, DCTOrtho)
+	for i, v := range short {
+		if v != float64(i+1) {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+	long := make([]float64, 10)
+	long[9] = 42
+	p.DCT2(long, testSignal(10), DCTBackward)
+	p.DCT3(long, long, DCTBackward)
+	if long[9] != 42 {
+		t.Fatalf("value past Len() modified")
+	}
+}
+
+// mfccRef is librosa.feature.mfcc on one log-mel frame in float64:
+// scipy.fft.dct(type=2, norm="ortho")[:nMFCC], then the optional lifter.
+func mfccRef(frame []float64, nMFCC int, lifter float64) []float64 {
+	full := dctRef(frame, 2, DCTOrtho)[:nMFCC]
+	if lifter > 0 {
+		for k := range full {
+			full[k] *= 1 + lifter/2*math.Sin(math.Pi*float64(k+1)/lifter)
+		}
+	}
+	return full
+}
+
+// TestMFCC runs the full front-end (STFT power, log-mel, MFCC) and checks the
+// coefficients against the float64 librosa port, with and without liftering, and
+// that the truncated projection agrees with a full DCTPlan.
+func TestMFCC(t *testing.T) {
+	fb, _ := NewMelFilterbank(16000, 400, 40, 0, 0, MelSlaney, MelNormSlaney)
+	power, frames := melPower(t, 400, 160, 4000)
+	logMel := make([]float64, frames*fb.NumMels())
+	fb.LogMel(logMel, power, 1, 1e-10, 80)
+	dct, _ := NewDCTPlan(fb.NumMels())
+	full := make([]float64, fb.NumMels())
+	for _, c := range []struct {
+		n      int
+		lifter float64
+	}{{13, 0}, {20, 22}, {40, 0}, {1, 0}} {
+		m, err := NewMFCC(fb, c.n, c.lifter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.NumMFCC() != c.n {
+			t.Fatalf("NumMFCC() = %d, want %d", m.NumMFCC(), c.n)
+		}
+		dst := make([]float64, frames*c.n)
+		if got := m.Apply(dst, logMel); got != frames {
+			t.Fatalf("Apply wrote %d frames, want %d", got, frames)
+		}
+		for f := range frames {
+			frame := logMel[f*fb.NumMels() : (f+1)*fb.NumMels()]
+			want := mfccRef(frame, c.n, c.lifter)
+			dct.DCT2(full, frame, DCTOrtho)
+			for k := range c.n {
+				got := dst[f*c.n+k]
+				ctx := fmt.Sprintf("n=%d lifter=%g f=%d k=%d", c.n, c.lifter, f, k)
+				if d := math.Abs(got - want[k]); d > 1e-10 {
+					t.Fatalf("%s: got %g want %g", ctx, got, want[k])
+				}
+				if c.lifter == 0 && math.Abs(got-full[k]) > 1e-10 {
+					t.Fatalf("%s: got %g, DCTPlan %g", ctx, got, full[k])
+				}
+			}
+		}
+	}
+}
+
+func TestNewMFCCErrors(t *testing.T) {
+	fb, _ := NewMelFilterbank(16000, 400, 40, 0, 0, MelSlaney, MelNormSlaney)
+	for i, c := range []struct {
+		fb     *MelFilterbank
+		n      int
+		lifter float64
+	}{{nil, 13, 0}, {fb, 0, 0}, {fb, 41, 0}, {fb, 13, -1}, {fb, 13, math.NaN()}} {
+		if _, err := NewMFCC(c.fb, c.n, c.lifter); !errors.Is(err, ErrMFCCConfig) {
+			t.Errorf("case %d: error = %v, want ErrMFCCConfig", i, err)
+		}
+	}
+}
+
+func TestDCTAllocFree(t *testing.T) {
+	for _, n := range []int{40, 128, 97} {
+		p, _ := NewDCTPlan(n)
+		src := testSignal(n)
+		dst := make([]float64, n)
+		if a := testing.AllocsPerRun(5, func() { p.DCT2(dst, src, DCTOrtho) }); a != 0 {
+			t.Errorf("n=%d: DCT2 allocated %v times per run, want 0", n, a)
+		}
+		if a := testing.AllocsPerRun(5, func() { p.DCT3(dst, src, DCTOrtho) }); a != 0 {
+			t.Errorf("n=%d: DCT3 allocated %v times per run, want 0", n, a)
+		}
+	}
+	fb, _ := NewMelFilterbank(16000, 400, 40, 0, 0, MelSlaney, MelNormSlaney)
+	m, _ := NewMFCC(fb, 13, 22)
+	logMel := testSignal(40 * 10)
+	dst := make([]float64, 13*10)
+	if a := testing.AllocsPerRun(5, func() { m.Apply(dst, logMel) }); a != 0 {
+		t.Errorf("MFCC.Apply allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkDCT2(b *testing.B) {
+	for _, n := range []int{40, 128, 1024} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			p, _ := NewDCTPlan(n)
+			src := testSignal(n)
+			dst := make([]float64, n)
+			b.ReportAllocs()
+			for b.Loop() {
+				p.DCT2(dst, src, DCTOrtho)
+			}
+		})
+	}
+}
@@ -32,7 +32,8 @@
 //     window, except that AddScaled also permits s==dst exactly.
 //   - ButterflyComplex, ButterflyComplexStage and ButterflyComplexStage4 update
 //     their data slices in place; those slices must not overlap one another, and
-//     the twiddles must not overlap them.
+//     the twiddles must not overlap them. FFTPlan.Forward and FFTPlan.Inverse
+//     likewise transform (re, im) in place, and re and im must not overlap.
 //   - The mirror, window, stride, interleave, batch and resample operations
 //     (Interleave2/N, Deinterleave2/N, ConvolveValid and ConvolveValidMulti,
 //     ConvolveDecimate, DotProductBatch, Autocorrelate, RealFFTUnpack and RealFFTPower)
@@ -378,6 +379,42 @@ func DotProductBatch(results []float64, rows [][]float64, vec []float64) {
 	dotProductBatch64(results[:n], rows[:n], vec)
 }
 
+// DotProductIndexed computes dot products between query and selected rows in a
+// flat row-major base slice. For each processed row i:
+//
+//	dst[i] = dot(base[rowIDs[i]*dims : rowIDs[i]*dims+dims], query[:dims])
+//
+// The number of processed rows is min(len(dst), len(rowIDs)). Ragged inputs are
+// safe: if query is shorter than dims or a row extends past base, the dot uses
+// the available common prefix; out-of-range row IDs, non-positive dims, or an
+// empty query produce a zero score for that row. The function returns true when
+// at least one optimized platform SIMD batch kernel was used; false means the
+// per-row fallback handled the call.
+func DotProductIndexed(dst, base, query []float64, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	return dotProductIndexed(dst[:n], base, query, rowIDs[:n], dims)
+}
+
+// DotProductStrided computes dot products between query and rowCount rows in a
+// flat base slice where row i starts at base[i*stride]. dims and stride are in
+// float64 elements, not bytes; use stride >= dims for non-overlapping rows.
+// The number of processed rows is
+// min(len(dst), rowCount). Ragged inputs are safe: if query is shorter than dims
+// or a row extends past base, the dot uses the available common prefix;
+// non-positive rowCount/dims/stride or an empty query produce zero scores for
+// processed rows. The function returns true when at least one optimized platform
+// SIMD batch kernel was used; false means the per-row fallback handled the call.
+func DotProductStrided(dst, base, query []float64, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	return dotProductStrided(dst[:n], base, query, n, dims, stride)
+}
+
 // Autocorrelate computes the autocorrelation of x at lags 0..maxLag:
 //
 //	autoc[lag] = Σ x[i]*x[i-lag]  for i in lag..len(x)-1
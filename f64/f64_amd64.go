@@ -17,7 +17,7 @@ const (
 	minAVX512Elements = 8
 )
 
-// minSIMDElements is set at init time based on which SIMD implementation is selected.
+// minSIMDElements is set by bindKernels based on which SIMD implementation is selected.
 // Used by min64/max64 to determine when to fall back to scalar code.
 var minSIMDElements = minAVXElements
 
@@ -25,6 +25,13 @@ var minSIMDElements = minAVXElements
 // a direct dispatch rather than the init-time function pointers above.
 var hasAVX2 = cpu.X86.AVX2
 
+// hasAVXFMA and hasAVX512 gate the GEMM microkernel and the biquad section
+// kernel, which are likewise dispatched per call; bindKernels re-reads them.
+var (
+	hasAVXFMA = cpu.X86.AVX && cpu.X86.FMA
+	hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
+)
+
 // Function pointer types for SIMD operations
 type (
 	dotProductFunc          func(a, b []float64) float64
@@ -73,6 +80,15 @@ var (
 )
 
 func init() {
+	bindKernels()
+}
+
+// bindKernels selects the implementations from the current cpu.X86 flags. It
+// runs from init, and again from cpu.Override.
+func bindKernels() {
+	hasAVX2 = cpu.X86.AVX2
+	hasAVXFMA = cpu.X86.AVX && cpu.X86.FMA
+	hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
 	// Select optimal implementation based on CPU features.
 	// Priority: AVX-512 > AVX+FMA > AVX (no FMA) > SSE2 > Go
 	switch {
@@ -186,6 +202,7 @@ func initAVXNoFMA() {
 }
 
 func initSSE2() {
+	minSIMDElements = minAVXElements
 	dotProductImpl = dotProductSSE2
 	addImpl = addSSE2
 	subImpl = subSSE2
@@ -214,6 +231,7 @@ func initSSE2() {
 }
 
 func initGo() {
+	minSIMDElements = minAVXElements
 	dotProductImpl = dotProductGo
 	addImpl = addGo
 	subImpl = subGo
@@ -422,6 +440,126 @@ func dotProductBatchKernel(useAVX512 bool, results []float64, rows [][]float64,
 	}
 }
 
+// dotProduct4Batch scores four full rows (base[off0..off3], each dims long)
+// against query and writes the four results starting at dst[di]. It centralizes
+// the unsafe pointer setup and the AVX-512/AVX kernel selection shared by the
+// indexed and strided batch loops.
+func dotProduct4Batch(useAVX512 bool, dst []float64, di int, base []float64, off0, off1, off2, off3 int, query []float64, dims int) {
+	results := (*float64)(unsafe.Pointer(&dst[di]))
+	r0 := (*float64)(unsafe.Pointer(&base[off0]))
+	r1 := (*float64)(unsafe.Pointer(&base[off1]))
+	r2 := (*float64)(unsafe.Pointer(&base[off2]))
+	r3 := (*float64)(unsafe.Pointer(&base[off3]))
+	q := (*float64)(unsafe.Pointer(&query[0]))
+	if useAVX512 {
+		dotProduct4AVX512(results, r0, r1, r2, r3, q, dims)
+	} else {
+		dotProduct4AVX(results, r0, r1, r2, r3, q, dims)
+	}
+}
+
+// gemmMicroKernel returns the GEMM microkernel of the widest tier this CPU
+// supports, or false when GEMM should take the pure-Go loop (initAVXNoFMA CPUs
+// included: both kernels need FMA3).
+func gemmMicroKernel() (gemmMicro, bool) {
+	switch {
+	case hasAVX512:
+		return gemmMicro{mr: 6, nr: 16, kernel: gemmKernel6x16AVX512}, true
+	case hasAVXFMA:
+		return gemmMicro{mr: 6, nr: 8, kernel: gemmKernel6x8AVX}, true
+	}
+	return gemmMicro{}, false
+}
+
+func dotProductIndexed(dst, base, query []float64, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	if !batchDotIndexedSIMDEligible(n, dims, len(query)) {
+		dotProductIndexedFallback(dst[:n], base, query, rowIDs[:n], dims)
+		return false
+	}
+	maxRow := fullRowMaxIndex(len(base), dims, dims)
+	if maxRow < 0 {
+		dotProductIndexedFallback(dst[:n], base, query, rowIDs[:n], dims)
+		return false
+	}
+	useAVX512 := cpu.X86.AVX512F && cpu.X86.AVX512VL
+	useAVX := !useAVX512 && cpu.X86.AVX && cpu.X86.FMA
+	if !useAVX512 && !useAVX {
+		dotProductIndexedFallback(dst[:n], base, query, rowIDs[:n], dims)
+		return false
+	}
+
+	queryFull := query[:dims]
+	usedSIMD := false
+	i := 0
+	for ; i+batchDotRows-1 < n; i += batchDotRows {
+		id0, id1, id2, id3 := rowIDs[i], rowIDs[i+1], rowIDs[i+2], rowIDs[i+3]
+		if rowIDInFullRange(id0, maxRow) && rowIDInFullRange(id1, maxRow) && rowIDInFullRange(id2, maxRow) && rowIDInFullRange(id3, maxRow) {
+			off0 := int(id0) * dims
+			off1 := int(id1) * dims
+			off2 := int(id2) * dims
+			off3 := int(id3) * dims
+			dotProduct4Batch(useAVX512, dst, i, base, off0, off1, off2, off3, queryFull, dims)
+			usedSIMD = true
+			continue
+		}
+		for j := range batchDotRows {
+			dst[i+j] = dotProductIndexedTail(base, query, queryFull, rowIDs[i+j], dims, maxRow)
+		}
+	}
+	for ; i < n; i++ {
+		dst[i] = dotProductIndexedTail(base, query, queryFull, rowIDs[i], dims, maxRow)
+	}
+	return usedSIMD
+}
+
+func dotProductStrided(dst, base, query []float64, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	if !batchDotStridedSIMDEligible(n, dims, stride, len(query)) {
+		dotProductStridedFallback(dst[:n], base, query, n, dims, stride)
+		return false
+	}
+	maxRow := fullRowMaxIndex(len(base), dims, stride)
+	if maxRow < 0 {
+		dotProductStridedFallback(dst[:n], base, query, n, dims, stride)
+		return false
+	}
+	useAVX512 := cpu.X86.AVX512F && cpu.X86.AVX512VL
+	useAVX := !useAVX512 && cpu.X86.AVX && cpu.X86.FMA
+	if !useAVX512 && !useAVX {
+		dotProductStridedFallback(dst[:n], base, query, n, dims, stride)
+		return false
+	}
+
+	queryFull := query[:dims]
+	usedSIMD := false
+	i := 0
+	for ; i+batchDotRows-1 < n; i += batchDotRows {
+		if i+batchDotRows-1 <= maxRow {
+			off0 := i * stride
+			off1 := off0 + stride
+			off2 := off1 + stride
+			off3 := off2 + stride
+			dotProduct4Batch(useAVX512, dst, i, base, off0, off1, off2, off3, queryFull, dims)
+			usedSIMD = true
+			continue
+		}
+		for j := range batchDotRows {
+			dst[i+j] = dotProductStridedTail(base, query, queryFull, i+j, dims, stride, maxRow)
+		}
+	}
+	for ; i < n; i++ {
+		dst[i] = dotProductStridedTail(base, query, queryFull, i, dims, stride, maxRow)
+	}
+	return usedSIMD
+}
+
 // autocorrelate64 computes autoc[lag] for lag in 0..maxLag. On AVX2 it
 // vectorizes ACROSS lags: four consecutive lags share one accumulator register,
 // each lane summing its lag's x[i]*x[i-lag] terms in increasing-i order with
@@ -799,6 +937,15 @@ func exp64(dst, src []float64) {
 	exp64Go(dst, src)
 }
 
+// expSum64 writes dst[i] = e^(src[i]-shift) and returns the sum of the written
+// values. Same AVX2 requirement as exp64.
+func expSum64(dst, src []float64, shift float64) float64 {
+	if cpu.X86.AVX2 && len(dst) >= minAVXElements {
+		return expSumAVX(dst, src, shift)
+	}
+	return expSum64Go(dst, src, shift)
+}
+
 // logSIMDOK reports whether the AVX log/pow kernels can run: they need AVX2
 // (YMM integer ops for the exponent extraction) and FMA (polynomial
 // evaluation). AVX1-only or FMA-less CPUs use the accurate Go path.
@@ -863,6 +1010,9 @@ func powElemAVX(dst, base, exp []float64)
 //go:noescape
 func expAVX(dst, src []float64)
 
+//go:noescape
+func expSumAVX(dst, src []float64, shift float64) float64
+
 //go:noescape
 func sigmoidAVX(dst, src []float64)
 
@@ -874,6 +1024,9 @@ func dotProductAVX(a, b []float64) float64
 //go:noescape
 func dotProduct4AVX(results, row0, row1, row2, row3, vec *float64, n int)
 
+//go:noescape
+func gemmKernel6x8AVX(k int, a, b, c *float64, ldc int)
+
 // autocorrStep4AVX accumulates the steady region of four autocorrelation lags.
 // acc points at four contiguous seeded accumulators (lags base..base+3);
 // broadcast at x[pmax] and window at x[pmax-base-3] advance one element per
@@ -970,6 +1123,9 @@ func dotProductAVX512(a, b []float64) float64
 //go:noescape
 func dotProduct4AVX512(results, row0, row1, row2, row3, vec *float64, n int)
 
+//go:noescape
+func gemmKernel6x16AVX512(k int, a, b, c *float64, ldc int)
+
 //go:noescape
 func addAVX512(dst, a, b []float64)
 
@@ -1166,3 +1322,26 @@ func realFFTUnpackAVX(outRe, outIm, zRe, zIm, twRe, twIm []float64, n int)
 //
 //go:noescape
 func realFFTPowerAVX(dst, zRe, zIm, twRe, twIm []float64, n int)
+
+// biquadSection runs one section over every frame of the interleaved data in
+// place: groups of 4 channels per biquadAVX call, then one group each of 2 and
+// 1 for the remainder, so stereo and other narrow layouts stay on the vector
+// path without masked moves.
+func biquadSection(data []float64, ch int, b *Biquad, s1, s2 []float64) {
+	if !hasAVXFMA {
+		biquadSectionGo(data, ch, 0, b, s1, s2)
+		return
+	}
+	frames := len(data) / ch
+	for c, lanes := 0, 4; c < ch; lanes >>= 1 {
+		for ; ch-c >= lanes; c += lanes {
+			biquadAVX(&data[c], frames, ch, b, &s1[c], &s2[c], lanes)
+		}
+	}
+}
+
+// biquadAVX runs one section over frames frames of lanes (4, 2 or 1) channels
+// starting at x, stride floats apart, with the channels' state in s1 and s2.
+//
+//go:noescape
+func biquadAVX(x *float64, frames, stride int, b *Biquad, s1, s2 *float64, lanes int)
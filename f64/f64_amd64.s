@@ -4798,6 +4798,126 @@ exp64_done:
     VZEROUPPER
     RET
 
+// func expSumAVX(dst, src []float64, shift float64) float64
+// Computes dst[i] = e^(src[i]-shift) with the expAVX core and returns the sum
+// of the stored values, the fused exp-and-sum pass of Softmax, LogSoftmax and
+// LogSumExp. The shifted inputs are clamped to [-709, 709] exactly as in
+// expAVX; the upper bound is read from memory so Y6/Y7 can hold the running
+// sum and the broadcast shift. Needs AVX2 for the 2^k reconstruction, no FMA.
+TEXT ·expSumAVX(SB), NOSPLIT, $0-64
+    MOVQ dst_base+0(FP), DX
+    MOVQ dst_len+8(FP), CX
+    MOVQ src_base+24(FP), SI
+
+    VMOVUPD tanh64_log2e<>(SB), Y9     // Y9 = log2(e)
+    VMOVUPD tanh64_ln2<>(SB), Y10      // Y10 = ln(2)
+    VMOVUPD sigmoid_one64<>(SB), Y11   // Y11 = 1.0
+    VMOVUPD sigmoid_half64<>(SB), Y12  // Y12 = 0.5 (c2)
+    VMOVUPD tanh64_c3<>(SB), Y13       // Y13 = 1/6 (c3)
+    VMOVUPD tanh64_c4<>(SB), Y14       // Y14 = 1/24 (c4)
+    VMOVUPD tanh64_c5<>(SB), Y15       // Y15 = 1/120 (c5)
+    VMOVUPD exp_clamp_lo64<>(SB), Y8   // Y8 = -709.0
+    VBROADCASTSD shift+48(FP), Y7      // Y7 = shift
+    VXORPD Y6, Y6, Y6                  // Y6 = running sum
+
+    MOVQ CX, R8
+    SHRQ $2, R8
+    JZ   expsum64_reduce
+
+expsum64_loop4:
+    VMOVUPD (SI), Y0
+    VSUBPD Y7, Y0, Y0                  // Y0 = x = src - shift
+
+    // Clamp x to [-709, 709]
+    VMINPD exp_clamp_hi64<>(SB), Y0, Y0
+    VMAXPD Y8, Y0, Y0
+
+    // Range reduction: k = round(x * log2e), r = x - k * ln2
+    VMULPD Y9, Y0, Y1
+    VROUNDPD $0, Y1, Y2                // Y2 = k
+    VMULPD Y10, Y2, Y3
+    VSUBPD Y3, Y0, Y3                  // Y3 = r
+
+    // Polynomial: exp(r) ~= 1 + r*(1 + r*(c2 + r*(c3 + r*(c4 + r*c5))))
+    VMULPD Y3, Y15, Y4
+    VADDPD Y14, Y4, Y4
+    VMULPD Y3, Y4, Y4
+    VADDPD Y13, Y4, Y4
+    VMULPD Y3, Y4, Y4
+    VADDPD Y12, Y4, Y4
+    VMULPD Y3, Y4, Y4
+    VADDPD Y11, Y4, Y4
+    VMULPD Y3, Y4, Y4
+    VADDPD Y11, Y4, Y4                 // Y4 = exp(r)
+
+    // Reconstruct exp(x) = exp(r) * 2^k
+    VCVTTPD2DQY Y2, X5
+    VPMOVSXDQ X5, Y5
+    VPSLLQ $52, Y5, Y5
+    VPADDQ sigmoid_one64<>(SB), Y5, Y5
+    VMULPD Y5, Y4, Y4                  // Y4 = exp(x)
+
+    VMOVUPD Y4, (DX)
+    VADDPD Y4, Y6, Y6                  // sum += exp(x)
+    ADDQ $32, SI
+    ADDQ $32, DX
+    DECQ R8
+    JNZ  expsum64_loop4
+
+expsum64_reduce:
+    VEXTRACTF128 $1, Y6, X1
+    VADDPD X1, X6, X6
+    VHADDPD X6, X6, X6                 // X6 = vector sum
+
+    ANDQ $3, CX
+    JZ   expsum64_done
+
+expsum64_scalar:
+    VMOVSD (SI), X0
+    VSUBSD X7, X0, X0                  // X0 = x = src - shift
+
+    // Clamp to [-709, 709]
+    VMINSD exp_clamp_hi64<>(SB), X0, X0
+    VMAXSD X8, X0, X0
+
+    // Range reduction
+    VMULSD X9, X0, X1
+    VROUNDSD $0, X1, X1, X2            // X2 = k
+    VMULSD X10, X2, X3
+    VSUBSD X3, X0, X3                  // X3 = r
+
+    // Polynomial
+    VMULSD X3, X15, X4
+    VADDSD X14, X4, X4
+    VMULSD X3, X4, X4
+    VADDSD X13, X4, X4
+    VMULSD X3, X4, X4
+    VADDSD X12, X4, X4
+    VMULSD X3, X4, X4
+    VADDSD X11, X4, X4
+    VMULSD X3, X4, X4
+    VADDSD X11, X4, X4                 // X4 = exp(r)
+
+    // Reconstruct 2^k
+    VCVTTSD2SI X2, AX
+    SHLQ $52, AX
+    MOVQ $0x3FF0000000000000, BX
+    ADDQ BX, AX
+    VMOVQ AX, X5
+    VMULSD X5, X4, X4                  // X4 = exp(x)
+
+    VMOVSD X4, (DX)
+    VADDSD X4, X6, X6                  // sum += exp(x)
+    ADDQ $8, SI
+    ADDQ $8, DX
+    DECQ CX
+    JNZ  expsum64_scalar
+
+expsum64_done:
+    VMOVSD X6, ret+56(FP)
+    VZEROUPPER
+    RET
+
 // ============================================================================
 // roundAVX: round-half-away-from-zero
 // ============================================================================
@@ -7203,3 +7323,292 @@ realfftpow64_remainder:
 realfftpow64_done:
     VZEROUPPER
     RET
+
+// ============================================================================
+// GEMM MICROKERNELS
+// Register-blocked tiles for the packed GEMM driver in gemm.go; the caller
+// handles blocking, packing, alpha/beta and partial tiles.
+// ============================================================================
+
+// func gemmKernel6x8AVX(k int, a, b, c *float64, ldc int)
+// AVX+FMA GEMM microkernel: c[i*ldc+j] += sum over p < k of a[p*6+i] * b[p*8+j]
+// for the 6x8 tile, from A packed 6 rows and B packed 8 columns per
+// step of p. Y4-Y15 hold the tile, 2 registers per row; Y0-Y1 hold the B row
+// and Y2-Y3 alternate as the broadcast A element.
+TEXT ·gemmKernel6x8AVX(SB), NOSPLIT, $0-40
+    MOVQ k+0(FP), CX
+    MOVQ a+8(FP), SI
+    MOVQ b+16(FP), DI
+    MOVQ c+24(FP), DX
+    MOVQ ldc+32(FP), R8
+    SHLQ $3, R8                // ldc in bytes
+
+    VXORPS Y4, Y4, Y4
+    VXORPS Y5, Y5, Y5
+    VXORPS Y6, Y6, Y6
+    VXORPS Y7, Y7, Y7
+    VXORPS Y8, Y8, Y8
+    VXORPS Y9, Y9, Y9
+    VXORPS Y10, Y10, Y10
+    VXORPS Y11, Y11, Y11
+    VXORPS Y12, Y12, Y12
+    VXORPS Y13, Y13, Y13
+    VXORPS Y14, Y14, Y14
+    VXORPS Y15, Y15, Y15
+
+    TESTQ CX, CX
+    JZ    gemmkernel6x8avx_store
+
+gemmkernel6x8avx_loop:
+    VMOVUPD (DI), Y0
+    VMOVUPD 32(DI), Y1
+    VBROADCASTSD (SI), Y2
+    VFMADD231PD Y0, Y2, Y4
+    VFMADD231PD Y1, Y2, Y5
+    VBROADCASTSD 8(SI), Y3
+    VFMADD231PD Y0, Y3, Y6
+    VFMADD231PD Y1, Y3, Y7
+    VBROADCASTSD 16(SI), Y2
+    VFMADD231PD Y0, Y2, Y8
+    VFMADD231PD Y1, Y2, Y9
+    VBROADCASTSD 24(SI), Y3
+    VFMADD231PD Y0, Y3, Y10
+    VFMADD231PD Y1, Y3, Y11
+    VBROADCASTSD 32(SI), Y2
+    VFMADD231PD Y0, Y2, Y12
+    VFMADD231PD Y1, Y2, Y13
+    VBROADCASTSD 40(SI), Y3
+    VFMADD231PD Y0, Y3, Y14
+    VFMADD231PD Y1, Y3, Y15
+    ADDQ $48, SI
+    ADDQ $64, DI
+    DECQ CX
+    JNZ  gemmkernel6x8avx_loop
+
+gemmkernel6x8avx_store:
+    VADDPD (DX), Y4, Y4
+    VMOVUPD Y4, (DX)
+    VADDPD 32(DX), Y5, Y5
+    VMOVUPD Y5, 32(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Y6, Y6
+    VMOVUPD Y6, (DX)
+    VADDPD 32(DX), Y7, Y7
+    VMOVUPD Y7, 32(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Y8, Y8
+    VMOVUPD Y8, (DX)
+    VADDPD 32(DX), Y9, Y9
+    VMOVUPD Y9, 32(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Y10, Y10
+    VMOVUPD Y10, (DX)
+    VADDPD 32(DX), Y11, Y11
+    VMOVUPD Y11, 32(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Y12, Y12
+    VMOVUPD Y12, (DX)
+    VADDPD 32(DX), Y13, Y13
+    VMOVUPD Y13, 32(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Y14, Y14
+    VMOVUPD Y14, (DX)
+    VADDPD 32(DX), Y15, Y15
+    VMOVUPD Y15, 32(DX)
+    VZEROUPPER
+    RET
+
+// func gemmKernel6x16AVX512(k int, a, b, c *float64, ldc int)
+// AVX-512 GEMM microkernel: c[i*ldc+j] += sum over p < k of a[p*6+i] * b[p*16+j]
+// for the 6x16 tile, from A packed 6 rows and B packed 16 columns per
+// step of p. Z4-Z15 hold the tile, 2 registers per row; Z0-Z1 hold the B row
+// and Z2-Z3 alternate as the broadcast A element.
+TEXT ·gemmKernel6x16AVX512(SB), NOSPLIT, $0-40
+    MOVQ k+0(FP), CX
+    MOVQ a+8(FP), SI
+    MOVQ b+16(FP), DI
+    MOVQ c+24(FP), DX
+    MOVQ ldc+32(FP), R8
+    SHLQ $3, R8                // ldc in bytes
+
+    VPXORD Z4, Z4, Z4
+    VPXORD Z5, Z5, Z5
+    VPXORD Z6, Z6, Z6
+    VPXORD Z7, Z7, Z7
+    VPXORD Z8, Z8, Z8
+    VPXORD Z9, Z9, Z9
+    VPXORD Z10, Z10, Z10
+    VPXORD Z11, Z11, Z11
+    VPXORD Z12, Z12, Z12
+    VPXORD Z13, Z13, Z13
+    VPXORD Z14, Z14, Z14
+    VPXORD Z15, Z15, Z15
+
+    TESTQ CX, CX
+    JZ    gemmkernel6x16avx512_store
+
+gemmkernel6x16avx512_loop:
+    VMOVUPD (DI), Z0
+    VMOVUPD 64(DI), Z1
+    VBROADCASTSD (SI), Z2
+    VFMADD231PD Z0, Z2, Z4
+    VFMADD231PD Z1, Z2, Z5
+    VBROADCASTSD 8(SI), Z3
+    VFMADD231PD Z0, Z3, Z6
+    VFMADD231PD Z1, Z3, Z7
+    VBROADCASTSD 16(SI), Z2
+    VFMADD231PD Z0, Z2, Z8
+    VFMADD231PD Z1, Z2, Z9
+    VBROADCASTSD 24(SI), Z3
+    VFMADD231PD Z0, Z3, Z10
+    VFMADD231PD Z1, Z3, Z11
+    VBROADCASTSD 32(SI), Z2
+    VFMADD231PD Z0, Z2, Z12
+    VFMADD231PD Z1, Z2, Z13
+    VBROADCASTSD 40(SI), Z3
+    VFMADD231PD Z0, Z3, Z14
+    VFMADD231PD Z1, Z3, Z15
+    ADDQ $48, SI
+    ADDQ $128, DI
+    DECQ CX
+    JNZ  gemmkernel6x16avx512_loop
+
+gemmkernel6x16avx512_store:
+    VADDPD (DX), Z4, Z4
+    VMOVUPD Z4, (DX)
+    VADDPD 64(DX), Z5, Z5
+    VMOVUPD Z5, 64(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Z6, Z6
+    VMOVUPD Z6, (DX)
+    VADDPD 64(DX), Z7, Z7
+    VMOVUPD Z7, 64(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Z8, Z8
+    VMOVUPD Z8, (DX)
+    VADDPD 64(DX), Z9, Z9
+    VMOVUPD Z9, 64(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Z10, Z10
+    VMOVUPD Z10, (DX)
+    VADDPD 64(DX), Z11, Z11
+    VMOVUPD Z11, 64(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Z12, Z12
+    VMOVUPD Z12, (DX)
+    VADDPD 64(DX), Z13, Z13
+    VMOVUPD Z13, 64(DX)
+    ADDQ R8, DX
+    VADDPD (DX), Z14, Z14
+    VMOVUPD Z14, (DX)
+    VADDPD 64(DX), Z15, Z15
+    VMOVUPD Z15, 64(DX)
+    VZEROUPPER
+    RET
+
+// func biquadAVX(x *float64, frames, stride int, b *Biquad, s1, s2 *float64, lanes int)
+// One direct form II transposed section across lanes (4, 2 or 1) channels,
+// frame by frame:
+//
+//	y = B0*x + s1;  s1 = B1*x - A1*y + s2;  s2 = B2*x - A2*y
+//
+// Y0/Y1 carry s1/s2 through the loop (Y0 turns into y, which is stored before
+// the new state rotates in) and Y8-Y12 hold B0, B1, B2, A1, A2. Narrower groups
+// run the same sequence on the low XMM lanes with 16- or 8-byte moves, never
+// touching memory past the group.
+TEXT ·biquadAVX(SB), NOSPLIT, $0-56
+    MOVQ x+0(FP), DI
+    MOVQ frames+8(FP), CX
+    MOVQ stride+16(FP), DX
+    MOVQ b+24(FP), SI
+    MOVQ s1+32(FP), R8
+    MOVQ s2+40(FP), R9
+    MOVQ lanes+48(FP), R10
+    SHLQ $3, DX                // stride in bytes
+
+    VBROADCASTSD 0(SI), Y8     // B0
+    VBROADCASTSD 8(SI), Y9     // B1
+    VBROADCASTSD 16(SI), Y10   // B2
+    VBROADCASTSD 24(SI), Y11   // A1
+    VBROADCASTSD 32(SI), Y12   // A2
+
+    CMPQ R10, $4
+    JNE  biquad64_avx_x2
+    VMOVUPD (R8), Y0
+    VMOVUPD (R9), Y1
+    TESTQ CX, CX
+    JZ    biquad64_avx_y4_store
+
+biquad64_avx_y4_loop:
+    VMOVUPD (DI), Y2           // x
+    VFMADD231PD Y2, Y8, Y0     // y = B0*x + s1
+    VFMADD231PD Y2, Y9, Y1     // s2 + B1*x
+    VFNMADD231PD Y0, Y11, Y1   // s1 = s2 + B1*x - A1*y
+    VMULPD Y2, Y10, Y4         // B2*x
+    VFNMADD231PD Y0, Y12, Y4   // s2 = B2*x - A2*y
+    VMOVUPD Y0, (DI)
+    VMOVAPD Y1, Y0
+    VMOVAPD Y4, Y1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad64_avx_y4_loop
+
+biquad64_avx_y4_store:
+    VMOVUPD Y0, (R8)
+    VMOVUPD Y1, (R9)
+    VZEROUPPER
+    RET
+
+biquad64_avx_x2:
+    CMPQ R10, $2
+    JNE  biquad64_avx_x1
+    VMOVUPD (R8), X0
+    VMOVUPD (R9), X1
+    TESTQ CX, CX
+    JZ    biquad64_avx_x2_store
+
+biquad64_avx_x2_loop:
+    VMOVUPD (DI), X2           // x
+    VFMADD231PD X2, X8, X0     // y = B0*x + s1
+    VFMADD231PD X2, X9, X1     // s2 + B1*x
+    VFNMADD231PD X0, X11, X1   // s1 = s2 + B1*x - A1*y
+    VMULPD X2, X10, X4         // B2*x
+    VFNMADD231PD X0, X12, X4   // s2 = B2*x - A2*y
+    VMOVUPD X0, (DI)
+    VMOVAPD X1, X0
+    VMOVAPD X4, X1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad64_avx_x2_loop
+
+biquad64_avx_x2_store:
+    VMOVUPD X0, (R8)
+    VMOVUPD X1, (R9)
+    VZEROUPPER
+    RET
+
+biquad64_avx_x1:
+    VMOVSD (R8), X0
+    VMOVSD (R9), X1
+    TESTQ CX, CX
+    JZ    biquad64_avx_x1_store
+
+biquad64_avx_x1_loop:
+    VMOVSD (DI), X2            // x
+    VFMADD231PD X2, X8, X0     // y = B0*x + s1
+    VFMADD231PD X2, X9, X1     // s2 + B1*x
+    VFNMADD231PD X0, X11, X1   // s1 = s2 + B1*x - A1*y
+    VMULPD X2, X10, X4         // B2*x
+    VFNMADD231PD X0, X12, X4   // s2 = B2*x - A2*y
+    VMOVSD X0, (DI)
+    VMOVAPD X1, X0
+    VMOVAPD X4, X1
+    ADDQ DX, DI
+    DECQ CX
+    JNZ  biquad64_avx_x1_loop
+
+biquad64_avx_x1_store:
+    VMOVSD X0, (R8)
+    VMOVSD X1, (R9)
+    VZEROUPPER
+    RET
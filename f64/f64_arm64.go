@@ -11,9 +11,20 @@ import (
 
 var (
 	hasNEON = cpu.ARM64.NEON
+	hasSVE  = cpu.ARM64.SVE
 )
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+	hasSVE = cpu.ARM64.SVE
+}
+
 func dotProduct(a, b []float64) float64 {
+	if hasSVE && len(a) >= 2 {
+		return dotProductSVE(a, b)
+	}
 	if hasNEON && len(a) >= 2 {
 		return dotProductNEON(a, b)
 	}
@@ -21,6 +32,10 @@ func dotProduct(a, b []float64) float64 {
 }
 
 func add(dst, a, b []float64) {
+	if hasSVE && len(dst) >= 2 {
+		addSVE(dst, a, b)
+		return
+	}
 	if hasNEON && len(dst) >= 2 {
 		addNEON(dst, a, b)
 		return
@@ -37,6 +52,10 @@ func sub(dst, a, b []float64) {
 }
 
 func mul(dst, a, b []float64) {
+	if hasSVE && len(dst) >= 2 {
+		mulSVE(dst, a, b)
+		return
+	}
 	if hasNEON && len(dst) >= 2 {
 		mulNEON(dst, a, b)
 		return
@@ -77,6 +96,9 @@ func subFromScalar64(dst, a []float64, s float64) {
 }
 
 func sum(a []float64) float64 {
+	if hasSVE && len(a) >= 2 {
+		return sumSVE(a)
+	}
 	if hasNEON && len(a) >= 2 {
 		return sumNEON(a)
 	}
@@ -121,6 +143,10 @@ func neg64(dst, a []float64) {
 }
 
 func fma64(dst, a, b, c []float64) {
+	if hasSVE && len(dst) >= 2 {
+		fmaSVE(dst, a, b, c)
+		return
+	}
 	if hasNEON && len(dst) >= 2 {
 		fmaNEON(dst, a, b, c)
 		return
@@ -180,8 +206,8 @@ func cumulativeSum64(dst, a []float64) {
 }
 
 // dotProductBatch64 scores rows against vec in groups of four, keeping the query
-// vector resident across each group via dotProduct4NEON instead of reloading it
-// per row. Rows shorter than vecLen (and any tail past the last full group of
+// vector resident across each group via dotProduct4SVE or dotProduct4NEON
+// instead of reloading it per row. Rows shorter than vecLen (and any tail past the last full group of
 // four) fall back to the per-row dotProduct, so results stay anchored to the
 // scalar contract regardless of row shape. f64 packs half the lanes of f32, so
 // the query-reuse win is smaller; benchmarks on the Raspberry Pi 5 justify
@@ -199,7 +225,11 @@ func dotProductBatch64(results []float64, rows [][]float64, vec []float64) {
 				r2 := (*float64)(unsafe.Pointer(&row2[0]))
 				r3 := (*float64)(unsafe.Pointer(&row3[0]))
 				q := (*float64)(unsafe.Pointer(&vec[0]))
-				dotProduct4NEON(res, r0, r1, r2, r3, q, vecLen)
+				if hasSVE {
+					dotProduct4SVE(res, r0, r1, r2, r3, q, vecLen)
+				} else {
+					dotProduct4NEON(res, r0, r1, r2, r3, q, vecLen)
+				}
 				i += 4
 				continue
 			}
@@ -226,6 +256,117 @@ func dotProductBatch64(results []float64, rows [][]float64, vec []float64) {
 	}
 }
 
+func dotProductIndexed(dst, base, query []float64, rowIDs []uint32, dims int) bool {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return false
+	}
+	if !hasNEON || !batchDotIndexedSIMDEligible(n, dims, len(query)) {
+		dotProductIndexedFallback(dst[:n], base, query, rowIDs[:n], dims)
+		return false
+	}
+	maxRow := fullRowMaxIndex(len(base), dims, dims)
+	if maxRow < 0 {
+		dotProductIndexedFallback(dst[:n], base, query, rowIDs[:n], dims)
+		return false
+	}
+
+	queryFull := query[:dims]
+	usedSIMD := false
+	i := 0
+	for ; i+batchDotRows-1 < n; i += batchDotRows {
+		id0, id1, id2, id3 := rowIDs[i], rowIDs[i+1], rowIDs[i+2], rowIDs[i+3]
+		if rowIDInFullRange(id0, maxRow) && rowIDInFullRange(id1, maxRow) && rowIDInFullRange(id2, maxRow) && rowIDInFullRange(id3, maxRow) {
+			off0 := int(id0) * dims
+			off1 := int(id1) * dims
+			off2 := int(id2) * dims
+			off3 := int(id3) * dims
+			dotProduct4Batch(dst, i, base, off0, off1, off2, off3, queryFull, dims)
+			usedSIMD = true
+			continue
+		}
+		for j := range batchDotRows {
+			dst[i+j] = dotProductIndexedTail(base, query, queryFull, rowIDs[i+j], dims, maxRow)
+		}
+	}
+	for ; i < n; i++ {
+		dst[i] = dotProductIndexedTail(base, query, queryFull, rowIDs[i], dims, maxRow)
+	}
+	return usedSIMD
+}
+
+func dotProductStrided(dst, base, query []float64, rowCount, dims, stride int) bool {
+	if rowCount <= 0 || len(dst) == 0 {
+		return false
+	}
+	n := min(len(dst), rowCount)
+	if !hasNEON || !batchDotStridedSIMDEligible(n, dims, stride, len(query)) {
+		dotProductStridedFallback(dst[:n], base, query, n, dims, stride)
+		return false
+	}
+	maxRow := fullRowMaxIndex(len(base), dims, stride)
+	if maxRow < 0 {
+		dotProductStridedFallback(dst[:n], base, query, n, dims, stride)
+		return false
+	}
+
+	queryFull := query[:dims]
+	usedSIMD := false
+	i := 0
+	for ; i+batchDotRows-1 < n; i += batchDotRows {
+		if i+batchDotRows-1 <= maxRow {
+			off0 := i * stride
+			off1 := off0 + stride
+			off2 := off1 + stride
+			off3 := off2 + stride
+			dotProduct4Batch(dst, i, base, off0, off1, off2, off3, queryFull, dims)
+			usedSIMD = true
+			continue
+		}
+		for j := range batchDotRows {
+			dst[i+j] = dotProductStridedTail(base, query, queryFull, i+j, dims, stride, maxRow)
+		}
+	}
+	for ; i < n; i++ {
+		dst[i] = dotProductStridedTail(base, query, queryFull, i, dims, stride, maxRow)
+	}
+	return usedSIMD
+}
+
+// gemmMicroKernel returns the NEON GEMM microkernel, or false when GEMM should
+// take the pure-Go loop. There is no SVE tier: the 8x4 tile is sized to the
+// NEON register file.
+func gemmMicroKernel() (gemmMicro, bool) {
+	if !hasNEON {
+		return gemmMicro{}, false
+	}
+	return gemmMicro{mr: 8, nr: 4, kernel: gemmKernel8x4NEON}, true
+}
+
+// dotProduct4Batch scores four full rows (base[off0..off3], each dims long)
+// against query and writes the four results starting at dst[di], centralizing
+// the unsafe pointer setup shared by the indexed and strided batch loops.
+func dotProduct4Batch(dst []float64, di int, base []float64, off0, off1, off2, off3 int, query []float64, dims int) {
+	results := (*float64)(unsafe.Pointer(&dst[di]))
+	r0 := (*float64)(unsafe.Pointer(&base[off0]))
+	r1 := (*float64)(unsafe.Pointer(&base[off1]))
+	r2 := (*float64)(unsafe.Pointer(&base[off2]))
+	r3 := (*float64)(unsafe.Pointer(&base[off3]))
+	q := (*float64)(unsafe.Pointer(&query[0]))
+	dotProduct4(results, r0, r1, r2, r3, q, dims)
+}
+
+// dotProduct4 runs the batch-of-4 kernel of the widest tier: SVE when present,
+// otherwise NEON. Callers have checked hasNEON, which every SVE host has (and
+// SIMD_DISABLE=neon clears SVE with it).
+func dotProduct4(results, row0, row1, row2, row3, vec *float64, n int) {
+	if hasSVE {
+		dotProduct4SVE(results, row0, row1, row2, row3, vec, n)
+		return
+	}
+	dotProduct4NEON(results, row0, row1, row2, row3, vec, n)
+}
+
 // autocorrelate64 computes autoc[lag] for lag in 0..maxLag. On NEON it
 // vectorizes ACROSS lags (two consecutive lags per accumulator register), each
 // lane summing its lag's x[i]*x[i-lag] terms in increasing-i order. The kernel
@@ -278,6 +419,16 @@ func convolveValid64(dst, signal, kernel []float64) {
 func convolveDecimate64(dst, signal, kernel []float64, factor, phase int) {
 	// Mirror dotProduct's NEON length threshold (>= 2) so the fused kernel and a
 	// per-window DotProductUnsafe pick the same backend, keeping results identical.
+	// SVE has no fused kernel: run its per-window dot directly.
+	if hasSVE && len(kernel) >= 2 {
+		kLen := len(kernel)
+		pos := phase
+		for k := range dst {
+			dst[k] = dotProductSVE(signal[pos:pos+kLen], kernel)
+			pos += factor
+		}
+		return
+	}
 	if hasNEON && len(kernel) >= 2 {
 		convolveDecimateNEON(dst, signal, kernel, factor, phase)
 		return
@@ -288,7 +439,10 @@ func convolveDecimate64(dst, signal, kernel []float64, factor, phase int) {
 func convolveValidMaxAbs64(signal, kernel []float64) float64 {
 	// Mirror dotProduct's NEON threshold (>= 2) so the fused kernel and the
 	// per-window dotProduct in ConvolveValid pick the same backend, keeping the
-	// peak bit-identical.
+	// peak bit-identical. Under SVE the Go loop runs that dotProduct itself.
+	if hasSVE {
+		return convolveValidMaxAbsGo(signal, kernel)
+	}
 	if hasNEON && len(kernel) >= 2 {
 		return convolveValidMaxAbsNEON(signal, kernel)
 	}
@@ -303,6 +457,10 @@ func convolveDecimateNEON(dst, signal, kernel []float64, factor, phase int)
 
 func accumulateAdd64(dst, src []float64) {
 	// AccumulateAdd is dst += src, use add with dst as both operands
+	if hasSVE && len(dst) >= 2 {
+		addSVE(dst, dst, src)
+		return
+	}
 	if hasNEON && len(dst) >= 2 {
 		addNEON(dst, dst, src)
 		return
@@ -316,6 +474,30 @@ func dotProductNEON(a, b []float64) float64
 //go:noescape
 func dotProduct4NEON(results, row0, row1, row2, row3, vec *float64, n int)
 
+//go:noescape
+func gemmKernel8x4NEON(k int, a, b, c *float64, ldc int)
+
+// SVE kernels (f64_sve_arm64.s): vector-length agnostic, same contracts as
+// their NEON counterparts.
+//
+//go:noescape
+func dotProductSVE(a, b []float64) float64
+
+//go:noescape
+func dotProduct4SVE(results, row0, row1, row2, row3, vec *float64, n int)
+
+//go:noescape
+func sumSVE(a []float64) float64
+
+//go:noescape
+func addSVE(dst, a, b []float64)
+
+//go:noescape
+func mulSVE(dst, a, b []float64)
+
+//go:noescape
+func fmaSVE(dst, a, b, c []float64)
+
 //go:noescape
 func addNEON(dst, a, b []float64)
 
@@ -627,6 +809,15 @@ func exp64(dst, src []float64) {
 	exp64Go(dst, src)
 }
 
+// expSum64 writes dst[i] = e^(src[i]-shift) and returns the sum of the written
+// values.
+func expSum64(dst, src []float64, shift float64) float64 {
+	if hasNEON && len(dst) >= 2 {
+		return expSumNEON64(dst, src, shift)
+	}
+	return expSum64Go(dst, src, shift)
+}
+
 func log64(dst, src []float64) {
 	// Assumes len(src) >= len(dst); caller ensures this via public API
 	if hasNEON && len(dst) >= 2 {
@@ -685,6 +876,9 @@ func powElemNEON64(dst, base, exp []float64)
 //go:noescape
 func expNEON64(dst, src []float64)
 
+//go:noescape
+func expSumNEON64(dst, src []float64, shift float64) float64
+
 //go:noescape
 func sigmoidNEON64(dst, src []float64)
 
@@ -693,3 +887,24 @@ func reluNEON64(dst, src []float64)
 
 //go:noescape
 func tanhNEON64(dst, src []float64)
+
+// biquadSection runs one section over every frame of the interleaved data in
+// place: a pair of channels per biquad2NEON call, and a last odd channel in Go.
+func biquadSection(data []float64, ch int, b *Biquad, s1, s2 []float64) {
+	c := 0
+	if hasNEON {
+		frames := len(data) / ch
+		for ; c+2 <= ch; c += 2 {
+			biquad2NEON(&data[c], frames, ch, b, &s1[c], &s2[c])
+		}
+	}
+	if c < ch {
+		biquadSectionGo(data, ch, c, b, s1, s2)
+	}
+}
+
+// biquad2NEON runs one section over frames frames of the two channels starting
+// at x, stride floats apart, with their state in s1 and s2.
+//
+//go:noescape
+func biquad2NEON(x *float64, frames, stride int, b *Biquad, s1, s2 *float64)
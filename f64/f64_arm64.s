@@ -1574,6 +1574,128 @@ exp64_neon_scalar_loop:
 exp64_neon_done:
     RET
 
+// func expSumNEON64(dst, src []float64, shift float64) float64
+// Computes dst[i] = e^(src[i]-shift) with the expNEON64 core and returns the
+// sum of the stored values, the fused exp-and-sum pass of Softmax, LogSoftmax
+// and LogSumExp. The shifted inputs are clamped to [-709, 709] exactly as in
+// expNEON64. V29 holds the broadcast shift and V30 the running sum, reduced
+// into D30 before the scalar tail.
+TEXT ·expSumNEON64(SB), NOSPLIT, $0-64
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD src_base+24(FP), R1
+
+    MOVD $0x3FF71547652B82FE, R10
+    VMOV R10, V20.D[0]
+    VDUP V20.D[0], V20.D2             // V20 = log2(e)
+    MOVD $0x3FE62E42FEFA39EF, R10
+    VMOV R10, V21.D[0]
+    VDUP V21.D[0], V21.D2             // V21 = ln(2)
+    FMOVD $1.0, F22
+    VDUP V22.D[0], V22.D2             // V22 = 1.0
+    FMOVD $0.5, F23
+    VDUP V23.D[0], V23.D2             // V23 = c2 = 0.5
+    MOVD $0x3FC5555555555555, R10
+    VMOV R10, V24.D[0]
+    VDUP V24.D[0], V24.D2             // V24 = c3 = 1/6
+    MOVD $0x3FA5555555555555, R10
+    VMOV R10, V25.D[0]
+    VDUP V25.D[0], V25.D2             // V25 = c4 = 1/24
+    MOVD $0x3F81111111111111, R10
+    VMOV R10, V26.D[0]
+    VDUP V26.D[0], V26.D2             // V26 = c5 = 1/120
+    MOVD $0x4086280000000000, R10
+    VMOV R10, V27.D[0]
+    VDUP V27.D[0], V27.D2             // V27 = 709.0 (clamp_hi)
+    MOVD $0xC086280000000000, R10
+    VMOV R10, V28.D[0]
+    VDUP V28.D[0], V28.D2             // V28 = -709.0 (clamp_lo)
+    FMOVD shift+48(FP), F29
+    VDUP V29.D[0], V29.D2             // V29 = shift
+    VEOR V30.B16, V30.B16, V30.B16    // V30 = running sum
+
+    LSR $1, R3, R4
+    CBZ R4, expsum64_neon_reduce
+
+expsum64_neon_loop2:
+    VLD1.P 16(R1), [V0.D2]
+    WORD $0x4EFDD400                  // FSUB V0.2D, V0.2D, V29.2D   V0 = x = src - shift
+
+    // Clamp x to [-709, 709]
+    WORD $0x4EFBF400                  // FMIN V0.2D, V0.2D, V27.2D
+    WORD $0x4E7CF400                  // FMAX V0.2D, V0.2D, V28.2D
+
+    // Range reduction: k = round(x * log2e), r = x - k * ln2
+    WORD $0x6E74DC01                  // FMUL V1.2D, V0.2D, V20.2D
+    WORD $0x4E618822                  // FRINTN V2.2D, V1.2D
+    WORD $0x6E75DC44                  // FMUL V4.2D, V2.2D, V21.2D
+    WORD $0x4EE4D403                  // FSUB V3.2D, V0.2D, V4.2D
+
+    // Polynomial: exp(r) ~= 1 + r*(1 + r*(c2 + r*(c3 + r*(c4 + r*c5))))
+    WORD $0x6E7ADC64                  // FMUL V4.2D, V3.2D, V26.2D
+    WORD $0x4E79D484                  // FADD V4.2D, V4.2D, V25.2D
+    WORD $0x6E63DC84                  // FMUL V4.2D, V4.2D, V3.2D
+    WORD $0x4E78D484                  // FADD V4.2D, V4.2D, V24.2D
+    WORD $0x6E63DC84                  // FMUL V4.2D, V4.2D, V3.2D
+    WORD $0x4E77D484                  // FADD V4.2D, V4.2D, V23.2D
+    WORD $0x6E63DC84                  // FMUL V4.2D, V4.2D, V3.2D
+    WORD $0x4E76D484                  // FADD V4.2D, V4.2D, V22.2D
+    WORD $0x6E63DC84                  // FMUL V4.2D, V4.2D, V3.2D
+    WORD $0x4E76D484                  // FADD V4.2D, V4.2D, V22.2D   V4 = exp(r)
+
+    // Reconstruct: exp(x) = exp(r) * 2^k
+    WORD $0x4EE1B841                  // FCVTZS V1.2D, V2.2D
+    WORD $0x4F745421                  // SHL V1.2D, V1.2D, #52
+    WORD $0x4EF68421                  // ADD V1.2D, V1.2D, V22.2D
+    WORD $0x6E61DC84                  // FMUL V4.2D, V4.2D, V1.2D    V4 = exp(x)
+
+    VST1.P [V4.D2], 16(R0)
+    WORD $0x4E64D7DE                  // FADD V30.2D, V30.2D, V4.2D  sum += exp(x)
+
+    SUB $1, R4
+    CBNZ R4, expsum64_neon_loop2
+
+expsum64_neon_reduce:
+    WORD $0x7E70DBDE                  // FADDP D30, V30.2D
+
+    AND $1, R3
+    CBZ R3, expsum64_neon_done
+
+    FMOVD (R1), F0
+    FSUBD F29, F0, F0                 // F0 = x = src - shift
+
+    FMIND F27, F0, F0
+    FMAXD F28, F0, F0
+
+    FMULD F20, F0, F1
+    FRINTND F1, F2                    // F2 = k
+    FMULD F21, F2, F3
+    FSUBD F3, F0, F0                  // F0 = r
+
+    FMULD F0, F26, F4
+    FADDD F25, F4, F4
+    FMULD F0, F4, F4
+    FADDD F24, F4, F4
+    FMULD F0, F4, F4
+    FADDD F23, F4, F4
+    FMULD F0, F4, F4
+    FADDD F22, F4, F4
+    FMULD F0, F4, F4
+    FADDD F22, F4, F4                 // F4 = exp(r)
+
+    FCVTZSD F2, R10
+    LSL $52, R10, R10
+    MOVD $0x3FF0000000000000, R11
+    ADD R11, R10, R10
+    FMOVD R10, F5
+    FMULD F5, F4, F4                  // F4 = exp(x)
+    FMOVD F4, (R0)
+    FADDD F4, F30, F30                // sum += exp(x)
+
+expsum64_neon_done:
+    FMOVD F30, ret+56(FP)
+    RET
+
 // func clampScaleNEON64(dst, src []float64, minVal, maxVal, scale float64)
 // Performs fused clamp and scale: dst[i] = (clamp(src[i], minVal, maxVal) - minVal) * scale
 TEXT ·clampScaleNEON64(SB), NOSPLIT, $0-72
@@ -3824,3 +3946,155 @@ realfftpow64_neon_scalar:
 
 realfftpow64_neon_done:
     RET
+
+// ============================================================================
+// GEMM MICROKERNELS
+// Register-blocked tiles for the packed GEMM driver in gemm.go; the caller
+// handles blocking, packing, alpha/beta and partial tiles.
+// ============================================================================
+
+// func gemmKernel8x4NEON(k int, a, b, c *float64, ldc int)
+// NEON GEMM microkernel: c[i*ldc+j] += sum over p < k of a[p*8+i] * b[p*4+j]
+// for the 8x4 tile. Each step loads 8 packed A elements into V0-V3 and the
+// 4-wide B row into V4-V5, then FMLA by element accumulates row i of the tile
+// into V16-V31, 2 registers per row.
+TEXT ·gemmKernel8x4NEON(SB), NOSPLIT, $0-40
+    MOVD k+0(FP), R0
+    MOVD a+8(FP), R1
+    MOVD b+16(FP), R2
+    MOVD c+24(FP), R3
+    MOVD ldc+32(FP), R4
+    LSL $3, R4, R4              // ldc in bytes
+
+    VEOR V16.B16, V16.B16, V16.B16
+    VEOR V17.B16, V17.B16, V17.B16
+    VEOR V18.B16, V18.B16, V18.B16
+    VEOR V19.B16, V19.B16, V19.B16
+    VEOR V20.B16, V20.B16, V20.B16
+    VEOR V21.B16, V21.B16, V21.B16
+    VEOR V22.B16, V22.B16, V22.B16
+    VEOR V23.B16, V23.B16, V23.B16
+    VEOR V24.B16, V24.B16, V24.B16
+    VEOR V25.B16, V25.B16, V25.B16
+    VEOR V26.B16, V26.B16, V26.B16
+    VEOR V27.B16, V27.B16, V27.B16
+    VEOR V28.B16, V28.B16, V28.B16
+    VEOR V29.B16, V29.B16, V29.B16
+    VEOR V30.B16, V30.B16, V30.B16
+    VEOR V31.B16, V31.B16, V31.B16
+
+    CBZ R0, gemmkernel8x4neon_store
+
+gemmkernel8x4neon_loop:
+    VLD1.P 64(R1), [V0.D2, V1.D2, V2.D2, V3.D2]
+    VLD1.P 32(R2), [V4.D2, V5.D2]
+    WORD $0x4FC01090           // FMLA V16.2D, V4.2D, V0.D[0]
+    WORD $0x4FC010B1           // FMLA V17.2D, V5.2D, V0.D[0]
+    WORD $0x4FC01892           // FMLA V18.2D, V4.2D, V0.D[1]
+    WORD $0x4FC018B3           // FMLA V19.2D, V5.2D, V0.D[1]
+    WORD $0x4FC11094           // FMLA V20.2D, V4.2D, V1.D[0]
+    WORD $0x4FC110B5           // FMLA V21.2D, V5.2D, V1.D[0]
+    WORD $0x4FC11896           // FMLA V22.2D, V4.2D, V1.D[1]
+    WORD $0x4FC118B7           // FMLA V23.2D, V5.2D, V1.D[1]
+    WORD $0x4FC21098           // FMLA V24.2D, V4.2D, V2.D[0]
+    WORD $0x4FC210B9           // FMLA V25.2D, V5.2D, V2.D[0]
+    WORD $0x4FC2189A           // FMLA V26.2D, V4.2D, V2.D[1]
+    WORD $0x4FC218BB           // FMLA V27.2D, V5.2D, V2.D[1]
+    WORD $0x4FC3109C           // FMLA V28.2D, V4.2D, V3.D[0]
+    WORD $0x4FC310BD           // FMLA V29.2D, V5.2D, V3.D[0]
+    WORD $0x4FC3189E           // FMLA V30.2D, V4.2D, V3.D[1]
+    WORD $0x4FC318BF           // FMLA V31.2D, V5.2D, V3.D[1]
+    SUB $1, R0
+    CBNZ R0, gemmkernel8x4neon_loop
+
+gemmkernel8x4neon_store:
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E70D4C6           // FADD V6.2D, V6.2D, V16.2D
+    WORD $0x4E71D4E7           // FADD V7.2D, V7.2D, V17.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E72D4C6           // FADD V6.2D, V6.2D, V18.2D
+    WORD $0x4E73D4E7           // FADD V7.2D, V7.2D, V19.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E74D4C6           // FADD V6.2D, V6.2D, V20.2D
+    WORD $0x4E75D4E7           // FADD V7.2D, V7.2D, V21.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E76D4C6           // FADD V6.2D, V6.2D, V22.2D
+    WORD $0x4E77D4E7           // FADD V7.2D, V7.2D, V23.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E78D4C6           // FADD V6.2D, V6.2D, V24.2D
+    WORD $0x4E79D4E7           // FADD V7.2D, V7.2D, V25.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E7AD4C6           // FADD V6.2D, V6.2D, V26.2D
+    WORD $0x4E7BD4E7           // FADD V7.2D, V7.2D, V27.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E7CD4C6           // FADD V6.2D, V6.2D, V28.2D
+    WORD $0x4E7DD4E7           // FADD V7.2D, V7.2D, V29.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    ADD R4, R3
+    VLD1 (R3), [V6.D2, V7.D2]
+    WORD $0x4E7ED4C6           // FADD V6.2D, V6.2D, V30.2D
+    WORD $0x4E7FD4E7           // FADD V7.2D, V7.2D, V31.2D
+    VST1 [V6.D2, V7.D2], (R3)
+    RET
+
+// func biquad2NEON(x *float64, frames, stride int, b *Biquad, s1, s2 *float64)
+// One direct form II transposed section across 2 channels, frame by frame:
+//
+//	y = B0*x + s1;  s1 = B1*x - A1*y + s2;  s2 = B2*x - A2*y
+//
+// V0/V1 carry s1/s2 through the loop (V0 turns into y, which is stored before
+// the new state rotates in) and V16-V20 hold B0, B1, B2, A1, A2.
+TEXT ·biquad2NEON(SB), NOSPLIT, $0-48
+    MOVD x+0(FP), R0
+    MOVD frames+8(FP), R1
+    MOVD stride+16(FP), R2
+    MOVD b+24(FP), R3
+    MOVD s1+32(FP), R4
+    MOVD s2+40(FP), R5
+    LSL $3, R2                 // stride in bytes
+
+    FMOVD 0(R3), F16
+    FMOVD 8(R3), F17
+    FMOVD 16(R3), F18
+    FMOVD 24(R3), F19
+    FMOVD 32(R3), F20
+    WORD $0x4E080610           // DUP V16.2D, V16.D[0]
+    WORD $0x4E080631           // DUP V17.2D, V17.D[0]
+    WORD $0x4E080652           // DUP V18.2D, V18.D[0]
+    WORD $0x4E080673           // DUP V19.2D, V19.D[0]
+    WORD $0x4E080694           // DUP V20.2D, V20.D[0]
+
+    VLD1 (R4), [V0.D2]
+    VLD1 (R5), [V1.D2]
+    CBZ R1, biquad2_neon64_store
+
+biquad2_neon64_loop:
+    VLD1 (R0), [V2.D2]         // x
+    WORD $0x4E70CC40           // FMLA V0.2D, V2.2D, V16.2D  (y = s1 + x*B0)
+    WORD $0x4E71CC41           // FMLA V1.2D, V2.2D, V17.2D  (s2 + x*B1)
+    WORD $0x4EF3CC01           // FMLS V1.2D, V0.2D, V19.2D  (s1 = s2 + x*B1 - y*A1)
+    WORD $0x6E72DC44           // FMUL V4.2D, V2.2D, V18.2D  (x*B2)
+    WORD $0x4EF4CC04           // FMLS V4.2D, V0.2D, V20.2D  (s2 = x*B2 - y*A2)
+    VST1 [V0.D2], (R0)
+    WORD $0x4EA11C20           // MOV V0.16B, V1.16B
+    WORD $0x4EA41C81           // MOV V1.16B, V4.16B
+    ADD R2, R0
+    SUB $1, R1
+    CBNZ R1, biquad2_neon64_loop
+
+biquad2_neon64_store:
+    VST1 [V0.D2], (R4)
+    VST1 [V1.D2], (R5)
+    RET
@@ -308,6 +308,99 @@ func dotProductBatch64Go(results []float64, rows [][]float64, vec []float64) {
 	}
 }
 
+func dotProductIndexedGo(dst, base, query []float64, rowIDs []uint32, dims int) {
+	n := min(len(dst), len(rowIDs))
+	if n == 0 {
+		return
+	}
+	if dims <= 0 || len(query) == 0 {
+		clear(dst[:n])
+		return
+	}
+	for i := range n {
+		dst[i] = dotProductIndexedOneGo(base, query, rowIDs[i], dims)
+	}
+}
+
+func dotProductIndexedOneGo(base, query []float64, rowID uint32, dims int) float64 {
+	if dims <= 0 || len(query) == 0 {
+		return 0
+	}
+	offset, ok := rowOffsetUint32InBase(rowID, dims, len(base))
+	if !ok {
+		return 0
+	}
+	n := min(dims, len(query))
+	if remaining := len(base) - offset; remaining < n {
+		n = remaining
+	}
+	if n <= 0 {
+		return 0
+	}
+	return dotProduct(base[offset:offset+n], query[:n])
+}
+
+func dotProductStridedGo(dst, base, query []float64, rowCount, dims, stride int) {
+	if rowCount <= 0 || len(dst) == 0 {
+		return
+	}
+	n := min(len(dst), rowCount)
+	if dims <= 0 || stride <= 0 || len(query) == 0 {
+		clear(dst[:n])
+		return
+	}
+	for i := range n {
+		dst[i] = dotProductStridedOneGo(base, query, i, dims, stride)
+	}
+}
+
+func dotProductStridedOneGo(base, query []float64, row, dims, stride int) float64 {
+	if row < 0 || dims <= 0 || stride <= 0 || len(query) == 0 {
+		return 0
+	}
+	offset, ok := rowOffsetStrideInBase(row, stride, len(base))
+	if !ok {
+		return 0
+	}
+	n := min(dims, len(query))
+	if remaining := len(base) - offset; remaining < n {
+		n = remaining
+	}
+	if n <= 0 {
+		return 0
+	}
+	return dotProduct(base[offset:offset+n], query[:n])
+}
+
+func rowOffsetUint32InBase(rowID uint32, stride, baseLen int) (int, bool) {
+	if stride <= 0 || baseLen <= 0 {
+		return 0, false
+	}
+	offset, ok := mulUint64(uint64(rowID), uint64(stride))
+	if !ok || offset >= uint64(baseLen) {
+		return 0, false
+	}
+	return int(offset), true
+}
+
+func rowOffsetStrideInBase(row, stride, baseLen int) (int, bool) {
+	if row < 0 || stride <= 0 || baseLen <= 0 {
+		return 0, false
+	}
+	offset, ok := mulUint64(uint64(row), uint64(stride))
+	if !ok || offset >= uint64(baseLen) {
+		return 0, false
+	}
+	return int(offset), true
+}
+
+func mulUint64(a, b uint64) (uint64, bool) {
+	if a != 0 && b > ^uint64(0)/a {
+		return 0, false
+	}
+	return a * b, true
+}
+
 func convolveValid64Go(dst, signal, kernel []float64) {
 	kLen := len(kernel)
 	for i := range dst {
@@ -598,6 +691,31 @@ func exp64Go(dst, src []float64) {
 	}
 }
 
+// expSum64Go writes dst[i] = e^(src[i]-shift), clamped like exp64Go, and
+// returns the sum of the written values.
+func expSum64Go(dst, src []float64, shift float64) float64 {
+	if len(dst) == 0 {
+		return 0
+	}
+	_ = src[len(dst)-1]
+	var sum float64
+	for i := range dst {
+		x := src[i] - shift
+		var e float64
+		switch {
+		case x > expOverflowThreshold:
+			e = math.Exp(expOverflowThreshold)
+		case x < -expOverflowThreshold:
+			e = 0
+		default:
+			e = math.Exp(x)
+		}
+		dst[i] = e
+		sum += e
+	}
+	return sum
+}
+
 // logSubnormScale64 normalizes a positive subnormal so math.Log sees a normal
 // input: math.Log's amd64 assembly extracts the exponent field without
 // normalizing, so it returns ~-709.09 for every subnormal x. Scaling by 2^54
@@ -880,3 +998,40 @@ func realFFTPower64Go(dst, zRe, zIm, twRe, twIm []float64, n int) {
 		dst[k] = xRe*xRe + xIm*xIm
 	}
 }
+
+// gemmGo is the pure-Go GEMM reference: row i of C is scaled by beta, then
+// alpha*op(A)[i][p] times row p of op(B) is added for each p. The caller has
+// validated the shapes.
+func gemmGo(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	for i := range m {
+		ci := c[i*ldc : i*ldc+n]
+		switch beta {
+		case 1:
+		case 0:
+			clear(ci)
+		default:
+			for j := range ci {
+				ci[j] *= beta
+			}
+		}
+		if alpha == 0 {
+			continue
+		}
+		for p := range k {
+			ai := i*lda + p
+			if transA {
+				ai = p*lda + i
+			}
+			aip := alpha * a[ai]
+			if transB {
+				for j := range ci {
+					ci[j] += aip * b[j*ldb+p]
+				}
+				continue
+			}
+			for j, v := range b[p*ldb : p*ldb+n] {
+				ci[j] += aip * v
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+//go:build arm64
+
+#include "textflag.h"
+
+// ARM64 SVE for float64: vector-length agnostic, each Z register holds VL/64
+// elements (2 at 128 bits, 4 at 256 bits). Go's assembler has no SVE
+// mnemonics, so every SVE instruction is a WORD opcode; asmcheck decodes them.
+//
+// Tails need no scalar loop: WHILELO sets the lanes still in range, predicated
+// loads zero the rest and predicated stores skip them. WHILELO sets the flags
+// like PTEST, so B.NONE (no lane active) is BEQ.
+//
+// Registers: P1 is all-true, P0 the WHILELO tail predicate. Z0-Z3 accumulate,
+// Z4-Z7 and Z16-Z20 hold loaded operands.
+
+// func dotProductSVE(a, b []float64) float64
+// Handles mismatched slice lengths: uses min(len(a), len(b)). The main loop
+// runs four independent accumulators over 4*VL elements to hide FMLA latency.
+TEXT ·dotProductSVE(SB), NOSPLIT, $0-56
+    MOVD a_base+0(FP), R0
+    MOVD a_len+8(FP), R2
+    MOVD b_len+32(FP), R3
+    CMP R3, R2
+    CSEL LT, R2, R3, R2        // R2 = min(len(a), len(b))
+    MOVD b_base+24(FP), R1
+
+    WORD $0x25D8E3E1           // PTRUE P1.D
+    WORD $0x25F8C000           // MOV Z0.D, #0
+    WORD $0x25F8C001           // MOV Z1.D, #0
+    WORD $0x25F8C002           // MOV Z2.D, #0
+    WORD $0x25F8C003           // MOV Z3.D, #0
+    MOVD $0, R4                // R4 = i
+    WORD $0x04E3E3E6           // CNTD X6, ALL, MUL #4
+    SUB R6, R2, R7             // R7 = n - 4*VL, the last i a full step fits
+
+dotsve64_loop4:
+    CMP R7, R4
+    BGT dotsve64_tail
+    ADD R4<<3, R0, R8
+    ADD R4<<3, R1, R9
+    WORD $0xA5E0A504           // LD1D {Z4.D}, P1/Z, [X8]
+    WORD $0xA5E1A505           // LD1D {Z5.D}, P1/Z, [X8, #1, MUL VL]
+    WORD $0xA5E2A506           // LD1D {Z6.D}, P1/Z, [X8, #2, MUL VL]
+    WORD $0xA5E3A507           // LD1D {Z7.D}, P1/Z, [X8, #3, MUL VL]
+    WORD $0xA5E0A530           // LD1D {Z16.D}, P1/Z, [X9]
+    WORD $0xA5E1A531           // LD1D {Z17.D}, P1/Z, [X9, #1, MUL VL]
+    WORD $0xA5E2A532           // LD1D {Z18.D}, P1/Z, [X9, #2, MUL VL]
+    WORD $0xA5E3A533           // LD1D {Z19.D}, P1/Z, [X9, #3, MUL VL]
+    WORD $0x65F00480           // FMLA Z0.D, P1/M, Z4.D, Z16.D
+    WORD $0x65F104A1           // FMLA Z1.D, P1/M, Z5.D, Z17.D
+    WORD $0x65F204C2           // FMLA Z2.D, P1/M, Z6.D, Z18.D
+    WORD $0x65F304E3           // FMLA Z3.D, P1/M, Z7.D, Z19.D
+    ADD R6, R4
+    B dotsve64_loop4
+
+dotsve64_tail:
+    WORD $0x25E21C80           // WHILELO P0.D, X4, X2
+    BEQ dotsve64_reduce
+    WORD $0xA5E44004           // LD1D {Z4.D}, P0/Z, [X0, X4, LSL #3]
+    WORD $0xA5E44030           // LD1D {Z16.D}, P0/Z, [X1, X4, LSL #3]
+    WORD $0x65F00080           // FMLA Z0.D, P0/M, Z4.D, Z16.D
+    WORD $0x04F0E3E4           // INCD X4
+    B dotsve64_tail
+
+dotsve64_reduce:
+    WORD $0x65C10000           // FADD Z0.D, Z0.D, Z1.D
+    WORD $0x65C30042           // FADD Z2.D, Z2.D, Z3.D
+    WORD $0x65C20000           // FADD Z0.D, Z0.D, Z2.D
+    WORD $0x65C02400           // FADDV D0, P1, Z0.D
+    FMOVD F0, ret+48(FP)
+    RET
+
+// func dotProduct4SVE(results, row0, row1, row2, row3, vec *float64, n int)
+// Scores four rows against the same vec, loading each vec chunk once for the
+// group. Z0-Z3 accumulate rows 0-3; Z16 holds the vec chunk, Z17-Z20 the rows.
+TEXT ·dotProduct4SVE(SB), NOSPLIT, $0-56
+    MOVD results+0(FP), R0
+    MOVD row0+8(FP), R1
+    MOVD row1+16(FP), R2
+    MOVD row2+24(FP), R3
+    MOVD row3+32(FP), R4
+    MOVD vec+40(FP), R5
+    MOVD n+48(FP), R6
+
+    WORD $0x25F8C000           // MOV Z0.D, #0
+    WORD $0x25F8C001           // MOV Z1.D, #0
+    WORD $0x25F8C002           // MOV Z2.D, #0
+    WORD $0x25F8C003           // MOV Z3.D, #0
+    MOVD $0, R7                // R7 = i
+
+dot4sve64_loop:
+    WORD $0x25E61CE0           // WHILELO P0.D, X7, X6
+    BEQ dot4sve64_reduce
+    WORD $0xA5E740B0           // LD1D {Z16.D}, P0/Z, [X5, X7, LSL #3]
+    WORD $0xA5E74031           // LD1D {Z17.D}, P0/Z, [X1, X7, LSL #3]
+    WORD $0xA5E74052           // LD1D {Z18.D}, P0/Z, [X2, X7, LSL #3]
+    WORD $0xA5E74073           // LD1D {Z19.D}, P0/Z, [X3, X7, LSL #3]
+    WORD $0xA5E74094           // LD1D {Z20.D}, P0/Z, [X4, X7, LSL #3]
+    WORD $0x65F00220           // FMLA Z0.D, P0/M, Z17.D, Z16.D
+    WORD $0x65F00241           // FMLA Z1.D, P0/M, Z18.D, Z16.D
+    WORD $0x65F00262           // FMLA Z2.D, P0/M, Z19.D, Z16.D
+    WORD $0x65F00283           // FMLA Z3.D, P0/M, Z20.D, Z16.D
+    WORD $0x04F0E3E7           // INCD X7
+    B dot4sve64_loop
+
+dot4sve64_reduce:
+    WORD $0x25D8E3E1           // PTRUE P1.D
+    WORD $0x65C02400           // FADDV D0, P1, Z0.D
+    WORD $0x65C02421           // FADDV D1, P1, Z1.D
+    WORD $0x65C02442           // FADDV D2, P1, Z2.D
+    WORD $0x65C02463           // FADDV D3, P1, Z3.D
+    FMOVD F0, 0(R0)
+    FMOVD F1, 8(R0)
+    FMOVD F2, 16(R0)
+    FMOVD F3, 24(R0)
+    RET
+
+// func sumSVE(a []float64) float64
+// Four accumulators over 4*VL elements, like dotProductSVE. The tail adds
+// under P0 so inactive lanes keep their sign of zero.
+TEXT ·sumSVE(SB), NOSPLIT, $0-32
+    MOVD a_base+0(FP), R0
+    MOVD a_len+8(FP), R2
+
+    WORD $0x25D8E3E1           // PTRUE P1.D
+    WORD $0x25F8C000           // MOV Z0.D, #0
+    WORD $0x25F8C001           // MOV Z1.D, #0
+    WORD $0x25F8C002           // MOV Z2.D, #0
+    WORD $0x25F8C003           // MOV Z3.D, #0
+    MOVD $0, R4                // R4 = i
+    WORD $0x04E3E3E6           // CNTD X6, ALL, MUL #4
+    SUB R6, R2, R7             // R7 = n - 4*VL, the last i a full step fits
+
+sumsve64_loop4:
+    CMP R7, R4
+    BGT sumsve64_tail
+    ADD R4<<3, R0, R8
+    WORD $0xA5E0A504           // LD1D {Z4.D}, P1/Z, [X8]
+    WORD $0xA5E1A505           // LD1D {Z5.D}, P1/Z, [X8, #1, MUL VL]
+    WORD $0xA5E2A506           // LD1D {Z6.D}, P1/Z, [X8, #2, MUL VL]
+    WORD $0xA5E3A507           // LD1D {Z7.D}, P1/Z, [X8, #3, MUL VL]
+    WORD $0x65C40000           // FADD Z0.D, Z0.D, Z4.D
+    WORD $0x65C50021           // FADD Z1.D, Z1.D, Z5.D
+    WORD $0x65C60042           // FADD Z2.D, Z2.D, Z6.D
+    WORD $0x65C70063           // FADD Z3.D, Z3.D, Z7.D
+    ADD R6, R4
+    B sumsve64_loop4
+
+sumsve64_tail:
+    WORD $0x25E21C80           // WHILELO P0.D, X4, X2
+    BEQ sumsve64_reduce
+    WORD $0xA5E44004           // LD1D {Z4.D}, P0/Z, [X0, X4, LSL #3]
+    WORD $0x65C08080           // FADD Z0.D, P0/M, Z0.D, Z4.D
+    WORD $0x04F0E3E4           // INCD X4
+    B sumsve64_tail
+
+sumsve64_reduce:
+    WORD $0x65C10000           // FADD Z0.D, Z0.D, Z1.D
+    WORD $0x65C30042           // FADD Z2.D, Z2.D, Z3.D
+    WORD $0x65C20000           // FADD Z0.D, Z0.D, Z2.D
+    WORD $0x65C02400           // FADDV D0, P1, Z0.D
+    FMOVD F0, ret+24(FP)
+    RET
+
+// func addSVE(dst, a, b []float64)
+// dst[i] = a[i] + b[i] for i < len(dst); the wrapper bounds a and b. Each
+// lane is loaded before it is stored, so dst may alias a or b.
+TEXT ·addSVE(SB), NOSPLIT, $0-72
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD a_base+24(FP), R1
+    MOVD b_base+48(FP), R2
+    MOVD $0, R4                // R4 = i
+
+addsve64_loop:
+    WORD $0x25E31C80           // WHILELO P0.D, X4, X3
+    BEQ addsve64_done
+    WORD $0xA5E44020           // LD1D {Z0.D}, P0/Z, [X1, X4, LSL #3]
+    WORD $0xA5E44041           // LD1D {Z1.D}, P0/Z, [X2, X4, LSL #3]
+    WORD $0x65C10000           // FADD Z0.D, Z0.D, Z1.D
+    WORD $0xE5E44000           // ST1D {Z0.D}, P0, [X0, X4, LSL #3]
+    WORD $0x04F0E3E4           // INCD X4
+    B addsve64_loop
+
+addsve64_done:
+    RET
+
+// func mulSVE(dst, a, b []float64)
+// dst[i] = a[i] * b[i] for i < len(dst); same contract as addSVE.
+TEXT ·mulSVE(SB), NOSPLIT, $0-72
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R3
+    MOVD a_base+24(FP), R1
+    MOVD b_base+48(FP), R2
+    MOVD $0, R4                // R4 = i
+
+mulsve64_loop:
+    WORD $0x25E31C80           // WHILELO P0.D, X4, X3
+    BEQ mulsve64_done
+    WORD $0xA5E44020           // LD1D {Z0.D}, P0/Z, [X1, X4, LSL #3]
+    WORD $0xA5E44041           // LD1D {Z1.D}, P0/Z, [X2, X4, LSL #3]
+    WORD $0x65C10800           // FMUL Z0.D, Z0.D, Z1.D
+    WORD $0xE5E44000           // ST1D {Z0.D}, P0, [X0, X4, LSL #3]
+    WORD $0x04F0E3E4           // INCD X4
+    B mulsve64_loop
+
+mulsve64_done:
+    RET
+
+// func fmaSVE(dst, a, b, c []float64)
+// dst[i] = a[i]*b[i] + c[i] with a single rounding (FMLA), for i < len(dst).
+TEXT ·fmaSVE(SB), NOSPLIT, $0-96
+    MOVD dst_base+0(FP), R0
+    MOVD dst_len+8(FP), R4
+    MOVD a_base+24(FP), R1
+    MOVD b_base+48(FP), R2
+    MOVD c_base+72(FP), R3
+    MOVD $0, R5                // R5 = i
+
+fmasve64_loop:
+    WORD $0x25E41CA0           // WHILELO P0.D, X5, X4
+    BEQ fmasve64_done
+    WORD $0xA5E54020           // LD1D {Z0.D}, P0/Z, [X1, X5, LSL #3]
+    WORD $0xA5E54041           // LD1D {Z1.D}, P0/Z, [X2, X5, LSL #3]
+    WORD $0xA5E54062           // LD1D {Z2.D}, P0/Z, [X3, X5, LSL #3]
+    WORD $0x65E10002           // FMLA Z2.D, P0/M, Z0.D, Z1.D
+    WORD $0xE5E54002           // ST1D {Z2.D}, P0, [X0, X5, LSL #3]
+    WORD $0x04F0E3E5           // INCD X5
+    B fmasve64_loop
+
+fmasve64_done:
+    RET
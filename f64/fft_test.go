@@ -0,0 +1,225 @@
+package f64
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// dftSplit computes the full complex DFT of (re, im) directly, as an
+// independent reference for FFTPlan. sign is -1 for the forward
+// transform and +1 for the (unnormalized) inverse.
+func dftSplit(re, im []float64, sign float64) (outRe, outIm []float64) {
+	n := len(re)
+	outRe = make([]float64, n)
+	outIm = make([]float64, n)
+	for k := range n {
+		var sr, si float64
+		for t := range n {
+			ang := sign * 2 * math.Pi * float64(k*t%n) / float64(n)
+			s, c := math.Sincos(ang)
+			xr, xi := re[t], im[t]
+			sr += xr*c - xi*s
+			si += xr*s + xi*c
+		}
+		outRe[k], outIm[k] = sr, si
+	}
+	return outRe, outIm
+}
+
+// fftInput builds a deterministic complex test vector of length n.
+func fftInput(n int) (re, im []float64) {
+	re = make([]float64, n)
+	im = make([]float64, n)
+	for i := range n {
+		re[i] = math.Sin(0.37*float64(i)) + 0.25*math.Cos(1.9*float64(i))
+		im[i] = math.Cos(0.61*float64(i)) - 0.5*math.Sin(0.13*float64(i))
+	}
+	return re, im
+}
+
+func TestNewFFTPlanErrors(t *testing.T) {
+	for _, bad := range []int{-4, -1, 0} {
+		if _, err := NewFFTPlan(bad); err == nil {
+			t.Errorf("NewFFTPlan(%d) = nil error, want ErrFFTSize", bad)
+		}
+	}
+	for _, good := range []int{1, 2, 3, 4, 5, 6, 7, 8, 12, 97, 100, 1000, 1024} {
+		p, err := NewFFTPlan(good)
+		if err != nil {
+			t.Errorf("NewFFTPlan(%d) unexpected error: %v", good, err)
+			continue
+		}
+		if p.Len() != good {
+			t.Errorf("NewFFTPlan(%d).Len() = %d", good, p.Len())
+		}
+	}
+}
+
+// TestFFTPlanAgainstDFT checks Forward and Inverse against a direct float64
+// DFT across sizes that exercise the pure radix-4 schedule (power-of-four n), the
+// trailing radix-2 stage (odd log2(n)), the mixed-radix 3/5 stages (including
+// the nfft/2 sizes of 400- and 480-point STFTs), and the Bluestein fallback
+// (sizes with a prime factor above 5).
+func TestFFTPlanAgainstDFT(t *testing.T) {
+	for _, n := range []int{
+		1, 2, 4, 8, 16, 32, 64, 128, 256, 1024,
+		3, 5, 6, 9, 10, 12, 15, 25, 30, 45, 60, 100, 120, 200, 240, 400, 480, 1000,
+		7, 11, 14, 97, 127, 210, 257, 1009,
+	} {
+		p, err := NewFFTPlan(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, dir := range []string{"forward", "inverse"} {
+			re, im := fftInput(n)
+			var scale float64
+			for i := range n {
+				scale += math.Hypot(re[i], im[i])
+			}
+			sign := -1.0
+			if dir == "inverse" {
+				sign = 1
+			}
+			wantRe, wantIm := dftSplit(re, im, sign)
+			if dir == "forward" {
+				p.Forward(re, im)
+			} else {
+				p.Inverse(re, im)
+			}
+			for k := range n {
+				ctx := fmt.Sprintf("n=%d %s bin=%d", n, dir, k)
+				cmplxClose(t, ctx, complex(re[k], im[k]), complex(wantRe[k], wantIm[k]), scale)
+			}
+		}
+	}
+}
+
+// TestFFTPlanRoundTrip checks Inverse(Forward(x)) == Len()*x.
+func TestFFTPlanRoundTrip(t *testing.T) {
+	for _, n := range []int{2, 16, 512, 4096, 400, 480, 3000, 97, 4099} {
+		p, _ := NewFFTPlan(n)
+		origRe, origIm := fftInput(n)
+		re := append([]float64(nil), origRe...)
+		im := append([]float64(nil), origIm...)
+		p.Forward(re, im)
+		p.Inverse(re, im)
+		inv := 1 / float64(n)
+		for i := range n {
+			gr, gi := re[i]*inv, im[i]*inv
+			if d := math.Hypot(gr-origRe[i], gi-origIm[i]); d > 1e-12 {
+				t.Fatalf("n=%d: round trip[%d] = (%v,%v), want (%v,%v)", n, i, gr, gi, origRe[i], origIm[i])
+			}
+		}
+	}
+}
+
+// TestFFTPlanStage4Tw3 pins the radix-4 w^(3j) table layout: the stage with span
+// s in {1,4,16,...} keeps exp(-i*2*pi*3j/(4s)) at offset (s-1)/3.
+func TestFFTPlanStage4Tw3(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 8, 64, 1024} {
+		p, _ := NewFFTPlan(n)
+		wantLen := 0
+		for s := 1; 4*s <= n; s *= 4 {
+			wantLen += s
+		}
+		if len(p.stage4Tw3Re) != wantLen || len(p.stage4Tw3Im) != wantLen {
+			t.Fatalf("n=%d: tw3 table len = (%d,%d), want %d", n, len(p.stage4Tw3Re), len(p.stage4Tw3Im), wantLen)
+		}
+		for s := 1; 4*s <= n; s *= 4 {
+			off := (s - 1) / 3
+			for j := range s {
+				sin, cos := math.Sincos(2 * math.Pi * float64(3*j) / float64(4*s))
+				if math.Abs(p.stage4Tw3Re[off+j]-cos) > 1e-15 || math.Abs(p.stage4Tw3Im[off+j]+sin) > 1e-15 {
+					t.Errorf("n=%d s=%d j=%d: tw3=(%g,%g) want (%g,%g)", n, s, j,
+						p.stage4Tw3Re[off+j], p.stage4Tw3Im[off+j], cos, -sin)
+				}
+			}
+		}
+	}
+}
+
+// TestFFTPlanSchedule pins which schedule each size takes: 2^a*3^b*5^c sizes
+// must run the direct mixed-radix stages (whose radices multiply back to n)
+// rather than the Bluestein fallback, and only other sizes may use Bluestein.
+func TestFFTPlanSchedule(t *testing.T) {
+	for _, n := range []int{3, 6, 200, 240, 400, 480, 1000} {
+		p, _ := NewFFTPlan(n)
+		if p.blue != nil || p.stages == nil {
+			t.Fatalf("n=%d: want mixed-radix schedule", n)
+		}
+		prod := 1
+		for _, st := range p.stages {
+			if st.span != prod {
+				t.Errorf("n=%d: stage span %d, want %d", n, st.span, prod)
+			}
+			prod *= st.radix
+		}
+		if prod != n {
+			t.Errorf("n=%d: radices multiply to %d", n, prod)
+		}
+	}
+	for _, n := range []int{7, 97, 1009} {
+		if p, _ := NewFFTPlan(n); p.blue == nil {
+			t.Errorf("n=%d: want Bluestein schedule", n)
+		}
+	}
+}
+
+// TestFFTPlanGuards checks that short slices are a no-op and that longer
+// slices only have their first Len() elements transformed.
+func TestFFTPlanGuards(t *testing.T) {
+	p, _ := NewFFTPlan(8)
+	re, im := fftInput(7)
+	origRe := append([]float64(nil), re...)
+	p.Forward(re, make([]float64, 8))
+	p.Inverse(make([]float64, 8), im)
+	for i := range re {
+		if re[i] != origRe[i] {
+			t.Fatalf("short re modified at %d", i)
+		}
+	}
+
+	longRe, longIm := fftInput(12)
+	wantRe := append([]float64(nil), longRe[:8]...)
+	wantIm := append([]float64(nil), longIm[:8]...)
+	p.Forward(wantRe, wantIm)
+	tailRe := append([]float64(nil), longRe[8:]...)
+	p.Forward(longRe, longIm)
+	for i := range 8 {
+		if longRe[i] != wantRe[i] || longIm[i] != wantIm[i] {
+			t.Fatalf("long input bin %d differs from exact-length transform", i)
+		}
+	}
+	for i, v := range tailRe {
+		if longRe[8+i] != v {
+			t.Fatalf("element %d past Len() modified", 8+i)
+		}
+	}
+}
+
+func TestFFTPlanAllocFree(t *testing.T) {
+	for _, n := range []int{1024, 400, 97} {
+		p, _ := NewFFTPlan(n)
+		re, im := fftInput(n)
+		if a := testing.AllocsPerRun(5, func() { p.Forward(re, im) }); a != 0 {
+			t.Errorf("n=%d: Forward allocated %v times per run, want 0", n, a)
+		}
+		if a := testing.AllocsPerRun(5, func() { p.Inverse(re, im) }); a != 0 {
+			t.Errorf("n=%d: Inverse allocated %v times per run, want 0", n, a)
+		}
+	}
+}
+
+func BenchmarkFFTPlanForward(b *testing.B) {
+	for _, n := range []int{256, 400, 480, 1024, 1009, 4096} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			p, _ := NewFFTPlan(n)
+			re, im := fftInput(n)
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(re, im)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package f64
+
+import "errors"
+
+// ErrFIRTaps is returned by NewFIRFilter for an empty tap set.
+var ErrFIRTaps = errors.New("f64: FIR filter needs at least one tap")
+
+// firChunk is the number of new samples the delay line takes per ConvolveValid
+// pass; longer blocks are filtered in chunks of this size.
+const firChunk = 1024
+
+// FIRFilter is a streaming FIR filter that owns its delay line, so consecutive
+// Process calls continue one signal without the caller stitching history:
+//
+//	y[n] = sum_{j=0}^{len(taps)-1} taps[j] * x[n-j]
+//
+// with x[n] = 0 before the first sample (scipy.signal.lfilter(taps, 1, x)). Note
+// the orientation: taps[0] weights the newest sample, the reverse of
+// ConvolveValid, which this runs internally on the reversed taps.
+//
+// A filter holds its delay line, so its methods are NOT safe for concurrent use
+// on the same filter; use one per stream.
+type FIRFilter struct {
+	rev   []float64 // taps reversed, the ConvolveValid kernel
+	line  []float64 // len(taps)-1 history samples, then up to chunk new ones
+	chunk int
+}
+
+// NewFIRFilter builds a streaming filter over a copy of taps with a zeroed delay
+// line. It returns ErrFIRTaps when taps is empty.
+func NewFIRFilter(taps []float64) (*FIRFilter, error) {
+	if len(taps) == 0 {
+		return nil, ErrFIRTaps
+	}
+	f := &FIRFilter{
+		rev:   make([]float64, len(taps)),
+		chunk: max(firChunk, len(taps)),
+	}
+	for i, v := range taps {
+		f.rev[len(taps)-1-i] = v
+	}
+	f.line = make([]float64, len(taps)-1+f.chunk)
+	return f, nil
+}
+
+// Len returns the number of taps.
+func (f *FIRFilter) Len() int { return len(f.rev) }
+
+// Reset clears the delay line, so the next Process starts from silence.
+func (f *FIRFilter) Reset() { clear(f.line) }
+
+// Process filters src into dst, one output per input sample, and returns the
+// number of samples processed, min(len(dst), len(src)); the delay line then
+// holds the last len(taps)-1 of them. dst may alias src exactly (in-place
+// filtering); it must not otherwise overlap it. Allocation-free.
+func (f *FIRFilter) Process(dst, src []float64) int {
+	n := min(len(dst), len(src))
+	h := len(f.rev) - 1
+	for done := 0; done < n; {
+		c := min(f.chunk, n-done)
+		copy(f.line[h:h+c], src[done:done+c])
+		convolveValid64(dst[done:done+c], f.line[:h+c], f.rev)
+		copy(f.line[:h], f.line[c:c+h])
+		done += c
+	}
+	return n
+}
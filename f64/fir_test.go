@@ -0,0 +1,106 @@
+package f64
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// firRef is the direct causal convolution y[n] = sum_j taps[j]*x[n-j].
+func firRef(x, taps []float64) []float64 {
+	y := make([]float64, len(x))
+	for n := range x {
+		for j := range taps {
+			if n >= j {
+				y[n] += taps[j] * x[n-j]
+			}
+		}
+	}
+	return y
+}
+
+func TestNewFIRFilterErrors(t *testing.T) {
+	if _, err := NewFIRFilter(nil); !errors.Is(err, ErrFIRTaps) {
+		t.Fatalf("NewFIRFilter(nil) error = %v, want ErrFIRTaps", err)
+	}
+	f, err := NewFIRFilter(make([]float64, 31))
+	if err != nil || f.Len() != 31 {
+		t.Fatalf("NewFIRFilter(31 taps) = %v, %v", f, err)
+	}
+}
+
+// TestFIRFilterStreaming checks Process against the direct convolution when
+// the signal arrives in uneven blocks (including empty ones and blocks longer
+// than the internal chunk), and that in-place filtering after Reset matches.
+func TestFIRFilterStreaming(t *testing.T) {
+	for _, nTaps := range []int{1, 2, 7, 64, 1500} {
+		taps := testSignal(nTaps + 3)[3:]
+		x := testSignal(5000)
+		want := firRef(x, taps)
+		f, _ := NewFIRFilter(taps)
+		got := make([]float64, len(x))
+		for pos, step := 0, 0; pos < len(x); step = step*2 + 1 {
+			end := min(len(x), pos+step)
+			if n := f.Process(got[pos:end], x[pos:end]); n != end-pos {
+				t.Fatalf("taps=%d: Process returned %d, want %d", nTaps, n, end-pos)
+			}
+			pos = end
+		}
+		var scale float64
+		for _, v := range taps {
+			scale += math.Abs(v)
+		}
+		tol := 1e-13 * scale
+		for i := range x {
+			if d := math.Abs(got[i] - want[i]); d > tol {
+				t.Fatalf("taps=%d: y[%d] = %g want %g", nTaps, i, got[i], want[i])
+			}
+		}
+
+		f.Reset()
+		inPlace := append([]float64(nil), x...)
+		f.Process(inPlace[:777], inPlace[:777])
+		f.Process(inPlace[777:], inPlace[777:])
+		for i := range x {
+			if inPlace[i] != got[i] {
+				t.Fatalf("taps=%d: in-place y[%d] = %g, want %g", nTaps, i, inPlace[i], got[i])
+			}
+		}
+	}
+}
+
+// TestFIRFilterClamp checks that only min(len(dst), len(src)) samples are
+// processed and that the tail of dst is untouched.
+func TestFIRFilterClamp(t *testing.T) {
+	f, _ := NewFIRFilter([]float64{1, 0.5})
+	dst := []float64{9, 9, 9, 9}
+	if n := f.Process(dst, []float64{1, 2}); n != 2 || dst[0] != 1 || dst[1] != 2.5 || dst[2] != 9 {
+		t.Fatalf("Process = %d, dst %v", n, dst)
+	}
+	if n := f.Process(dst[:1], []float64{4, 4, 4}); n != 1 || dst[0] != 5 {
+		t.Fatalf("Process = %d, dst %v (history not carried)", n, dst)
+	}
+}
+
+func TestFIRFilterAllocFree(t *testing.T) {
+	f, _ := NewFIRFilter(testSignal(63))
+	buf := testSignal(4096)
+	if a := testing.AllocsPerRun(5, func() { f.Process(buf, buf) }); a != 0 {
+		t.Errorf("Process allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkFIRFilter(b *testing.B) {
+	for _, nTaps := range []int{16, 64, 256} {
+		b.Run(fmt.Sprintf("taps=%d", nTaps), func(b *testing.B) {
+			f, _ := NewFIRFilter(testSignal(nTaps))
+			buf := testSignal(480)
+			b.SetBytes(int64(8 * len(buf)))
+			b.ReportAllocs()
+			for b.Loop() {
+				f.Process(buf, buf)
+			}
+		})
+	}
+}
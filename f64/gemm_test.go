@@ -0,0 +1,179 @@
+package f64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+)
+
+// gemmTiers masks features so the GEMM tests run every microkernel the host
+// has (AVX-512, AVX+FMA, NEON) and the pure-Go loop.
+var gemmTiers = []string{"", "avx512", "all"}
+
+// naiveGEMM is the textbook triple loop, the oracle for GEMM.
+func naiveGEMM(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) []float64 {
+	out := make([]float64, len(c))
+	copy(out, c)
+	for i := range m {
+		for j := range n {
+			var sum float64
+			for p := range k {
+				ai, bi := i*lda+p, p*ldb+j
+				if transA {
+					ai = p*lda + i
+				}
+				if transB {
+					bi = j*ldb + p
+				}
+				av, bv := a[ai], b[bi]
+				sum += av * bv
+			}
+			v := alpha * sum
+			if beta != 0 {
+				v += beta * c[i*ldc+j]
+			}
+			out[i*ldc+j] = v
+		}
+	}
+	return out
+}
+
+func assertGEMMClose(t *testing.T, got, want []float64, k int) {
+	t.Helper()
+	tol := 1e-13 * float64(k+1)
+	for i := range want {
+		if d := math.Abs(got[i] - want[i]); d > tol*(1+math.Abs(want[i])) || math.IsNaN(got[i]) {
+			t.Fatalf("[%d] got %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGEMM_MatchesNaive(t *testing.T) {
+	shapes := [][3]int{
+		{1, 1, 1}, {3, 5, 7}, {6, 16, 9}, {6, 32, 4}, {8, 8, 8}, {7, 17, 33},
+		{13, 40, 1}, {97, 33, 19}, {20, 520, 3}, {5, 7, 300}, {100, 70, 260},
+	}
+	for _, tier := range gemmTiers {
+		restore := cpu.Override(tier)
+		for _, s := range shapes {
+			m, n, k := s[0], s[1], s[2]
+			for _, tr := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+				transA, transB := tr[0], tr[1]
+				aRows, aCols := m, k
+				if transA {
+					aRows, aCols = k, m
+				}
+				bRows, bCols := k, n
+				if transB {
+					bRows, bCols = n, k
+				}
+				lda, ldb, ldc := aCols+1, bCols+3, n+2
+				a := deterministicF64Vector(m*31+k, aRows*lda)
+				b := deterministicF64Vector(n*17+k, bRows*ldb)
+				c := deterministicF64Vector(m+n, m*ldc)
+				for _, ab := range [][2]float64{{1, 0}, {1, 1}, {-0.5, 2}} {
+					want := naiveGEMM(transA, transB, m, n, k, ab[0], a, lda, b, ldb, ab[1], c, ldc)
+					got := append([]float64(nil), c...)
+					GEMM(transA, transB, m, n, k, ab[0], a, lda, b, ldb, ab[1], got, ldc)
+					for i := range m {
+						// Padding past column n of each C row must be left alone.
+						for j := n; j < ldc && i*ldc+j < len(c); j++ {
+							if got[i*ldc+j] != c[i*ldc+j] {
+								t.Fatalf("tier %q: C padding (%d, %d) overwritten", tier, i, j)
+							}
+						}
+					}
+					assertGEMMClose(t, got, want, k)
+				}
+			}
+		}
+		restore()
+	}
+}
+
+func TestGEMM_BLASConventions(t *testing.T) {
+	nan := math.NaN()
+	a := []float64{1, 2, 3, 4}
+	b := []float64{5, 6, 7, 8}
+	for _, tier := range gemmTiers {
+		restore := cpu.Override(tier)
+		// beta == 0 never reads C, so NaNs there vanish.
+		c := []float64{nan, nan, nan, nan}
+		GEMM(false, false, 2, 2, 2, 1, a, 2, b, 2, 0, c, 2)
+		assertCloseSlice(t, c, []float64{19, 22, 43, 50})
+
+		// alpha == 0 or k == 0 only scales C.
+		c = []float64{1, 2, 3, 4}
+		GEMM(false, false, 2, 2, 2, 0, a, 2, b, 2, 3, c, 2)
+		assertCloseSlice(t, c, []float64{3, 6, 9, 12})
+		GEMM(false, false, 2, 2, 0, 1, nil, 1, nil, 2, 0.5, c, 2)
+		assertCloseSlice(t, c, []float64{1.5, 3, 4.5, 6})
+		restore()
+	}
+}
+
+func TestGEMM_Panics(t *testing.T) {
+	a := make([]float64, 6)
+	cases := map[string]func(){
+		"negative": func() { GEMM(false, false, -1, 2, 2, 1, a, 2, a, 2, 0, a, 2) },
+		"lda":      func() { GEMM(false, false, 2, 2, 3, 1, a, 2, a, 2, 0, a, 2) },
+		"short b":  func() { GEMM(false, true, 2, 4, 2, 1, a, 2, a, 2, 0, make([]float64, 8), 4) },
+		"short c":  func() { GEMM(false, false, 2, 3, 2, 1, a, 2, a, 3, 0, a[:5], 3) },
+		"gemv x":   func() { GEMV(false, 2, 3, 1, a, 3, a[:2], 0, a) },
+	}
+	for name, fn := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestGEMV_MatchesNaive(t *testing.T) {
+	for _, tier := range gemmTiers {
+		restore := cpu.Override(tier)
+		for _, s := range [][2]int{{1, 1}, {5, 3}, {33, 64}, {70, 65}, {9, 200}} {
+			m, n := s[0], s[1]
+			lda := n + 5
+			a := deterministicF64Vector(m+n, m*lda)
+			for _, transA := range []bool{false, true} {
+				xLen, yLen := n, m
+				if transA {
+					xLen, yLen = m, n
+				}
+				x := deterministicF64Vector(xLen, xLen)
+				y := deterministicF64Vector(yLen+1, yLen)
+				for _, ab := range [][2]float64{{1, 0}, {2, -1}} {
+					// GEMV is GEMM with a one-column op(B).
+					want := naiveGEMM(transA, false, yLen, 1, xLen, ab[0], a, lda, x, 1, ab[1], y, 1)
+					got := append([]float64(nil), y...)
+					GEMV(transA, m, n, ab[0], a, lda, x, ab[1], got)
+					assertGEMMClose(t, got, want, xLen)
+				}
+			}
+		}
+		restore()
+	}
+}
+
+func TestGEMM_Allocs(t *testing.T) {
+	const m, n, k = 40, 48, 64
+	a := deterministicF64Vector(1, m*k)
+	b := deterministicF64Vector(2, k*n)
+	c := make([]float64, m*n)
+	x := deterministicF64Vector(3, k)
+	y := make([]float64, m)
+	allocs := testing.AllocsPerRun(100, func() {
+		GEMM(false, false, m, n, k, 1, a, k, b, n, 0, c, n)
+		GEMV(false, m, k, 1, a, k, x, 0, y)
+		GEMV(true, m, k, 1, a, k, y, 0, x)
+	})
+	if allocs != 0 {
+		t.Fatalf("GEMM/GEMV allocations = %v, want 0", allocs)
+	}
+}
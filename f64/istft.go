@@ -0,0 +1,206 @@
+package f64
+
+// This file implements the inverse of STFTPlan.STFT: an overlap-add resynthesis
+// that takes the Hermitian half-spectrum rows the forward transform emits back to
+// the time domain, so a mask or denoise pipeline can analyze, modify, and
+// resynthesize without leaving the package.
+//
+// Each frame is brought back with an inverse rfft that runs on the plan's own
+// half-length complex FFT (the forward fftHalf on the conjugated, re-packed
+// spectrum), multiplied by the synthesis window, and overlap-added into the
+// output with AccumulateAdd. The sum is then divided by the window-sum-square
+// envelope, the least-squares (Griffin-Lim) inverse librosa.istft computes, so
+// STFT followed by ISTFT with the same window, hop and PadMode reproduces the
+// signal wherever the squared window envelope is non-zero.
+
+// istftWSSFloor is the smallest window-sum-square value ISTFT divides by: the
+// smallest normal float64, the same threshold as librosa's util.tiny. Samples
+// whose envelope falls at or below it (the zero endpoints of an uncentered Hann
+// frame, say) are left as the raw overlap-add sum, exactly as librosa does.
+const istftWSSFloor = 0x1p-1022
+
+// NumSamples reports how many time-domain samples ISTFT reconstructs from frames
+// hop-spaced frames under the given pad mode: every sample that at least one
+// frame covers, after the centering pad is trimmed.
+//
+//	NoPad:              (frames-1)*hop + nfft
+//	PadZero/PadReflect: (frames-1)*hop + nfft - nfft/2
+//
+// It is 0 when frames or hop is not positive. For a centered round trip this is
+// at least the original signal length whenever hop <= nfft/2, so pass
+// dst[:len(signal)] to ISTFT to trim to it (librosa's length= argument).
+func (p *STFTPlan) NumSamples(frames, hop int, pad PadMode) int {
+	if frames <= 0 || hop <= 0 {
+		return 0
+	}
+	if pad == NoPad {
+		return (frames-1)*hop + p.nfft
+	}
+	return (frames-1)*hop + p.nfft - p.half
+}
+
+// binAt returns bin k of a half-spectrum row, treating bins past the end of a
+// short row as zero.
+func binAt(row []complex128, k int) (re, im float64) {
+	if k >= len(row) {
+		return 0, 0
+	}
+	return real(row[k]), imag(row[k])
+}
+
+// synthFrame runs the inverse real FFT of one half-spectrum row and writes the
+// windowed nfft-sample frame into p.frame. The row is split back into the even
+// and odd half-spectra (the inverse of unravelBin), repacked as
+// C[k] = E[k] + i*O[k], and inverted through the forward fftHalf via
+// ifft(C) = conj(fft(conj(C)))/half. The imaginary parts of the DC and Nyquist
+// bins are ignored, as numpy.fft.irfft does. An odd nfft takes synthOdd instead.
+func (p *STFTPlan) synthFrame(spec []complex128, window []float64) {
+	if p.odd {
+		p.synthOdd(spec, window)
+		return
+	}
+	re, im := p.re, p.im
+	h := p.half
+	for k := range h {
+		xkr, xki := binAt(spec, k)
+		xmr, xmi := binAt(spec, h-k)
+		if k == 0 {
+			xki, xmi = 0, 0 // DC and Nyquist are real
+		}
+		// E = 0.5*(X[k] + conj(X[half-k])), D = 0.5*(X[k] - conj(X[half-k])).
+		er := rfftHalf * (xkr + xmr)
+		ei := rfftHalf * (xki - xmi)
+		dr := rfftHalf * (xkr - xmr)
+		di := rfftHalf * (xki + xmi)
+		// O = D * conj(W_N^k).
+		wr, wi := p.unRe[k], -p.unIm[k]
+		or := dr*wr - di*wi
+		oi := dr*wi + di*wr
+		// Store conj(E + i*O) so the forward FFT yields the conjugated inverse.
+		re[k] = er - oi
+		im[k] = -(ei + or)
+	}
+	p.fftHalf()
+
+	scale := 1 / float64(h)
+	frame := p.frame
+	if window == nil {
+		for j := range h {
+			frame[2*j] = re[j] * scale
+			frame[2*j+1] = -im[j] * scale
+		}
+		return
+	}
+	for j := range h {
+		frame[2*j] = re[j] * scale * window[2*j]
+		frame[2*j+1] = -im[j] * scale * window[2*j+1]
+	}
+}
+
+// synthOdd is synthFrame for an odd nfft: the row is mirrored into the full
+// conjugated Hermitian spectrum conj(X[k]), X[nfft-k] = conj(X[k]), whose forward
+// FFT over nfft is nfft times the real frame. The imaginary part of the DC bin is
+// ignored (an odd nfft has no Nyquist bin), as numpy.fft.irfft does.
+func (p *STFTPlan) synthOdd(spec []complex128, window []float64) {
+	re, im := p.re, p.im
+	n := p.nfft
+	re[0], _ = binAt(spec, 0)
+	im[0] = 0
+	for k := 1; k <= p.half; k++ {
+		xr, xi := binAt(spec, k)
+		re[k], im[k] = xr, -xi
+		re[n-k], im[n-k] = xr, xi
+	}
+	p.fftHalf()
+
+	scale := 1 / float64(n)
+	frame := p.frame
+	for j := range frame {
+		frame[j] = re[j] * scale
+		if window != nil {
+			frame[j] *= window[j]
+		}
+	}
+}
+
+// ISTFT reconstructs a time-domain signal from STFT half-spectrum rows by
+// weighted overlap-add, the inverse of STFT for the same window, hop, and pad.
+// Each row of spec is one frame of NumBins complex128 bins (a short row is
+// zero-extended; extra bins are ignored). window, when non-nil, is the synthesis
+// window and must have length nfft; a nil or short window is rectangular, as in
+// STFT. pad must match the forward call: PadZero and PadReflect trim the nfft/2
+// centering pad from the front, NoPad trims nothing.
+//
+// The overlap-added frames are divided by the window-sum-square envelope
+// sum_f window[t-f*hop]^2, matching librosa.istft, so a signal analyzed with
+// window w and resynthesized with the same w round-trips to within float64
+// rounding wherever that envelope is non-zero. Samples with a (numerically) zero
+// envelope keep the raw overlap-add sum.
+//
+// It writes min(len(dst), NumSamples(len(spec), hop, pad)) samples, overwriting
+// whatever dst held there, and returns that count; pass dst[:len(signal)] to
+// trim a centered round trip to the original length. hop <= 0 or an empty spec
+// writes nothing. dst must not overlap window. It is allocation-free and reuses
+// the plan scratch.
+func (p *STFTPlan) ISTFT(dst []float64, spec [][]complex128, window []float64, hop int, pad PadMode) int {
+	frames := len(spec)
+	n := min(len(dst), p.NumSamples(frames, hop, pad))
+	if n <= 0 {
+		return 0
+	}
+	if window != nil && len(window) < p.nfft {
+		window = nil
+	}
+	off := 0
+	if pad != NoPad {
+		off = p.half // frame f starts at output sample f*hop - nfft/2
+	}
+	dst = dst[:n]
+	clear(dst)
+
+	for f := range frames {
+		base := f*hop - off
+		if base >= n {
+			break
+		}
+		p.synthFrame(spec[f], window)
+		// Clip the frame to the output window [0, n) before the overlap-add.
+		lo := max(0, -base)
+		hi := min(p.nfft, n-base)
+		if lo < hi {
+			AccumulateAdd(dst, p.frame[lo:hi], base+lo)
+		}
+	}
+
+	p.normalizeOLA(dst, window, frames, hop, off)
+	return n
+}
+
+// normalizeOLA divides each overlap-added sample by its window-sum-square
+// envelope: output sample t sits at frame-coordinate s = t+off, which frames
+// ceil((s-nfft+1)/hop) .. s/hop cover, each contributing window[s-f*hop]^2.
+func (p *STFTPlan) normalizeOLA(dst, window []float64, frames, hop, off int) {
+	wsq := p.winSq
+	for i := range wsq {
+		if window == nil {
+			wsq[i] = 1
+		} else {
+			wsq[i] = window[i] * window[i]
+		}
+	}
+	for t := range dst {
+		s := t + off
+		fLo := 0
+		if s >= p.nfft {
+			fLo = (s - p.nfft + hop) / hop // ceil((s-nfft+1)/hop)
+		}
+		fHi := min(frames-1, s/hop)
+		var wss float64
+		for f := fLo; f <= fHi; f++ {
+			wss += wsq[s-f*hop]
+		}
+		if wss > istftWSSFloor {
+			dst[t] /= wss
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package f64
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":                      absGo,
+	"AccumulateAdd":            accumulateAdd64Go,
+	"Add":                      addGo,
+	"AddScalar":                addScalarGo,
+	"AddScaled":                addScaledGo64,
+	"Autocorrelate":            autocorrelateGo,
+	"BiquadCascade.Process":    biquadSectionGo,
+	"ButterflyComplex":         butterflyComplex64Go,
+	"ButterflyComplexStage":    butterflyComplexStage64Go,
+	"ButterflyComplexStage4":   butterflyComplexStage4x64Go,
+	"Clamp":                    clampGo,
+	"ClampScale":               clampScale64Go,
+	"ConvolveDecimate":         convolveDecimate64Go,
+	"ConvolveValid":            convolveValid64Go,
+	"ConvolveValidMaxAbs":      convolveValidMaxAbsGo,
+	"ConvolveValidMaxAbsMulti": convolveValidMaxAbsGo,
+	"ConvolveValidMulti":       convolveValidMultiGo,
+	"CubicInterpDot":           cubicInterpDotGo,
+	"CubicInterpDotUnsafe":     cubicInterpDotGo,
+	"CumulativeSum":            cumulativeSum64Go,
+	"Deinterleave2":            deinterleave2Go,
+	"DeinterleaveN":            deinterleaveNGo,
+	"Div":                      divGo,
+	"DotProduct":               dotProductGo,
+	"DotProductBatch":          dotProductBatch64Go,
+	"DotProductIndexed":        dotProductIndexedGo,
+	"DotProductStrided":        dotProductStridedGo,
+	"DotProductUnsafe":         dotProductGo,
+	"EuclideanDistance":        euclideanDistance64Go,
+	"Exp":                      exp64Go,
+	"ExpInPlace":               exp64Go,
+	"FMA":                      fmaGo,
+	"GEMM":                     gemmGo,
+	"Interleave2":              interleave2Go,
+	"InterleaveN":              interleaveNGo,
+	"Log":                      logGo,
+	"Log10":                    log10Go,
+	"Log2":                     log2Go,
+	"LogInPlace":               logGo,
+	"LogSoftmax":               expSum64Go,
+	"LogSoftmaxRows":           expSum64Go,
+	"LogSumExp":                expSum64Go,
+	"LogSumExpRows":            expSum64Go,
+	"Max":                      maxGo,
+	"MaxAbs":                   maxAbsGo,
+	"MaxIdx":                   maxIdxGo64,
+	"Min":                      minGo,
+	"MinIdx":                   minIdxGo64,
+	"Mul":                      mulGo,
+	"Neg":                      negGo,
+	"Pow":                      powGo,
+	"PowElem":                  powElemGo,
+	"PowInPlace":               powGo,
+	"ReLU":                     relu64Go,
+	"ReLUInPlace":              relu64Go,
+	"RealFFTPower":             realFFTPower64Go,
+	"RealFFTUnpack":            realFFTUnpack64Go,
+	"Reciprocal":               reciprocal64Go,
+	"Round":                    round64Go,
+	"Scale":                    scaleGo,
+	"Sigmoid":                  sigmoid64Go,
+	"SigmoidInPlace":           sigmoid64Go,
+	"Softmax":                  expSum64Go,
+	"SoftmaxRows":              expSum64Go,
+	"Sqrt":                     sqrt64Go,
+	"Sub":                      subGo,
+	"SubFromScalar":            subFromScalarGo,
+	"Sum":                      sumGo,
+	"SumOfSquares":             dotProductGo,
+	"Tanh":                     tanh64Go,
+	"TanhInPlace":              tanh64Go,
+	"Variance":                 variance64Go,
+	"WeightedSum":              dotProductGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "f64", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
@@ -0,0 +1,211 @@
+//go:build amd64
+
+package f64
+
+import (
+	"github.com/tphakala/simd/cpu"
+	"github.com/tphakala/simd/internal/dispatch"
+)
+
+// kernelSuffixes names the tier of each kernel the initXXX functions install in
+// the function pointers. initAVXNoFMA installs the same AVX kernels as initAVX
+// minus the FMA ones, so an AVX kernel reports AVX+FMA only on hosts with FMA.
+func kernelSuffixes() []dispatch.Suffix {
+	avx := dispatch.AVX
+	if cpu.X86.FMA {
+		avx = dispatch.AVXFMA
+	}
+	return []dispatch.Suffix{
+		{Suffix: "AVX512", Tier: dispatch.AVX512},
+		{Suffix: "AVX", Tier: avx},
+		{Suffix: "SSE2", Tier: dispatch.SSE2},
+	}
+}
+
+// kernelPointer returns the function pointer op dispatches through, or nil for
+// the operations dispatched on feature flags directly.
+func kernelPointer(op string) any {
+	switch op {
+	case "DotProduct", "DotProductUnsafe", "SumOfSquares", "WeightedSum", "ConvolveValid", "ConvolveValidMulti":
+		return dotProductImpl
+	case "Add", "AccumulateAdd":
+		return addImpl
+	case "Sub":
+		return subImpl
+	case "Mul":
+		return mulImpl
+	case "Div":
+		return divImpl
+	case "Scale":
+		return scaleImpl
+	case "AddScalar":
+		return addScalarImpl
+	case "Sum":
+		return sumImpl
+	case "Min":
+		return minImpl
+	case "Max":
+		return maxImpl
+	case "MaxAbs":
+		return maxAbsImpl
+	case "Abs":
+		return absImpl
+	case "Neg", "SubFromScalar":
+		return negImpl
+	case "Sqrt":
+		return sqrtImpl
+	case "Reciprocal":
+		return reciprocalImpl
+	case "Round":
+		return roundImpl
+	case "FMA":
+		return fmaImpl
+	case "Clamp":
+		return clampImpl
+	case "Variance":
+		return varianceImpl
+	case "EuclideanDistance":
+		return euclideanDistanceImpl
+	case "AddScaled":
+		return addScaledImpl
+	case "ConvolveDecimate":
+		return convolveDecimateImpl
+	case "ConvolveValidMaxAbs", "ConvolveValidMaxAbsMulti":
+		return convolveValidMaxAbsImpl
+	case "Interleave2":
+		return interleave2Impl
+	case "Deinterleave2":
+		return deinterleave2Impl
+	}
+	return nil
+}
+
+// kernelBinding reports the kernel op is bound to, from the same function
+// pointers and cpu.X86 flags its dispatcher reads.
+func kernelBinding(op string) dispatch.Binding {
+	if fn := kernelPointer(op); fn != nil {
+		return dispatch.Bound(fn, kernelSuffixes())
+	}
+	if b := fftBinding(op); b.Func != "" {
+		return b
+	}
+	if b := activationBinding(op); b.Func != "" {
+		return b
+	}
+	x := &cpu.X86
+	switch op {
+	case "DotProductBatch", "DotProductIndexed", "DotProductStrided":
+		if x.AVX512F && x.AVX512VL {
+			return dispatch.Bind(dispatch.AVX512, dotProduct4AVX512)
+		}
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, dotProduct4AVX)
+		}
+	case "GEMM":
+		if x.AVX512F && x.AVX512VL {
+			return dispatch.Bind(dispatch.AVX512, gemmKernel6x16AVX512)
+		}
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, gemmKernel6x8AVX)
+		}
+	case "Autocorrelate":
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, autocorrStep4AVX)
+		}
+	case "InterleaveN":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, interleave3AVX)
+		}
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, interleave4AVX)
+		}
+	case "DeinterleaveN":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, deinterleave3AVX)
+		}
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, deinterleave4AVX)
+		}
+	case "CubicInterpDot", "CubicInterpDotUnsafe":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, cubicInterpDotAVX)
+		}
+	case "BiquadCascade.Process":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, biquadAVX)
+		}
+	}
+	return dispatch.Binding{} // below every kernel tier, or MinIdx, MaxIdx, CumulativeSum (scalar by design)
+}
+
+// fftBinding reports the FFT butterfly and real-FFT kernels.
+func fftBinding(op string) dispatch.Binding {
+	x := &cpu.X86
+	switch op {
+	case "ButterflyComplex":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, butterflyComplexAVX)
+		}
+	case "ButterflyComplexStage":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, butterflyComplexStageAVX)
+		}
+	case "ButterflyComplexStage4":
+		if x.AVX && x.FMA {
+			return dispatch.Bind(dispatch.AVXFMA, butterflyComplexStage4AVX)
+		}
+	case "RealFFTUnpack":
+		if hasAVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, realFFTUnpackAVX)
+		}
+	case "RealFFTPower":
+		if hasAVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, realFFTPowerAVX)
+		}
+	}
+	return dispatch.Binding{}
+}
+
+// activationBinding reports the activation and transcendental kernels.
+func activationBinding(op string) dispatch.Binding {
+	x := &cpu.X86
+	switch op {
+	case "Sigmoid", "SigmoidInPlace":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, sigmoidAVX)
+		}
+	case "Tanh", "TanhInPlace":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, tanhAVX)
+		}
+	case "Exp", "ExpInPlace":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, expAVX)
+		}
+	case "Softmax", "SoftmaxRows", "LogSoftmax", "LogSoftmaxRows", "LogSumExp", "LogSumExpRows":
+		if x.AVX2 {
+			return dispatch.Bind(dispatch.AVX2, expSumAVX)
+		}
+	case "ReLU", "ReLUInPlace":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, reluAVX)
+		}
+	case "ClampScale":
+		if x.AVX {
+			return dispatch.Bind(dispatch.AVX, clampScaleAVX)
+		}
+	case "Log", "LogInPlace", "Log2", "Log10":
+		if x.AVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, logAVX)
+		}
+	case "Pow", "PowInPlace":
+		if x.AVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, powAVX)
+		}
+	case "PowElem":
+		if x.AVX2 && x.FMA {
+			return dispatch.Bind(dispatch.AVX2FMA, powElemAVX)
+		}
+	}
+	return dispatch.Binding{}
+}
@@ -0,0 +1,25 @@
+//go:build amd64
+
+package f64
+
+import (
+	"testing"
+
+	"github.com/tphakala/simd/internal/dispatch"
+)
+
+// TestKernelsFollowDispatch checks the report follows the function pointers
+// when an init* function rebinds them.
+func TestKernelsFollowDispatch(t *testing.T) {
+	saved := snapshotDispatch()
+	t.Cleanup(func() { restoreDispatch(&saved) })
+
+	initSSE2()
+	if k, _ := dispatch.Lookup("f64", "Add"); k.Impl != dispatch.SSE2.Name || k.Func != "addSSE2" {
+		t.Errorf("after initSSE2: Add = %s (%s), want SSE2 (addSSE2)", k.Impl, k.Func)
+	}
+	initGo()
+	if k, _ := dispatch.Lookup("f64", "Add"); k.Impl != dispatch.Go.Name || k.Func != k.Fallback {
+		t.Errorf("after initGo: Add = %s (%s), want Go (%s)", k.Impl, k.Func, k.Fallback)
+	}
+}
@@ -0,0 +1,113 @@
+//go:build arm64
+
+package f64
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation with a NEON path to its kernel. MinIdx,
+// MaxIdx and CumulativeSum are scalar by design and absent.
+var neonKernels = map[string]any{
+	"DotProduct":               dotProductNEON,
+	"DotProductUnsafe":         dotProductNEON,
+	"SumOfSquares":             dotProductNEON,
+	"WeightedSum":              dotProductNEON,
+	"ConvolveValid":            dotProductNEON,
+	"ConvolveValidMulti":       dotProductNEON,
+	"DotProductBatch":          dotProduct4NEON,
+	"DotProductIndexed":        dotProduct4NEON,
+	"DotProductStrided":        dotProduct4NEON,
+	"Autocorrelate":            autocorrStep2NEON,
+	"Add":                      addNEON,
+	"AccumulateAdd":            addNEON,
+	"Sub":                      subNEON,
+	"Mul":                      mulNEON,
+	"Div":                      divNEON,
+	"Scale":                    scaleNEON,
+	"AddScalar":                addScalarNEON,
+	"AddScaled":                addScaledNEON,
+	"FMA":                      fmaNEON,
+	"GEMM":                     gemmKernel8x4NEON,
+	"Sum":                      sumNEON,
+	"Min":                      minNEON,
+	"Max":                      maxNEON,
+	"MaxAbs":                   maxAbsNEON,
+	"Abs":                      absNEON,
+	"Neg":                      negNEON,
+	"SubFromScalar":            negNEON,
+	"Clamp":                    clampNEON,
+	"Sqrt":                     sqrtNEON,
+	"Round":                    roundNEON,
+	"Reciprocal":               reciprocalNEON,
+	"Variance":                 varianceNEON,
+	"EuclideanDistance":        euclideanDistanceNEON,
+	"ConvolveDecimate":         convolveDecimateNEON,
+	"ConvolveValidMaxAbs":      convolveValidMaxAbsNEON,
+	"ConvolveValidMaxAbsMulti": convolveValidMaxAbsNEON,
+	"Interleave2":              interleave2NEON,
+	"Deinterleave2":            deinterleave2NEON,
+	"InterleaveN":              interleave4NEON,
+	"DeinterleaveN":            deinterleave4NEON,
+	"CubicInterpDot":           cubicInterpDotNEON,
+	"CubicInterpDotUnsafe":     cubicInterpDotNEON,
+	"BiquadCascade.Process":    biquad2NEON,
+	"ButterflyComplex":         butterflyComplexNEON,
+	"ButterflyComplexStage":    butterflyComplexStageNEON,
+	"ButterflyComplexStage4":   butterflyComplexStage4NEON,
+	"RealFFTUnpack":            realFFTUnpackNEON,
+	"RealFFTPower":             realFFTPowerNEON,
+	"Sigmoid":                  sigmoidNEON64,
+	"SigmoidInPlace":           sigmoidNEON64,
+	"ReLU":                     reluNEON64,
+	"ReLUInPlace":              reluNEON64,
+	"ClampScale":               clampScaleNEON64,
+	"Tanh":                     tanhNEON64,
+	"TanhInPlace":              tanhNEON64,
+	"Exp":                      expNEON64,
+	"ExpInPlace":               expNEON64,
+	"LogSoftmax":               expSumNEON64,
+	"LogSoftmaxRows":           expSumNEON64,
+	"LogSumExp":                expSumNEON64,
+	"LogSumExpRows":            expSumNEON64,
+	"Softmax":                  expSumNEON64,
+	"SoftmaxRows":              expSumNEON64,
+	"Log":                      logNEON64,
+	"LogInPlace":               logNEON64,
+	"Log2":                     logNEON64,
+	"Log10":                    logNEON64,
+	"Pow":                      powNEON64,
+	"PowInPlace":               powNEON64,
+	"PowElem":                  powElemNEON64,
+}
+
+// sveKernels maps the operations with an SVE path to their kernel, which the
+// SVE tier runs in place of the NEON one. ConvolveDecimate and
+// ConvolveValidMaxAbs run the SVE dot per window instead of a fused kernel.
+var sveKernels = map[string]any{
+	"DotProduct":               dotProductSVE,
+	"DotProductUnsafe":         dotProductSVE,
+	"SumOfSquares":             dotProductSVE,
+	"WeightedSum":              dotProductSVE,
+	"ConvolveValid":            dotProductSVE,
+	"ConvolveValidMulti":       dotProductSVE,
+	"ConvolveDecimate":         dotProductSVE,
+	"ConvolveValidMaxAbs":      dotProductSVE,
+	"ConvolveValidMaxAbsMulti": dotProductSVE,
+	"DotProductBatch":          dotProduct4SVE,
+	"DotProductIndexed":        dotProduct4SVE,
+	"DotProductStrided":        dotProduct4SVE,
+	"Add":                      addSVE,
+	"AccumulateAdd":            addSVE,
+	"Mul":                      mulSVE,
+	"FMA":                      fmaSVE,
+	"Sum":                      sumSVE,
+}
+
+// kernelBinding reports the kernel op is bound to: its SVE kernel when the
+// host has SVE, else its NEON kernel when the host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	if fn, ok := sveKernels[op]; ok && hasSVE {
+		return dispatch.Bind(dispatch.SVE, fn)
+	}
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
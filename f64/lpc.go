@@ -0,0 +1,142 @@
+package f64
+
+import "math"
+
+// Linear prediction: the steps of a FLAC-style LPC encoder after Autocorrelate.
+// LagWindow conditions the autocorrelation, LevinsonDurbin solves it for the
+// predictors of every order up to the maximum, and QuantizeLPC turns one of them
+// into the integer coefficients and shift that i32.LPCResidual and
+// i32.LPCRestore apply. LevinsonDurbin and QuantizeLPC are transcriptions of
+// libFLAC's FLAC__lpc_compute_lp_coefficients and FLAC__lpc_quantize_coefficients,
+// so an encoder that feeds them libFLAC's autocorrelation chooses the same
+// quantized predictor.
+
+// LagWindow conditions an autocorrelation in place before LevinsonDurbin:
+//
+//	autoc[0]   *= 1 + whiteNoise
+//	autoc[lag] *= exp(-0.5 * (2*pi*bandwidth*lag)^2)
+//
+// The Gaussian lag window smooths the implied spectrum over about bandwidth (a
+// fraction of the sample rate, such as 60.0/16000) so sharp peaks do not produce
+// ill-conditioned predictors, and the white-noise correction (such as 1e-4,
+// about -40 dB) bounds the predictor gain. Zero for either leaves that part
+// untouched.
+func LagWindow(autoc []float64, bandwidth, whiteNoise float64) {
+	if len(autoc) == 0 {
+		return
+	}
+	autoc[0] *= 1 + whiteNoise
+	const half = 0.5
+	for lag := 1; lag < len(autoc); lag++ {
+		x := 2 * math.Pi * bandwidth * float64(lag)
+		autoc[lag] *= math.Exp(-half * x * x)
+	}
+}
+
+// LevinsonDurbin solves the autocorrelation normal equations for the linear
+// predictors of every order 1..maxOrder, maxOrder = min(len(autoc)-1, len(lpc)),
+// by the Levinson-Durbin recursion of libFLAC's
+// FLAC__lpc_compute_lp_coefficients, operation for operation:
+//
+//   - lpc[i][:i+1] receives the order-(i+1) predictor, x[n] ~ sum_j lpc[i][j]*x[n-1-j]
+//     (libFLAC's sign, which stores the result in float32; QuantizeLPC applies
+//     that rounding);
+//   - refl[i], if refl is long enough, receives the reflection (PARCOR)
+//     coefficient of stage i, which is also lpc[i][i];
+//   - predErr[i], if predErr is long enough, receives the prediction error
+//     autoc[0] * prod_{k<=i} (1 - refl[k]^2) of the order-(i+1) predictor.
+//
+// It returns the number of orders solved: maxOrder, or fewer when the error
+// reaches exactly zero (a perfectly predictable signal), when a row of lpc is
+// shorter than its order, or 0 when autoc[0] is zero. Allocation-free: each
+// order is built from the row before it.
+func LevinsonDurbin(lpc [][]float64, refl, predErr, autoc []float64) int {
+	maxOrder := min(len(autoc)-1, len(lpc))
+	if maxOrder <= 0 || autoc[0] == 0 {
+		return 0
+	}
+	err := autoc[0]
+	var prev []float64
+	for i := range maxOrder {
+		row := lpc[i]
+		if len(row) < i+1 {
+			return i
+		}
+		// libFLAC: r = -autoc[i+1] - sum_j c[j]*autoc[i-j] with c = -prev.
+		r := -autoc[i+1]
+		for j := range i {
+			r += prev[j] * autoc[i-j]
+		}
+		r /= err
+		// c_new[j] = c[j] + r*c[i-1-j] and c_new[i] = r, stored negated.
+		for j := range i {
+			row[j] = prev[j] + r*prev[i-1-j]
+		}
+		row[i] = -r
+		err *= 1 - r*r
+		if i < len(refl) {
+			refl[i] = -r
+		}
+		if i < len(predErr) {
+			predErr[i] = err
+		}
+		if err == 0 {
+			return i + 1
+		}
+		prev = row
+	}
+	return maxOrder
+}
+
+// FLAC format limits on the quantized predictor.
+const (
+	lpcMinPrecision = 5  // FLAC__MIN_QLP_COEFF_PRECISION
+	lpcMaxPrecision = 15 // FLAC__MAX_QLP_COEFF_PRECISION
+	lpcMaxShift     = 15 // largest shift the 5-bit signed field codes
+	lpcMinShift     = -16
+)
+
+// QuantizeLPC quantizes the predictor lpc (one LevinsonDurbin row) to integer
+// coefficients of precision bits (sign included, 5..15, libFLAC's
+// FLAC__MIN/MAX_QLP_COEFF_PRECISION) and returns the right shift that rescales
+// them, as libFLAC's FLAC__lpc_quantize_coefficients does: the coefficients are rounded to float32 (libFLAC's FLAC__real), the shift is
+// chosen from the largest magnitude and capped at 15, and each coefficient is
+// rounded half away from zero with the rounding error carried into the next. A
+// shift that would be negative is applied by scaling the coefficients down and
+// reported as 0. It writes qlp[:order], order = min(len(qlp), len(lpc)), and
+// returns ok == false, writing nothing, for an empty order, a precision out of
+// range, all-zero coefficients, or coefficients too large for any shift (libFLAC's
+// return codes 2 and 1).
+func QuantizeLPC(qlp []int32, lpc []float64, precision int) (shift int, ok bool) {
+	order := min(len(qlp), len(lpc))
+	if order == 0 || precision < lpcMinPrecision || precision > lpcMaxPrecision {
+		return 0, false
+	}
+	precision-- // one bit for the sign
+	qmax := int32(1)<<precision - 1
+	qmin := -qmax - 1
+
+	var cmax float64
+	for _, c := range lpc[:order] {
+		cmax = max(cmax, math.Abs(float64(float32(c))))
+	}
+	if cmax <= 0 {
+		return 0, false
+	}
+	_, log2cmax := math.Frexp(cmax)
+	log2cmax--
+	shift = min(precision-log2cmax-1, lpcMaxShift)
+	if shift < lpcMinShift {
+		return 0, false
+	}
+
+	scale := math.Ldexp(1, shift)
+	var carry float64
+	for i, c := range lpc[:order] {
+		carry += float64(float32(c)) * scale
+		q := min(max(int32(math.Round(carry)), qmin), qmax)
+		carry -= float64(q)
+		qlp[i] = q
+	}
+	return max(shift, 0), true
+}
@@ -0,0 +1,283 @@
+package f64
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// lpcSignal is testSignal with a little deterministic noise, so the predictors
+// stay well conditioned at every order.
+func lpcSignal(n int) []float64 {
+	s := testSignal(n)
+	x := uint32(12345)
+	for i := range s {
+		x = x*1664525 + 1013904223
+		s[i] += 0.01 * (float64(x>>8)/(1<<24) - 0.5)
+	}
+	return s
+}
+
+// flacLevinsonRef is FLAC__lpc_compute_lp_coefficients as written in C, without
+// the float32 store: the working predictor lpc has the opposite sign of the
+// output.
+func flacLevinsonRef(autoc []float64, maxOrder int) (coeffs [][]float64, errs []float64) {
+	lpc := make([]float64, maxOrder)
+	err := autoc[0]
+	for i := range maxOrder {
+		r := -autoc[i+1]
+		for j := range i {
+			r -= lpc[j] * autoc[i-j]
+		}
+		r /= err
+		lpc[i] = r
+		j := 0
+		for ; j < i>>1; j++ {
+			tmp := lpc[j]
+			lpc[j] += r * lpc[i-1-j]
+			lpc[i-1-j] += r * tmp
+		}
+		if i&1 != 0 {
+			lpc[j] += lpc[j] * r
+		}
+		err *= 1 - r*r
+		row := make([]float64, i+1)
+		for j := range row {
+			row[j] = -lpc[j]
+		}
+		coeffs, errs = append(coeffs, row), append(errs, err)
+		if err == 0 {
+			break
+		}
+	}
+	return coeffs, errs
+}
+
+func lpcRows(maxOrder int) [][]float64 {
+	rows := make([][]float64, maxOrder)
+	for i := range rows {
+		rows[i] = make([]float64, i+1)
+	}
+	return rows
+}
+
+// TestLevinsonDurbinAgainstFLAC checks every order bit for bit against the libFLAC
+// transcription, and that each predictor solves its normal equations and has the
+// stated error and reflection coefficient.
+func TestLevinsonDurbinAgainstFLAC(t *testing.T) {
+	const maxOrder = 32
+	x := lpcSignal(4096)
+	autoc := make([]float64, maxOrder+1)
+	Autocorrelate(autoc, x, maxOrder)
+	LagWindow(autoc, 0.004, 1e-5)
+
+	lpc, refl, predErr := lpcRows(maxOrder), make([]float64, maxOrder), make([]float64, maxOrder)
+	if n := LevinsonDurbin(lpc, refl, predErr, autoc); n != maxOrder {
+		t.Fatalf("LevinsonDurbin returned %d, want %d", n, maxOrder)
+	}
+	wantCoeffs, wantErrs := flacLevinsonRef(autoc, maxOrder)
+	for i := range maxOrder {
+		for j := range i + 1 {
+			if lpc[i][j] != wantCoeffs[i][j] {
+				t.Fatalf("order %d: lpc[%d] = %v, want %v", i+1, j, lpc[i][j], wantCoeffs[i][j])
+			}
+		}
+		if predErr[i] != wantErrs[i] || refl[i] != lpc[i][i] {
+			t.Fatalf("order %d: err %v refl %v, want %v and %v", i+1, predErr[i], refl[i], wantErrs[i], lpc[i][i])
+		}
+
+		a := lpc[i]
+		for k := range a {
+			var lhs float64
+			for j := range a {
+				lhs += a[j] * autoc[max(k-j, j-k)]
+			}
+			if d := math.Abs(lhs - autoc[k+1]); d > 1e-9*autoc[0] {
+				t.Fatalf("order %d: normal equation %d off by %g", i+1, k, d)
+			}
+		}
+		e := autoc[0]
+		for j := range a {
+			e -= a[j] * autoc[j+1]
+		}
+		if d := math.Abs(e - predErr[i]); d > 1e-9*autoc[0] {
+			t.Fatalf("order %d: predErr %g, direct %g", i+1, predErr[i], e)
+		}
+	}
+}
+
+// TestLevinsonDurbinStops checks the early exits: a perfectly predictable
+// autocorrelation, a zero one, a short row, and a maxOrder limited by lpc or
+// autoc, with nil refl and predErr.
+func TestLevinsonDurbinStops(t *testing.T) {
+	lpc := lpcRows(4)
+	if n := LevinsonDurbin(lpc, nil, nil, []float64{2, 2, 2, 2, 2}); n != 1 || lpc[0][0] != 1 {
+		t.Fatalf("constant signal: n = %d, lpc[0] = %v", n, lpc[0])
+	}
+	if n := LevinsonDurbin(lpc, nil, nil, []float64{0, 0, 0}); n != 0 {
+		t.Fatalf("zero autoc: n = %d", n)
+	}
+	if n := LevinsonDurbin(lpc, nil, nil, []float64{1}); n != 0 {
+		t.Fatalf("autoc without lags: n = %d", n)
+	}
+	autoc := []float64{1, 0.5, 0.2, 0.1, 0.05, 0.02}
+	if n := LevinsonDurbin(lpc[:2], nil, nil, autoc); n != 2 {
+		t.Fatalf("two rows: n = %d", n)
+	}
+	if n := LevinsonDurbin(lpc, nil, nil, autoc[:3]); n != 2 {
+		t.Fatalf("two lags: n = %d", n)
+	}
+	short := [][]float64{make([]float64, 1), make([]float64, 1)}
+	if n := LevinsonDurbin(short, nil, nil, autoc); n != 1 {
+		t.Fatalf("short row: n = %d", n)
+	}
+	// AR(1) with coefficient 0.5: the order-1 predictor is exact.
+	refl := make([]float64, 1)
+	if n := LevinsonDurbin(lpc[:1], refl, nil, []float64{4, 2}); n != 1 || lpc[0][0] != 0.5 || refl[0] != 0.5 {
+		t.Fatalf("AR(1): n = %d, lpc %v, refl %v", n, lpc[0], refl)
+	}
+}
+
+func TestLagWindow(t *testing.T) {
+	autoc := []float64{2, 1, 1, 1}
+	LagWindow(autoc, 0.01, 1e-3)
+	if autoc[0] != 2*(1+1e-3) {
+		t.Fatalf("autoc[0] = %v", autoc[0])
+	}
+	for lag := 1; lag < len(autoc); lag++ {
+		x := 2 * math.Pi * 0.01 * float64(lag)
+		if want := math.Exp(-0.5 * x * x); math.Abs(autoc[lag]-want) > 1e-15 {
+			t.Fatalf("autoc[%d] = %v, want %v", lag, autoc[lag], want)
+		}
+	}
+	same := []float64{3, 2, 1}
+	LagWindow(same, 0, 0)
+	if same[0] != 3 || same[1] != 2 || same[2] != 1 {
+		t.Fatalf("zero window changed autoc: %v", same)
+	}
+	LagWindow(nil, 0.1, 0.1)
+}
+
+// flacQuantizeRef is FLAC__lpc_quantize_coefficients as written in C.
+func flacQuantizeRef(lpc []float64, precision int) (qlp []int32, shift, status int) {
+	precision--
+	qmax := int32(1)<<precision - 1
+	qmin := -qmax - 1
+	var cmax float64
+	for _, c := range lpc {
+		cmax = max(cmax, math.Abs(float64(float32(c))))
+	}
+	if cmax <= 0 {
+		return nil, 0, 2
+	}
+	_, log2cmax := math.Frexp(cmax)
+	log2cmax--
+	shift = min(precision-log2cmax-1, 15)
+	if shift < -16 {
+		return nil, 0, 1
+	}
+	qlp = make([]int32, len(lpc))
+	var e float64
+	if shift >= 0 {
+		for i, c := range lpc {
+			e += float64(float32(c)) * float64(int(1)<<shift)
+			q := int32(math.Round(e))
+			q = min(max(q, qmin), qmax)
+			e -= float64(q)
+			qlp[i] = q
+		}
+		return qlp, shift, 0
+	}
+	nshift := -shift
+	for i, c := range lpc {
+		e += float64(float32(c)) / float64(int(1)<<nshift)
+		q := int32(math.Round(e))
+		q = min(max(q, qmin), qmax)
+		e -= float64(q)
+		qlp[i] = q
+	}
+	return qlp, 0, 0
+}
+
+func TestQuantizeLPC(t *testing.T) {
+	qlp := make([]int32, 2)
+	if shift, ok := QuantizeLPC(qlp, []float64{1.5, -0.75}, 12); !ok || shift != 10 || qlp[0] != 1536 || qlp[1] != -768 {
+		t.Fatalf("QuantizeLPC = %d, %v, %v", shift, ok, qlp)
+	}
+	// The rounding error carries: 0.3*32 = 9.6 rounds to 10, and the -0.4
+	// carried makes the second 9.2, which rounds to 9.
+	if shift, ok := QuantizeLPC(qlp, []float64{0.3, 0.3}, 5); !ok || shift != 5 || qlp[0] != 10 || qlp[1] != 9 {
+		t.Fatalf("error feedback: %d, %v, %v", shift, ok, qlp)
+	}
+	// Coefficients of 2^14 need a negative shift at 15 bits: scaled by 1/2.
+	if shift, ok := QuantizeLPC(qlp, []float64{16384, -100}, 15); !ok || shift != 0 || qlp[0] != 8192 || qlp[1] != -50 {
+		t.Fatalf("negative shift: %d, %v, %v", shift, ok, qlp)
+	}
+	for _, c := range []struct {
+		name      string
+		qlp       []int32
+		lpc       []float64
+		precision int
+	}{
+		{"all zero", qlp, []float64{0, 0}, 12},
+		{"too large", qlp, []float64{1 << 20, 1}, 5},
+		{"precision 1", qlp, []float64{0.5, 0.5}, 1},
+		{"precision 4", qlp, []float64{0.5, 0.5}, 4},
+		{"precision 16", qlp, []float64{0.5, 0.5}, 16},
+		{"empty", nil, []float64{0.5}, 12},
+	} {
+		if _, ok := QuantizeLPC(c.qlp, c.lpc, c.precision); ok {
+			t.Errorf("%s: ok = true", c.name)
+		}
+	}
+
+	// Every order of a real solve, at the precisions a FLAC encoder uses.
+	const maxOrder = 32
+	autoc := make([]float64, maxOrder+1)
+	Autocorrelate(autoc, lpcSignal(4096), maxOrder)
+	LagWindow(autoc, 0.004, 1e-5)
+	lpc := lpcRows(maxOrder)
+	LevinsonDurbin(lpc, nil, nil, autoc)
+	q := make([]int32, maxOrder)
+	for _, precision := range []int{5, 8, 12, 15} {
+		for i, row := range lpc {
+			wantQ, wantShift, status := flacQuantizeRef(row, precision)
+			shift, ok := QuantizeLPC(q, row, precision)
+			if ok != (status == 0) || shift != wantShift {
+				t.Fatalf("order %d precision %d: shift %d ok %v, want %d status %d", i+1, precision, shift, ok, wantShift, status)
+			}
+			for j := range wantQ {
+				if q[j] != wantQ[j] {
+					t.Fatalf("order %d precision %d: qlp[%d] = %d, want %d", i+1, precision, j, q[j], wantQ[j])
+				}
+			}
+		}
+	}
+}
+
+func TestLPCAllocFree(t *testing.T) {
+	autoc := make([]float64, 33)
+	Autocorrelate(autoc, lpcSignal(1024), 32)
+	lpc, refl, predErr := lpcRows(32), make([]float64, 32), make([]float64, 32)
+	q := make([]int32, 32)
+	if a := testing.AllocsPerRun(5, func() {
+		LevinsonDurbin(lpc, refl, predErr, autoc)
+		QuantizeLPC(q, lpc[31], 15)
+	}); a != 0 {
+		t.Errorf("LevinsonDurbin + QuantizeLPC allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkLevinsonDurbin(b *testing.B) {
+	for _, order := range []int{8, 12, 32} {
+		b.Run(fmt.Sprintf("order=%d", order), func(b *testing.B) {
+			autoc := make([]float64, order+1)
+			Autocorrelate(autoc, lpcSignal(4096), order)
+			lpc, predErr := lpcRows(order), make([]float64, order)
+			b.ReportAllocs()
+			for b.Loop() {
+				LevinsonDurbin(lpc, nil, predErr, autoc)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package f64
+
+import (
+	"errors"
+	"math"
+)
+
+// This file closes the spectral feature pipeline on top of STFTPlan: a mel
+// filterbank builder matching librosa.filters.mel, and fused projection stages
+// (LogMel, PCEN) that consume the frame-contiguous power buffer
+// STFTPlan.STFTPowerInto writes, so a log-mel or PCEN front-end no longer needs a
+// hand-built filterbank wired through DotProductBatch, Log10 and Pow.
+//
+// The filterbank is stored dense and row-major (librosa's mel_basis layout), and
+// every row also records the [lo, hi) bin range outside which its triangle is
+// zero. Projection runs DotProduct over that support only, so a frame costs
+// about two passes over the spectrum rather than NumMels passes.
+
+// ErrMel* describe invalid filterbank and PCEN configurations.
+var (
+	// ErrMelConfig is returned by NewMelFilterbank for a non-positive sample rate
+	// or mel count, nfft < 2, a negative fmin, or fmin >= fmax.
+	ErrMelConfig = errors.New("f64: invalid mel filterbank configuration")
+
+	// ErrPCENConfig is returned by NewPCEN for a nil filterbank, a non-positive
+	// sample rate, hop, time constant or eps, or a negative gain, bias or power.
+	ErrPCENConfig = errors.New("f64: invalid PCEN configuration")
+)
+
+// MelScale selects the Hz-to-mel mapping the filter centers are spaced on.
+//
+//   - MelSlaney: the Auditory Toolbox scale, linear below 1 kHz and logarithmic
+//     above (librosa's default, htk=False).
+//   - MelHTK: mel = 2595*log10(1 + f/700) (librosa htk=True, torchaudio
+//     mel_scale="htk").
+type MelScale int
+
+// Mel scales; see MelScale.
+const (
+	MelSlaney MelScale = iota
+	MelHTK
+)
+
+// MelNorm selects the per-filter weight normalization.
+//
+//   - MelNormSlaney: divide each triangle by its bandwidth in Hz (2/(f[m+2]-f[m])),
+//     so every filter has roughly constant energy (librosa's default,
+//     norm="slaney").
+//   - MelNormNone: unit-peak triangles (librosa norm=None).
+type MelNorm int
+
+// Mel normalizations; see MelNorm.
+const (
+	MelNormSlaney MelNorm = iota
+	MelNormNone
+)
+
+// Slaney mel scale constants (librosa.hz_to_mel with htk=False): linear at
+// 200/3 Hz per mel up to 1 kHz (15 mel), then log-spaced at log(6.4)/27 per mel.
+const (
+	melSlaneyHzPerMel = 200.0 / 3
+	melSlaneyMinLogHz = 1000.0
+	melSlaneyMinLog   = melSlaneyMinLogHz / melSlaneyHzPerMel
+	melSlaneyLogStep  = 0.06875177742094912 // log(6.4) / 27
+)
+
+// HTK mel scale constants: mel = melHTKScale*log10(1 + f/melHTKBreakHz).
+const (
+	melHTKScale   = 2595.0
+	melHTKBreakHz = 700.0
+)
+
+// logMelDB is the 10 in 10*log10, the power-to-decibel factor.
+const logMelDB = 10
+
+// melSlaneyNorm is the numerator of the Slaney area normalization 2/(f[m+2]-f[m]).
+const melSlaneyNorm = 2.0
+
+// hzToMel converts a frequency in Hz to mels on the given scale.
+func hzToMel(hz float64, scale MelScale) float64 {
+	if scale == MelHTK {
+		return melHTKScale * math.Log10(1+hz/melHTKBreakHz)
+	}
+	if hz >= melSlaneyMinLogHz {
+		return melSlaneyMinLog + math.Log(hz/melSlaneyMinLogHz)/melSlaneyLogStep
+	}
+	return hz / melSlaneyHzPerMel
+}
+
+// melToHz is the inverse of hzToMel.
+func melToHz(mel float64, scale MelScale) float64 {
+	if scale == MelHTK {
+		return melHTKBreakHz * (math.Pow(10, mel/melHTKScale) - 1)
+	}
+	if mel >= melSlaneyMinLog {
+		return melSlaneyMinLogHz * math.Exp(melSlaneyLogStep*(mel-melSlaneyMinLog))
+	}
+	return melSlaneyHzPerMel * mel
+}
+
+// MelFilterbank is a resident mel projection matrix for one STFT configuration:
+// NumMels triangular filters over the NumBins bins of an nfft-point
+// half-spectrum. Build one with NewMelFilterbank and reuse it; its methods only
+// read the plan, so one filterbank may be shared by any number of goroutines.
+type MelFilterbank struct {
+	nMels, bins int
+
+	// weights is the dense nMels x bins matrix, row m at [m*bins : (m+1)*bins].
+	weights []float64
+
+	// lo[m], hi[m] bound the non-zero support of row m; lo == hi for an empty
+	// filter (more mels than the FFT resolution can place).
+	lo, hi []int
+}
+
+// NewMelFilterbank builds the librosa.filters.mel matrix for an nfft-point STFT
+// at sampleRate Hz: nMels triangular filters whose centers are evenly spaced on
+// the chosen mel scale between fmin and fmax. fmax <= 0 selects sampleRate/2
+// (librosa's fmax=None). The filters span bins 0..nfft/2 with bin k at
+// k*sampleRate/nfft Hz. It returns ErrMelConfig for an invalid configuration.
+//
+// The librosa defaults are MelSlaney with MelNormSlaney; a Whisper- or
+// torchaudio-style HTK bank is MelHTK with MelNormNone.
+func NewMelFilterbank(sampleRate float64, nfft, nMels int, fmin, fmax float64, scale MelScale, norm MelNorm) (*MelFilterbank, error) {
+	if fmax <= 0 {
+		fmax = sampleRate / 2
+	}
+	if !(sampleRate > 0) || nfft < 2 || nMels < 1 || fmin < 0 || !(fmin < fmax) {
+		return nil, ErrMelConfig
+	}
+	bins := nfft/2 + 1
+	fb := &MelFilterbank{
+		nMels:   nMels,
+		bins:    bins,
+		weights: make([]float64, nMels*bins),
+		lo:      make([]int, nMels),
+		hi:      make([]int, nMels),
+	}
+
+	// nMels+2 band edges evenly spaced in mel, converted back to Hz.
+	minMel, maxMel := hzToMel(fmin, scale), hzToMel(fmax, scale)
+	edges := make([]float64, nMels+2)
+	for i := range edges {
+		edges[i] = melToHz(minMel+(maxMel-minMel)*float64(i)/float64(nMels+1), scale)
+	}
+
+	for m := range nMels {
+		lower, center, upper := edges[m], edges[m+1], edges[m+2]
+		enorm := 1.0
+		if norm == MelNormSlaney {
+			enorm = melSlaneyNorm / (upper - lower)
+		}
+		row := fb.weights[m*bins : (m+1)*bins]
+		lo, hi := bins, 0
+		for k := range bins {
+			f := float64(k) * sampleRate / float64(nfft)
+			// Rising and falling slopes of the triangle; the weight is their
+			// minimum, clipped at zero (librosa's ramps formulation).
+			w := min((f-lower)/(center-lower), (upper-f)/(upper-center))
+			if w <= 0 {
+				continue
+			}
+			row[k] = w * enorm
+			lo, hi = min(lo, k), k+1
+		}
+		if lo < hi {
+			fb.lo[m], fb.hi[m] = lo, hi
+		}
+	}
+	return fb, nil
+}
+
+// NumMels returns the number of mel bands (rows).
+func (fb *MelFilterbank) NumMels() int { return fb.nMels }
+
+// NumBins returns the number of spectrum bins per frame (columns), nfft/2 + 1.
+func (fb *MelFilterbank) NumBins() int { return fb.bins }
+
+// Weights returns the dense row-major filter matrix: row m (mel band m) occupies
+// Weights()[m*NumBins() : (m+1)*NumBins()], the layout librosa.filters.mel
+// returns. It is the filterbank's own storage; do not modify it.
+func (fb *MelFilterbank) Weights() []float64 { return fb.weights }
+
+// Support returns the half-open bin range [lo, hi) outside which row m is zero;
+// lo == hi for an empty filter. It panics if m is out of range.
+func (fb *MelFilterbank) Support(m int) (lo, hi int) { return fb.lo[m], fb.hi[m] }
+
+// frames reports how many whole frames fit both the power input (stride
+// NumBins) and the output (stride NumMels).
+func (fb *MelFilterbank) frames(dst, power []float64) int {
+	return min(len(power)/fb.bins, len(dst)/fb.nMels)
+}
+
+// project writes the mel energies of one power frame into row.
+func (fb *MelFilterbank) project(row, frame []float64) {
+	for m := range row {
+		lo, hi := fb.lo[m], fb.hi[m]
+		off := m * fb.bins
+		row[m] = DotProduct(fb.weights[off+lo:off+hi], frame[lo:hi])
+	}
+}
+
+// Apply projects a frame-contiguous power spectrogram onto the mel bands (the
+// librosa.feature.melspectrogram matrix product, frame by frame). power holds
+// frames of NumBins bins, as STFTPlan.STFTPowerInto writes them; dst receives
+// frames of NumMels values, also frame-contiguous. It processes
+// min(len(power)/NumBins, len(dst)/NumMels) whole frames and returns that count.
+// dst must not overlap power. Allocation-free.
+func (fb *MelFilterbank) Apply(dst, power []float64) int {
+	frames := fb.frames(dst, power)
+	for f := range frames {
+		fb.project(dst[f*fb.nMels:(f+1)*fb.nMels], power[f*fb.bins:(f+1)*fb.bins])
+	}
+	return frames
+}
+
+// LogMel is Apply fused with librosa.power_to_db: each mel energy S becomes
+//
+//	10*log10(max(amin, S)) - 10*log10(max(amin, ref))
+//
+// and, when topDB > 0, is then floored at the maximum output minus topDB. ref <= 0
+// selects the largest mel energy in the call as the reference (librosa
+// ref=np.max); librosa's defaults are ref = 1, amin = 1e-10, topDB = 80. Frame
+// handling and the return value follow Apply. The reference and the topDB floor
+// are taken over the frames this call writes, so pass the whole spectrogram
+// for librosa parity. Allocation-free.
+func (fb *MelFilterbank) LogMel(dst, power []float64, ref, amin, topDB float64) int {
+	frames := fb.frames(dst, power)
+	if frames == 0 {
+		return 0
+	}
+	out := dst[:frames*fb.nMels]
+	fb.Apply(out, power)
+	if ref <= 0 {
+		ref = Max(out)
+	}
+	// max(amin, S) is Clamp with an infinite ceiling, so the log never sees zero.
+	inf := math.Inf(1)
+	Clamp(out, out, amin, inf)
+	Log10(out, out)
+	refDB := logMelDB * math.Log10(max(amin, ref))
+	for i, v := range out {
+		out[i] = logMelDB*v - refDB
+	}
+	if topDB > 0 {
+		Clamp(out, out, Max(out)-topDB, inf)
+	}
+	return frames
+}
+
+// PCEN is a streaming per-channel energy normalization stage (librosa.pcen) fused
+// with the mel projection of a MelFilterbank. Each mel energy S is smoothed over
+// time by a first-order IIR filter M = (1-b)*M + b*S, and then compressed as
+//
+//	(S / (eps + M)^gain + bias)^power - bias^power
+//
+// The smoother state carries over between Process calls, so a spectrogram fed in
+// chunks gives the same output as one call over the whole thing. A PCEN holds
+// that state and scratch, so its methods are NOT safe for concurrent use; use one
+// per stream.
+type PCEN struct {
+	fb *MelFilterbank
+
+	b                      float64 // smoother coefficient
+	gain, bias, power, eps float64
+	biasPow                float64 // bias^power
+	state                  []float64
+	mel                    []float64
+}
+
+// NewPCEN builds a PCEN stage over fb for spectrogram frames hop samples apart at
+// sampleRate Hz. gain, bias, power, timeConstant (seconds) and eps have the
+// librosa.pcen meanings; its defaults are gain 0.98, bias 2, power 0.5,
+// timeConstant 0.4 and eps 1e-6. The smoother coefficient is derived from the
+// time constant exactly as librosa does:
+//
+//	t = timeConstant*sampleRate/hop,  b = (sqrt(1 + 4*t^2) - 1) / (2*t^2)
+//
+// It returns ErrPCENConfig for an invalid configuration.
+func NewPCEN(fb *MelFilterbank, sampleRate float64, hop int, gain, bias, power, timeConstant, eps float64) (*PCEN, error) {
+	if fb == nil || !(sampleRate > 0) || hop <= 0 || !(timeConstant > 0) || !(eps > 0) ||
+		!(gain >= 0) || !(bias >= 0) || !(power >= 0) {
+		return nil, ErrPCENConfig
+	}
+	t := timeConstant * sampleRate / float64(hop)
+	p := &PCEN{
+		fb:      fb,
+		b:       (math.Sqrt(1+4*t*t) - 1) / (2 * t * t),
+		gain:    gain,
+		bias:    bias,
+		power:   power,
+		eps:     eps,
+		biasPow: math.Pow(bias, power),
+		state:   make([]float64, fb.nMels),
+		mel:     make([]float64, fb.nMels),
+	}
+	p.Reset()
+	return p, nil
+}
+
+// Reset restores the initial smoother state, so the next Process starts a new
+// stream. Like librosa, which runs the smoother from scipy's lfilter_zi (the
+// steady state for a unit input), every channel starts at M = 1, so the first
+// frame smooths to (1-b) + b*S.
+func (p *PCEN) Reset() {
+	for m := range p.state {
+		p.state[m] = 1
+	}
+}
+
+// Process projects a frame-contiguous power spectrogram onto the mel bands and
+// writes the PCEN output, frame-contiguous with stride NumMels. Frame handling
+// and the return value follow MelFilterbank.Apply. dst must not overlap power.
+// Allocation-free.
+func (p *PCEN) Process(dst, power []float64) int {
+	fb := p.fb
+	frames := fb.frames(dst, power)
+	for f := range frames {
+		fb.project(p.mel, power[f*fb.bins:(f+1)*fb.bins])
+		for m, s := range p.mel {
+			p.state[m] = (1-p.b)*p.state[m] + p.b*s
+		}
+		row := dst[f*fb.nMels : (f+1)*fb.nMels]
+		for m, s := range p.mel {
+			row[m] = p.compress(s, p.state[m])
+		}
+	}
+	return frames
+}
+
+// compress applies the gain normalization and root compression to one energy s
+// with smoothed energy ms, in librosa.pcen's numerically careful forms.
+func (p *PCEN) compress(s, ms float64) float64 {
+	// smooth = (eps + M)^-gain, via log1p for small M/eps.
+	smooth := math.Exp(-p.gain * (math.Log(p.eps) + math.Log1p(ms/p.eps)))
+	switch {
+	case p.power == 0:
+		return math.Log1p(s * smooth)
+	case p.bias == 0:
+		return math.Exp(p.power * (math.Log(s) + math.Log(smooth)))
+	default:
+		return p.biasPow * math.Expm1(p.power*math.Log1p(s*smooth/p.bias))
+	}
+}
@@ -0,0 +1,508 @@
+package f64
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// melRef is an independent float64 port of librosa.filters.mel: it builds the
+// band edges with librosa's mel_frequencies and evaluates the ramps form
+// max(0, min(lower, upper)) on the rfftfreq grid, then applies the Slaney area
+// normalization.
+func melRef(sr float64, nfft, nMels int, fmin, fmax float64, htk, slaney bool) [][]float64 {
+	toMel := func(f float64) float64 {
+		if htk {
+			return 2595 * math.Log10(1+f/700)
+		}
+		if f >= 1000 {
+			return 15 + math.Log(f/1000)/(math.Log(6.4)/27)
+		}
+		return 3 * f / 200
+	}
+	toHz := func(m float64) float64 {
+		if htk {
+			return 700 * (math.Pow(10, m/2595) - 1)
+		}
+		if m >= 15 {
+			return 1000 * math.Exp(math.Log(6.4)/27*(m-15))
+		}
+		return 200 * m / 3
+	}
+	melF := make([]float64, nMels+2)
+	lo, hi := toMel(fmin), toMel(fmax)
+	for i := range melF {
+		melF[i] = toHz(lo + (hi-lo)*float64(i)/float64(nMels+1))
+	}
+	bins := nfft/2 + 1
+	out := make([][]float64, nMels)
+	for m := range out {
+		out[m] = make([]float64, bins)
+		for k := range bins {
+			f := float64(k) * sr / float64(nfft)
+			lower := (f - melF[m]) / (melF[m+1] - melF[m])
+			upper := (melF[m+2] - f) / (melF[m+2] - melF[m+1])
+			w := math.Max(0, math.Min(lower, upper))
+			if slaney {
+				w *= 2 / (melF[m+2] - melF[m])
+			}
+			out[m][k] = w
+		}
+	}
+	return out
+}
+
+func TestMelScaleAnchors(t *testing.T) {
+	cases := []struct {
+		hz, mel float64
+		scale   MelScale
+	}{
+		{0, 0, MelSlaney},
+		{500, 7.5, MelSlaney},
+		{1000, 15, MelSlaney},
+		{6400, 42, MelSlaney},
+		{0, 0, MelHTK},
+		{700, 2595 * math.Log10(2), MelHTK},
+	}
+	for _, c := range cases {
+		if got := hzToMel(c.hz, c.scale); math.Abs(got-c.mel) > 1e-9*(1+c.mel) {
+			t.Errorf("hzToMel(%g, %d) = %g, want %g", c.hz, c.scale, got, c.mel)
+		}
+		if got := melToHz(c.mel, c.scale); math.Abs(got-c.hz) > 1e-9*(1+c.hz) {
+			t.Errorf("melToHz(%g, %d) = %g, want %g", c.mel, c.scale, got, c.hz)
+		}
+	}
+}
+
+func TestNewMelFilterbankErrors(t *testing.T) {
+	bad := []struct {
+		sr         float64
+		nfft, mels int
+		fmin, fmax float64
+	}{
+		{0, 512, 40, 0, 0},
+		{-1, 512, 40, 0, 0},
+		{16000, 1, 40, 0, 0},
+		{16000, 512, 0, 0, 0},
+		{16000, 512, 40, -1, 0},
+		{16000, 512, 40, 9000, 0},
+		{16000, 512, 40, 4000, 4000},
+		{math.NaN(), 512, 40, 0, 0},
+	}
+	for _, c := range bad {
+		if _, err := NewMelFilterbank(c.sr, c.nfft, c.mels, c.fmin, c.fmax, MelSlaney, MelNormSlaney); !errors.Is(err, ErrMelConfig) {
+			t.Errorf("NewMelFilterbank(%v) error = %v, want ErrMelConfig", c, err)
+		}
+	}
+	fb, err := NewMelFilterbank(16000, 400, 80, 0, 0, MelSlaney, MelNormSlaney)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fb.NumMels() != 80 || fb.NumBins() != 201 || len(fb.Weights()) != 80*201 {
+		t.Errorf("shape = %d x %d (len %d), want 80 x 201", fb.NumMels(), fb.NumBins(), len(fb.Weights()))
+	}
+}
+
+// TestMelFilterbankAgainstRef checks every weight against the float64 librosa
+// port across both scales, both normalizations, and fmin/fmax choices, and that
+// Support brackets exactly the non-zero weights.
+func TestMelFilterbankAgainstRef(t *testing.T) {
+	cases := []struct {
+		sr         float64
+		nfft, mels int
+		fmin, fmax float64
+	}{
+		{16000, 400, 80, 0, 8000},
+		{22050, 2048, 128, 0, 0},
+		{16000, 512, 40, 20, 7600},
+		{44100, 1024, 64, 300, 12000},
+		{8000, 64, 40, 0, 0}, // more mels than bins: some filters are empty
+	}
+	for _, c := range cases {
+		for _, scale := range []MelScale{MelSlaney, MelHTK} {
+			for _, norm := range []MelNorm{MelNormSlaney, MelNormNone} {
+				fb, err := NewMelFilterbank(c.sr, c.nfft, c.mels, c.fmin, c.fmax, scale, norm)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fmax := c.fmax
+				if fmax <= 0 {
+					fmax = c.sr / 2
+				}
+				ref := melRef(c.sr, c.nfft, c.mels, c.fmin, fmax, scale == MelHTK, norm == MelNormSlaney)
+				w := fb.Weights()
+				for m := range c.mels {
+					lo, hi := fb.Support(m)
+					for k, want := range ref[m] {
+						got := w[m*fb.NumBins()+k]
+						ctx := fmt.Sprintf("sr=%g nfft=%d scale=%d norm=%d m=%d k=%d", c.sr, c.nfft, scale, norm, m, k)
+						if d := math.Abs(got - want); d > 1e-12*math.Abs(want)+1e-18 {
+							t.Fatalf("%s: weight %g, want %g", ctx, got, want)
+						}
+						if inside := k >= lo && k < hi; (got != 0) && !inside {
+							t.Fatalf("%s: non-zero weight outside support [%d,%d)", ctx, lo, hi)
+						}
+					}
+					if lo < hi && (w[m*fb.NumBins()+lo] == 0 || w[m*fb.NumBins()+hi-1] == 0) {
+						t.Fatalf("m=%d: support [%d,%d) not tight", m, lo, hi)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestMelFilterbankSlaneyArea checks the defining property of the Slaney
+// normalization: every filter wide enough to be well sampled integrates to 1
+// over frequency.
+func TestMelFilterbankSlaneyArea(t *testing.T) {
+	const sr, nfft = 16000.0, 4096
+	fb, _ := NewMelFilterbank(sr, nfft, 40, 0, 0, MelSlaney, MelNormSlaney)
+	df := sr / nfft
+	for m := range fb.NumMels() {
+		lo, hi := fb.Support(m)
+		if hi-lo < 16 {
+			continue
+		}
+		var area float64
+		for _, v := range fb.Weights()[m*fb.NumBins()+lo : m*fb.NumBins()+hi] {
+			area += v * df
+		}
+		if math.Abs(area-1) > 0.02 {
+			t.Errorf("m=%d: area %g, want 1", m, area)
+		}
+	}
+}
+
+// melPower runs the STFT front-end the mel stages consume: a centered Hann
+// STFT power spectrogram, flat and frame-contiguous.
+func melPower(t *testing.T, nfft, hop, n int) (power []float64, frames int) {
+	t.Helper()
+	p, err := NewSTFTPlan(nfft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := testSignal(n)
+	frames = p.NumFrames(len(sig), hop, PadZero)
+	power = make([]float64, frames*p.NumBins())
+	p.STFTPowerInto(power, sig, hann(nfft), hop, PadZero)
+	return power, frames
+}
+
+// TestMelFilterbankApply checks the support-restricted projection against a
+// dense float64 matrix product over every weight, on a real nfft=400 power
+// spectrogram.
+func TestMelFilterbankApply(t *testing.T) {
+	fb, _ := NewMelFilterbank(16000, 400, 80, 0, 8000, MelSlaney, MelNormSlaney)
+	power, frames := melPower(t, 400, 160, 4000)
+	dst := make([]float64, frames*fb.NumMels())
+	if got := fb.Apply(dst, power); got != frames {
+		t.Fatalf("Apply wrote %d frames, want %d", got, frames)
+	}
+	bins, w := fb.NumBins(), fb.Weights()
+	for f := range frames {
+		for m := range fb.NumMels() {
+			var want, scale float64
+			for k := range bins {
+				p := w[m*bins+k] * power[f*bins+k]
+				want += p
+				scale += math.Abs(p)
+			}
+			if d := math.Abs(dst[f*fb.NumMels()+m] - want); d > 1e-12*scale+1e-300 {
+				t.Fatalf("f=%d m=%d: mel %g, want %g", f, m, dst[f*fb.NumMels()+m], want)
+			}
+		}
+	}
+
+	// A short dst floors to the frames that fit.
+	if got := fb.Apply(dst[:2*fb.NumMels()+1], power); got != 2 {
+		t.Errorf("short dst: Apply wrote %d frames, want 2", got)
+	}
+	if got := fb.Apply(dst, power[:bins-1]); got != 0 {
+		t.Errorf("short power: Apply wrote %d frames, want 0", got)
+	}
+}
+
+// TestMelFilterbankLogMel checks LogMel against librosa.power_to_db applied
+// in float64 to the Apply output, for a fixed ref, ref=np.max, and with and
+// without the topDB floor.
+func TestMelFilterbankLogMel(t *testing.T) {
+	fb, _ := NewMelFilterbank(16000, 512, 64, 0, 0, MelHTK, MelNormNone)
+	power, frames := melPower(t, 512, 128, 6000)
+	mel := make([]float64, frames*fb.NumMels())
+	fb.Apply(mel, power)
+	cases := []struct{ ref, amin, topDB float64 }{
+		{1, 1e-10, 80},
+		{1, 1e-10, 0},
+		{0, 1e-10, 80},
+		{0, 1e-5, 30},
+	}
+	for _, c := range cases {
+		ref := c.ref
+		if c.ref <= 0 {
+			ref = Max(mel)
+		}
+		want := make([]float64, len(mel))
+		peak := math.Inf(-1)
+		for i, v := range mel {
+			want[i] = 10*math.Log10(math.Max(c.amin, v)) - 10*math.Log10(math.Max(c.amin, ref))
+			peak = math.Max(peak, want[i])
+		}
+		if c.topDB > 0 {
+			for i := range want {
+				want[i] = math.Max(want[i], peak-c.topDB)
+			}
+		}
+		got := make([]float64, len(mel))
+		if n := fb.LogMel(got, power, c.ref, c.amin, c.topDB); n != frames {
+			t.Fatalf("%+v: LogMel wrote %d frames, want %d", c, n, frames)
+		}
+		for i := range got {
+			if d := math.Abs(got[i] - want[i]); d > 1e-9 {
+				t.Fatalf("%+v: i=%d got %g dB want %g dB", c, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+// pcenRef is librosa.pcen in float64 on a frame-contiguous mel spectrogram:
+// the smoother starts from lfilter_zi (M = 1), then the three compression
+// branches exactly as librosa writes them.
+func pcenRef(mel []float64, nMels int, sr float64, hop int, gain, bias, power, tc, eps float64) []float64 {
+	tf := tc * sr / float64(hop)
+	b := (math.Sqrt(1+4*tf*tf) - 1) / (2 * tf * tf)
+	out := make([]float64, len(mel))
+	m := make([]float64, nMels)
+	for c := range m {
+		m[c] = 1 // lfilter_zi([b], [1, b-1]): the unit-input steady state
+	}
+	for f := range len(mel) / nMels {
+		for c := range nMels {
+			s := mel[f*nMels+c]
+			m[c] = (1-b)*m[c] + b*s
+			smooth := math.Exp(-gain * (math.Log(eps) + math.Log1p(m[c]/eps)))
+			var v float64
+			switch {
+			case power == 0:
+				v = math.Log1p(s * smooth)
+			case bias == 0:
+				v = math.Exp(power * (math.Log(s) + math.Log(smooth)))
+			default:
+				v = math.Pow(bias, power) * math.Expm1(power*math.Log1p(s*smooth/bias))
+			}
+			out[f*nMels+c] = v
+		}
+	}
+	return out
+}
+
+// TestPCEN checks PCEN against the float64 librosa port across the three
+// compression branches, that a chunked stream equals one whole call, and that
+// Reset restarts the smoother.
+func TestPCEN(t *testing.T) {
+	const sr, hop = 16000.0, 160
+	fb, _ := NewMelFilterbank(sr, 400, 40, 0, 0, MelSlaney, MelNormSlaney)
+	power, frames := melPower(t, 400, hop, 8000)
+	mel := make([]float64, frames*fb.NumMels())
+	fb.Apply(mel, power)
+	params := []struct{ gain, bias, power, tc, eps float64 }{
+		{0.98, 2, 0.5, 0.4, 1e-6}, // librosa defaults
+		{0.8, 10, 0.25, 0.06, 1e-6},
+		{0.98, 2, 0, 0.4, 1e-6},
+		{0.5, 0, 0.5, 0.2, 1e-6},
+	}
+	for _, c := range params {
+		p, err := NewPCEN(fb, sr, hop, c.gain, c.bias, c.power, c.tc, c.eps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := pcenRef(mel, fb.NumMels(), sr, hop, c.gain, c.bias, c.power, c.tc, c.eps)
+		got := make([]float64, len(mel))
+		if n := p.Process(got, power); n != frames {
+			t.Fatalf("%+v: Process wrote %d frames, want %d", c, n, frames)
+		}
+		for i := range got {
+			if d := math.Abs(got[i] - want[i]); d > 1e-10*(1+math.Abs(want[i])) {
+				t.Fatalf("%+v: i=%d got %g want %g", c, i, got[i], want[i])
+			}
+		}
+
+		// Reset, then stream in uneven chunks: identical to the whole call.
+		p.Reset()
+		chunked := make([]float64, len(mel))
+		bins, nm := fb.NumBins(), fb.NumMels()
+		for f := 0; f < frames; {
+			step := min(1+f%3, frames-f)
+			p.Process(chunked[f*nm:(f+step)*nm], power[f*bins:(f+step)*bins])
+			f += step
+		}
+		for i := range got {
+			if chunked[i] != got[i] {
+				t.Fatalf("%+v: chunked[%d] = %g, whole call %g", c, i, chunked[i], got[i])
+			}
+		}
+	}
+}
+
+//go:embed testdata/mel_reference_golden.json
+var melGoldenJSON []byte
+
+// melGolden is testdata/mel_reference_golden.json (see
+// testdata/gen_mel_golden.py): mel spectrograms for a few filterbank
+// configurations and PCEN outputs over them, frame-contiguous. Each entry
+// names its generator; the committed ones are the script's stdlib
+// transcriptions of librosa.feature.melspectrogram and librosa.pcen, not
+// librosa output.
+type melGolden struct {
+	N   int `json:"n"`
+	Mel []struct {
+		SR        float64   `json:"sr"`
+		NFFT      int       `json:"nfft"`
+		Hop       int       `json:"hop"`
+		NMels     int       `json:"n_mels"`
+		FMin      float64   `json:"fmin"`
+		FMax      float64   `json:"fmax"`
+		HTK       bool      `json:"htk"`
+		Norm      string    `json:"norm"`
+		Generator string    `json:"generator"`
+		Frames    int       `json:"frames"`
+		Values    []float64 `json:"mel"`
+	} `json:"mel"`
+	PCEN []struct {
+		MelCase      int       `json:"mel_case"`
+		Gain         float64   `json:"gain"`
+		Bias         float64   `json:"bias"`
+		Power        float64   `json:"power"`
+		TimeConstant float64   `json:"time_constant"`
+		Eps          float64   `json:"eps"`
+		Generator    string    `json:"generator"`
+		Values       []float64 `json:"pcen"`
+	} `json:"pcen"`
+}
+
+// maxRelErr returns the largest |got-want| / (|want| + floor*peak) over the
+// pair, where peak is the largest |want|.
+func maxRelErr(got []float64, want []float64, floor float64) float64 {
+	var peak, worst float64
+	for _, v := range want {
+		peak = max(peak, math.Abs(v))
+	}
+	for i := range want {
+		worst = max(worst, math.Abs(got[i]-want[i])/(math.Abs(want[i])+floor*peak))
+	}
+	return worst
+}
+
+// TestMelReferenceParity pins Apply and PCEN.Process against the melGolden
+// vectors, end to end from the centered Hann STFT. Those come from a second,
+// independently written transcription (Python, exact fsum DFT), so this
+// cross-checks the Go code and the float64 ports above against it; it is not
+// a librosa parity check until the golden is regenerated under librosa. 1e-6
+// relative separates float64 rounding from a convention error.
+func TestMelReferenceParity(t *testing.T) {
+	var g melGolden
+	if err := json.Unmarshal(melGoldenJSON, &g); err != nil {
+		t.Fatalf("unmarshal golden: %v", err)
+	}
+	sig := testSignal(g.N)
+	banks := make([]*MelFilterbank, len(g.Mel))
+	powers := make([][]float64, len(g.Mel))
+	for i, c := range g.Mel {
+		t.Logf("mel case %d golden generated by %s", i, c.Generator)
+		scale, norm := MelSlaney, MelNormNone
+		if c.HTK {
+			scale = MelHTK
+		}
+		if c.Norm == "slaney" {
+			norm = MelNormSlaney
+		}
+		fb, err := NewMelFilterbank(c.SR, c.NFFT, c.NMels, c.FMin, c.FMax, scale, norm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, _ := NewSTFTPlan(c.NFFT)
+		if nf := p.NumFrames(len(sig), c.Hop, PadZero); nf != c.Frames {
+			t.Fatalf("case %d: NumFrames=%d but the golden has %d frames", i, nf, c.Frames)
+		}
+		power := make([]float64, c.Frames*p.NumBins())
+		p.STFTPowerInto(power, sig, hann(c.NFFT), c.Hop, PadZero)
+		got := make([]float64, c.Frames*c.NMels)
+		fb.Apply(got, power)
+		if rel := maxRelErr(got, c.Values, 1e-12); rel > 1e-6 {
+			t.Errorf("mel case %d: max relative error %g exceeds 1e-6 vs the golden (%s)", i, rel, c.Generator)
+		}
+		banks[i], powers[i] = fb, power
+	}
+	for i, c := range g.PCEN {
+		m := g.Mel[c.MelCase]
+		pc, err := NewPCEN(banks[c.MelCase], m.SR, m.Hop, c.Gain, c.Bias, c.Power, c.TimeConstant, c.Eps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]float64, len(c.Values))
+		pc.Process(got, powers[c.MelCase])
+		// PCEN outputs are O(1) after compression; bound them as TestPCEN does.
+		for j, want := range c.Values {
+			if d := math.Abs(got[j] - want); d > 1e-6*(1+math.Abs(want)) {
+				t.Fatalf("pcen case %d: i=%d got %g want %g (%s)", i, j, got[j], want, c.Generator)
+			}
+		}
+	}
+}
+
+func TestNewPCENErrors(t *testing.T) {
+	fb, _ := NewMelFilterbank(16000, 400, 40, 0, 0, MelSlaney, MelNormSlaney)
+	bad := []struct {
+		fb                         *MelFilterbank
+		sr                         float64
+		hop                        int
+		gain, bias, power, tc, eps float64
+	}{
+		{nil, 16000, 160, 0.98, 2, 0.5, 0.4, 1e-6},
+		{fb, 0, 160, 0.98, 2, 0.5, 0.4, 1e-6},
+		{fb, 16000, 0, 0.98, 2, 0.5, 0.4, 1e-6},
+		{fb, 16000, 160, -1, 2, 0.5, 0.4, 1e-6},
+		{fb, 16000, 160, 0.98, -2, 0.5, 0.4, 1e-6},
+		{fb, 16000, 160, 0.98, 2, -0.5, 0.4, 1e-6},
+		{fb, 16000, 160, 0.98, 2, 0.5, 0, 1e-6},
+		{fb, 16000, 160, 0.98, 2, 0.5, 0.4, 0},
+	}
+	for i, c := range bad {
+		if _, err := NewPCEN(c.fb, c.sr, c.hop, c.gain, c.bias, c.power, c.tc, c.eps); !errors.Is(err, ErrPCENConfig) {
+			t.Errorf("case %d: error = %v, want ErrPCENConfig", i, err)
+		}
+	}
+}
+
+func TestMelAllocFree(t *testing.T) {
+	fb, _ := NewMelFilterbank(16000, 400, 80, 0, 0, MelSlaney, MelNormSlaney)
+	power, frames := melPower(t, 400, 160, 4000)
+	dst := make([]float64, frames*fb.NumMels())
+	pc, _ := NewPCEN(fb, 16000, 160, 0.98, 2, 0.5, 0.4, 1e-6)
+	if a := testing.AllocsPerRun(5, func() { fb.Apply(dst, power) }); a != 0 {
+		t.Errorf("Apply allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { fb.LogMel(dst, power, 1, 1e-10, 80) }); a != 0 {
+		t.Errorf("LogMel allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { pc.Process(dst, power) }); a != 0 {
+		t.Errorf("PCEN.Process allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkLogMel(b *testing.B) {
+	fb, _ := NewMelFilterbank(16000, 400, 80, 0, 0, MelSlaney, MelNormSlaney)
+	p, _ := NewSTFTPlan(400)
+	sig := testSignal(16000)
+	frames := p.NumFrames(len(sig), 160, PadZero)
+	power := make([]float64, frames*p.NumBins())
+	p.STFTPowerInto(power, sig, hann(400), 160, PadZero)
+	dst := make([]float64, frames*fb.NumMels())
+	b.ReportAllocs()
+	for b.Loop() {
+		fb.LogMel(dst, power, 1, 1e-10, 80)
+	}
+}
@@ -0,0 +1,244 @@
+package f64
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// resampleProto designs the resample_poly prototype up*firwin(...) directly
+// in float64.
+func resampleProto(up, down, zc int, beta float64) []float64 {
+	maxRate := max(up, down)
+	n := 2*zc*maxRate + 1
+	cutoff := 1 / float64(maxRate)
+	c := float64(n-1) / 2
+	h := make([]float64, n)
+	var sum float64
+	for j := range h {
+		m := float64(j) - c
+		v := cutoff
+		if m != 0 {
+			v = math.Sin(math.Pi*cutoff*m) / (math.Pi * m)
+		}
+		r := m / c
+		h[j] = v * besselI0(beta*math.Sqrt(1-r*r)) / besselI0(beta)
+		sum += h[j]
+	}
+	for j := range h {
+		h[j] *= float64(up) / sum
+	}
+	return h
+}
+
+// upfirdnRef is scipy.signal.upfirdn(h, x, up, down) on one channel of an
+// interleaved signal, truncated to nOut outputs, evaluated over the nonzero
+// taps of each output.
+func upfirdnRef(h []float64, x []float64, ch, c, up, down, nOut int) []float64 {
+	frames := len(x) / ch
+	y := make([]float64, nOut)
+	for k := range y {
+		n := k * down
+		for j := n % up; j < len(h) && j <= n; j += up {
+			if i := (n - j) / up; i < frames {
+				y[k] += h[j] * x[i*ch+c]
+			}
+		}
+	}
+	return y
+}
+
+func TestNewResamplerErrors(t *testing.T) {
+	for _, c := range []struct {
+		up, down, ch, zc int
+		beta             float64
+	}{
+		{0, 1, 1, 8, 5}, {1, 0, 1, 8, 5}, {1, 1, 0, 8, 5}, {1, 1, 1, 0, 5},
+		{1, 1, 1, 8, -1}, {1, 1, 1, 8, math.NaN()}, {1, 1, 1, 8, math.Inf(1)},
+	} {
+		if _, err := NewResampler(c.up, c.down, c.ch, c.zc, c.beta); !errors.Is(err, ErrResamplerConfig) {
+			t.Errorf("NewResampler(%+v) error = %v, want ErrResamplerConfig", c, err)
+		}
+	}
+	r, err := NewResampler(48000, 44100, 2, 16, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up, down := r.Ratio(); up != 160 || down != 147 || r.Channels() != 2 {
+		t.Fatalf("Ratio = %d/%d, Channels = %d", up, down, r.Channels())
+	}
+	if got, want := r.TapsPerPhase(), (2*16*160+1+159)/160; got != want {
+		t.Fatalf("TapsPerPhase = %d, want %d", got, want)
+	}
+}
+
+// TestResamplerAgainstUpfirdn streams interleaved signals through exact-table
+// and interpolated-table resamplers in uneven blocks and checks every channel
+// against upfirdn with the float64 prototype.
+func TestResamplerAgainstUpfirdn(t *testing.T) {
+	for _, c := range []struct {
+		up, down, ch, zc int
+		beta             float64
+		interp           bool
+	}{
+		{160, 147, 2, 8, 6, false}, // 44.1 kHz -> 48 kHz
+		{147, 160, 1, 8, 6, false}, // 48 kHz -> 44.1 kHz
+		{3, 1, 3, 10, 5, false},
+		{1, 4, 2, 10, 5, false},
+		{1, 1, 1, 4, 5, false},
+		{4801, 4800, 2, 8, 6, true},
+		{999, 4801, 1, 8, 6, true},
+	} {
+		r, err := NewResampler(c.up, c.down, c.ch, c.zc, c.beta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.phases == nil; got != c.interp {
+			t.Fatalf("%d/%d: interpolated = %v, want %v", c.up, c.down, got, c.interp)
+		}
+		const frames = 3000
+		x := testSignal(frames * c.ch)
+		y := make([]float64, (r.MaxOutput(frames)+1)*c.ch)
+		in, out := 0, 0
+		for step := 1; in < frames; step = step*2 + 1 {
+			end := min(frames, in+step)
+			i, o := r.Process(y[out*c.ch:], x[in*c.ch:end*c.ch])
+			if i != end-in {
+				t.Fatalf("%d/%d: consumed %d of %d frames", c.up, c.down, i, end-in)
+			}
+			in, out = end, out+o
+		}
+		if want := (frames*c.up + c.down - 1) / c.down; out != want {
+			t.Fatalf("%d/%d: produced %d frames, want %d", c.up, c.down, out, want)
+		}
+		h := resampleProto(c.up, c.down, c.zc, c.beta)
+		tol := 1e-12
+		if c.interp {
+			tol = 1e-8 // cubic phase interpolation
+		}
+		for ch := range c.ch {
+			want := upfirdnRef(h, x, c.ch, ch, c.up, c.down, out)
+			for k := range out {
+				if d := math.Abs(y[k*c.ch+ch] - want[k]); d > tol {
+					t.Fatalf("%d/%d ch %d: y[%d] = %g want %g", c.up, c.down, ch, k, y[k*c.ch+ch], want[k])
+				}
+			}
+		}
+	}
+}
+
+// TestResamplerBackpressure checks that a short dst stops consumption at the
+// next output's window and that resuming gives the same stream, and that Reset
+// starts over.
+func TestResamplerBackpressure(t *testing.T) {
+	const frames = 2500
+	x := testSignal(frames * 2)
+	whole, _ := NewResampler(160, 147, 2, 8, 6)
+	want := make([]float64, 2*whole.MaxOutput(frames))
+	_, nWant := whole.Process(want, x)
+
+	r, _ := NewResampler(160, 147, 2, 8, 6)
+	got := make([]float64, len(want))
+	in, out := 0, 0
+	for in < frames {
+		room := min(len(got), (out+7)*2)
+		i, o := r.Process(got[out*2:room], x[in*2:])
+		if i == 0 && o == 0 {
+			t.Fatalf("no progress at in=%d out=%d", in, out)
+		}
+		in, out = in+i, out+o
+	}
+	if out != nWant {
+		t.Fatalf("produced %d frames, want %d", out, nWant)
+	}
+	for i := range 2 * out {
+		if got[i] != want[i] {
+			t.Fatalf("lane %d = %g, want %g", i, got[i], want[i])
+		}
+	}
+
+	r.Reset()
+	again := make([]float64, len(want))
+	r.Process(again, x)
+	for i := range again {
+		if again[i] != want[i] {
+			t.Fatalf("after Reset lane %d = %g, want %g", i, again[i], want[i])
+		}
+	}
+}
+
+// TestResamplerDCGain checks unit passband gain: a constant input settles to
+// the same constant once the filter is full.
+func TestResamplerDCGain(t *testing.T) {
+	for _, ratio := range [][2]int{{160, 147}, {147, 160}, {3, 1}, {1, 3}, {4801, 4800}} {
+		r, _ := NewResampler(ratio[0], ratio[1], 1, 16, KaiserBeta(100))
+		x := make([]float64, 4000)
+		for i := range x {
+			x[i] = 0.5
+		}
+		y := make([]float64, r.MaxOutput(len(x)))
+		_, n := r.Process(y, x)
+		for k := n / 2; k < n; k++ {
+			if math.Abs(y[k]-0.5) > 1e-4 {
+				t.Fatalf("%d/%d: y[%d] = %g, want 0.5", ratio[0], ratio[1], k, y[k])
+			}
+		}
+	}
+}
+
+func TestKaiserSinc(t *testing.T) {
+	h := make([]float64, 63)
+	KaiserSinc(h, 0.25, 6)
+	var sum float64
+	for i, v := range h {
+		sum += v
+		if v != h[len(h)-1-i] {
+			t.Fatalf("not symmetric at %d", i)
+		}
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Fatalf("DC gain %g, want 1", sum)
+	}
+	if h[31] < h[30] || h[30] < h[29] {
+		t.Fatalf("center is not the peak: %v", h[28:34])
+	}
+	one := []float64{7}
+	KaiserSinc(one, 0.5, 5)
+	if one[0] != 1 {
+		t.Fatalf("1-tap design = %g, want 1", one[0])
+	}
+	KaiserSinc(nil, 0.5, 5)
+
+	for _, c := range [][2]float64{{65, 0.1102 * (65 - 8.7)}, {40, 0.5842*math.Pow(19, 0.4) + 0.07886*19}, {20, 0}} {
+		if got := KaiserBeta(c[0]); math.Abs(got-c[1]) > 1e-12 {
+			t.Errorf("KaiserBeta(%g) = %g, want %g", c[0], got, c[1])
+		}
+	}
+}
+
+func TestResamplerAllocFree(t *testing.T) {
+	for _, ratio := range [][2]int{{160, 147}, {4801, 4800}} {
+		r, _ := NewResampler(ratio[0], ratio[1], 2, 8, 6)
+		x := testSignal(2 * 2048)
+		y := make([]float64, 2*r.MaxOutput(2048))
+		if a := testing.AllocsPerRun(5, func() { r.Process(y, x) }); a != 0 {
+			t.Errorf("%d/%d: Process allocated %v times per run, want 0", ratio[0], ratio[1], a)
+		}
+	}
+}
+
+func BenchmarkResampler(b *testing.B) {
+	for _, c := range []struct{ up, down, ch int }{{160, 147, 1}, {160, 147, 2}, {3, 1, 1}, {4801, 4800, 1}} {
+		b.Run(fmt.Sprintf("%d/%d/ch=%d", c.up, c.down, c.ch), func(b *testing.B) {
+			r, _ := NewResampler(c.up, c.down, c.ch, 16, 8)
+			x := testSignal(1024 * c.ch)
+			y := make([]float64, c.ch*r.MaxOutput(1024))
+			b.SetBytes(int64(8 * len(x)))
+			b.ReportAllocs()
+			for b.Loop() {
+				r.Process(y, x)
+			}
+		})
+	}
+}
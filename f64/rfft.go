@@ -0,0 +1,181 @@
+package f64
+
+import (
+	"errors"
+	"math"
+)
+
+// This file turns the real-FFT building blocks into a complete transform: an
+// n-point real-input FFT (numpy.fft.rfft) and its inverse (numpy.fft.irfft) over
+// a resident plan, so single-frame spectra, cepstra and FFT convolution need not
+// assemble the packing, the half-length complex FFT and the twiddle tables
+// themselves, nor misuse a one-frame STFTPlan.
+//
+// Forward packs the real input as n/2 complex samples z[j] = x[2j] + i*x[2j+1],
+// runs the half-length FFTPlan, and recombines the even and odd half-spectra with
+// RealFFTUnpack (DC and Nyquist are formed directly). Inverse undoes the
+// recombination, runs the same forward FFT on the conjugate, and unpacks the
+// interleaved result.
+
+// ErrRealFFTSize is returned by NewRealFFTPlan when n is not even or is less
+// than 2.
+var ErrRealFFTSize = errors.New("f64: real FFT size must be even and >= 2")
+
+// realFFTUnscaled is the factor that turns the normalized inverse of the packed
+// half-length transform (scaled by 1/half) into the unnormalized real inverse
+// (scaled by 1): n/half.
+const realFFTUnscaled = 2
+
+// RealFFTPlan is a reusable n-point real-input FFT: Forward maps n real samples
+// to the n/2+1 bins of the Hermitian half-spectrum, and Inverse maps them back.
+// Build one with NewRealFFTPlan and reuse it across calls to stay
+// allocation-free. Any even n is accepted; powers of two are fastest (see
+// FFTPlan for the schedules).
+//
+// A plan holds per-transform scratch, so its methods are NOT safe for concurrent
+// use on the same plan; use one plan per goroutine. Distinct plans share no
+// mutable state.
+type RealFFTPlan struct {
+	n    int // transform size (even)
+	half int // n / 2: size of the packed complex FFT
+
+	fft *FFTPlan // size-half complex FFT core
+
+	// Unpack twiddles W_n^k = exp(-i*2*pi*k/n) for k in [1, half) at index k-1,
+	// the layout RealFFTUnpack reads.
+	twRe, twIm []float64
+
+	// Per-transform scratch: the packed complex vector, FFT'd in place, and the
+	// split half-spectrum RealFFTUnpack writes.
+	re, im       []float64
+	binRe, binIm []float64
+}
+
+// NewRealFFTPlan builds a reusable plan for n-point real-input FFTs. n must be
+// even and at least 2; otherwise ErrRealFFTSize is returned.
+func NewRealFFTPlan(n int) (*RealFFTPlan, error) {
+	if n < 2 || n%2 != 0 {
+		return nil, ErrRealFFTSize
+	}
+	half := n >> 1
+	fft, err := NewFFTPlan(half)
+	if err != nil {
+		return nil, err
+	}
+	p := &RealFFTPlan{
+		n:     n,
+		half:  half,
+		fft:   fft,
+		twRe:  make([]float64, half-1),
+		twIm:  make([]float64, half-1),
+		re:    make([]float64, half),
+		im:    make([]float64, half),
+		binRe: make([]float64, half),
+		binIm: make([]float64, half),
+	}
+	for k := 1; k < half; k++ {
+		ang := 2 * math.Pi * float64(k) / float64(n)
+		s, c := math.Sincos(ang)
+		p.twRe[k-1] = c
+		p.twIm[k-1] = -s
+	}
+	return p, nil
+}
+
+// Len returns the transform size the plan was built for.
+func (p *RealFFTPlan) Len() int { return p.n }
+
+// NumBins returns the number of half-spectrum bins, n/2 + 1 (DC through
+// Nyquist).
+func (p *RealFFTPlan) NumBins() int { return p.half + 1 }
+
+// Forward computes the unnormalized real-input DFT of src into dst, keeping the
+// non-negative frequencies (numpy.fft.rfft):
+//
+//	dst[k] = sum_{t=0}^{n-1} src[t] * exp(-i*2*pi*k*t/n),  k in [0, n/2]
+//
+// It reads src[:n] and writes dst[:NumBins()], where n is Len(), and is a no-op
+// when either slice is shorter. The DC and Nyquist bins are real. Allocation-free.
+func (p *RealFFTPlan) Forward(dst []complex128, src []float64) {
+	h := p.half
+	if len(dst) < h+1 || len(src) < p.n {
+		return
+	}
+	re, im := p.re, p.im
+	for j := range h {
+		re[j] = src[2*j]
+		im[j] = src[2*j+1]
+	}
+	p.fft.transform(re, im)
+
+	// X[0] = Z[0].re + Z[0].im and X[half] = Z[0].re - Z[0].im; RealFFTUnpack
+	// fills the bins in between (it needs half >= 2).
+	dst[0] = complex(re[0]+im[0], 0)
+	dst[h] = complex(re[0]-im[0], 0)
+	RealFFTUnpack(p.binRe, p.binIm, re, im, p.twRe, p.twIm)
+	for k := 1; k < h; k++ {
+		dst[k] = complex(p.binRe[k], p.binIm[k])
+	}
+}
+
+// Inverse computes the unnormalized inverse of Forward, the real signal whose
+// half-spectrum is src:
+//
+//	dst[t] = sum_{k=0}^{n-1} X[k] * exp(+i*2*pi*k*t/n),  X[n-k] = conj(src[k])
+//
+// No 1/n factor is applied (the FFTW convention), so Inverse(Forward(x)) is
+// Len()*x; use InverseScaled for the normalized inverse. The imaginary parts of
+// the DC and Nyquist bins are ignored, as numpy.fft.irfft does. It reads
+// src[:NumBins()] and writes dst[:n], and is a no-op when either slice is
+// shorter. Allocation-free.
+func (p *RealFFTPlan) Inverse(dst []float64, src []complex128) {
+	p.inverse(dst, src, realFFTUnscaled)
+}
+
+// InverseScaled computes the normalized inverse, Inverse scaled by 1/Len()
+// (numpy.fft.irfft), so InverseScaled(Forward(x)) recovers x. The scaling is
+// fused into the output pass. Length handling follows Inverse. Allocation-free.
+func (p *RealFFTPlan) InverseScaled(dst []float64, src []complex128) {
+	p.inverse(dst, src, 1/float64(p.half))
+}
+
+// inverse is the shared body of Inverse and InverseScaled: s scales the
+// normalized inverse of the packed half-length transform. The half-spectrum is
+// split back into the even and odd half-spectra (the inverse of
+// RealFFTUnpack), repacked as C[k] = E[k] + i*O[k], and inverted through the
+// forward FFT via ifft(C) = conj(fft(conj(C)))/half.
+func (p *RealFFTPlan) inverse(dst []float64, src []complex128, s float64) {
+	h := p.half
+	if len(dst) < p.n || len(src) < h+1 {
+		return
+	}
+	re, im := p.re, p.im
+	for k := range h {
+		xkr, xki := real(src[k]), imag(src[k])
+		xmr, xmi := real(src[h-k]), imag(src[h-k])
+		wr, wi := 1.0, 0.0
+		if k == 0 {
+			xki, xmi = 0, 0 // DC and Nyquist are real
+		} else {
+			wr, wi = p.twRe[k-1], -p.twIm[k-1] // conj(W_n^k)
+		}
+		// E = 0.5*(X[k] + conj(X[half-k])), D = 0.5*(X[k] - conj(X[half-k])).
+		er := rfftHalf * (xkr + xmr)
+		ei := rfftHalf * (xki - xmi)
+		dr := rfftHalf * (xkr - xmr)
+		di := rfftHalf * (xki + xmi)
+		// O = D * conj(W_n^k).
+		or := dr*wr - di*wi
+		oi := dr*wi + di*wr
+		// Store conj(E + i*O) so the forward FFT yields the conjugated inverse.
+		re[k] = er - oi
+		im[k] = -(ei + or)
+	}
+	p.fft.transform(re, im)
+
+	ns := -s
+	for j := range h {
+		dst[2*j] = re[j] * s
+		dst[2*j+1] = im[j] * ns
+	}
+}
@@ -0,0 +1,171 @@
+package f64
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// rfftSizes covers the power-of-two, mixed-radix, and Bluestein schedules of
+// the packed half-length FFT.
+var rfftSizes = []int{2, 4, 8, 16, 64, 1024, 6, 12, 400, 480, 14, 194}
+
+func TestNewRealFFTPlanErrors(t *testing.T) {
+	for _, bad := range []int{-2, 0, 1, 3, 401} {
+		if _, err := NewRealFFTPlan(bad); err == nil {
+			t.Errorf("NewRealFFTPlan(%d) = nil error, want ErrRealFFTSize", bad)
+		}
+	}
+	for _, good := range []int{2, 4, 6, 400, 1024} {
+		p, err := NewRealFFTPlan(good)
+		if err != nil {
+			t.Errorf("NewRealFFTPlan(%d) unexpected error: %v", good, err)
+			continue
+		}
+		if p.Len() != good || p.NumBins() != good/2+1 {
+			t.Errorf("NewRealFFTPlan(%d): Len=%d NumBins=%d", good, p.Len(), p.NumBins())
+		}
+	}
+}
+
+// TestRealFFTForward checks every half-spectrum bin against a direct float64
+// DFT of the real input.
+func TestRealFFTForward(t *testing.T) {
+	for _, n := range rfftSizes {
+		p, err := NewRealFFTPlan(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src := testSignal(n)
+		var scale float64
+		for _, v := range src {
+			scale += math.Abs(v)
+		}
+		wantRe, wantIm := dftSplit(src, make([]float64, n), -1)
+		dst := make([]complex128, p.NumBins())
+		p.Forward(dst, src)
+		for k := range dst {
+			ctx := fmt.Sprintf("n=%d bin=%d", n, k)
+			cmplxClose(t, ctx, dst[k], complex(wantRe[k], wantIm[k]), scale)
+		}
+		if imag(dst[0]) != 0 || imag(dst[n/2]) != 0 {
+			t.Errorf("n=%d: DC/Nyquist not real: %v %v", n, dst[0], dst[n/2])
+		}
+	}
+}
+
+// TestRealFFTInverse checks Inverse against a direct float64 Hermitian inverse
+// DFT on half-spectra that are not the transform of any real signal, including
+// imaginary parts on DC and Nyquist that must be ignored, and that
+// InverseScaled is Inverse/n.
+func TestRealFFTInverse(t *testing.T) {
+	for _, n := range rfftSizes {
+		p, _ := NewRealFFTPlan(n)
+		h := n / 2
+		src := make([]complex128, h+1)
+		for k := range src {
+			x := float64(k)
+			src[k] = complex(math.Sin(0.7*x+0.2), math.Cos(1.3*x))
+		}
+		got := make([]float64, n)
+		p.Inverse(got, src)
+		scaled := make([]float64, n)
+		p.InverseScaled(scaled, src)
+		// Every bin has modulus <= sqrt(2) and enters at most twice.
+		tol := 1e-9*2*math.Sqrt2*float64(h+1) + 1e-9
+		for t0 := range n {
+			want := real(src[0]) + real(src[h])*math.Cos(math.Pi*float64(t0))
+			for k := 1; k < h; k++ {
+				s, c := math.Sincos(2 * math.Pi * float64(k*t0%n) / float64(n))
+				want += 2 * (real(src[k])*c - imag(src[k])*s)
+			}
+			if d := math.Abs(got[t0] - want); d > tol {
+				t.Fatalf("n=%d t=%d: Inverse=%v want %v (|diff|=%g tol=%g)", n, t0, got[t0], want, d, tol)
+			}
+			if d := math.Abs(scaled[t0] - want/float64(n)); d > tol/float64(n) {
+				t.Fatalf("n=%d t=%d: InverseScaled=%v want %v", n, t0, scaled[t0], want/float64(n))
+			}
+		}
+	}
+}
+
+// TestRealFFTRoundTrip checks InverseScaled(Forward(x)) == x.
+func TestRealFFTRoundTrip(t *testing.T) {
+	for _, n := range rfftSizes {
+		p, _ := NewRealFFTPlan(n)
+		src := testSignal(n)
+		spec := make([]complex128, p.NumBins())
+		p.Forward(spec, src)
+		got := make([]float64, n)
+		p.InverseScaled(got, spec)
+		for i := range n {
+			if d := math.Abs(got[i] - src[i]); d > 1e-12 {
+				t.Fatalf("n=%d: round trip[%d] = %v want %v", n, i, got[i], src[i])
+			}
+		}
+	}
+}
+
+// TestRealFFTGuards checks that short slices are a no-op and that only the
+// first Len() samples and NumBins() bins are touched.
+func TestRealFFTGuards(t *testing.T) {
+	p, _ := NewRealFFTPlan(8)
+	spec := []complex128{1, 2, 3, 4}
+	p.Forward(spec, testSignal(8))
+	p.Forward(make([]complex128, 5), testSignal(7))
+	out := []float64{1, 2, 3, 4, 5, 6, 7}
+	p.Inverse(out, make([]complex128, 5))
+	p.InverseScaled(make([]float64, 8), spec)
+	for i, v := range spec {
+		if v != complex(float64(i+1), 0) {
+			t.Fatalf("short dst modified at %d", i)
+		}
+	}
+	for i, v := range out {
+		if v != float64(i+1) {
+			t.Fatalf("short Inverse dst modified at %d", i)
+		}
+	}
+
+	long := make([]complex128, 7)
+	long[6] = 42
+	p.Forward(long, testSignal(12))
+	if long[6] != 42 {
+		t.Fatalf("bin past NumBins() modified")
+	}
+	samples := make([]float64, 10)
+	samples[9] = 42
+	p.Inverse(samples, long)
+	if samples[9] != 42 {
+		t.Fatalf("sample past Len() modified")
+	}
+}
+
+func TestRealFFTAllocFree(t *testing.T) {
+	for _, n := range []int{1024, 400, 194} {
+		p, _ := NewRealFFTPlan(n)
+		src := testSignal(n)
+		spec := make([]complex128, p.NumBins())
+		out := make([]float64, n)
+		if a := testing.AllocsPerRun(5, func() { p.Forward(spec, src) }); a != 0 {
+			t.Errorf("n=%d: Forward allocated %v times per run, want 0", n, a)
+		}
+		if a := testing.AllocsPerRun(5, func() { p.InverseScaled(out, spec) }); a != 0 {
+			t.Errorf("n=%d: InverseScaled allocated %v times per run, want 0", n, a)
+		}
+	}
+}
+
+func BenchmarkRealFFTForward(b *testing.B) {
+	for _, n := range []int{400, 512, 1024, 4096} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			p, _ := NewRealFFTPlan(n)
+			src := testSignal(n)
+			dst := make([]complex128, p.NumBins())
+			b.ReportAllocs()
+			for b.Loop() {
+				p.Forward(dst, src)
+			}
+		})
+	}
+}
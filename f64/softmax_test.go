@@ -0,0 +1,191 @@
+package f64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+)
+
+// softmaxTiers runs the fused exp-and-sum kernel (AVX2 or NEON) and the
+// pure-Go reference.
+var softmaxTiers = []string{"", "all"}
+
+// softmaxOracle returns the float64 log-sum-exp of a, the reference for the
+// whole softmax family.
+func softmaxOracle(a []float64) float64 {
+	m := math.Inf(-1)
+	for _, v := range a {
+		m = math.Max(m, v)
+	}
+	var s float64
+	for _, v := range a {
+		s += math.Exp(v - m)
+	}
+	return m + math.Log(s)
+}
+
+func softmaxInput(n, seed int) []float64 {
+	a := make([]float64, n)
+	for i := range a {
+		a[i] = float64((i*37+seed*11)%101-50) * 0.37
+	}
+	return a
+}
+
+func TestSoftmax_MatchesReference(t *testing.T) {
+	for _, tier := range softmaxTiers {
+		restore := cpu.Override(tier)
+		for _, n := range []int{1, 2, 3, 7, 8, 9, 15, 16, 17, 31, 100, 255, 256, 257, 1000} {
+			src := softmaxInput(n, n)
+			lse := softmaxOracle(src)
+
+			if got := LogSumExp(src); math.Abs(got-lse) > 1e-5*(1+math.Abs(lse)) {
+				t.Fatalf("tier %q n=%d: LogSumExp = %g, want %g", tier, n, got, lse)
+			}
+
+			dst := make([]float64, n)
+			Softmax(dst, src)
+			var total float64
+			for i, v := range dst {
+				want := math.Exp(src[i] - lse)
+				if math.Abs(v-want) > 3e-5*want+1e-12 {
+					t.Fatalf("tier %q n=%d: Softmax[%d] = %g, want %g", tier, n, i, v, want)
+				}
+				total += v
+			}
+			if math.Abs(total-1) > 3e-5 {
+				t.Fatalf("tier %q n=%d: Softmax sums to %g", tier, n, total)
+			}
+
+			LogSoftmax(dst, src)
+			for i, v := range dst {
+				want := src[i] - lse
+				if math.Abs(v-want) > 1e-5*(1+math.Abs(want)) {
+					t.Fatalf("tier %q n=%d: LogSoftmax[%d] = %g, want %g", tier, n, i, v, want)
+				}
+			}
+
+			// In place gives the same answer as out of place.
+			want := make([]float64, n)
+			Softmax(want, src)
+			inPlace := append([]float64(nil), src...)
+			Softmax(inPlace, inPlace)
+			assertSoftmaxEqual(t, "Softmax in place", inPlace, want)
+			LogSoftmax(want, src)
+			inPlace = append(inPlace[:0], src...)
+			LogSoftmax(inPlace, inPlace)
+			assertSoftmaxEqual(t, "LogSoftmax in place", inPlace, want)
+		}
+		restore()
+	}
+}
+
+func assertSoftmaxEqual(t *testing.T, what string, got, want []float64) {
+	t.Helper()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s: [%d] = %g, want %g", what, i, got[i], want[i])
+		}
+	}
+}
+
+func TestSoftmax_EdgeCases(t *testing.T) {
+	inf := math.Inf(1)
+	for _, tier := range softmaxTiers {
+		restore := cpu.Override(tier)
+
+		// Large inputs do not overflow, and -Inf entries (masked logits) get
+		// exactly zero probability.
+		src := []float64{1000, -inf, 1000, 999, -inf, 998, 1000, -inf, 1000, 997}
+		dst := make([]float64, len(src))
+		Softmax(dst, src)
+		for i, v := range dst {
+			if (src[i] == -inf) != (v == 0) || math.IsNaN(v) {
+				t.Errorf("tier %q: Softmax[%d] = %g for input %g", tier, i, v, src[i])
+			}
+		}
+		if got := LogSumExp(src); math.Abs(got-softmaxOracle(src)) > 1e-3 {
+			t.Errorf("tier %q: LogSumExp = %g, want %g", tier, got, softmaxOracle(src))
+		}
+
+		if got := LogSumExp(nil); !math.IsInf(got, -1) {
+			t.Errorf("LogSumExp(nil) = %g, want -Inf", got)
+		}
+		allMasked := []float64{-inf, -inf, -inf, -inf, -inf, -inf, -inf, -inf, -inf}
+		if got := LogSumExp(allMasked); !math.IsInf(got, -1) {
+			t.Errorf("LogSumExp(all -Inf) = %g, want -Inf", got)
+		}
+		withInf := []float64{1, inf, 2, 3, 4, 5, 6, 7, 8}
+		if got := LogSumExp(withInf); !math.IsInf(got, 1) {
+			t.Errorf("LogSumExp(+Inf) = %g, want +Inf", got)
+		}
+		for _, row := range [][]float64{allMasked, withInf} {
+			out := make([]float64, len(row))
+			Softmax(out, row)
+			for i, v := range out {
+				if !math.IsNaN(v) {
+					t.Errorf("tier %q: Softmax of non-finite row [%d] = %g, want NaN", tier, i, v)
+				}
+			}
+		}
+		restore()
+	}
+}
+
+func TestSoftmaxRows(t *testing.T) {
+	const rows, cols = 5, 19
+	src := softmaxInput(rows*cols+7, 3) // 7 elements of a partial row
+	dst := make([]float64, len(src))
+	for i := range dst {
+		dst[i] = 42
+	}
+	lse := make([]float64, rows+1)
+	lse[rows] = 42
+
+	SoftmaxRows(dst, src, cols)
+	LogSumExpRows(lse, src, cols)
+	want := make([]float64, cols)
+	for r := range rows {
+		row := src[r*cols : (r+1)*cols]
+		Softmax(want, row)
+		assertSoftmaxEqual(t, "SoftmaxRows", dst[r*cols:(r+1)*cols], want)
+		if got := LogSumExp(row); lse[r] != got {
+			t.Errorf("LogSumExpRows[%d] = %g, want %g", r, lse[r], got)
+		}
+	}
+	for i := rows * cols; i < len(dst); i++ {
+		if dst[i] != 42 {
+			t.Fatalf("SoftmaxRows wrote the partial row at %d", i)
+		}
+	}
+	if lse[rows] != 42 {
+		t.Errorf("LogSumExpRows wrote past the last full row")
+	}
+
+	LogSoftmaxRows(dst, src, cols)
+	for r := range rows {
+		LogSoftmax(want, src[r*cols:(r+1)*cols])
+		assertSoftmaxEqual(t, "LogSoftmaxRows", dst[r*cols:(r+1)*cols], want)
+	}
+
+	SoftmaxRows(dst, src, 0)
+	LogSoftmaxRows(dst, src, -1)
+	LogSumExpRows(lse, src, 0)
+}
+
+func TestSoftmax_Allocs(t *testing.T) {
+	src := softmaxInput(1000, 1)
+	dst := make([]float64, len(src))
+	lse := make([]float64, 10)
+	allocs := testing.AllocsPerRun(100, func() {
+		Softmax(dst, src)
+		LogSoftmax(dst, src)
+		_ = LogSumExp(src)
+		SoftmaxRows(dst, src, 100)
+		LogSumExpRows(lse, src, 100)
+	})
+	if allocs != 0 {
+		t.Fatalf("softmax family allocations = %v, want 0", allocs)
+	}
+}
@@ -3,6 +3,7 @@ package f64
 import (
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"testing"
@@ -48,12 +49,15 @@ func cmplxClose(t *testing.T, ctx string, got, want complex128, scale float64) {
 }
 
 func TestNewSTFTPlanErrors(t *testing.T) {
-	for _, bad := range []int{0, 1, 3, 5, 6, 7, 9, 100, 1000} {
-		if _, err := NewSTFTPlan(bad); err == nil {
-			t.Errorf("NewSTFTPlan(%d) = nil error, want ErrNotPowerOfTwo", bad)
+	for _, bad := range []int{-2, 0, 1} {
+		if _, err := NewSTFTPlan(bad); !errors.Is(err, ErrSTFTSize) {
+			t.Errorf("NewSTFTPlan(%d) error = %v, want ErrSTFTSize", bad, err)
 		}
 	}
-	for _, good := range []int{2, 4, 8, 16, 1024} {
+	if _, err := NewSTFTPlan(1); !errors.Is(err, ErrNotPowerOfTwo) {
+		t.Errorf("deprecated ErrNotPowerOfTwo no longer matches: %v", err)
+	}
+	for _, good := range []int{2, 3, 4, 5, 6, 8, 9, 16, 100, 400, 401, 480, 1000, 1024} {
 		p, err := NewSTFTPlan(good)
 		if err != nil {
 			t.Errorf("NewSTFTPlan(%d) unexpected error: %v", good, err)
@@ -72,8 +76,9 @@ func TestSTFTAgainstDFT(t *testing.T) {
 	signal := testSignal(5000)
 	// The size list spans both radix-4 schedule shapes: even log2(half) runs only
 	// radix-4 stages (nfft 8/32/128 = 1/2/3 stages, no trailing), odd log2(half)
-	// finishes with one trailing radix-2 stage (nfft 4/16/64/256/1024).
-	for _, nfft := range []int{2, 4, 8, 16, 32, 64, 128, 256, 1024} {
+	// finishes with one trailing radix-2 stage (nfft 4/16/64/256/1024). The tail
+	// covers mixed-radix halves (6/12/30/400/480) and Bluestein halves (14/194).
+	for _, nfft := range []int{2, 4, 8, 16, 32, 64, 128, 256, 1024, 6, 12, 30, 400, 480, 14, 194, 3, 5, 9, 15, 401} {
 		for _, useWin := range []bool{false, true} {
 			plan, err := NewSTFTPlan(nfft)
 			if err != nil {
@@ -424,6 +429,14 @@ func TestNumFrames(t *testing.T) {
 			t.Errorf("NumFrames(%d,%d,%v)=%d want %d", c.n, c.hop, c.pad, got, c.want)
 		}
 	}
+	// An odd nfft pads nfft/2 (rounded down) per side, matching
+	// librosa's 1 + (len + 2*(n_fft//2) - n_fft)//hop.
+	odd, _ := NewSTFTPlan(9)
+	for _, c := range []struct{ n, hop, want int }{{1, 4, 1}, {8, 4, 2}, {9, 4, 3}, {16, 4, 4}} {
+		if got := odd.NumFrames(c.n, c.hop, PadZero); got != c.want {
+			t.Errorf("nfft=9: NumFrames(%d,%d,PadZero)=%d want %d", c.n, c.hop, got, c.want)
+		}
+	}
 }
 
 func TestReflectIndex(t *testing.T) {
@@ -485,7 +498,7 @@ func stftRef(signal, window []float64, nfft, hop int, pad PadMode) [][]complex12
 			nf = 1 + (len(signal)-nfft)/hop
 		}
 	case len(signal) > 0:
-		nf = 1 + len(signal)/hop
+		nf = 1 + (len(signal)+2*(nfft/2)-nfft)/hop
 	}
 	out := make([][]complex128, nf)
 	frame := make([]float64, nfft)
@@ -511,7 +524,7 @@ func stftRef(signal, window []float64, nfft, hop int, pad PadMode) [][]complex12
 // frame must match the independent reference across nfft, hop, pad mode, and
 // window choice.
 func TestSTFTCenteredAgainstRef(t *testing.T) {
-	for _, nfft := range []int{16, 64, 512, 1024} {
+	for _, nfft := range []int{16, 64, 512, 1024, 400, 480, 45, 401} {
 		for _, hop := range []int{nfft / 4, nfft / 2, nfft} {
 			for _, pad := range []PadMode{NoPad, PadZero, PadReflect} {
 				for _, useWin := range []bool{false, true} {
@@ -589,62 +602,76 @@ func TestSTFTPowerInto(t *testing.T) {
 //go:embed testdata/stft_librosa_golden.json
 var librosaGoldenJSON []byte
 
-// TestSTFTLibrosaParity pins the output convention against a golden vector
-// generated by real librosa (see testdata/gen_stft_golden.py). This is the
-// acceptance check that a model trained on librosa features accepts simd output.
+// TestSTFTLibrosaParity pins the output convention against golden vectors
+// for the radix-2, 400/480 mixed-radix and odd nfft paths; each entry records
+// its generator. Only the nfft 1024 entry is librosa output, the acceptance
+// check that a model trained on librosa features accepts simd output. The
+// 400, 480 and 401 entries come from the stdlib transcription of librosa.stft
+// in testdata/gen_stft_golden.py (exact fsum DFT), librosa being unavailable
+// when they were generated, so those sizes are checked against that second
+// port, not against librosa, until the script is re-run under librosa.
 // The golden is embedded (not read from disk) so the test runs from any working
 // directory, including the cross-arch "copy the test binary and run it" flow. The
 // signal and window are regenerated here with the same deterministic formulas the
-// generator used, so only librosa's power output is pinned in the data file.
+// generator used, so only the power output is pinned in the data file.
 func TestSTFTLibrosaParity(t *testing.T) {
 	var g struct {
-		LibrosaVersion string `json:"librosa_version"`
-		NFFT           int    `json:"nfft"`
-		Hop            int    `json:"hop"`
-		N              int    `json:"n"`
-		Cases          []struct {
-			GoPad  string    `json:"go_pad"`
-			Frames int       `json:"frames"`
-			Bins   int       `json:"bins"`
-			Power  []float64 `json:"power"`
-		} `json:"cases"`
+		N     int `json:"n"`
+		Sizes []struct {
+			NFFT      int    `json:"nfft"`
+			Hop       int    `json:"hop"`
+			Generator string `json:"generator"`
+			Cases     []struct {
+				GoPad  string    `json:"go_pad"`
+				Frames int       `json:"frames"`
+				Bins   int       `json:"bins"`
+				Power  []float64 `json:"power"`
+			} `json:"cases"`
+		} `json:"sizes"`
 	}
 	if err := json.Unmarshal(librosaGoldenJSON, &g); err != nil {
 		t.Fatalf("unmarshal golden: %v", err)
 	}
-	t.Logf("golden generated by librosa %s (nfft=%d hop=%d)", g.LibrosaVersion, g.NFFT, g.Hop)
-	signal := testSignal(g.N) // same formula the generator fed to librosa
-	window := hann(g.NFFT)    // periodic Hann, same as the generator
-	p, _ := NewSTFTPlan(g.NFFT)
+	signal := testSignal(g.N)
 	padOf := map[string]PadMode{"PadZero": PadZero, "PadReflect": PadReflect}
-	for _, c := range g.Cases {
-		pad, ok := padOf[c.GoPad]
-		if !ok {
-			t.Fatalf("unknown go_pad %q in golden", c.GoPad)
-		}
-		if nf := p.NumFrames(len(signal), g.Hop, pad); nf != c.Frames {
-			t.Fatalf("%s: NumFrames=%d but librosa produced %d frames", c.GoPad, nf, c.Frames)
-		}
-		if c.Bins != p.NumBins() {
-			t.Fatalf("%s: golden bins=%d but NumBins=%d", c.GoPad, c.Bins, p.NumBins())
-		}
-		flat := make([]float64, c.Frames*c.Bins)
-		p.STFTPowerInto(flat, signal, window, g.Hop, pad)
-		var maxRel float64
-		for i := range flat {
-			ref := c.Power[i]
-			rel := math.Abs(flat[i]-ref) / (math.Abs(ref) + 1e-12)
-			if rel > maxRel {
-				maxRel = rel
-			}
+	for _, e := range g.Sizes {
+		t.Logf("nfft=%d hop=%d golden generated by %s", e.NFFT, e.Hop, e.Generator)
+		window := hann(e.NFFT)
+		p, err := NewSTFTPlan(e.NFFT)
+		if err != nil {
+			t.Fatalf("NewSTFTPlan(%d): %v", e.NFFT, err)
 		}
-		// librosa uses pocketfft and we use a radix-2 rfft, so the bins differ at
-		// the float64 algorithm-noise level (~5e-8 relative; squaring to power
-		// roughly doubles the amplitude error). A convention error (wrong
-		// centering, window, or normalization) would be orders of magnitude
-		// larger, so 1e-6 cleanly separates "matches librosa" from "wrong".
-		if maxRel > 1e-6 {
-			t.Errorf("%s: max relative error %g exceeds 1e-6 vs librosa", c.GoPad, maxRel)
+		for _, c := range e.Cases {
+			ctx := fmt.Sprintf("nfft=%d %s", e.NFFT, c.GoPad)
+			pad, ok := padOf[c.GoPad]
+			if !ok {
+				t.Fatalf("unknown go_pad %q in golden", c.GoPad)
+			}
+			if nf := p.NumFrames(len(signal), e.Hop, pad); nf != c.Frames {
+				t.Fatalf("%s: NumFrames=%d but %s produced %d frames", ctx, nf, e.Generator, c.Frames)
+			}
+			if c.Bins != p.NumBins() {
+				t.Fatalf("%s: golden bins=%d but NumBins=%d", ctx, c.Bins, p.NumBins())
+			}
+			flat := make([]float64, c.Frames*c.Bins)
+			p.STFTPowerInto(flat, signal, window, e.Hop, pad)
+			var maxRel float64
+			for i := range flat {
+				ref := c.Power[i]
+				rel := math.Abs(flat[i]-ref) / (math.Abs(ref) + 1e-12)
+				if rel > maxRel {
+					maxRel = rel
+				}
+			}
+			// librosa uses pocketfft (the transcription an exact DFT) and we use our
+			// own rfft, so the bins differ at the float64 algorithm-noise level
+			// (~5e-8 relative; squaring to power roughly doubles the amplitude
+			// error). A convention error (wrong centering, window, or
+			// normalization) would be orders of magnitude larger, so 1e-6 cleanly
+			// separates "matches the golden" from "wrong".
+			if maxRel > 1e-6 {
+				t.Errorf("%s: max relative error %g exceeds 1e-6 vs %s", ctx, maxRel, e.Generator)
+			}
 		}
 	}
 }
@@ -717,9 +744,9 @@ func TestSTFTStageTwiddles(t *testing.T) {
 		}
 		half := nfft >> 1
 		wantLen := max(half-1, 0)
-		if len(p.stageTwRe) != wantLen || len(p.stageTwIm) != wantLen {
+		if len(p.fft.stageTwRe) != wantLen || len(p.fft.stageTwIm) != wantLen {
 			t.Fatalf("nfft=%d: twiddle table len = (%d,%d), want %d",
-				nfft, len(p.stageTwRe), len(p.stageTwIm), wantLen)
+				nfft, len(p.fft.stageTwRe), len(p.fft.stageTwIm), wantLen)
 		}
 		for m := 2; m <= half; m <<= 1 {
 			span := m >> 1
@@ -727,11 +754,11 @@ func TestSTFTStageTwiddles(t *testing.T) {
 			for j := range span {
 				ang := 2 * math.Pi * float64(j) / float64(m)
 				s, c := math.Sincos(ang)
-				if d := math.Abs(p.stageTwRe[off+j] - c); d > 1e-15 {
-					t.Errorf("nfft=%d m=%d j=%d: stageTwRe=%g want %g", nfft, m, j, p.stageTwRe[off+j], c)
+				if d := math.Abs(p.fft.stageTwRe[off+j] - c); d > 1e-15 {
+					t.Errorf("nfft=%d m=%d j=%d: stageTwRe=%g want %g", nfft, m, j, p.fft.stageTwRe[off+j], c)
 				}
-				if d := math.Abs(p.stageTwIm[off+j] - (-s)); d > 1e-15 {
-					t.Errorf("nfft=%d m=%d j=%d: stageTwIm=%g want %g", nfft, m, j, p.stageTwIm[off+j], -s)
+				if d := math.Abs(p.fft.stageTwIm[off+j] - (-s)); d > 1e-15 {
+					t.Errorf("nfft=%d m=%d j=%d: stageTwIm=%g want %g", nfft, m, j, p.fft.stageTwIm[off+j], -s)
 				}
 			}
 		}
@@ -0,0 +1,88 @@
+//go:build arm64
+
+package f64
+
+import "testing"
+
+// sveTestLengths straddles the 4*VL main loop of the reductions and the
+// WHILELO tail at every vector length up to 2048 bits (32 float64 lanes).
+var sveTestLengths = []int{1, 2, 3, 4, 5, 7, 8, 15, 16, 17, 31, 32, 33, 63, 64, 65, 127, 128, 129, 255, 256, 257, 1000, 1027}
+
+// TestSVEReductions checks dotProductSVE, sumSVE and dotProduct4SVE against
+// the scalar references, including a longer b to exercise the min-length clamp.
+func TestSVEReductions(t *testing.T) {
+	if !hasSVE {
+		t.Skip("SVE required")
+	}
+	for _, n := range sveTestLengths {
+		a := deterministicF64Vector(1, n)
+		b := deterministicF64Vector(2, n+3)
+		if got, want := dotProductSVE(a, b), dotProductGo(a, b[:n]); !closeFloat64(got, want) {
+			t.Errorf("dotProductSVE n=%d: got %g, want %g", n, got, want)
+		}
+		if got, want := sumSVE(a), sumGo(a); !closeFloat64(got, want) {
+			t.Errorf("sumSVE n=%d: got %g, want %g", n, got, want)
+		}
+		rows := [4][]float64{
+			deterministicF64Vector(100, n),
+			deterministicF64Vector(101, n),
+			deterministicF64Vector(102, n),
+			deterministicF64Vector(103, n),
+		}
+		results := make([]float64, 4)
+		dotProduct4SVE(&results[0], &rows[0][0], &rows[1][0], &rows[2][0], &rows[3][0], &a[0], n)
+		for i, row := range rows {
+			if want := dotProductGo(row, a); !closeFloat64(results[i], want) {
+				t.Errorf("dotProduct4SVE n=%d row=%d: got %g, want %g", n, i, results[i], want)
+			}
+		}
+	}
+}
+
+// TestSVEElementwise checks addSVE, mulSVE and fmaSVE match the scalar
+// references exactly, never write past len(dst), and allow dst to alias a.
+func TestSVEElementwise(t *testing.T) {
+	if !hasSVE {
+		t.Skip("SVE required")
+	}
+	const sentinel = float64(-12345)
+	for _, n := range sveTestLengths {
+		a := deterministicF64Vector(3, n)
+		b := deterministicF64Vector(4, n)
+		c := deterministicF64Vector(5, n)
+		want := make([]float64, n)
+		buf := make([]float64, n+1)
+		dst := buf[:n]
+		for _, tc := range []struct {
+			name   string
+			kernel func()
+			ref    func()
+		}{
+			{"addSVE", func() { addSVE(dst, a, b) }, func() { addGo(want, a, b) }},
+			{"mulSVE", func() { mulSVE(dst, a, b) }, func() { mulGo(want, a, b) }},
+			// fmaGo is math.FMA: one rounding, as FMLA.
+			{"fmaSVE", func() { fmaSVE(dst, a, b, c) }, func() { fmaGo(want, a, b, c) }},
+		} {
+			buf[n] = sentinel
+			tc.kernel()
+			tc.ref()
+			for i := range n {
+				if dst[i] != want[i] {
+					t.Fatalf("%s n=%d: dst[%d] = %g, want %g", tc.name, n, i, dst[i], want[i])
+				}
+			}
+			if buf[n] != sentinel {
+				t.Fatalf("%s n=%d: wrote past len(dst)", tc.name, n)
+			}
+		}
+
+		addGo(want, a, b)
+		copy(dst, a)
+		addSVE(dst, dst, b)
+		for i := range n {
+			if dst[i] != want[i] {
+				t.Fatalf("addSVE aliased n=%d: dst[%d] = %g, want %g", n, i, dst[i], want[i])
+			}
+		}
+	}
+}
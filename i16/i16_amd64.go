@@ -14,7 +14,8 @@ const (
 )
 
 // Dispatch priority mirrors f32/f64: AVX2 > SSE2 > Go, with XCorr adding an
-// AVX-VNNI tier above AVX2 (see xcorrI16). The kernels gate on the CPU feature
+// AVX-VNNI tier above AVX2 (see xcorrI16), and DotProduct and XCorr adding
+// AVX-512 VNNI > AVX-512BW tiers above both. The kernels gate on the CPU feature
 // explicitly (rather than relying on length alone) so the package is correct on
 // every amd64 baseline.
 //
@@ -24,11 +25,23 @@ const (
 // MaxAbs) are AVX2-or-Go, so on a pre-AVX2 amd64 host their Go reference is a
 // live, reachable path rather than a formality.
 var (
-	hasAVXVNNI = cpu.X86.AVXVNNI
-	hasAVX2    = cpu.X86.AVX2
-	hasSSE2    = cpu.X86.SSE2
+	hasAVX512VNNI = cpu.X86.AVX512VNNI
+	hasAVX512BW   = cpu.X86.AVX512BW
+	hasAVXVNNI    = cpu.X86.AVXVNNI
+	hasAVX2       = cpu.X86.AVX2
+	hasSSE2       = cpu.X86.SSE2
 )
 
+// bindKernels re-reads the feature flags cached above from cpu.X86.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasAVX512VNNI = cpu.X86.AVX512VNNI
+	hasAVX512BW = cpu.X86.AVX512BW
+	hasAVXVNNI = cpu.X86.AVXVNNI
+	hasAVX2 = cpu.X86.AVX2
+	hasSSE2 = cpu.X86.SSE2
+}
+
 // Dot dispatch thresholds. They are independent literals rather than aliases of
 // the interleave block sizes they happen to equal, so retuning the interleave
 // kernels cannot silently retune the dot dispatch.
@@ -48,6 +61,17 @@ const (
 	minAVX2Dot = 16 // below this AVX2's fold overhead outweighs its width
 )
 
+// The AVX-512 kernels carry the AVX2 kernels' blocks below their 32-wide loop
+// plus one more fold step, so below one ZMM block they would only add that fold
+// to the AVX2 work. Each tier therefore starts at a full 32-wide block, and the
+// dot and xcorr cuts are independent literals like the ones above.
+const (
+	minAVX512BWDot     = 32
+	minAVX512VNNIDot   = 32
+	minAVX512BWXCorr   = 32
+	minAVX512VNNIXCorr = 32
+)
+
 // XCorr vectorizes once x is long enough that reusing each x load across four
 // lags pays for the kernel call, and below 8 the Go reference runs.
 //
@@ -75,6 +99,14 @@ func xcorrI16(dst []int32, x, y []int16) {
 	m := xcorrLags(dst, x, y)
 	k := 0
 	switch {
+	case hasAVX512VNNI && len(x) >= minAVX512VNNIXCorr:
+		for ; k+xcorrLagBlock <= m; k += xcorrLagBlock {
+			xcorr4AVX512VNNI(dst[k:k+xcorrLagBlock], x, xcorrWindow(x, y, k))
+		}
+	case hasAVX512BW && len(x) >= minAVX512BWXCorr:
+		for ; k+xcorrLagBlock <= m; k += xcorrLagBlock {
+			xcorr4AVX512BW(dst[k:k+xcorrLagBlock], x, xcorrWindow(x, y, k))
+		}
 	case hasAVXVNNI && len(x) >= minAVXVNNIXCorr:
 		for ; k+xcorrLagBlock <= m; k += xcorrLagBlock {
 			xcorr4AVXVNNI(dst[k:k+xcorrLagBlock], x, xcorrWindow(x, y, k))
@@ -99,6 +131,10 @@ func xcorrI16(dst []int32, x, y []int16) {
 func dotI16(a, b []int16) int32 {
 	n := min(len(a), len(b))
 	switch {
+	case hasAVX512VNNI && n >= minAVX512VNNIDot:
+		return dotAVX512VNNI(a, b)
+	case hasAVX512BW && n >= minAVX512BWDot:
+		return dotAVX512BW(a, b)
 	case hasAVX2 && n >= minAVX2Dot:
 		return dotAVX2(a, b)
 	case hasSSE2 && n >= minSSE2Dot:
@@ -178,6 +214,12 @@ func absAVX2(dst, a []int16)
 //go:noescape
 func maxAbsAVX2(a []int16) int
 
+//go:noescape
+func xcorr4AVX512VNNI(dst []int32, x, y []int16)
+
+//go:noescape
+func xcorr4AVX512BW(dst []int32, x, y []int16)
+
 //go:noescape
 func xcorr4AVXVNNI(dst []int32, x, y []int16)
 
@@ -187,6 +229,12 @@ func xcorr4AVX2(dst []int32, x, y []int16)
 //go:noescape
 func xcorr4SSE2(dst []int32, x, y []int16)
 
+//go:noescape
+func dotAVX512VNNI(a, b []int16) int32
+
+//go:noescape
+func dotAVX512BW(a, b []int16) int32
+
 //go:noescape
 func dotAVX2(a, b []int16) int32
 
@@ -1194,3 +1194,541 @@ maxabs_avx2_done:
     MOVQ AX, ret+24(FP)
     VZEROUPPER
     RET
+
+// AVX-512 dot product and cross-correlation.
+//
+// Each kernel is its AVX2 sibling one width up: the AVX2 kernel's 8- (and for
+// XCorr 4-) wide XMM blocks plus a 16-wide YMM block run BEFORE a 32-wide ZMM
+// loop, then VEXTRACTI64X4 folds the 512-bit accumulators to 256 bits and the
+// AVX2 fold and scalar tail finish. The pre-loop order is forced, as in
+// xcorr4AVX2: a VEX write zeroes every accumulator bit above its width, here
+// bits 511:128 or 511:256, which is harmless only while those bits are still the
+// VPXOR'd zero. Reordering the terms that way is exact because the int32
+// accumulation wraps; see xcorr4AVX2.
+//
+// The ...AVX512BW kernels need AVX-512BW for VPMADDWD on ZMM. The ...AVX512VNNI
+// kernels fuse each VPMADDWD+VPADDD pair of the loop into one VPDPWSSD, which
+// wraps like the pair (never VPDPWSSDS, which saturates). Unlike xcorr4AVXVNNI
+// no hand encoding is needed: the Go assembler emits the EVEX form of VPDPWSSD,
+// which is exactly the AVX-512 VNNI encoding, and its memory operand folds the
+// lagged y loads into the instruction.
+
+// func dotAVX512BW(a, b []int16) int32
+// dotAVX2 with a 16-wide YMM block and a 32-wide ZMM loop.
+TEXT ·dotAVX512BW(SB), NOSPLIT, $0-52
+    MOVQ a_base+0(FP), SI
+    MOVQ a_len+8(FP), CX
+    MOVQ b_len+32(FP), DX
+    CMPQ DX, CX
+    CMOVQLT DX, CX             // CX = n = min(len(a), len(b))
+    MOVQ b_base+24(FP), DI
+
+    VPXOR Y0, Y0, Y0           // int32 accumulator (VEX zeroes Z0[511:256])
+
+    TESTQ $8, CX               // n % 16 >= 8?
+    JZ   dot512bw_block16
+    VMOVDQU (SI), X1
+    VMOVDQU (DI), X2
+    VPMADDWD X2, X1, X1
+    VPADDD X1, X0, X0          // Z0[511:128] is still zero
+    ADDQ $16, SI
+    ADDQ $16, DI
+
+dot512bw_block16:
+    TESTQ $16, CX              // n % 32 >= 16?
+    JZ   dot512bw_blocks32
+    VMOVDQU (SI), Y1
+    VMOVDQU (DI), Y2
+    VPMADDWD Y2, Y1, Y1
+    VPADDD Y1, Y0, Y0          // Z0[511:256] is still zero
+    ADDQ $32, SI
+    ADDQ $32, DI
+
+dot512bw_blocks32:
+    MOVQ CX, BX
+    SHRQ $5, BX                // BX = n / 32
+    JZ   dot512bw_reduce
+
+dot512bw_loop32:
+    VMOVDQU64 (SI), Z1
+    VMOVDQU64 (DI), Z2
+    VPMADDWD Z2, Z1, Z1        // 32 int16 pairs -> 16 int32
+    VPADDD Z1, Z0, Z0          // accumulate (wrapping)
+    ADDQ $64, SI
+    ADDQ $64, DI
+    DECQ BX
+    JNZ  dot512bw_loop32
+
+dot512bw_reduce:
+    VEXTRACTI64X4 $1, Z0, Y1
+    VPADDD Y1, Y0, Y0          // fold 16 -> 8 int32
+    VEXTRACTI128 $1, Y0, X1
+    VPADDD X1, X0, X0
+    VPSHUFD $0x4E, X0, X1
+    VPADDD X1, X0, X0
+    VPSHUFD $0xB1, X0, X1
+    VPADDD X1, X0, X0
+    MOVQ X0, AX                // low int32 = vector total (in EAX)
+
+    ANDQ $7, CX                // the 8- and 16-wide blocks took n % 32 down to n % 8
+    JZ   dot512bw_done
+
+dot512bw_scalar:
+    MOVWLSX (SI), BX
+    MOVWLSX (DI), DX
+    IMULL DX, BX
+    ADDL BX, AX                // 32-bit add: wraps like dotGo
+    ADDQ $2, SI
+    ADDQ $2, DI
+    DECQ CX
+    JNZ  dot512bw_scalar
+
+dot512bw_done:
+    MOVL AX, ret+48(FP)
+    VZEROUPPER
+    RET
+
+// func dotAVX512VNNI(a, b []int16) int32
+// dotAVX512BW with the loop fused into VPDPWSSD.
+TEXT ·dotAVX512VNNI(SB), NOSPLIT, $0-52
+    MOVQ a_base+0(FP), SI
+    MOVQ a_len+8(FP), CX
+    MOVQ b_len+32(FP), DX
+    CMPQ DX, CX
+    CMOVQLT DX, CX             // CX = n = min(len(a), len(b))
+    MOVQ b_base+24(FP), DI
+
+    VPXOR Y0, Y0, Y0           // int32 accumulator (VEX zeroes Z0[511:256])
+
+    TESTQ $8, CX               // n % 16 >= 8?
+    JZ   dotvnni512_block16
+    VMOVDQU (SI), X1
+    VMOVDQU (DI), X2
+    VPMADDWD X2, X1, X1
+    VPADDD X1, X0, X0          // Z0[511:128] is still zero
+    ADDQ $16, SI
+    ADDQ $16, DI
+
+dotvnni512_block16:
+    TESTQ $16, CX              // n % 32 >= 16?
+    JZ   dotvnni512_blocks32
+    VMOVDQU (SI), Y1
+    VMOVDQU (DI), Y2
+    VPMADDWD Y2, Y1, Y1
+    VPADDD Y1, Y0, Y0          // Z0[511:256] is still zero
+    ADDQ $32, SI
+    ADDQ $32, DI
+
+dotvnni512_blocks32:
+    MOVQ CX, BX
+    SHRQ $5, BX                // BX = n / 32
+    JZ   dotvnni512_reduce
+
+dotvnni512_loop32:
+    VMOVDQU64 (SI), Z1
+    VMOVDQU64 (DI), Z2
+    VPDPWSSD Z2, Z1, Z0        // Z0 += madd(Z1, Z2), wrapping
+    ADDQ $64, SI
+    ADDQ $64, DI
+    DECQ BX
+    JNZ  dotvnni512_loop32
+
+dotvnni512_reduce:
+    VEXTRACTI64X4 $1, Z0, Y1
+    VPADDD Y1, Y0, Y0          // fold 16 -> 8 int32
+    VEXTRACTI128 $1, Y0, X1
+    VPADDD X1, X0, X0
+    VPSHUFD $0x4E, X0, X1
+    VPADDD X1, X0, X0
+    VPSHUFD $0xB1, X0, X1
+    VPADDD X1, X0, X0
+    MOVQ X0, AX                // low int32 = vector total (in EAX)
+
+    ANDQ $7, CX                // the 8- and 16-wide blocks took n % 32 down to n % 8
+    JZ   dotvnni512_done
+
+dotvnni512_scalar:
+    MOVWLSX (SI), BX
+    MOVWLSX (DI), DX
+    IMULL DX, BX
+    ADDL BX, AX                // 32-bit add: wraps like dotGo
+    ADDQ $2, SI
+    ADDQ $2, DI
+    DECQ CX
+    JNZ  dotvnni512_scalar
+
+dotvnni512_done:
+    MOVL AX, ret+48(FP)
+    VZEROUPPER
+    RET
+
+// func xcorr4AVX512BW(dst []int32, x, y []int16)
+// xcorr4AVX2 with a 16-wide YMM block and a 32-wide ZMM loop, each x load
+// reused across the four lags.
+TEXT ·xcorr4AVX512BW(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DI
+    MOVQ x_base+24(FP), SI
+    MOVQ x_len+32(FP), CX
+    MOVQ y_base+48(FP), BX
+    MOVQ y_len+56(FP), DX
+
+    VPXOR Y4, Y4, Y4           // lag 0 accumulator (VEX zeroes Z4[511:256])
+    VPXOR Y5, Y5, Y5           // lag 1
+    VPXOR Y6, Y6, Y6           // lag 2
+    VPXOR Y7, Y7, Y7           // lag 3
+
+    SUBQ $3, DX                // DX = len(y) - 3
+    JLE  xcorr4_512bw_empty
+    CMPQ DX, CX
+    CMOVQLT DX, CX             // CX = n = min(len(x), len(y)-3)
+    JMP  xcorr4_512bw_blocks
+
+xcorr4_512bw_empty:
+    XORQ CX, CX
+
+xcorr4_512bw_blocks:
+    TESTQ $8, CX               // n % 16 >= 8?
+    JZ   xcorr4_512bw_block4
+    VMOVDQU (SI), X0           // x[0..8), reused by all four lags
+    VMOVDQU (BX), X1           // lag 0
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X4, X4          // Z4[511:128] is still zero
+    VMOVDQU 2(BX), X1          // lag 1
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X5, X5
+    VMOVDQU 4(BX), X1          // lag 2
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X6, X6
+    VMOVDQU 6(BX), X1          // lag 3
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X7, X7
+    ADDQ $16, SI
+    ADDQ $16, BX
+
+xcorr4_512bw_block4:
+    TESTQ $4, CX               // n % 8 >= 4?
+    JZ   xcorr4_512bw_block16
+    VMOVQ (SI), X0             // x[0..4) in the low 64 bits (upper zeroed)
+    VMOVQ (BX), X1             // lag 0
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X4, X4
+    VMOVQ 2(BX), X1            // lag 1
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X5, X5
+    VMOVQ 4(BX), X1            // lag 2
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X6, X6
+    VMOVQ 6(BX), X1            // lag 3
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X7, X7
+    ADDQ $8, SI
+    ADDQ $8, BX
+
+xcorr4_512bw_block16:
+    TESTQ $16, CX              // n % 32 >= 16?
+    JZ   xcorr4_512bw_blocks32
+    VMOVDQU (SI), Y0           // x[0..16), reused by all four lags
+    VMOVDQU (BX), Y1           // lag 0
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y4, Y4          // Z4[511:256] is still zero
+    VMOVDQU 2(BX), Y1          // lag 1
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y5, Y5
+    VMOVDQU 4(BX), Y1          // lag 2
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y6, Y6
+    VMOVDQU 6(BX), Y1          // lag 3
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y7, Y7
+    ADDQ $32, SI
+    ADDQ $32, BX
+
+xcorr4_512bw_blocks32:
+    MOVQ CX, AX
+    SHRQ $5, AX                // AX = n / 32, the 32-wide block count
+    JZ   xcorr4_512bw_fold512
+
+xcorr4_512bw_loop32:
+    VMOVDQU64 (SI), Z0         // x[j..j+32), reused by all four lags
+    VMOVDQU64 (BX), Z1         // lag 0
+    VPMADDWD Z0, Z1, Z1
+    VPADDD Z1, Z4, Z4
+    VMOVDQU64 2(BX), Z1        // lag 1
+    VPMADDWD Z0, Z1, Z1
+    VPADDD Z1, Z5, Z5
+    VMOVDQU64 4(BX), Z1        // lag 2
+    VPMADDWD Z0, Z1, Z1
+    VPADDD Z1, Z6, Z6
+    VMOVDQU64 6(BX), Z1        // lag 3
+    VPMADDWD Z0, Z1, Z1
+    VPADDD Z1, Z7, Z7
+    ADDQ $64, SI
+    ADDQ $64, BX
+    DECQ AX
+    JNZ  xcorr4_512bw_loop32
+
+    // Fold each lag's upper 256 bits into its lower; from here on the
+    // accumulators are YMM and xcorr4AVX2's dual-path fold finishes.
+xcorr4_512bw_fold512:
+    VEXTRACTI64X4 $1, Z4, Y0
+    VPADDD Y0, Y4, Y4
+    VEXTRACTI64X4 $1, Z5, Y0
+    VPADDD Y0, Y5, Y5
+    VEXTRACTI64X4 $1, Z6, Y0
+    VPADDD Y0, Y6, Y6
+    VEXTRACTI64X4 $1, Z7, Y0
+    VPADDD Y0, Y7, Y7
+
+    ANDQ $3, CX                // $3: the 16-, 8- and 4-wide blocks took the other bits
+    JNZ  xcorr4_512bw_fold_tail
+    VEXTRACTI128 $1, Y4, X0
+    VPADDD X0, X4, X4          // X4 = four int32 partials for lag 0
+    VEXTRACTI128 $1, Y5, X0
+    VPADDD X0, X5, X5          // X5 = lag 1 partials
+    VEXTRACTI128 $1, Y6, X0
+    VPADDD X0, X6, X6          // X6 = lag 2 partials
+    VEXTRACTI128 $1, Y7, X0
+    VPADDD X0, X7, X7          // X7 = lag 3 partials
+    VPHADDD X5, X4, X4         // X4 = [lag0(0+1), lag0(2+3), lag1(0+1), lag1(2+3)]
+    VPHADDD X7, X6, X6         // X6 = [lag2(0+1), lag2(2+3), lag3(0+1), lag3(2+3)]
+    VPHADDD X6, X4, X4         // X4 = [sumLag0, sumLag1, sumLag2, sumLag3]
+    VMOVDQU X4, (DI)           // no scalar tail: store all four lag sums at once
+    VZEROUPPER
+    RET
+
+xcorr4_512bw_fold_tail:
+    VEXTRACTI128 $1, Y4, X0
+    VPADDD X0, X4, X4
+    VPSHUFD $0x4E, X4, X0
+    VPADDD X0, X4, X4
+    VPSHUFD $0xB1, X4, X0
+    VPADDD X0, X4, X4
+    MOVQ X4, R8                // lag 0 partial sum
+    VEXTRACTI128 $1, Y5, X0
+    VPADDD X0, X5, X5
+    VPSHUFD $0x4E, X5, X0
+    VPADDD X0, X5, X5
+    VPSHUFD $0xB1, X5, X0
+    VPADDD X0, X5, X5
+    MOVQ X5, R9                // lag 1
+    VEXTRACTI128 $1, Y6, X0
+    VPADDD X0, X6, X6
+    VPSHUFD $0x4E, X6, X0
+    VPADDD X0, X6, X6
+    VPSHUFD $0xB1, X6, X0
+    VPADDD X0, X6, X6
+    MOVQ X6, R10               // lag 2
+    VEXTRACTI128 $1, Y7, X0
+    VPADDD X0, X7, X7
+    VPSHUFD $0x4E, X7, X0
+    VPADDD X0, X7, X7
+    VPSHUFD $0xB1, X7, X0
+    VPADDD X0, X7, X7
+    MOVQ X7, R11               // lag 3
+
+xcorr4_512bw_scalar:
+    MOVWLSX (SI), AX           // x[j], sign-extended
+    MOVWLSX (BX), DX
+    IMULL AX, DX
+    ADDL DX, R8
+    MOVWLSX 2(BX), DX
+    IMULL AX, DX
+    ADDL DX, R9
+    MOVWLSX 4(BX), DX
+    IMULL AX, DX
+    ADDL DX, R10
+    MOVWLSX 6(BX), DX
+    IMULL AX, DX
+    ADDL DX, R11
+    ADDQ $2, SI
+    ADDQ $2, BX
+    DECQ CX
+    JNZ  xcorr4_512bw_scalar
+
+    MOVL R8, 0(DI)
+    MOVL R9, 4(DI)
+    MOVL R10, 8(DI)
+    MOVL R11, 12(DI)
+    VZEROUPPER
+    RET
+
+// func xcorr4AVX512VNNI(dst []int32, x, y []int16)
+// xcorr4AVX512BW with the loop fused into one VPDPWSSD per lag.
+TEXT ·xcorr4AVX512VNNI(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DI
+    MOVQ x_base+24(FP), SI
+    MOVQ x_len+32(FP), CX
+    MOVQ y_base+48(FP), BX
+    MOVQ y_len+56(FP), DX
+
+    VPXOR Y4, Y4, Y4           // lag 0 accumulator (VEX zeroes Z4[511:256])
+    VPXOR Y5, Y5, Y5           // lag 1
+    VPXOR Y6, Y6, Y6           // lag 2
+    VPXOR Y7, Y7, Y7           // lag 3
+
+    SUBQ $3, DX                // DX = len(y) - 3
+    JLE  xcorrvnni512_empty
+    CMPQ DX, CX
+    CMOVQLT DX, CX             // CX = n = min(len(x), len(y)-3)
+    JMP  xcorrvnni512_blocks
+
+xcorrvnni512_empty:
+    XORQ CX, CX
+
+xcorrvnni512_blocks:
+    TESTQ $8, CX               // n % 16 >= 8?
+    JZ   xcorrvnni512_block4
+    VMOVDQU (SI), X0           // x[0..8), reused by all four lags
+    VMOVDQU (BX), X1           // lag 0
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X4, X4          // Z4[511:128] is still zero
+    VMOVDQU 2(BX), X1          // lag 1
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X5, X5
+    VMOVDQU 4(BX), X1          // lag 2
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X6, X6
+    VMOVDQU 6(BX), X1          // lag 3
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X7, X7
+    ADDQ $16, SI
+    ADDQ $16, BX
+
+xcorrvnni512_block4:
+    TESTQ $4, CX               // n % 8 >= 4?
+    JZ   xcorrvnni512_block16
+    VMOVQ (SI), X0             // x[0..4) in the low 64 bits (upper zeroed)
+    VMOVQ (BX), X1             // lag 0
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X4, X4
+    VMOVQ 2(BX), X1            // lag 1
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X5, X5
+    VMOVQ 4(BX), X1            // lag 2
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X6, X6
+    VMOVQ 6(BX), X1            // lag 3
+    VPMADDWD X0, X1, X1
+    VPADDD X1, X7, X7
+    ADDQ $8, SI
+    ADDQ $8, BX
+
+xcorrvnni512_block16:
+    TESTQ $16, CX              // n % 32 >= 16?
+    JZ   xcorrvnni512_blocks32
+    VMOVDQU (SI), Y0           // x[0..16), reused by all four lags
+    VMOVDQU (BX), Y1           // lag 0
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y4, Y4          // Z4[511:256] is still zero
+    VMOVDQU 2(BX), Y1          // lag 1
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y5, Y5
+    VMOVDQU 4(BX), Y1          // lag 2
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y6, Y6
+    VMOVDQU 6(BX), Y1          // lag 3
+    VPMADDWD Y0, Y1, Y1
+    VPADDD Y1, Y7, Y7
+    ADDQ $32, SI
+    ADDQ $32, BX
+
+xcorrvnni512_blocks32:
+    MOVQ CX, AX
+    SHRQ $5, AX                // AX = n / 32, the 32-wide block count
+    JZ   xcorrvnni512_fold512
+
+xcorrvnni512_loop32:
+    VMOVDQU64 (SI), Z0         // x[j..j+32), reused by all four lags
+    VPDPWSSD (BX), Z0, Z4      // lag 0: Z4 += madd(x, y[j..]), wrapping
+    VPDPWSSD 2(BX), Z0, Z5     // lag 1
+    VPDPWSSD 4(BX), Z0, Z6     // lag 2
+    VPDPWSSD 6(BX), Z0, Z7     // lag 3
+    ADDQ $64, SI
+    ADDQ $64, BX
+    DECQ AX
+    JNZ  xcorrvnni512_loop32
+
+    // Fold each lag's upper 256 bits into its lower; from here on the
+    // accumulators are YMM and xcorr4AVX2's dual-path fold finishes.
+xcorrvnni512_fold512:
+    VEXTRACTI64X4 $1, Z4, Y0
+    VPADDD Y0, Y4, Y4
+    VEXTRACTI64X4 $1, Z5, Y0
+    VPADDD Y0, Y5, Y5
+    VEXTRACTI64X4 $1, Z6, Y0
+    VPADDD Y0, Y6, Y6
+    VEXTRACTI64X4 $1, Z7, Y0
+    VPADDD Y0, Y7, Y7
+
+    ANDQ $3, CX                // $3: the 16-, 8- and 4-wide blocks took the other bits
+    JNZ  xcorrvnni512_fold_tail
+    VEXTRACTI128 $1, Y4, X0
+    VPADDD X0, X4, X4          // X4 = four int32 partials for lag 0
+    VEXTRACTI128 $1, Y5, X0
+    VPADDD X0, X5, X5          // X5 = lag 1 partials
+    VEXTRACTI128 $1, Y6, X0
+    VPADDD X0, X6, X6          // X6 = lag 2 partials
+    VEXTRACTI128 $1, Y7, X0
+    VPADDD X0, X7, X7          // X7 = lag 3 partials
+    VPHADDD X5, X4, X4         // X4 = [lag0(0+1), lag0(2+3), lag1(0+1), lag1(2+3)]
+    VPHADDD X7, X6, X6         // X6 = [lag2(0+1), lag2(2+3), lag3(0+1), lag3(2+3)]
+    VPHADDD X6, X4, X4         // X4 = [sumLag0, sumLag1, sumLag2, sumLag3]
+    VMOVDQU X4, (DI)           // no scalar tail: store all four lag sums at once
+    VZEROUPPER
+    RET
+
+xcorrvnni512_fold_tail:
+    VEXTRACTI128 $1, Y4, X0
+    VPADDD X0, X4, X4
+    VPSHUFD $0x4E, X4, X0
+    VPADDD X0, X4, X4
+    VPSHUFD $0xB1, X4, X0
+    VPADDD X0, X4, X4
+    MOVQ X4, R8                // lag 0 partial sum
+    VEXTRACTI128 $1, Y5, X0
+    VPADDD X0, X5, X5
+    VPSHUFD $0x4E, X5, X0
+    VPADDD X0, X5, X5
+    VPSHUFD $0xB1, X5, X0
+    VPADDD X0, X5, X5
+    MOVQ X5, R9                // lag 1
+    VEXTRACTI128 $1, Y6, X0
+    VPADDD X0, X6, X6
+    VPSHUFD $0x4E, X6, X0
+    VPADDD X0, X6, X6
+    VPSHUFD $0xB1, X6, X0
+    VPADDD X0, X6, X6
+    MOVQ X6, R10               // lag 2
+    VEXTRACTI128 $1, Y7, X0
+    VPADDD X0, X7, X7
+    VPSHUFD $0x4E, X7, X0
+    VPADDD X0, X7, X7
+    VPSHUFD $0xB1, X7, X0
+    VPADDD X0, X7, X7
+    MOVQ X7, R11               // lag 3
+
+xcorrvnni512_scalar:
+    MOVWLSX (SI), AX           // x[j], sign-extended
+    MOVWLSX (BX), DX
+    IMULL AX, DX
+    ADDL DX, R8
+    MOVWLSX 2(BX), DX
+    IMULL AX, DX
+    ADDL DX, R9
+    MOVWLSX 4(BX), DX
+    IMULL AX, DX
+    ADDL DX, R10
+    MOVWLSX 6(BX), DX
+    IMULL AX, DX
+    ADDL DX, R11
+    ADDQ $2, SI
+    ADDQ $2, BX
+    DECQ CX
+    JNZ  xcorrvnni512_scalar
+
+    MOVL R8, 0(DI)
+    MOVL R9, 4(DI)
+    MOVL R10, 8(DI)
+    MOVL R11, 12(DI)
+    VZEROUPPER
+    RET
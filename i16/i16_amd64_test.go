@@ -168,6 +168,8 @@ type dotKernel struct {
 
 func dotKernels() []dotKernel {
 	return []dotKernel{
+		{"AVX512VNNI", cpu.X86.AVX512VNNI, dotAVX512VNNI},
+		{"AVX512BW", cpu.X86.AVX512BW, dotAVX512BW},
 		{"AVX2", cpu.X86.AVX2, dotAVX2},
 		{"SSE2", cpu.X86.SSE2, dotSSE2},
 	}
@@ -223,7 +225,7 @@ func TestDotAMD64_MinInt16(t *testing.T) {
 // that is zeroed, which multiplies to 0 and leaves every sum correct. Non-zero
 // bytes after short are what make over-consumption observable rather than a
 // coin flip on heap layout, and the slack has to cover a whole block of the
-// widest kernel here (16 elements, dotAVX2's body). Same reasoning as
+// widest kernel here (32 elements, the AVX-512 bodies). Same reasoning as
 // TestXCorr4AMD64_LongWindowIsClamped; without it this test detects nothing on
 // amd64 at any n.
 //
@@ -285,6 +287,8 @@ type xcorr4Kernel struct {
 
 func xcorr4Kernels() []xcorr4Kernel {
 	return []xcorr4Kernel{
+		{"AVX512VNNI", cpu.X86.AVX512VNNI, xcorr4AVX512VNNI},
+		{"AVX512BW", cpu.X86.AVX512BW, xcorr4AVX512BW},
 		{"AVXVNNI", cpu.X86.AVXVNNI, xcorr4AVXVNNI},
 		{"AVX2", cpu.X86.AVX2, xcorr4AVX2},
 		{"SSE2", cpu.X86.SSE2, xcorr4SSE2},
@@ -428,6 +432,14 @@ func TestXCorrDispatch_ReachesSIMD(t *testing.T) {
 	if hasAVXVNNI != cpu.X86.AVXVNNI {
 		t.Fatalf("hasAVXVNNI = %v but cpu.X86.AVXVNNI = %v: dispatch flag is not wired to CPU detection", hasAVXVNNI, cpu.X86.AVXVNNI)
 	}
+	if hasAVX512BW != cpu.X86.AVX512BW || hasAVX512VNNI != cpu.X86.AVX512VNNI {
+		t.Fatalf("hasAVX512BW/hasAVX512VNNI = %v/%v but cpu.X86 has %v/%v: dispatch flags are not wired to CPU detection",
+			hasAVX512BW, hasAVX512VNNI, cpu.X86.AVX512BW, cpu.X86.AVX512VNNI)
+	}
+	// The AVX-512 VNNI kernels also run AVX-512BW's VPMADDWD blocks.
+	if hasAVX512VNNI && !hasAVX512BW {
+		t.Fatal("hasAVX512VNNI is true but hasAVX512BW is false: the VNNI tier would dispatch without the byte/word ops its blocks use")
+	}
 	// AVX-VNNI sits above AVX2 in the switch, so it can never be selected while
 	// AVX2 is absent: the VEX form runs on YMM state that AVX2 detection gates on.
 	if hasAVXVNNI && !hasAVX2 {
@@ -32,6 +32,12 @@ const minNEONDot = 8 // the kernel's 8-wide block, below its 16-wide body
 
 var hasNEON = cpu.ARM64.NEON
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+}
+
 func interleave2I16(dst, a, b []int16) {
 	if hasNEON && len(a) >= minNEONElements {
 		interleave2NEON(dst, a, b)
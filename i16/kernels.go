@@ -0,0 +1,22 @@
+package i16
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":              absGo,
+	"Deinterleave2":    deinterleave2Go,
+	"DotProduct":       dotGo,
+	"DotProductUnsafe": dotGo,
+	"Interleave2":      interleave2Go,
+	"MaxAbs":           maxAbsGo,
+	"MulQ15":           mulQ15Go,
+	"XCorr":            xcorrGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "i16", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
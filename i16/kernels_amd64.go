@@ -0,0 +1,70 @@
+//go:build amd64
+
+package i16
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelBinding reports the kernel op is bound to, from the same flags its
+// dispatcher reads: AVX-512 VNNI > AVX-512BW > AVX-VNNI > AVX2 > SSE2 for XCorr,
+// AVX-512 VNNI > AVX-512BW > AVX2 > SSE2 for the dot product, AVX2 > SSE2 for
+// the interleave pair, AVX2 for the element-wise ops.
+func kernelBinding(op string) dispatch.Binding {
+	switch op {
+	case "XCorr":
+		if hasAVX512VNNI {
+			return dispatch.Bind(dispatch.AVX512VNNI, xcorr4AVX512VNNI)
+		}
+		if hasAVX512BW {
+			return dispatch.Bind(dispatch.AVX512BW, xcorr4AVX512BW)
+		}
+		if hasAVXVNNI {
+			return dispatch.Bind(dispatch.AVXVNNI, xcorr4AVXVNNI)
+		}
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, xcorr4AVX2)
+		}
+		if hasSSE2 {
+			return dispatch.Bind(dispatch.SSE2, xcorr4SSE2)
+		}
+	case "DotProduct", "DotProductUnsafe":
+		if hasAVX512VNNI {
+			return dispatch.Bind(dispatch.AVX512VNNI, dotAVX512VNNI)
+		}
+		if hasAVX512BW {
+			return dispatch.Bind(dispatch.AVX512BW, dotAVX512BW)
+		}
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, dotAVX2)
+		}
+		if hasSSE2 {
+			return dispatch.Bind(dispatch.SSE2, dotSSE2)
+		}
+	case "Interleave2":
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, interleave2AVX2)
+		}
+		if hasSSE2 {
+			return dispatch.Bind(dispatch.SSE2, interleave2SSE2)
+		}
+	case "Deinterleave2":
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, deinterleave2AVX2)
+		}
+		if hasSSE2 {
+			return dispatch.Bind(dispatch.SSE2, deinterleave2SSE2)
+		}
+	case "MulQ15":
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, mulQ15AVX2)
+		}
+	case "Abs":
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, absAVX2)
+		}
+	case "MaxAbs":
+		if hasAVX2 {
+			return dispatch.Bind(dispatch.AVX2, maxAbsAVX2)
+		}
+	}
+	return dispatch.Binding{}
+}
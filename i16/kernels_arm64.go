@@ -0,0 +1,24 @@
+//go:build arm64
+
+package i16
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation to its NEON kernel.
+var neonKernels = map[string]any{
+	"Interleave2":      interleave2NEON,
+	"Deinterleave2":    deinterleave2NEON,
+	"XCorr":            xcorr4NEON,
+	"DotProduct":       dotNEON,
+	"DotProductUnsafe": dotNEON,
+	"MulQ15":           mulQ15NEON,
+	"Abs":              absNEON,
+	"MaxAbs":           maxAbsNEON,
+}
+
+// kernelBinding reports the kernel op is bound to: its NEON kernel when the
+// host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
@@ -131,7 +131,86 @@ func TestFIRValidQ15Dispatch_ReachesSIMD(t *testing.T) {
 	if hasAVX2 != cpu.X86.AVX2 {
 		t.Fatalf("hasAVX2 = %v but cpu.X86.AVX2 = %v: dispatch flag is not wired to CPU detection", hasAVX2, cpu.X86.AVX2)
 	}
+	if want := cpu.X86.AVX512F && cpu.X86.AVX512VL; hasAVX512 != want {
+		t.Fatalf("hasAVX512 = %v but cpu.X86 AVX512F&&AVX512VL = %v: dispatch flag is not wired to CPU detection", hasAVX512, want)
+	}
 	if minAVX2FIR > 16 {
 		t.Fatalf("minAVX2FIR = %d exceeds two vector blocks: FIRValidQ15 would not vectorize at the lengths it was written for", minAVX2FIR)
 	}
 }
+
+// TestFIRValidQ15AVX512_ParityWithGo drives the AVX-512 kernel directly over
+// output lengths that reach the 16-wide body, the 8-wide block and every
+// scalar-output remainder, with the same planted extremes as the AVX2 test.
+func TestFIRValidQ15AVX512_ParityWithGo(t *testing.T) {
+	if !cpu.X86.AVX512F || !cpu.X86.AVX512VL {
+		t.Skip("AVX-512 not available")
+	}
+	for _, kl := range []int{1, 2, 3, 5, 8, 16, 33} {
+		taps := genI16(kl, uint32(kl)*19+5)
+		taps[0] = math.MinInt16
+		taps[kl-1] = math.MaxInt16
+		for outLen := 1; outLen <= 72; outLen++ {
+			xl := outLen + kl - 1
+			x := genI32(xl, uint32(xl)*11+uint32(kl))
+			x[0] = math.MinInt32
+			x[xl-1] = math.MaxInt32
+			got := make([]int32, outLen)
+			want := make([]int32, outLen)
+			firValidQ15AVX512(got, x, taps)
+			firValidQ15Go(want, x, taps)
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("firValidQ15AVX512 kl=%d outLen=%d: dst[%d] = %d, want %d", kl, outLen, i, got[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+// TestFIRValidQ15AVX512_Bounds is the AVX-512 counterpart of the AVX2 over-read
+// and overwrite checks: x is poisoned past len(x) and dst carries a sentinel
+// past n, each over one 16-output block of slack.
+func TestFIRValidQ15AVX512_Bounds(t *testing.T) {
+	if !cpu.X86.AVX512F || !cpu.X86.AVX512VL {
+		t.Skip("AVX-512 not available")
+	}
+	const (
+		poison   = int32(0x55555555)
+		sentinel = int32(math.MaxInt32)
+		slack    = 16
+	)
+	for _, kl := range []int{1, 5, 8} {
+		taps := make([]int16, kl)
+		for i := range taps {
+			taps[i] = 0x4000
+		}
+		for _, outLen := range []int{16, 17, 23, 24, 31, 40, 47} {
+			xl := outLen + kl - 1
+			backing := make([]int32, xl+slack)
+			for i := range backing {
+				backing[i] = poison
+				if i < xl {
+					backing[i] = int32(i%5 - 2)
+				}
+			}
+			dst := make([]int32, outLen+slack)
+			for i := range dst {
+				dst[i] = sentinel
+			}
+			want := make([]int32, outLen)
+			firValidQ15AVX512(dst[:outLen], backing[:xl], taps)
+			firValidQ15Go(want, backing[:xl], taps)
+			for i := range want {
+				if dst[i] != want[i] {
+					t.Fatalf("firValidQ15AVX512 kl=%d outLen=%d: dst[%d] = %d, want %d: kernel read x past len", kl, outLen, i, dst[i], want[i])
+				}
+			}
+			for i := outLen; i < len(dst); i++ {
+				if dst[i] != sentinel {
+					t.Fatalf("firValidQ15AVX512 kl=%d outLen=%d wrote past end at dst[%d] = %d", kl, outLen, i, dst[i])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package i32
+
+import "errors"
+
+// ErrFIRTaps is returned by NewFIRFilterQ15 for an empty tap set.
+var ErrFIRTaps = errors.New("i32: FIR filter needs at least one tap")
+
+// firChunk is the number of new samples the delay line takes per FIRValidQ15
+// pass; longer blocks are filtered in chunks of this size.
+const firChunk = 1024
+
+// FIRFilterQ15 is the streaming form of FIRValidQ15: it owns a delay line of the
+// last len(taps)-1 input samples, so consecutive Process calls continue one
+// signal and emit exactly one output per input sample:
+//
+//	y[n] = sum over j in [0, len(taps)) of int32(int64(taps[j]) * int64(x[n-j]) >> 15)
+//
+// with x[n] = 0 before the first sample. Unlike FIRValidQ15's correlation
+// orientation, taps[0] weights the newest sample (a causal convolution); the
+// filter runs the FIRValidQ15 kernels on the reversed taps, so every product is
+// Q15-truncated and the accumulator wraps in int32 exactly as there, and the
+// output is bit-identical on every backend and for every split of the input into
+// blocks.
+//
+// A filter holds its delay line, so its methods are NOT safe for concurrent use
+// on the same filter; use one per stream.
+type FIRFilterQ15 struct {
+	rev   []int16 // taps reversed, the FIRValidQ15 kernel
+	line  []int32 // len(taps)-1 history samples, then up to chunk new ones
+	chunk int
+}
+
+// NewFIRFilterQ15 builds a streaming Q15 filter over a copy of taps with a zeroed
+// delay line. It returns ErrFIRTaps when taps is empty.
+func NewFIRFilterQ15(taps []int16) (*FIRFilterQ15, error) {
+	if len(taps) == 0 {
+		return nil, ErrFIRTaps
+	}
+	f := &FIRFilterQ15{
+		rev:   make([]int16, len(taps)),
+		chunk: max(firChunk, len(taps)),
+	}
+	for i, v := range taps {
+		f.rev[len(taps)-1-i] = v
+	}
+	f.line = make([]int32, len(taps)-1+f.chunk)
+	return f, nil
+}
+
+// Len returns the number of taps.
+func (f *FIRFilterQ15) Len() int { return len(f.rev) }
+
+// Reset clears the delay line, so the next Process starts from silence.
+func (f *FIRFilterQ15) Reset() { clear(f.line) }
+
+// Process filters x into dst, one output per input sample, and returns the
+// number of samples processed, min(len(dst), len(x)); the delay line then holds
+// the last len(taps)-1 of them. Each block of x is copied into the delay line
+// before any output is written, so dst may alias x exactly (in-place filtering),
+// unlike FIRValidQ15; it must not otherwise overlap it. Allocation-free.
+func (f *FIRFilterQ15) Process(dst, x []int32) int {
+	n := min(len(dst), len(x))
+	h := len(f.rev) - 1
+	for done := 0; done < n; {
+		c := min(f.chunk, n-done)
+		copy(f.line[h:h+c], x[done:done+c])
+		firValidQ15I32(dst[done:done+c], f.line[:h+c], f.rev)
+		copy(f.line[:h], f.line[c:c+h])
+		done += c
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package i32
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// firFilterQ15Oracle is the causal convolution written directly: per-product
+// truncating Q15 shift, wrapping int32 accumulate, zero history.
+func firFilterQ15Oracle(x []int32, taps []int16) []int32 {
+	y := make([]int32, len(x))
+	for n := range x {
+		var acc int32
+		for j := range taps {
+			if n >= j {
+				acc += int32(int64(taps[j]) * int64(x[n-j]) >> 15)
+			}
+		}
+		y[n] = acc
+	}
+	return y
+}
+
+func TestNewFIRFilterQ15Errors(t *testing.T) {
+	if _, err := NewFIRFilterQ15(nil); !errors.Is(err, ErrFIRTaps) {
+		t.Fatalf("NewFIRFilterQ15(nil) error = %v, want ErrFIRTaps", err)
+	}
+	f, err := NewFIRFilterQ15(make([]int16, 9))
+	if err != nil || f.Len() != 9 {
+		t.Fatalf("NewFIRFilterQ15(9 taps) = %v, %v", f, err)
+	}
+}
+
+// TestFIRFilterQ15Streaming checks Process bit for bit against the oracle over
+// the full int32 range (where the accumulator wraps), for uneven block splits
+// including empty blocks and blocks longer than the internal chunk, and that
+// in-place filtering after Reset matches.
+func TestFIRFilterQ15Streaming(t *testing.T) {
+	for _, nTaps := range []int{1, 2, 5, 24, 1100} {
+		taps := genI16(nTaps, uint32(nTaps))
+		x := genI32(4000, 7)
+		want := firFilterQ15Oracle(x, taps)
+		f, _ := NewFIRFilterQ15(taps)
+		got := make([]int32, len(x))
+		for pos, step := 0, 0; pos < len(x); step = step*2 + 1 {
+			end := min(len(x), pos+step)
+			if n := f.Process(got[pos:end], x[pos:end]); n != end-pos {
+				t.Fatalf("taps=%d: Process returned %d, want %d", nTaps, n, end-pos)
+			}
+			pos = end
+		}
+		for i := range x {
+			if got[i] != want[i] {
+				t.Fatalf("taps=%d: y[%d] = %d want %d", nTaps, i, got[i], want[i])
+			}
+		}
+
+		f.Reset()
+		inPlace := append([]int32(nil), x...)
+		f.Process(inPlace[:1500], inPlace[:1500])
+		f.Process(inPlace[1500:], inPlace[1500:])
+		for i := range x {
+			if inPlace[i] != want[i] {
+				t.Fatalf("taps=%d: in-place y[%d] = %d, want %d", nTaps, i, inPlace[i], want[i])
+			}
+		}
+	}
+}
+
+// TestFIRFilterQ15Clamp checks that only min(len(dst), len(x)) samples are
+// processed and that the history carries into the next call.
+func TestFIRFilterQ15Clamp(t *testing.T) {
+	f, _ := NewFIRFilterQ15([]int16{1 << 14, 1 << 13}) // 0.5, 0.25
+	dst := []int32{9, 9, 9}
+	if n := f.Process(dst, []int32{400, 800}); n != 2 || dst[0] != 200 || dst[1] != 500 || dst[2] != 9 {
+		t.Fatalf("Process = %d, dst %v", n, dst)
+	}
+	if n := f.Process(dst[:1], []int32{0, 4, 4}); n != 1 || dst[0] != 200 {
+		t.Fatalf("Process = %d, dst %v (history not carried)", n, dst)
+	}
+}
+
+func TestFIRFilterQ15AllocFree(t *testing.T) {
+	f, _ := NewFIRFilterQ15(genI16(31, 1))
+	buf := genI32(4096, 2)
+	if a := testing.AllocsPerRun(5, func() { f.Process(buf, buf) }); a != 0 {
+		t.Errorf("Process allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkFIRFilterQ15(b *testing.B) {
+	for _, nTaps := range []int{16, 64} {
+		b.Run(fmt.Sprintf("taps=%d", nTaps), func(b *testing.B) {
+			f, _ := NewFIRFilterQ15(genI16(nTaps, 1))
+			buf := genI32(480, 2)
+			b.SetBytes(int64(4 * len(buf)))
+			b.ReportAllocs()
+			for b.Loop() {
+				f.Process(buf, buf)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package i32
+
+// fixedMaxOrder is FLAC__MAX_FIXED_ORDER, the highest fixed predictor order; it
+// is also the number of warm-up samples FixedAbsSums reads before its first
+// predicted sample.
+const fixedMaxOrder = 4
+
+// riceMaxPartitionOrder is FLAC__MAX_RICE_PARTITION_ORDER.
+const riceMaxPartitionOrder = 15
+
+// FixedResidual computes the residual of FLAC's fixed polynomial predictor of
+// the given order (0..4), libFLAC's FLAC__fixed_compute_residual:
+//
+//	order 0: residual = x[i]
+//	order 1: residual = x[i] - x[i-1]
+//	order 2: residual = x[i] - 2*x[i-1] + x[i-2]
+//	order 3: residual = x[i] - 3*x[i-1] + 3*x[i-2] - x[i-3]
+//	order 4: residual = x[i] - 4*x[i-1] + 6*x[i-2] - 4*x[i-3] + x[i-4]
+//
+// written to residual[i-order] for i in [order, len(data)); the first order
+// samples are the warm-up. The arithmetic wraps in int32, which is the exact
+// residual whenever it fits (libFLAC's _wide variant truncates the same way).
+//
+// It returns the number of residuals written, len(data)-order. It is a no-op
+// returning 0 when order is outside 0..4, len(data) <= order, or residual is
+// shorter than len(data)-order. residual must not overlap data.
+func FixedResidual(residual, data []int32, order int) int {
+	n := len(data) - order
+	if order < 0 || order > fixedMaxOrder || n <= 0 || len(residual) < n {
+		return 0
+	}
+	if order == 0 {
+		copy(residual, data)
+		return n
+	}
+	fixedResidualI32(residual[:n], data, order)
+	return n
+}
+
+// FixedAbsSums returns, for each fixed predictor order 0..4, the sum of the
+// absolute residuals over data[4:], the totals libFLAC's
+// FLAC__fixed_compute_best_predictor compares: every order is scored over the
+// same samples, with data[:4] as the warm-up. The residuals are exact (int64, so
+// order 4 of full-range int32 data cannot overflow) and the totals are uint64,
+// matching libFLAC's 32-bit, _wide and _limit_residual variants wherever each
+// applies. BestFixedOrder picks the order from the totals.
+//
+// It returns all zeros when len(data) <= 4. Allocation-free.
+func FixedAbsSums(data []int32) (sums [fixedMaxOrder + 1]uint64) {
+	if len(data) <= fixedMaxOrder {
+		return sums
+	}
+	fixedAbsSumsI32(&sums, data)
+	return sums
+}
+
+// BestFixedOrder returns the fixed predictor order with the smallest
+// FixedAbsSums total, preferring the lower order on ties, as libFLAC does.
+func BestFixedOrder(sums [fixedMaxOrder + 1]uint64) int {
+	best := 0
+	for order := 1; order <= fixedMaxOrder; order++ {
+		if sums[order] < sums[best] {
+			best = order
+		}
+	}
+	return best
+}
+
+// PartitionAbsSums fills sums with the per-partition sums of absolute residuals
+// that FLAC's Rice parameter search works from, libFLAC's
+// precompute_partition_info_sums_: for a block of len(residual)+predictorOrder
+// samples split into 1<<maxPartitionOrder partitions, the first partition holds
+// predictorOrder fewer residuals (the warm-up), and every lower partition order
+// down to minPartitionOrder is the pairwise sum of the one above. The table is
+// laid out as libFLAC's: the 1<<maxPartitionOrder sums of the highest order
+// first, then each lower order in turn, so partition order o starts at
+//
+//	(1 << (maxPartitionOrder + 1)) - (1 << (o + 1))
+//
+// Each |residual| is exact (|MinInt32| is 2^31) and the sums are uint64.
+//
+// It returns the number of sums written, (1 << (maxPartitionOrder + 1)) -
+// (1 << minPartitionOrder). It is a no-op returning 0 unless 0 <= minPartitionOrder
+// <= maxPartitionOrder <= 15, the block length is a multiple of
+// 1<<maxPartitionOrder whose partitions hold at least predictorOrder samples, and
+// sums has room for the table. Allocation-free.
+func PartitionAbsSums(sums []uint64, residual []int32, predictorOrder, minPartitionOrder, maxPartitionOrder int) int {
+	if minPartitionOrder < 0 || minPartitionOrder > maxPartitionOrder || maxPartitionOrder > riceMaxPartitionOrder || predictorOrder < 0 {
+		return 0
+	}
+	blockLen := len(residual) + predictorOrder
+	partitions := 1 << maxPartitionOrder
+	partLen := blockLen >> maxPartitionOrder
+	total := partitions<<1 - 1<<minPartitionOrder
+	if partLen == 0 || partLen<<maxPartitionOrder != blockLen || partLen < predictorOrder || len(sums) < total {
+		return 0
+	}
+
+	first := partLen - predictorOrder
+	partitionAbsSumsI32(sums[:1], residual[:first], first)
+	if partitions > 1 {
+		partitionAbsSumsI32(sums[1:partitions], residual[first:], partLen)
+	}
+
+	// Merge pairs for each lower order.
+	from, to := 0, partitions
+	for p := partitions >> 1; p >= 1<<minPartitionOrder; p >>= 1 {
+		for range p {
+			sums[to] = sums[from] + sums[from+1]
+			from += 2
+			to++
+		}
+	}
+	return total
+}
@@ -0,0 +1,62 @@
+//go:build amd64
+
+package i32
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+)
+
+// TestFixedKernelsAVX2_ParityWithGo drives the three fixed-predictor kernels
+// directly against their Go references over whole-block lengths, with the int32
+// extremes alternating so every order's residual reaches its largest magnitude.
+func TestFixedKernelsAVX2_ParityWithGo(t *testing.T) {
+	if !cpu.X86.AVX2 {
+		t.Skip("AVX2 not available")
+	}
+	for _, blocks := range []int{1, 2, 3, 8, 33} {
+		for order := 1; order <= 4; order++ {
+			n := blocks * fixedResidualBlock
+			data := genI32(n+order, uint32(n+order))
+			data[0], data[1] = math.MinInt32, math.MaxInt32
+			got, want := make([]int32, n), make([]int32, n)
+			fixedResidualAVX2(got, data, order)
+			fixedResidualGo(want, data, order)
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("fixedResidualAVX2 order %d n=%d: residual[%d] = %d, want %d", order, n, i, got[i], want[i])
+				}
+			}
+		}
+
+		data := genI32(4+blocks*fixedAbsSumsBlock, uint32(blocks))
+		for i := range min(len(data), 9) {
+			data[i] = math.MinInt32
+			if i&1 != 0 {
+				data[i] = math.MaxInt32
+			}
+		}
+		var got, want [5]uint64
+		got[3], want[3] = 7, 7 // the kernel adds to sums
+		fixedAbsSumsAVX2(&got, data)
+		fixedAbsSumsGo(&want, data)
+		if got != want {
+			t.Fatalf("fixedAbsSumsAVX2 blocks=%d: %v, want %v", blocks, got, want)
+		}
+	}
+
+	for _, partLen := range []int{8, 9, 15, 16, 17, 72} {
+		residual := genI32(5*partLen, uint32(partLen))
+		residual[partLen-1] = math.MinInt32
+		got, want := make([]uint64, 5), make([]uint64, 5)
+		partitionAbsSumsAVX2(got, residual, partLen)
+		partitionAbsSumsGo(want, residual, partLen)
+		for p := range got {
+			if got[p] != want[p] {
+				t.Fatalf("partitionAbsSumsAVX2 partLen=%d: sums[%d] = %d, want %d", partLen, p, got[p], want[p])
+			}
+		}
+	}
+}
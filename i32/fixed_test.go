@@ -0,0 +1,239 @@
+package i32
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// fixedResidualExact is the order-k fixed residual of data[i] written from the
+// binomial coefficients in int64, without the running differences.
+func fixedResidualExact(data []int32, i, order int) int64 {
+	coef := [5][5]int64{{1}, {1, -1}, {1, -2, 1}, {1, -3, 3, -1}, {1, -4, 6, -4, 1}}
+	var r int64
+	for k := 0; k <= order; k++ {
+		r += coef[order][k] * int64(data[i-k])
+	}
+	return r
+}
+
+// fixedData is full-range data with the int32 extremes in a run, where the
+// order-4 residual needs 36 bits.
+func fixedData(n int, seed uint32) []int32 {
+	x := genI32(n, seed)
+	for i := 5; i+4 < n; i += 97 {
+		x[i], x[i+1], x[i+2], x[i+3] = math.MaxInt32, math.MinInt32, math.MaxInt32, math.MinInt32
+	}
+	return x
+}
+
+// TestFixedResidual checks every order against the binomial form, truncated to
+// int32, at lengths that cover the vector body and every tail remainder.
+func TestFixedResidual(t *testing.T) {
+	for _, n := range []int{5, 8, 12, 19, 20, 21, 27, 64, 1001} {
+		data := fixedData(n, uint32(n))
+		for order := range 5 {
+			res := make([]int32, n)
+			if got := FixedResidual(res, data, order); got != n-order {
+				t.Fatalf("n=%d order %d: returned %d, want %d", n, order, got, n-order)
+			}
+			for i := order; i < n; i++ {
+				if want := int32(fixedResidualExact(data, i, order)); res[i-order] != want {
+					t.Fatalf("n=%d order %d: residual[%d] = %d, want %d", n, order, i-order, res[i-order], want)
+				}
+			}
+			if order > 0 && res[n-order] != 0 {
+				t.Fatalf("n=%d order %d: wrote past the residual", n, order)
+			}
+		}
+	}
+}
+
+// TestFixedAbsSums checks the totals against the exact residuals summed in
+// uint64, over full-range data where the order-4 residual overflows int32, at
+// lengths that cover the vector body and every tail remainder.
+func TestFixedAbsSums(t *testing.T) {
+	for _, n := range []int{5, 6, 11, 12, 13, 14, 15, 36, 1003, 4100} {
+		data := fixedData(n, uint32(n)+9)
+		var want [5]uint64
+		for i := 4; i < n; i++ {
+			for order := range 5 {
+				r := fixedResidualExact(data, i, order)
+				want[order] += uint64(max(r, -r))
+			}
+		}
+		if got := FixedAbsSums(data); got != want {
+			t.Fatalf("n=%d: FixedAbsSums = %v, want %v", n, got, want)
+		}
+	}
+	if got := FixedAbsSums(make([]int32, 4)); got != [5]uint64{} {
+		t.Fatalf("warm-up only: %v", got)
+	}
+}
+
+// TestBestFixedOrder checks the choice on smooth signals and libFLAC's
+// preference for the lower order on ties.
+func TestBestFixedOrder(t *testing.T) {
+	ramp := make([]int32, 64)
+	quad := make([]int32, 64)
+	for i := range ramp {
+		ramp[i] = int32(5*i + 3)
+		quad[i] = int32(i*i - 7*i)
+	}
+	if got := BestFixedOrder(FixedAbsSums(ramp)); got != 2 {
+		t.Errorf("ramp: order %d, want 2 (orders 2..4 all zero)", got)
+	}
+	if got := BestFixedOrder(FixedAbsSums(quad)); got != 3 {
+		t.Errorf("quadratic: order %d, want 3", got)
+	}
+	if got := BestFixedOrder(FixedAbsSums(make([]int32, 64))); got != 0 {
+		t.Errorf("silence: order %d, want 0", got)
+	}
+	if got := BestFixedOrder([5]uint64{9, 7, 8, 7, 7}); got != 1 {
+		t.Errorf("tie: order %d, want 1", got)
+	}
+}
+
+// partitionAbsSumsOracle is libFLAC's precompute_partition_info_sums_ as written
+// in C: sum the highest order partition by partition, then merge pairs.
+func partitionAbsSumsOracle(residual []int32, predictorOrder, minOrder, maxOrder int) []uint64 {
+	partitions := 1 << maxOrder
+	partSamples := (len(residual) + predictorOrder) >> maxOrder
+	var sums []uint64
+	sample, end := 0, -predictorOrder
+	for range partitions {
+		end += partSamples
+		var s uint64
+		for ; sample < end; sample++ {
+			s += uint64(max(int64(residual[sample]), -int64(residual[sample])))
+		}
+		sums = append(sums, s)
+	}
+	from := 0
+	for order := maxOrder - 1; order >= minOrder; order-- {
+		partitions >>= 1
+		for range partitions {
+			sums = append(sums, sums[from]+sums[from+1])
+			from += 2
+		}
+	}
+	return sums
+}
+
+func TestPartitionAbsSums(t *testing.T) {
+	for _, c := range []struct{ blockLen, predictorOrder, minOrder, maxOrder int }{
+		{4096, 0, 0, 0},
+		{4096, 4, 0, 8},
+		{4608, 2, 3, 9},  // 9 samples per partition: vector body plus a 1-sample tail
+		{4608, 32, 0, 6}, // LPC order 32, 72 samples per partition
+		{192, 3, 0, 6},   // 3 samples per partition: all warm-up in the first
+		{1152, 12, 2, 4},
+	} {
+		residual := genI32(c.blockLen-c.predictorOrder, uint32(c.blockLen+c.maxOrder))
+		residual[0] = math.MinInt32
+		residual[len(residual)-1] = math.MinInt32
+		want := partitionAbsSumsOracle(residual, c.predictorOrder, c.minOrder, c.maxOrder)
+		sums := make([]uint64, len(want)+1)
+		if n := PartitionAbsSums(sums, residual, c.predictorOrder, c.minOrder, c.maxOrder); n != len(want) {
+			t.Fatalf("%+v: returned %d, want %d", c, n, len(want))
+		}
+		for i := range want {
+			if sums[i] != want[i] {
+				t.Fatalf("%+v: sums[%d] = %d, want %d", c, i, sums[i], want[i])
+			}
+		}
+		if sums[len(want)] != 0 {
+			t.Fatalf("%+v: wrote past the table", c)
+		}
+	}
+}
+
+func TestFixedGuards(t *testing.T) {
+	data := genI32(16, 1)
+	res := make([]int32, 16)
+	for _, c := range []struct {
+		name  string
+		res   []int32
+		data  []int32
+		order int
+	}{
+		{"order -1", res, data, -1},
+		{"order 5", res, data, 5},
+		{"warm-up only", res, data[:3], 3},
+		{"short residual", res[:11], data, 4},
+	} {
+		if n := FixedResidual(c.res, c.data, c.order); n != 0 {
+			t.Errorf("%s: FixedResidual returned %d, want 0", c.name, n)
+		}
+	}
+
+	sums := make([]uint64, 64)
+	residual := make([]int32, 60)
+	for _, c := range []struct {
+		name                string
+		sums                []uint64
+		order, minPO, maxPO int
+	}{
+		{"min above max", sums, 4, 3, 2},
+		{"negative min", sums, 4, -1, 2},
+		{"max above 15", sums, 4, 0, 16},
+		{"indivisible block", sums, 6, 0, 3},
+		{"partition shorter than the warm-up", sums, 4, 0, 5},
+		{"short table", sums[:6], 4, 0, 2},
+		{"negative order", sums, -4, 0, 2},
+	} {
+		if n := PartitionAbsSums(c.sums, residual, c.order, c.minPO, c.maxPO); n != 0 {
+			t.Errorf("%s: PartitionAbsSums returned %d, want 0", c.name, n)
+		}
+	}
+}
+
+func TestFixedAllocFree(t *testing.T) {
+	data := genI32(4096, 2)
+	res := make([]int32, len(data))
+	sums := make([]uint64, 1<<9)
+	if a := testing.AllocsPerRun(5, func() {
+		FixedResidual(res, data, 2)
+		FixedAbsSums(data)
+		PartitionAbsSums(sums, res[:len(data)-2], 2, 0, 8)
+	}); a != 0 {
+		t.Errorf("allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkFixedAbsSums(b *testing.B) {
+	data := genI32(4096, 2)
+	b.SetBytes(int64(4 * len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		FixedAbsSums(data)
+	}
+}
+
+func BenchmarkFixedResidual(b *testing.B) {
+	for _, order := range []int{1, 2, 4} {
+		b.Run(fmt.Sprintf("order=%d", order), func(b *testing.B) {
+			data := genI32(4096, 2)
+			res := make([]int32, len(data))
+			b.SetBytes(int64(4 * len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				FixedResidual(res, data, order)
+			}
+		})
+	}
+}
+
+func BenchmarkPartitionAbsSums(b *testing.B) {
+	for _, maxOrder := range []int{4, 8} {
+		b.Run(fmt.Sprintf("maxOrder=%d", maxOrder), func(b *testing.B) {
+			residual := genI32(4096-2, 2)
+			sums := make([]uint64, 1<<(maxOrder+1))
+			b.SetBytes(int64(4 * len(residual)))
+			b.ReportAllocs()
+			for b.Loop() {
+				PartitionAbsSums(sums, residual, 2, 0, maxOrder)
+			}
+		})
+	}
+}
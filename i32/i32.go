@@ -2,16 +2,18 @@
 //
 // It is the integer counterpart to the f32/f64 packages, covering the
 // element-wise integer arithmetic, the signed min/max and wrapping-sum
-// reductions, and the channel (de)interleaving that integer-domain DSP hot
-// loops need where the per-sample work is integer arithmetic rather than
-// floating-point math.
+// reductions, the FLAC fixed and quantized linear-prediction residuals, and
+// the channel (de)interleaving that integer-domain DSP hot loops need where the
+// per-sample work is integer arithmetic rather than floating-point math.
 //
 // All functions automatically select the optimal implementation based on
 // runtime CPU feature detection and fall back to a pure-Go implementation on
 // unsupported architectures.
 //
-// Thread Safety: All functions are safe for concurrent use.
-// Memory: All functions are zero-allocation (no heap allocations).
+// Thread Safety: All functions are safe for concurrent use; a FIRFilterQ15 holds
+// a delay line and is one per stream.
+// Memory: All functions are zero-allocation (no heap allocations), as is
+// FIRFilterQ15.Process after construction.
 //
 // # Aliasing
 //
@@ -34,9 +36,12 @@
 // Deinterleave2 have a destination whose length differs from their inputs (twice,
 // or half). FIRValidQ15 writes a valid-convolution output shorter than its input
 // and reads a sliding window ahead of each output, so its dst must be distinct
-// from x. Butterfly rewrites its two operands in place, so lo and hi must not
-// overlap each other. The reductions (Sum, MaxAbs, MinMax) write no output slice,
-// so aliasing does not apply to them.
+// from x (FIRFilterQ15.Process copies its input into the delay line first and
+// does take dst == x). Butterfly rewrites its two operands in place, so lo and hi
+// must not overlap each other. LPCResidual, LPCRestore and FixedResidual read
+// samples behind the one they write, so their residual must be distinct from
+// data. The reductions
+// (Sum, MaxAbs, MinMax) write no output slice, so aliasing does not apply to them.
 package i32
 
 // interleave2Channels is the number of channels handled by Interleave2 and
@@ -43,6 +43,19 @@ func deinterleave2AVX(a, b, src []int32)
 // AVX2 explicitly and fall back to the pure-Go reference otherwise.
 var hasAVX2 = cpu.X86.AVX2
 
+// hasAVX512 gates the 512-bit FIRValidQ15 kernel, which needs only AVX-512F
+// instructions (VPMULDQ / VPBLENDMD / VPBROADCASTD on ZMM) but, like the f32 and
+// f64 AVX-512 tiers, is selected on the AVX512F+VL pair.
+var hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
+
+// bindKernels re-reads the feature flags cached above from cpu.X86.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasAVX = cpu.X86.AVX
+	hasAVX2 = cpu.X86.AVX2
+	hasAVX512 = cpu.X86.AVX512F && cpu.X86.AVX512VL
+}
+
 func addI32(dst, a, b []int32) {
 	if hasAVX2 && len(dst) >= minAVXElements {
 		addAVX2(dst, a, b)
@@ -237,13 +250,85 @@ func maxAbsAVX2(a []int32) int32
 // len(dst) here is already the clamped output count n from the public FIRValidQ15.
 const minAVX2FIR = 8
 
+// minAVX512FIR is one 16-wide (512-bit) output block, the AVX-512 kernel's
+// body; below it the AVX2 kernel already covers the output in one block.
+const minAVX512FIR = 16
+
 func firValidQ15I32(dst, x []int32, taps []int16) {
-	if hasAVX2 && len(dst) >= minAVX2FIR {
+	switch {
+	case hasAVX512 && len(dst) >= minAVX512FIR:
+		firValidQ15AVX512(dst, x, taps)
+	case hasAVX2 && len(dst) >= minAVX2FIR:
 		firValidQ15AVX2(dst, x, taps)
-		return
+	default:
+		firValidQ15Go(dst, x, taps)
 	}
-	firValidQ15Go(dst, x, taps)
 }
 
+//go:noescape
+func firValidQ15AVX512(dst, x []int32, taps []int16)
+
 //go:noescape
 func firValidQ15AVX2(dst, x []int32, taps []int16)
+
+// The fixed-predictor kernels cover whole blocks only and the dispatch finishes
+// the remainder with the pure-Go reference, which continues from the same
+// samples. fixedResidualAVX2 does 8 residuals per iteration in wrapping int32
+// lanes; fixedAbsSumsAVX2 widens 4 samples per iteration to int64 lanes
+// (VPMOVSXDQ) so every order's residual is exact, and accumulates the five
+// |residual| totals in uint64 lanes. Both gate on AVX2 for the 256-bit integer
+// ops and on at least two blocks, below which the call costs more than it saves.
+const (
+	fixedResidualBlock = 8
+	fixedAbsSumsBlock  = 4
+	minAVX2FixedBlocks = 2
+)
+
+func fixedResidualI32(residual, data []int32, order int) {
+	n := len(residual)
+	m := n &^ (fixedResidualBlock - 1)
+	if !hasAVX2 || m < minAVX2FixedBlocks*fixedResidualBlock {
+		fixedResidualGo(residual, data, order)
+		return
+	}
+	fixedResidualAVX2(residual[:m], data[:m+order], order)
+	if m < n {
+		fixedResidualGo(residual[m:n], data[m:n+order], order)
+	}
+}
+
+func fixedAbsSumsI32(sums *[fixedMaxOrder + 1]uint64, data []int32) {
+	m := (len(data) - fixedMaxOrder) &^ (fixedAbsSumsBlock - 1)
+	if !hasAVX2 || m < minAVX2FixedBlocks*fixedAbsSumsBlock {
+		fixedAbsSumsGo(sums, data)
+		return
+	}
+	fixedAbsSumsAVX2(sums, data[:fixedMaxOrder+m])
+	if fixedMaxOrder+m < len(data) {
+		fixedAbsSumsGo(sums, data[m:])
+	}
+}
+
+//go:noescape
+func fixedResidualAVX2(residual, data []int32, order int)
+
+//go:noescape
+func fixedAbsSumsAVX2(sums *[fixedMaxOrder + 1]uint64, data []int32)
+
+// minAVX2PartitionLen is one 8-wide (256-bit) block per partition. The kernel
+// loops over the partitions itself and has a scalar tail per partition, so this
+// is a performance cut only: shorter partitions (high partition orders of small
+// blocks) are summed faster by the pure-Go loop. It gates on AVX2 because
+// VPABSD/VPUNPCKLDQ/VPADDQ are 256-bit integer ops.
+const minAVX2PartitionLen = 8
+
+func partitionAbsSumsI32(sums []uint64, residual []int32, partLen int) {
+	if hasAVX2 && partLen >= minAVX2PartitionLen {
+		partitionAbsSumsAVX2(sums, residual, partLen)
+		return
+	}
+	partitionAbsSumsGo(sums, residual, partLen)
+}
+
+//go:noescape
+func partitionAbsSumsAVX2(sums []uint64, residual []int32, partLen int)
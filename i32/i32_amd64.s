@@ -858,3 +858,383 @@ fir_avx2_tail_tap:
 fir_avx2_done:
     VZEROUPPER
     RET
+
+// func firValidQ15AVX512(dst, x []int32, taps []int16)
+// firValidQ15AVX2 at 16 outputs per block: the same per-tap sliding window and
+// Q15-truncating VPMULDQ recombine on ZMM, with the odd-lane merge done by a
+// VPBLENDMD under the 0xAAAA opmask (VPBLENDD has no 512-bit form) and the tap
+// broadcast straight from the GP register (VPBROADCASTD r32 is AVX-512F). A
+// remainder of 8-15 outputs takes one 8-output block, firValidQ15AVX2's body on
+// YMM, and the last n mod 8 outputs the same scalar-output tail, so the result is
+// bit-exact with firValidQ15Go. Every instruction is AVX-512F or AVX2. The window
+// reads stop at index n-1+len(taps)-1 <= len(x)-1 as in the AVX2 kernel; dst must
+// not overlap x. Frame is dst+x+taps slice headers: dst+0, x+24, taps+48.
+TEXT ·firValidQ15AVX512(SB), NOSPLIT, $0-72
+    MOVQ dst_base+0(FP), DX
+    MOVQ dst_len+8(FP), CX        // n = number of outputs
+    MOVQ x_base+24(FP), SI        // x base = window base for output block 0
+    MOVQ taps_base+48(FP), DI     // taps base (constant)
+    MOVQ taps_len+56(FP), R8      // number of taps (>=1)
+
+    MOVL  $0xAAAA, AX
+    KMOVW AX, K1                  // odd int32 lanes
+
+    MOVQ CX, R9
+    SHRQ $4, R9                   // R9 = n / 16 = full 16-output blocks
+    JZ   fir_avx512_block8
+
+fir_avx512_block:
+    VPXORD Z2, Z2, Z2            // acc = 0
+    MOVQ  SI, BX                 // BX = window pointer = block base
+    MOVQ  DI, R10                // R10 = taps pointer
+    MOVQ  R8, R11                // R11 = tap counter
+fir_avx512_tap:
+    MOVWQSX (R10), AX            // AX = int64(taps[j]), sign-extended int16
+    VPBROADCASTD AX, Z3          // taps[j] in all 16 int32 lanes
+    VMOVDQU32 (BX), Z0           // window x[i+j .. i+j+15]
+    VPMULDQ  Z3, Z0, Z4          // even-lane products (8x int64)
+    VPSRLQ   $32, Z0, Z1         // slide odd lanes into even positions
+    VPMULDQ  Z3, Z1, Z5          // odd-lane products (8x int64)
+    VPSRLQ   $15, Z4, Z4         // low 32 of each = even result lanes
+    VPSRLQ   $15, Z5, Z5         // low 32 of each = odd result lanes
+    VPSLLQ   $32, Z5, Z5         // lift odd results to the odd positions
+    VPBLENDMD Z5, Z4, K1, Z6     // odd lanes <- Z5, even lanes <- Z4
+    VPADDD   Z6, Z2, Z2          // acc += 16 Q15-truncated products (wrapping)
+    ADDQ  $4, BX                 // slide window by 1 int32
+    ADDQ  $2, R10                // next tap (int16)
+    DECQ  R11
+    JNZ   fir_avx512_tap
+    VMOVDQU32 Z2, (DX)           // store 16 outputs
+    ADDQ  $64, SI                // next block window base
+    ADDQ  $64, DX                // next dst block
+    DECQ  R9
+    JNZ   fir_avx512_block
+
+fir_avx512_block8:
+    TESTQ $8, CX                 // n % 16 >= 8? one YMM block takes the 8
+    JZ    fir_avx512_tail
+    VPXOR Y2, Y2, Y2
+    MOVQ  SI, BX
+    MOVQ  DI, R10
+    MOVQ  R8, R11
+fir_avx512_tap8:
+    MOVWQSX (R10), AX
+    VMOVD   AX, X3
+    VPBROADCASTD X3, Y3
+    VMOVDQU  (BX), Y0
+    VPMULDQ  Y3, Y0, Y4
+    VPSRLQ   $32, Y0, Y1
+    VPMULDQ  Y3, Y1, Y5
+    VPSRLQ   $15, Y4, Y4
+    VPSRLQ   $15, Y5, Y5
+    VPSLLQ   $32, Y5, Y5
+    VPBLENDD $0xAA, Y5, Y4, Y6
+    VPADDD   Y6, Y2, Y2
+    ADDQ  $4, BX
+    ADDQ  $2, R10
+    DECQ  R11
+    JNZ   fir_avx512_tap8
+    VMOVDQU Y2, (DX)
+    ADDQ  $32, SI
+    ADDQ  $32, DX
+
+fir_avx512_tail:
+    ANDQ $7, CX                  // CX = n mod 8 = scalar-output tail count
+    JZ   fir_avx512_done
+fir_avx512_tail_out:
+    XORL R11, R11               // acc32 = 0
+    MOVQ SI, BX
+    MOVQ DI, R10
+    MOVQ R8, R12
+fir_avx512_tail_tap:
+    MOVWQSX (R10), AX          // int64(taps[j])
+    MOVLQSX (BX), R13          // int64(x[i+j])
+    IMULQ   R13, AX            // taps[j] * x[i+j] (|p| <= 2^46)
+    SARQ    $15, AX            // Q15 truncating arithmetic shift
+    ADDL    AX, R11            // acc32 += low 32 (wrapping)
+    ADDQ    $4, BX
+    ADDQ    $2, R10
+    DECQ    R12
+    JNZ     fir_avx512_tail_tap
+    MOVL    R11, (DX)
+    ADDQ    $4, SI
+    ADDQ    $4, DX
+    DECQ    CX
+    JNZ     fir_avx512_tail_out
+
+fir_avx512_done:
+    VZEROUPPER
+    RET
+
+// FLAC fixed-predictor and Rice partition kernels (AVX2).
+//
+// The fixed-predictor kernels cover whole blocks only; the dispatch in
+// i32_amd64.go finishes the remainder with the pure-Go reference.
+
+// func fixedResidualAVX2(residual, data []int32, order int)
+// Fixed polynomial predictor residual for order 1..4, 8 residuals per iteration.
+// SI points at data[order], the sample predicted for residual[0], and the
+// unaligned loads at -4k(SI) supply x[n-k] for all 8 lanes. Each order evaluates
+// its binomial form with VPADDD/VPSUBD/VPSLLD only, all wrapping in 32-bit lanes,
+// and wrapping arithmetic is exact modulo 2^32, so the result equals
+// fixedResidualGo for every input:
+//
+//	order 1: a - b
+//	order 2: (a + c) - (b + b)
+//	order 3: (a - d) + 3*(c - b)
+//	order 4: (a + e) + 6*c - 4*(b + d)
+//
+// with a..e = x[n], x[n-1], ..., x[n-4]. residual_len is a non-zero multiple of
+// 8 and data holds residual_len+order samples, so no load leaves data. Frame is
+// two slice headers and an int: residual+0, data+24, order+48.
+TEXT ·fixedResidualAVX2(SB), NOSPLIT, $0-56
+    MOVQ residual_base+0(FP), DI
+    MOVQ residual_len+8(FP), CX
+    MOVQ data_base+24(FP), SI
+    MOVQ order+48(FP), AX
+    SHRQ $3, CX                   // CX = blocks of 8 residuals (>=1)
+    LEAQ (SI)(AX*4), SI           // SI = &data[order]
+    CMPQ AX, $1
+    JEQ  fixres_avx2_o1
+    CMPQ AX, $2
+    JEQ  fixres_avx2_o2
+    CMPQ AX, $3
+    JEQ  fixres_avx2_o3
+
+fixres_avx2_o4:
+    VMOVDQU (SI), Y0              // a = x[n]
+    VMOVDQU -4(SI), Y1            // b = x[n-1]
+    VMOVDQU -8(SI), Y2            // c = x[n-2]
+    VMOVDQU -12(SI), Y3           // d = x[n-3]
+    VMOVDQU -16(SI), Y4           // e = x[n-4]
+    VPADDD Y4, Y0, Y0             // a + e
+    VPADDD Y3, Y1, Y1             // b + d
+    VPSLLD $2, Y1, Y1             // 4*(b + d)
+    VPADDD Y2, Y2, Y5             // 2c
+    VPADDD Y2, Y5, Y5             // 3c
+    VPADDD Y5, Y5, Y5             // 6c
+    VPADDD Y5, Y0, Y0
+    VPSUBD Y1, Y0, Y0
+    VMOVDQU Y0, (DI)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    DECQ CX
+    JNZ  fixres_avx2_o4
+    JMP  fixres_avx2_done
+
+fixres_avx2_o3:
+    VMOVDQU (SI), Y0              // a = x[n]
+    VMOVDQU -4(SI), Y1            // b = x[n-1]
+    VMOVDQU -8(SI), Y2            // c = x[n-2]
+    VMOVDQU -12(SI), Y3           // d = x[n-3]
+    VPSUBD Y3, Y0, Y0             // a - d
+    VPSUBD Y1, Y2, Y2             // c - b
+    VPADDD Y2, Y2, Y5             // 2*(c - b)
+    VPADDD Y2, Y5, Y5             // 3*(c - b)
+    VPADDD Y5, Y0, Y0
+    VMOVDQU Y0, (DI)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    DECQ CX
+    JNZ  fixres_avx2_o3
+    JMP  fixres_avx2_done
+
+fixres_avx2_o2:
+    VMOVDQU (SI), Y0              // a = x[n]
+    VMOVDQU -4(SI), Y1            // b = x[n-1]
+    VPADDD -8(SI), Y0, Y0         // a + c
+    VPADDD Y1, Y1, Y1             // 2b
+    VPSUBD Y1, Y0, Y0
+    VMOVDQU Y0, (DI)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    DECQ CX
+    JNZ  fixres_avx2_o2
+    JMP  fixres_avx2_done
+
+fixres_avx2_o1:
+    VMOVDQU (SI), Y0              // a = x[n]
+    VPSUBD -4(SI), Y0, Y0         // a - b
+    VMOVDQU Y0, (DI)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    DECQ CX
+    JNZ  fixres_avx2_o1
+
+fixres_avx2_done:
+    VZEROUPPER
+    RET
+
+// func fixedAbsSumsAVX2(sums *[5]uint64, data []int32)
+// Adds the absolute order-0..4 fixed residuals of data[4:] into sums, 4 samples
+// per iteration. VPMOVSXDQ sign-extends x[n], x[n-1], ..., x[n-4] for the 4
+// samples into int64 lanes (Y0..Y4), and the residual of each order is the
+// forward difference of the one below, computed as a triangle of VPSUBQ: after
+// step k, Y0 holds the order-k residual and Y1..Y(4-k) the order-(k-1)
+// differences it needs next. Magnitudes stay below 2^36, so every residual is
+// exact. |r| is (r ^ m) - m with m = (0 > r) from VPCMPGTQ (AVX2 has no
+// VPABSQ), accumulated per order in the uint64 lanes of Y10..Y14, which are
+// reduced horizontally and added to sums at the end. data_len-4 is a non-zero
+// multiple of 4. Frame is a pointer and a slice header: sums+0, data+8.
+TEXT ·fixedAbsSumsAVX2(SB), NOSPLIT, $0-32
+    MOVQ sums+0(FP), DI
+    MOVQ data_base+8(FP), SI
+    MOVQ data_len+16(FP), CX
+    SUBQ $4, CX
+    SHRQ $2, CX                   // CX = blocks of 4 samples (>=1)
+    ADDQ $16, SI                  // SI = &data[4]
+
+    VPXOR Y15, Y15, Y15           // zero, for the sign masks
+    VPXOR Y10, Y10, Y10           // order-0 total
+    VPXOR Y11, Y11, Y11           // order-1 total
+    VPXOR Y12, Y12, Y12           // order-2 total
+    VPXOR Y13, Y13, Y13           // order-3 total
+    VPXOR Y14, Y14, Y14           // order-4 total
+
+fixsum_avx2_loop:
+    VPMOVSXDQ (SI), Y0            // x[n]
+    VPMOVSXDQ -4(SI), Y1          // x[n-1]
+    VPMOVSXDQ -8(SI), Y2          // x[n-2]
+    VPMOVSXDQ -12(SI), Y3         // x[n-3]
+    VPMOVSXDQ -16(SI), Y4         // x[n-4]
+
+    VPCMPGTQ Y0, Y15, Y5          // m = 0 > r0
+    VPXOR    Y5, Y0, Y6
+    VPSUBQ   Y5, Y6, Y6           // |r0|
+    VPADDQ   Y6, Y10, Y10
+
+    VPSUBQ Y1, Y0, Y0             // r1 = x[n] - x[n-1]
+    VPSUBQ Y2, Y1, Y1             // first differences one and two back
+    VPSUBQ Y3, Y2, Y2
+    VPSUBQ Y4, Y3, Y3
+    VPCMPGTQ Y0, Y15, Y5
+    VPXOR    Y5, Y0, Y6
+    VPSUBQ   Y5, Y6, Y6           // |r1|
+    VPADDQ   Y6, Y11, Y11
+
+    VPSUBQ Y1, Y0, Y0             // r2
+    VPSUBQ Y2, Y1, Y1
+    VPSUBQ Y3, Y2, Y2
+    VPCMPGTQ Y0, Y15, Y5
+    VPXOR    Y5, Y0, Y6
+    VPSUBQ   Y5, Y6, Y6           // |r2|
+    VPADDQ   Y6, Y12, Y12
+
+    VPSUBQ Y1, Y0, Y0             // r3
+    VPSUBQ Y2, Y1, Y1
+    VPCMPGTQ Y0, Y15, Y5
+    VPXOR    Y5, Y0, Y6
+    VPSUBQ   Y5, Y6, Y6           // |r3|
+    VPADDQ   Y6, Y13, Y13
+
+    VPSUBQ Y1, Y0, Y0             // r4
+    VPCMPGTQ Y0, Y15, Y5
+    VPXOR    Y5, Y0, Y6
+    VPSUBQ   Y5, Y6, Y6           // |r4|
+    VPADDQ   Y6, Y14, Y14
+
+    ADDQ $16, SI
+    DECQ CX
+    JNZ  fixsum_avx2_loop
+
+    VEXTRACTI128 $1, Y10, X0
+    VPADDQ  X0, X10, X0
+    VPSHUFD $0x4E, X0, X1         // swap 64-bit halves
+    VPADDQ  X1, X0, X0
+    MOVQ    X0, AX
+    ADDQ    AX, 0(DI)
+
+    VEXTRACTI128 $1, Y11, X0
+    VPADDQ  X0, X11, X0
+    VPSHUFD $0x4E, X0, X1
+    VPADDQ  X1, X0, X0
+    MOVQ    X0, AX
+    ADDQ    AX, 8(DI)
+
+    VEXTRACTI128 $1, Y12, X0
+    VPADDQ  X0, X12, X0
+    VPSHUFD $0x4E, X0, X1
+    VPADDQ  X1, X0, X0
+    MOVQ    X0, AX
+    ADDQ    AX, 16(DI)
+
+    VEXTRACTI128 $1, Y13, X0
+    VPADDQ  X0, X13, X0
+    VPSHUFD $0x4E, X0, X1
+    VPADDQ  X1, X0, X0
+    MOVQ    X0, AX
+    ADDQ    AX, 24(DI)
+
+    VEXTRACTI128 $1, Y14, X0
+    VPADDQ  X0, X14, X0
+    VPSHUFD $0x4E, X0, X1
+    VPADDQ  X1, X0, X0
+    MOVQ    X0, AX
+    ADDQ    AX, 32(DI)
+
+    VZEROUPPER
+    RET
+
+// func partitionAbsSumsAVX2(sums []uint64, residual []int32, partLen int)
+// For each of the len(sums) partitions of partLen consecutive residuals, the sum
+// of |residual| in uint64. VPABSD takes 8 magnitudes per iteration (|MinInt32|
+// is 0x80000000, exact as unsigned), VPUNPCKLDQ/VPUNPCKHDQ against zero
+// zero-extend them into uint64 lanes, and VPADDQ accumulates; a horizontal add
+// and a scalar tail (SARL/XORL/SUBL on 32-bit registers, whose result is
+// zero-extended) finish each partition. residual holds at least
+// len(sums)*partLen samples and len(sums) >= 1. Frame is two slice headers and
+// an int: sums+0, residual+24, partLen+48.
+TEXT ·partitionAbsSumsAVX2(SB), NOSPLIT, $0-56
+    MOVQ sums_base+0(FP), DI
+    MOVQ sums_len+8(FP), R8       // partitions (>=1)
+    MOVQ residual_base+24(FP), SI
+    MOVQ partLen+48(FP), R9
+
+    VPXOR Y15, Y15, Y15           // zero, for the widening unpacks
+
+part_avx2_partition:
+    VPXOR Y0, Y0, Y0              // uint64 accumulator
+    MOVQ R9, CX
+    SHRQ $3, CX                   // CX = blocks of 8 in this partition
+    JZ   part_avx2_reduce
+
+part_avx2_loop8:
+    VPABSD     (SI), Y1
+    VPUNPCKLDQ Y15, Y1, Y2        // |r| 0,1 | 4,5 as uint64
+    VPUNPCKHDQ Y15, Y1, Y3        // |r| 2,3 | 6,7 as uint64
+    VPADDQ     Y2, Y0, Y0
+    VPADDQ     Y3, Y0, Y0
+    ADDQ $32, SI
+    DECQ CX
+    JNZ  part_avx2_loop8
+
+part_avx2_reduce:
+    VEXTRACTI128 $1, Y0, X1
+    VPADDQ  X1, X0, X0
+    VPSHUFD $0x4E, X0, X1         // swap 64-bit halves
+    VPADDQ  X1, X0, X0
+    MOVQ    X0, AX                // partition total so far
+
+    MOVQ R9, CX
+    ANDQ $7, CX
+    JZ   part_avx2_store
+
+part_avx2_scalar:
+    MOVL (SI), DX
+    MOVL DX, BX
+    SARL $31, BX                  // m = r >> 31
+    XORL BX, DX
+    SUBL BX, DX                   // |r| as uint32, upper half zeroed
+    ADDQ DX, AX
+    ADDQ $4, SI
+    DECQ CX
+    JNZ  part_avx2_scalar
+
+part_avx2_store:
+    MOVQ AX, (DI)
+    ADDQ $8, DI
+    DECQ R8
+    JNZ  part_avx2_partition
+
+    VZEROUPPER
+    RET
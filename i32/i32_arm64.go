@@ -9,6 +9,12 @@ const minNEONElements = 4
 
 var hasNEON = cpu.ARM64.NEON
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+}
+
 func interleave2I32(dst, a, b []int32) {
 	if hasNEON && len(a) >= minNEONElements {
 		interleave2NEON(dst, a, b)
@@ -223,3 +229,14 @@ func firValidQ15I32(dst, x []int32, taps []int16) {
 
 //go:noescape
 func firValidQ15NEON(dst, x []int32, taps []int16)
+
+// The FLAC fixed-predictor and partition-sum kernels have no NEON
+// implementation yet; arm64 runs the pure-Go references, which are what the
+// amd64 AVX2 kernels are validated against.
+func fixedResidualI32(residual, data []int32, order int) { fixedResidualGo(residual, data, order) }
+
+func fixedAbsSumsI32(sums *[fixedMaxOrder + 1]uint64, data []int32) { fixedAbsSumsGo(sums, data) }
+
+func partitionAbsSumsI32(sums []uint64, residual []int32, partLen int) {
+	partitionAbsSumsGo(sums, residual, partLen)
+}
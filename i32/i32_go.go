@@ -268,3 +268,80 @@ func firValidQ15Go(dst, x []int32, taps []int16) {
 		dst[i] = acc
 	}
 }
+
+// fixedResidualGo writes the order-1..4 fixed predictor residual
+// residual[i] = data[i+order] - prediction, for i in [0, len(residual)), in
+// wrapping int32 arithmetic; data holds len(residual)+order samples (the warm-up
+// first). The public FixedResidual handles order 0 and the guards.
+func fixedResidualGo(residual, data []int32, order int) {
+	n := len(residual)
+	data = data[:n+order]
+	switch order {
+	case 1:
+		for i := range residual {
+			residual[i] = data[i+1] - data[i]
+		}
+	case 2:
+		for i := range residual {
+			residual[i] = data[i+2] - 2*data[i+1] + data[i]
+		}
+	case 3:
+		for i := range residual {
+			residual[i] = data[i+3] - 3*data[i+2] + 3*data[i+1] - data[i]
+		}
+	case 4:
+		for i := range residual {
+			residual[i] = data[i+4] - 4*data[i+3] + 6*data[i+2] - 4*data[i+1] + data[i]
+		}
+	}
+}
+
+// abs64 is |v| as a uint64, exact for every int64 the fixed residuals reach.
+func abs64(v int64) uint64 {
+	m := v >> 63
+	return uint64((v ^ m) - m)
+}
+
+// fixedAbsSumsGo adds the absolute order-0..4 fixed residuals of data[4:] into
+// sums, carrying the lower-order residuals of the previous sample as libFLAC's
+// FLAC__fixed_compute_best_predictor does, but in int64 so no order overflows.
+// len(data) > 4.
+func fixedAbsSumsGo(sums *[fixedMaxOrder + 1]uint64, data []int32) {
+	x1, x2, x3, x4 := int64(data[3]), int64(data[2]), int64(data[1]), int64(data[0])
+	last0 := x1
+	last1 := x1 - x2
+	last2 := last1 - (x2 - x3)
+	last3 := last2 - (x2 - 2*x3 + x4)
+	var t0, t1, t2, t3, t4 uint64
+	for _, v := range data[fixedMaxOrder:] {
+		e0 := int64(v)
+		e1 := e0 - last0
+		e2 := e1 - last1
+		e3 := e2 - last2
+		e4 := e3 - last3
+		t0 += abs64(e0)
+		t1 += abs64(e1)
+		t2 += abs64(e2)
+		t3 += abs64(e3)
+		t4 += abs64(e4)
+		last0, last1, last2, last3 = e0, e1, e2, e3
+	}
+	sums[0] += t0
+	sums[1] += t1
+	sums[2] += t2
+	sums[3] += t3
+	sums[4] += t4
+}
+
+// partitionAbsSumsGo sets sums[p] to the sum of |residual| over
+// residual[p*partLen : (p+1)*partLen] for each p in [0, len(sums)); residual
+// holds at least len(sums)*partLen samples.
+func partitionAbsSumsGo(sums []uint64, residual []int32, partLen int) {
+	for p := range sums {
+		var s uint64
+		for _, r := range residual[p*partLen : (p+1)*partLen] {
+			s += abs64(int64(r))
+		}
+		sums[p] = s
+	}
+}
@@ -26,3 +26,14 @@ func gainQ31I32(dst, a []int32, gain int32, preShift, postShift int) {
 func butterflyI32(lo, hi []int32) { butterflyGo(lo, hi) }
 
 func firValidQ15I32(dst, x []int32, taps []int16) { firValidQ15Go(dst, x, taps) }
+
+func fixedResidualI32(residual, data []int32, order int) { fixedResidualGo(residual, data, order) }
+
+func fixedAbsSumsI32(sums *[fixedMaxOrder + 1]uint64, data []int32) { fixedAbsSumsGo(sums, data) }
+
+func partitionAbsSumsI32(sums []uint64, residual []int32, partLen int) {
+	partitionAbsSumsGo(sums, residual, partLen)
+}
+
+// bindKernels has no flags to re-read: every operation runs pure Go here.
+func bindKernels() {}
@@ -0,0 +1,31 @@
+package i32
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":              absGo,
+	"Add":              addGo,
+	"Butterfly":        butterflyGo,
+	"Deinterleave2":    deinterleave2Go,
+	"FIRValidQ15":      firValidQ15Go,
+	"FixedAbsSums":     fixedAbsSumsGo,
+	"FixedResidual":    fixedResidualGo,
+	"GainQ31":          gainQ31Go,
+	"Interleave2":      interleave2Go,
+	"MaxAbs":           maxAbsGo,
+	"MinMax":           minMaxGo,
+	"NegWhereNeg":      negWhereNegGo,
+	"PartitionAbsSums": partitionAbsSumsGo,
+	"ScaleQ15":         scaleQ15Go,
+	"ScaleQ31":         scaleQ31Go,
+	"Sub":              subGo,
+	"Sum":              sumGo,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "i32", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
@@ -0,0 +1,46 @@
+//go:build amd64
+
+package i32
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// avx2Kernels maps each operation with an AVX2 path to its kernel. The
+// interleave pair only needs AVX and is bound separately.
+var avx2Kernels = map[string]any{
+	"Add":              addAVX2,
+	"Sub":              subAVX2,
+	"Sum":              sumAVX2,
+	"Abs":              absAVX2,
+	"NegWhereNeg":      negWhereNegAVX2,
+	"ScaleQ31":         scaleQ31AVX2,
+	"ScaleQ15":         scaleQ15AVX2,
+	"GainQ31":          gainQ31AVX2,
+	"Butterfly":        butterflyAVX2,
+	"MinMax":           minMaxAVX2,
+	"MaxAbs":           maxAbsAVX2,
+	"FIRValidQ15":      firValidQ15AVX2,
+	"FixedResidual":    fixedResidualAVX2,
+	"FixedAbsSums":     fixedAbsSumsAVX2,
+	"PartitionAbsSums": partitionAbsSumsAVX2,
+}
+
+// kernelBinding reports the kernel op is bound to, from the same flags its
+// dispatcher reads. FIRValidQ15 has an AVX-512 tier above its AVX2 kernel.
+func kernelBinding(op string) dispatch.Binding {
+	switch op {
+	case "Interleave2":
+		if hasAVX {
+			return dispatch.Bind(dispatch.AVX, interleave2AVX)
+		}
+	case "Deinterleave2":
+		if hasAVX {
+			return dispatch.Bind(dispatch.AVX, deinterleave2AVX)
+		}
+	case "FIRValidQ15":
+		if hasAVX512 {
+			return dispatch.Bind(dispatch.AVX512, firValidQ15AVX512)
+		}
+	}
+	fn, ok := avx2Kernels[op]
+	return dispatch.When(ok && hasAVX2, dispatch.AVX2, fn)
+}
@@ -0,0 +1,32 @@
+//go:build arm64
+
+package i32
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation with a NEON path to its kernel. The FLAC
+// residual helpers (FixedResidual, FixedAbsSums, PartitionAbsSums) are Go on
+// arm64.
+var neonKernels = map[string]any{
+	"Interleave2":   interleave2NEON,
+	"Deinterleave2": deinterleave2NEON,
+	"Add":           addNEON,
+	"Sub":           subNEON,
+	"Sum":           sumNEON,
+	"Abs":           absNEON,
+	"NegWhereNeg":   negWhereNegNEON,
+	"ScaleQ31":      scaleQ31NEON,
+	"ScaleQ15":      scaleQ15NEON,
+	"GainQ31":       gainQ31NEON,
+	"Butterfly":     butterflyNEON,
+	"MinMax":        minMaxNEON,
+	"MaxAbs":        maxAbsNEON,
+	"FIRValidQ15":   firValidQ15NEON,
+}
+
+// kernelBinding reports the kernel op is bound to: its NEON kernel when the
+// host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
@@ -0,0 +1,90 @@
+package i32
+
+// lpcMaxOrder is FLAC__MAX_LPC_ORDER, the longest quantized predictor.
+const lpcMaxOrder = 32
+
+// lpcMaxShift is the largest quantization shift LPCResidual and LPCRestore
+// accept.
+const lpcMaxShift = 31
+
+// LPCResidual computes the prediction residual of a quantized linear predictor,
+// the integer kernel of a FLAC LPC subframe:
+//
+//	residual[i-order] = data[i] - int32(sum(int64(qlp[j]) * int64(data[i-1-j])) >> shift)
+//
+// for i in [order, len(data)), order = len(qlp), with an int64 sum and an
+// arithmetic shift. This is libFLAC's
+// FLAC__lpc_compute_residual_from_qlp_coefficients_wide, and so also its 32-bit
+// variant whenever libFLAC would choose that one (its sum cannot overflow); the
+// subtraction wraps in int32. The first order samples are the warm-up and are
+// not predicted. qlp and shift come from f64.QuantizeLPC.
+//
+// It returns the number of residuals written, len(data)-order. It is a no-op
+// returning 0 when order is outside 1..32, shift is outside 0..31, len(data) <=
+// order, or residual is shorter than len(data)-order. residual must not overlap
+// data. Allocation-free.
+func LPCResidual(residual, data, qlp []int32, shift int) int {
+	order := len(qlp)
+	n := len(data) - order
+	if !lpcValid(order, shift) || n <= 0 || len(residual) < n {
+		return 0
+	}
+	var rev [lpcMaxOrder]int32
+	k := lpcReverse(&rev, qlp)
+	for i := range n {
+		residual[i] = data[i+order] - int32(lpcPredict(data[i:i+order], k)>>shift)
+	}
+	return n
+}
+
+// LPCRestore is the inverse of LPCResidual, libFLAC's
+// FLAC__lpc_restore_signal(_wide): with the order warm-up samples already in
+// data[:order], it reconstructs
+//
+//	data[i] = residual[i-order] + int32(sum(int64(qlp[j]) * int64(data[i-1-j])) >> shift)
+//
+// for i in [order, order+len(residual)), each sample predicted from the ones just
+// restored, so restoring LPCResidual's output reproduces data bit for bit.
+//
+// It returns the number of samples restored, min(len(residual),
+// len(data)-order). It is a no-op returning 0 for an order or shift out of range
+// as in LPCResidual, or when data holds no room past the warm-up. residual must
+// not overlap data. Allocation-free.
+func LPCRestore(data, residual, qlp []int32, shift int) int {
+	order := len(qlp)
+	n := min(len(residual), len(data)-order)
+	if !lpcValid(order, shift) || n <= 0 {
+		return 0
+	}
+	var rev [lpcMaxOrder]int32
+	k := lpcReverse(&rev, qlp)
+	for i := range n {
+		data[i+order] = residual[i] + int32(lpcPredict(data[i:i+order], k)>>shift)
+	}
+	return n
+}
+
+func lpcValid(order, shift int) bool {
+	return order >= 1 && order <= lpcMaxOrder && shift >= 0 && shift <= lpcMaxShift
+}
+
+// lpcReverse stores qlp reversed in rev, so coefficient k[t] weights the t-th
+// oldest sample of a window, and returns that prefix.
+func lpcReverse(rev *[lpcMaxOrder]int32, qlp []int32) []int32 {
+	order := len(qlp)
+	for j, c := range qlp {
+		rev[order-1-j] = c
+	}
+	return rev[:order]
+}
+
+// lpcPredict is the int64 prediction sum over the window of the order samples
+// preceding the predicted one, oldest first.
+func lpcPredict(window, k []int32) int64 {
+	window = window[:len(k)]
+	var sum int64
+	for t, c := range k {
+		sum += int64(c) * int64(window[t])
+	}
+	return sum
+}
@@ -0,0 +1,188 @@
+package i32
+
+import (
+	"fmt"
+	"testing"
+)
+
+// lpcResidualOracle is libFLAC's FLAC__lpc_compute_residual_from_qlp_coefficients_wide
+// written as in C: qlp[j] weights data[i-j-1], FLAC__int64 sum, int32 difference.
+func lpcResidualOracle(data, qlp []int32, shift int) []int32 {
+	order := len(qlp)
+	res := make([]int32, len(data)-order)
+	for i := order; i < len(data); i++ {
+		var sum int64
+		for j := range order {
+			sum += int64(qlp[j]) * int64(data[i-j-1])
+		}
+		res[i-order] = data[i] - int32(sum>>shift)
+	}
+	return res
+}
+
+// lpcQLP draws order quantized coefficients of the given precision (sign
+// included).
+func lpcQLP(order, precision int, seed uint32) []int32 {
+	q := make([]int32, order)
+	for j, v := range genI16(order, seed) {
+		q[j] = int32(v) >> (16 - precision)
+	}
+	return q
+}
+
+// TestLPCResidualAgainstOracle checks every order 1..32 against the libFLAC
+// transcription, over full-range data (where the prediction and the difference
+// overflow int32) and over 24-bit audio-like data, and that LPCRestore inverts
+// it bit for bit from the warm-up samples.
+func TestLPCResidualAgainstOracle(t *testing.T) {
+	full := genI32(1200, 3)
+	audio := make([]int32, len(full))
+	for i := range audio {
+		audio[i] = full[i] >> 8
+	}
+	for order := 1; order <= 32; order++ {
+		for _, c := range []struct {
+			data             []int32
+			precision, shift int
+		}{
+			{full, 15, 14}, {full, 12, 0}, {audio, 15, 13}, {audio, 8, 31}, {audio, 5, 3},
+		} {
+			qlp := lpcQLP(order, c.precision, uint32(order))
+			want := lpcResidualOracle(c.data, qlp, c.shift)
+			res := make([]int32, len(want)+1)
+			if n := LPCResidual(res, c.data, qlp, c.shift); n != len(want) {
+				t.Fatalf("order %d: LPCResidual returned %d, want %d", order, n, len(want))
+			}
+			for i := range want {
+				if res[i] != want[i] {
+					t.Fatalf("order %d shift %d: residual[%d] = %d, want %d", order, c.shift, i, res[i], want[i])
+				}
+			}
+
+			restored := make([]int32, len(c.data))
+			copy(restored, c.data[:order])
+			if n := LPCRestore(restored, res[:len(want)], qlp, c.shift); n != len(want) {
+				t.Fatalf("order %d: LPCRestore returned %d, want %d", order, n, len(want))
+			}
+			for i := range restored {
+				if restored[i] != c.data[i] {
+					t.Fatalf("order %d shift %d: restored[%d] = %d, want %d", order, c.shift, i, restored[i], c.data[i])
+				}
+			}
+		}
+	}
+}
+
+// TestLPCPredictsRamp checks a known predictor: 2*x[n-1] - x[n-2] predicts a
+// ramp exactly, leaving a zero residual.
+func TestLPCPredictsRamp(t *testing.T) {
+	data := make([]int32, 64)
+	for i := range data {
+		data[i] = int32(7*i - 100)
+	}
+	res := make([]int32, len(data)-2)
+	if n := LPCResidual(res, data, []int32{2 << 4, -1 << 4}, 4); n != len(res) {
+		t.Fatalf("LPCResidual returned %d", n)
+	}
+	for i, r := range res {
+		if r != 0 {
+			t.Fatalf("residual[%d] = %d, want 0", i, r)
+		}
+	}
+}
+
+func TestLPCGuards(t *testing.T) {
+	data := genI32(40, 1)
+	res := make([]int32, 40)
+	qlp := lpcQLP(8, 12, 1)
+	for _, c := range []struct {
+		name  string
+		res   []int32
+		data  []int32
+		qlp   []int32
+		shift int
+	}{
+		{"empty qlp", res, data, nil, 0},
+		{"order 33", res, data, lpcQLP(33, 12, 1), 0},
+		{"negative shift", res, data, qlp, -1},
+		{"shift 32", res, data, qlp, 32},
+		{"warm-up only", res, data[:8], qlp, 0},
+		{"short residual", res[:31], data, qlp, 0},
+	} {
+		sentinel := append([]int32(nil), c.res...)
+		if n := LPCResidual(c.res, c.data, c.qlp, c.shift); n != 0 {
+			t.Errorf("%s: LPCResidual returned %d, want 0", c.name, n)
+		}
+		for i := range c.res {
+			if c.res[i] != sentinel[i] {
+				t.Fatalf("%s: LPCResidual wrote residual[%d]", c.name, i)
+			}
+		}
+	}
+	restored := append([]int32(nil), data...)
+	for _, c := range []struct {
+		name  string
+		data  []int32
+		qlp   []int32
+		shift int
+	}{
+		{"empty qlp", restored, nil, 0},
+		{"shift 32", restored, qlp, 32},
+		{"warm-up only", restored[:8], qlp, 0},
+	} {
+		if n := LPCRestore(c.data, res, c.qlp, c.shift); n != 0 {
+			t.Errorf("%s: LPCRestore returned %d, want 0", c.name, n)
+		}
+	}
+	// A short residual restores only as many samples as it holds.
+	if n := LPCRestore(restored, res[:5], qlp, 0); n != 5 {
+		t.Fatalf("LPCRestore with 5 residuals returned %d", n)
+	}
+	for i := 13; i < len(restored); i++ {
+		if restored[i] != data[i] {
+			t.Fatalf("LPCRestore wrote past the residual at %d", i)
+		}
+	}
+}
+
+func TestLPCAllocFree(t *testing.T) {
+	data := genI32(4096, 2)
+	res := make([]int32, len(data))
+	qlp := lpcQLP(12, 15, 2)
+	if a := testing.AllocsPerRun(5, func() { LPCResidual(res, data, qlp, 14) }); a != 0 {
+		t.Errorf("LPCResidual allocated %v times per run, want 0", a)
+	}
+	if a := testing.AllocsPerRun(5, func() { LPCRestore(data, res, qlp, 14) }); a != 0 {
+		t.Errorf("LPCRestore allocated %v times per run, want 0", a)
+	}
+}
+
+func BenchmarkLPCResidual(b *testing.B) {
+	for _, order := range []int{8, 12, 32} {
+		b.Run(fmt.Sprintf("order=%d", order), func(b *testing.B) {
+			data := genI32(4096, 2)
+			res := make([]int32, len(data))
+			qlp := lpcQLP(order, 15, 2)
+			b.SetBytes(int64(4 * len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				LPCResidual(res, data, qlp, 14)
+			}
+		})
+	}
+}
+
+func BenchmarkLPCRestore(b *testing.B) {
+	for _, order := range []int{8, 12, 32} {
+		b.Run(fmt.Sprintf("order=%d", order), func(b *testing.B) {
+			data := genI32(4096, 2)
+			res := make([]int32, len(data))
+			qlp := lpcQLP(order, 15, 2)
+			b.SetBytes(int64(4 * len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				LPCRestore(data, res, qlp, 14)
+			}
+		})
+	}
+}
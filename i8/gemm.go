@@ -0,0 +1,190 @@
+package i8
+
+// Quantized matrix products. GEMM (int8 activations) and GEMMU8 (uint8
+// activations) multiply an m x k activation matrix A by the transpose of an
+// n x k weight matrix B, one row per output channel as in the TFLite
+// FullyConnected filter layout. Each C element is then a dot product of two
+// contiguous rows, so the driver runs the DotProduct kernel (VNNI, AVX-512BW,
+// AVX2 or SDOT) directly. The zero points are folded out of the inner loop with
+// the gemmlowp row and column sums:
+//
+//	sum_p (a[p]-za)(b[p]-zb) = sum_p a[p]*b[p] - zb*sum_p a[p] - za*sum_p b[p] + k*za*zb
+//
+// Every term is int32 with two's-complement wraparound, which is associative,
+// so the rearranged sum is bit-identical to the reference loop. The epilogue
+// adds the bias and runs Requantize per tensor (the SIMD kernel on a whole
+// chunk of a C row) or per channel.
+
+const (
+	// gemmQuantCols is how many output channels one pass covers: their column
+	// sums, the bias and the int32 accumulators of one C row live in stack
+	// arrays of this size.
+	gemmQuantCols = 64
+
+	// gemmU8KC is how many uint8 activations GEMMU8 re-biases into int8 at a
+	// time, in a stack buffer, before running the int8 dot kernel on them.
+	gemmU8KC = 256
+)
+
+// GEMMQuant describes the quantization of a GEMM or GEMMU8 call, following the
+// TFLite int8 conventions:
+//
+//   - AZeroPoint and BZeroPoint are the zero points of A and B; each product
+//     term is (a - AZeroPoint) * (b - BZeroPoint). Per-channel quantized weights
+//     are symmetric, so BZeroPoint is then 0.
+//   - Bias, if non-nil, holds one int32 per output channel (at least n), added
+//     to the accumulator before requantization.
+//   - Multiplier and Shift are the Requantize parameters: one of each for a
+//     per-tensor scale, or one per output channel (at least n) for per-channel
+//     scales. Shift must have the same length as Multiplier.
+//   - ZeroPoint is the zero point of the int8 output.
+type GEMMQuant struct {
+	AZeroPoint int32
+	BZeroPoint int32
+	Bias       []int32
+	Multiplier []int32
+	Shift      []int
+	ZeroPoint  int8
+}
+
+// GEMM computes the quantized matrix product of int8 activations and int8
+// weights:
+//
+//	acc = Bias[j] + sum_p (a[i*lda+p] - AZeroPoint) * (b[j*ldb+p] - BZeroPoint)
+//	c[i*ldc+j] = Requantize(acc, Multiplier[j], Shift[j], ZeroPoint)
+//
+// for i in [0, m), j in [0, n) and p in [0, k), with the per-tensor Multiplier[0]
+// and Shift[0] when q has one of each. A is m x k and B n x k, both row-major:
+// row j of B holds the k weights of output channel j. The accumulator is int32
+// with two's-complement wraparound and the epilogue is the Requantize
+// double-rounding rescale, so the result is bit-identical to TFLite's reference
+// FullyConnected kernel with the activation range left at [-128, 127]; a fused
+// activation is a Clamp on the output.
+//
+// GEMM panics if m, n or k is negative, a leading dimension is shorter than its
+// matrix's row, a slice is too short for its matrix, or q's Bias, Multiplier or
+// Shift is too short. C must not overlap A or B; padding past column n of each
+// C row is left untouched. The call allocates nothing.
+func GEMM(m, n, k int, a []int8, lda int, b []int8, ldb int, q GEMMQuant, c []int8, ldc int) {
+	gemmQuantCheck("i8.GEMM", m, n, k, len(a), lda, len(b), ldb, &q, len(c), ldc)
+	if m == 0 || n == 0 {
+		return
+	}
+	gemmQuant(m, n, k, a, nil, lda, q.AZeroPoint, b, ldb, &q, c, ldc)
+}
+
+// GEMMU8 is GEMM with uint8 activations and int8 weights, the u8 x s8 operand
+// pairing of VPDPBUSD and of asymmetric uint8 activation quantization (typically
+// AZeroPoint 128). The product and epilogue are the same as GEMM's, with a[p]
+// read as an unsigned byte.
+//
+// Internally each activation a is re-biased to the int8 a-128 and AZeroPoint
+// to AZeroPoint-128, which leaves every product term unchanged, so the int8 dot
+// kernels serve both variants bit for bit.
+func GEMMU8(m, n, k int, a []uint8, lda int, b []int8, ldb int, q GEMMQuant, c []int8, ldc int) {
+	gemmQuantCheck("i8.GEMMU8", m, n, k, len(a), lda, len(b), ldb, &q, len(c), ldc)
+	if m == 0 || n == 0 {
+		return
+	}
+	gemmQuant(m, n, k, nil, a, lda, q.AZeroPoint-128, b, ldb, &q, c, ldc)
+}
+
+// gemmQuantCheck validates a GEMM or GEMMU8 call, panicking with a message
+// prefixed by op.
+func gemmQuantCheck(op string, m, n, k, lenA, lda, lenB, ldb int, q *GEMMQuant, lenC, ldc int) {
+	if m < 0 || n < 0 || k < 0 {
+		panic(op + ": negative dimension")
+	}
+	gemmQuantCheckMatrix(op+": A", lenA, m, k, lda)
+	gemmQuantCheckMatrix(op+": B", lenB, n, k, ldb)
+	gemmQuantCheckMatrix(op+": C", lenC, m, n, ldc)
+	if len(q.Shift) != len(q.Multiplier) {
+		panic(op + ": Shift and Multiplier lengths differ")
+	}
+	if len(q.Multiplier) != 1 && len(q.Multiplier) < n {
+		panic(op + ": Multiplier needs 1 or n entries")
+	}
+	if q.Bias != nil && len(q.Bias) < n {
+		panic(op + ": Bias shorter than n")
+	}
+}
+
+// gemmQuantCheckMatrix panics unless a rows x cols matrix with leading
+// dimension ld fits in a slice of length n.
+func gemmQuantCheckMatrix(what string, n, rows, cols, ld int) {
+	if ld < max(1, cols) {
+		panic(what + " leading dimension shorter than a row")
+	}
+	if rows > 0 && cols > 0 && n < (rows-1)*ld+cols {
+		panic(what + " slice too short")
+	}
+}
+
+// gemmQuant is the shared driver. Exactly one of a (int8 activations) and au
+// (uint8 activations, re-biased to int8 on the fly) is used; za is the int8-side
+// A zero point, already shifted by -128 for au.
+func gemmQuant(m, n, k int, a []int8, au []uint8, lda int, za int32, b []int8, ldb int, q *GEMMQuant, c []int8, ldc int) {
+	zb := q.BZeroPoint
+	kzz := int32(k) * za * zb
+	perTensor := len(q.Multiplier) == 1
+	var colTerm, acc [gemmQuantCols]int32
+	var flip [gemmU8KC]int8
+	for j0 := 0; j0 < n; j0 += gemmQuantCols {
+		cols := min(gemmQuantCols, n-j0)
+		// Everything that depends only on the output channel: bias, the
+		// -za*sum(b) correction and the constant k*za*zb.
+		for j := range cols {
+			t := kzz
+			if q.Bias != nil {
+				t += q.Bias[j0+j]
+			}
+			if za != 0 && k > 0 {
+				t -= za * sumI8(b[(j0+j)*ldb:(j0+j)*ldb+k])
+			}
+			colTerm[j] = t
+		}
+		for i := range m {
+			var rowSum int32
+			switch {
+			case k == 0:
+				clear(acc[:cols])
+			case au == nil:
+				rowA := a[i*lda : i*lda+k]
+				for j := range cols {
+					acc[j] = dotI8(rowA, b[(j0+j)*ldb:(j0+j)*ldb+k])
+				}
+				if zb != 0 {
+					rowSum = sumI8(rowA)
+				}
+			default:
+				clear(acc[:cols])
+				for p0 := 0; p0 < k; p0 += gemmU8KC {
+					kc := min(gemmU8KC, k-p0)
+					chunk := flip[:kc]
+					for p, v := range au[i*lda+p0 : i*lda+p0+kc] {
+						chunk[p] = int8(v ^ 0x80)
+					}
+					for j := range cols {
+						off := (j0+j)*ldb + p0
+						acc[j] += dotI8(chunk, b[off:off+kc])
+					}
+					if zb != 0 {
+						rowSum += sumI8(chunk)
+					}
+				}
+			}
+			rowTerm := zb * rowSum
+			for j := range cols {
+				acc[j] += colTerm[j] - rowTerm
+			}
+			out := c[i*ldc+j0 : i*ldc+j0+cols]
+			if perTensor {
+				requantizeI8(out, acc[:cols], q.Multiplier[0], q.Shift[0], q.ZeroPoint)
+				continue
+			}
+			for j := range cols {
+				requantizeGo(out[j:j+1], acc[j:j+1], q.Multiplier[j0+j], q.Shift[j0+j], q.ZeroPoint)
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package i8
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+)
+
+// gemmTiers masks features so the GEMM tests run every DotProduct and
+// Requantize kernel the host has (VNNI, AVX-512BW, AVX2, SDOT/NEON) and the
+// pure-Go references.
+var gemmTiers = []string{"", "avx512vnni", "avx512", "all"}
+
+// naiveGEMMQuant is TFLite's reference FullyConnected loop: accumulate the
+// zero-point-adjusted products in int32, add the bias, requantize per channel.
+// aAt returns activation (i, p) as an int32, so one oracle covers both variants.
+func naiveGEMMQuant(m, n, k int, aAt func(i, p int) int32, b []int8, ldb int, q GEMMQuant, c []int8, ldc int) []int8 {
+	out := append([]int8(nil), c...)
+	for i := range m {
+		for j := range n {
+			var acc int32
+			for p := range k {
+				acc += (aAt(i, p) - q.AZeroPoint) * (int32(b[j*ldb+p]) - q.BZeroPoint)
+			}
+			if q.Bias != nil {
+				acc += q.Bias[j]
+			}
+			ch := j
+			if len(q.Multiplier) == 1 {
+				ch = 0
+			}
+			requantizeGo(out[i*ldc+j:i*ldc+j+1], []int32{acc}, q.Multiplier[ch], q.Shift[ch], q.ZeroPoint)
+		}
+	}
+	return out
+}
+
+// gemmQuantCases returns per-tensor and per-channel parameter sets for n
+// output channels, with and without bias and zero points.
+func gemmQuantCases(n int, aZero int32) []GEMMQuant {
+	mul := make([]int32, n)
+	shift := make([]int, n)
+	for j := range n {
+		mul[j] = requantizeMuls[j%len(requantizeMuls)]
+		shift[j] = -(j % 12)
+	}
+	bias := genI32(n, uint32(n))
+	for j := range bias {
+		bias[j] >>= 12
+	}
+	return []GEMMQuant{
+		{Multiplier: []int32{0x40000000}, Shift: []int{-6}},
+		{AZeroPoint: aZero, BZeroPoint: -3, Bias: bias, Multiplier: []int32{0x5A000000}, Shift: []int{-9}, ZeroPoint: 5},
+		{AZeroPoint: aZero, Bias: bias, Multiplier: mul, Shift: shift, ZeroPoint: -7},
+		{AZeroPoint: -aZero, BZeroPoint: 127, Multiplier: mul, Shift: shift, ZeroPoint: 127},
+		{AZeroPoint: aZero, Multiplier: []int32{0x7FFFFFFF}, Shift: []int{2}, ZeroPoint: -128},
+	}
+}
+
+func TestGEMM_MatchesReference(t *testing.T) {
+	shapes := [][3]int{
+		{1, 1, 1}, {3, 5, 7}, {4, 64, 16}, {2, 65, 63}, {7, 17, 64},
+		{5, 130, 100}, {3, 9, 257}, {2, 3, 600}, {4, 6, 0},
+	}
+	for _, tier := range gemmTiers {
+		restore := cpu.Override(tier)
+		for _, s := range shapes {
+			m, n, k := s[0], s[1], s[2]
+			lda, ldb, ldc := k+3, k+1, n+2
+			a := genI8(m*lda, uint32(m*31+k))
+			au := make([]uint8, len(a))
+			for i, v := range a {
+				au[i] = uint8(v) * 7
+			}
+			b := genI8(n*ldb, uint32(n*17+k))
+			c := genI8(m*ldc, uint32(m+n))
+			for qi, q := range gemmQuantCases(n, 11) {
+				want := naiveGEMMQuant(m, n, k, func(i, p int) int32 { return int32(a[i*lda+p]) }, b, ldb, q, c, ldc)
+				got := append([]int8(nil), c...)
+				GEMM(m, n, k, a, lda, b, ldb, q, got, ldc)
+				assertGEMMQuant(t, "GEMM", tier, s, qi, got, want)
+
+				q.AZeroPoint = 128 + q.AZeroPoint
+				want = naiveGEMMQuant(m, n, k, func(i, p int) int32 { return int32(au[i*lda+p]) }, b, ldb, q, c, ldc)
+				got = append([]int8(nil), c...)
+				GEMMU8(m, n, k, au, lda, b, ldb, q, got, ldc)
+				assertGEMMQuant(t, "GEMMU8", tier, s, qi, got, want)
+			}
+		}
+		restore()
+	}
+}
+
+// assertGEMMQuant compares the whole C slice, so it also catches writes to the
+// padding past column n.
+func assertGEMMQuant(t *testing.T, op, tier string, shape [3]int, qi int, got, want []int8) {
+	t.Helper()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s tier %q shape %v case %d: c[%d] = %d, want %d", op, tier, shape, qi, i, got[i], want[i])
+		}
+	}
+}
+
+func TestGEMM_KnownAnswer(t *testing.T) {
+	// A = [[1, 2], [3, 4]] with zero point 1, B rows [5, 6] and [-7, 8] with
+	// zero point 2: acc = [[0*3 + 1*4, 0*-9 + 1*6], [2*3 + 3*4, 2*-9 + 3*6]] =
+	// [[4, 6], [18, 0]], plus bias [10, -6] = [[14, 0], [28, -6]]. Multiplier
+	// 0.5 in Q31 with shift -1 divides by 4, rounding ties away from zero.
+	q := GEMMQuant{
+		AZeroPoint: 1, BZeroPoint: 2,
+		Bias:       []int32{10, -6},
+		Multiplier: []int32{0x40000000}, Shift: []int{-1},
+		ZeroPoint: 3,
+	}
+	a := []int8{1, 2, 3, 4}
+	b := []int8{5, 6, -7, 8}
+	c := make([]int8, 4)
+	GEMM(2, 2, 2, a, 2, b, 2, q, c, 2)
+	if want := []int8{7, 3, 10, 1}; !slices.Equal(c, want) {
+		t.Errorf("GEMM = %v, want %v", c, want)
+	}
+
+	// Per-channel, uint8 activations: channel 1 scales by ~8 (multiplier ~1.0,
+	// shift 3), so its accumulators [0, -6] become [0, -48].
+	q.Multiplier = []int32{0x40000000, 0x7FFFFFFF}
+	q.Shift = []int{-1, 3}
+	GEMMU8(2, 2, 2, []uint8{1, 2, 3, 4}, 2, b, 2, q, c, 2)
+	if want := []int8{7, 3, 10, -45}; !slices.Equal(c, want) {
+		t.Errorf("GEMMU8 = %v, want %v", c, want)
+	}
+}
+
+func TestGEMM_Panics(t *testing.T) {
+	a := make([]int8, 8)
+	perTensor := GEMMQuant{Multiplier: []int32{1 << 30}, Shift: []int{0}}
+	cases := map[string]func(){
+		"negative":  func() { GEMM(-1, 2, 2, a, 2, a, 2, perTensor, a, 2) },
+		"lda":       func() { GEMM(2, 2, 3, a, 2, a, 3, perTensor, a, 2) },
+		"short b":   func() { GEMM(2, 5, 2, a, 2, a, 2, perTensor, make([]int8, 10), 5) },
+		"short c":   func() { GEMMU8(2, 3, 2, make([]uint8, 4), 2, a, 2, perTensor, a[:5], 3) },
+		"no scale":  func() { GEMM(2, 2, 2, a, 2, a, 2, GEMMQuant{}, a, 2) },
+		"shift len": func() { GEMM(2, 2, 2, a, 2, a, 2, GEMMQuant{Multiplier: []int32{1, 1}, Shift: []int{0}}, a, 2) },
+		"channels":  func() { GEMM(2, 3, 2, a, 2, a, 2, GEMMQuant{Multiplier: []int32{1, 1}, Shift: []int{0, 0}}, a, 3) },
+		"short bias": func() {
+			GEMM(2, 2, 2, a, 2, a, 2, GEMMQuant{Bias: []int32{1}, Multiplier: []int32{1}, Shift: []int{0}}, a, 2)
+		},
+	}
+	for name, fn := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestGEMM_Allocs(t *testing.T) {
+	const m, n, k = 8, 100, 300
+	a := genI8(m*k, 1)
+	au := make([]uint8, m*k)
+	b := genI8(n*k, 2)
+	c := make([]int8, m*n)
+	q := gemmQuantCases(n, 128)[2]
+	allocs := testing.AllocsPerRun(20, func() {
+		GEMM(m, n, k, a, k, b, k, q, c, n)
+		GEMMU8(m, n, k, au, k, b, k, q, c, n)
+	})
+	if allocs != 0 {
+		t.Fatalf("GEMM/GEMMU8 allocations = %v, want 0", allocs)
+	}
+}
@@ -28,6 +28,11 @@
 //     round-to-nearest-even; Dequantize is an exact subtract and single
 //     multiply; Requantize is the gemmlowp fixed-point rescale (Q31 multiplier
 //     and shift) that turns an int32 accumulator back into int8.
+//   - Quantized matrix multiply (GEMM for int8 activations, GEMMU8 for uint8):
+//     int32 accumulation on the DotProduct kernels with the zero points folded
+//     out as row and column sums, then an optional per-channel bias and a
+//     per-tensor or per-channel Requantize epilogue, bit-identical to TFLite's
+//     reference FullyConnected kernel.
 //
 // Sum and DotProduct accumulate in int32 with two's-complement wraparound,
 // exactly like their pure-Go references. int32 wrapping addition is associative
@@ -66,7 +71,8 @@
 // float32 or a wider integer, so their input and output have distinct element
 // types and cannot alias in safe Go (see the note on the quantization group). The
 // reductions (DotProduct, Sum, SumAbs, SAD, MaxAbs, MinMax) write no output slice,
-// so aliasing does not apply to them.
+// so aliasing does not apply to them. GEMM and GEMMU8 write C, which must not overlap A
+// or B.
 package i8
 
 // AddSaturate writes dst[i] = clamp(int(a[i]) + int(b[i]), -128, 127) for i in
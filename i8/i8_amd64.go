@@ -8,7 +8,22 @@ import "github.com/tphakala/simd/cpu"
 // VPMAXSB/VPMOVSXB*/VPMADDWD), which require AVX2. They gate on AVX2 explicitly
 // and fall back to the pure-Go reference on the (now rare) AVX-less baseline and
 // for slices shorter than one vector block.
-var hasAVX2 = cpu.X86.AVX2
+//
+// DotProduct and SAD add 512-bit tiers above AVX2: AVX-512BW for the ZMM forms
+// of VPMOVSXBW/VPMADDWD/VPSADBW, and AVX-512 VNNI for the VPDPBUSD dot product.
+var (
+	hasAVX2       = cpu.X86.AVX2
+	hasAVX512BW   = cpu.X86.AVX512BW
+	hasAVX512VNNI = cpu.X86.AVX512VNNI
+)
+
+// bindKernels re-reads the feature flags cached above from cpu.X86.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasAVX2 = cpu.X86.AVX2
+	hasAVX512BW = cpu.X86.AVX512BW
+	hasAVX512VNNI = cpu.X86.AVX512VNNI
+}
 
 // Per-kernel minimum element counts: one full vector iteration's worth of int8
 // inputs. Shorter slices use the pure-Go reference.
@@ -18,6 +33,10 @@ const (
 	blockReduce  = 16 // Sum/DotProduct widen 16 bytes per iteration (VPMOVSXBW)
 	blockWiden16 = 16 // ToInt16 widens 16 bytes per iteration (VPMOVSXBW)
 	blockWiden32 = 8  // ToInt32 widens 8 bytes per iteration (VPMOVSXBD)
+
+	blockDot512  = 32 // dotAVX512BW widens 32 bytes per iteration into a ZMM
+	blockDotVNNI = 64 // dotAVX512VNNI fuses 64 bytes per iteration (VPDPBUSD)
+	blockSAD512  = 64 // sadAVX512BW sums 64 byte differences per iteration
 )
 
 func addSatI8(dst, a, b []int8) {
@@ -60,10 +79,16 @@ func sumI8(a []int8) int32 {
 }
 
 func dotI8(a, b []int8) int32 {
-	if hasAVX2 && len(a) >= blockReduce {
+	switch {
+	case hasAVX512VNNI && len(a) >= blockDotVNNI:
+		return dotAVX512VNNI(a, b)
+	case hasAVX512BW && len(a) >= blockDot512:
+		return dotAVX512BW(a, b)
+	case hasAVX2 && len(a) >= blockReduce:
 		return dotAVX2(a, b)
+	default:
+		return dotGo(a, b)
 	}
-	return dotGo(a, b)
 }
 
 func minMaxI8(a []int8) (minVal, maxVal int8) {
@@ -144,10 +169,14 @@ func sumAbsI8(a []int8) int32 {
 }
 
 func sadI8(a, b []int8) int32 {
-	if hasAVX2 && len(a) >= blockSat32 {
+	switch {
+	case hasAVX512BW && len(a) >= blockSAD512:
+		return sadAVX512BW(a, b)
+	case hasAVX2 && len(a) >= blockSat32:
 		return sadAVX2(a, b)
+	default:
+		return sadGo(a, b)
 	}
-	return sadGo(a, b)
 }
 
 func subScalarSatI8(dst, a []int8, s int8) {
@@ -176,6 +205,12 @@ func sumAVX2(a []int8) int32
 //go:noescape
 func dotAVX2(a, b []int8) int32
 
+//go:noescape
+func dotAVX512BW(a, b []int8) int32
+
+//go:noescape
+func dotAVX512VNNI(a, b []int8) int32
+
 //go:noescape
 func minMaxAVX2(a []int8) (minVal, maxVal int8)
 
@@ -212,6 +247,9 @@ func sumAbsAVX2(a []int8) int32
 //go:noescape
 func sadAVX2(a, b []int8) int32
 
+//go:noescape
+func sadAVX512BW(a, b []int8) int32
+
 // Quantization dispatch (Part of #132). The AVX2 kernels process 16/8/8 lanes
 // per iteration; shorter slices use the pure-Go reference. Requantize also
 // routes out-of-contract inputs (multiplier == math.MinInt32, or shift outside
@@ -2,9 +2,9 @@
 
 #include "textflag.h"
 
-// int8 SIMD kernels on AMD64 (AVX2).
+// int8 SIMD kernels on AMD64 (AVX2, with AVX-512 tiers for DotProduct and SAD).
 //
-// All kernels gate on AVX2 in i8_amd64.go and run at least one full vector
+// All kernels gate on AVX2 (the AVX-512 ones on AVX512BW) in i8_amd64.go and run at least one full vector
 // block (the dispatch guards the minimum length), with a scalar tail for the
 // (n mod block) remainder. The Go assembler's 3-operand AVX order is dst-last:
 // VPSUBSB a, b, c is c = b - a, and VPMADDWD a, b, c is c = madd(b, a). No
@@ -1518,3 +1518,287 @@ requant_tail:
 requant_done:
     VZEROUPPER
     RET
+
+// -----------------------------------------------------------------------------
+// AVX-512 kernels. dotAVX512BW and sadAVX512BW gate on AVX512BW (VPMOVSXBW,
+// VPMADDWD and VPSADBW on ZMM are byte/word ops) and dotAVX512VNNI on AVX512VNNI
+// as well, in i8_amd64.go. Each keeps its AVX2 sibling's structure one width up:
+// the narrower blocks run BEFORE the 512-bit loop, while the accumulator is still
+// the freshly zeroed register, because a VEX.128 or VEX.256 write zeroes every
+// bit above it (bits 511:128 or 511:256), so a VEX block after the loop would
+// discard the loop's upper-lane sums. Reordering the sum that way is exact only
+// because the accumulation wraps. Every mnemonic is assembler-native; the Go
+// assembler emits VPDPBUSD in its EVEX form, which is the one wanted here.
+// -----------------------------------------------------------------------------
+
+// func dotAVX512BW(a, b []int8) int32
+// dotAVX2 at twice the width: 8- and 16-wide blocks, then a 32-byte loop that
+// widens each operand to 32 int16 in a ZMM (VPMOVSXBW) and pair-reduces the
+// products to 16 int32 with VPMADDWD. VEXTRACTI64X4 folds the 512-bit
+// accumulator to 256 bits and the dotAVX2 fold and scalar tail finish.
+TEXT ·dotAVX512BW(SB), NOSPLIT, $0-52
+    MOVQ a_base+0(FP), SI
+    MOVQ a_len+8(FP), CX
+    MOVQ b_base+24(FP), DI
+
+    VPXOR Y2, Y2, Y2           // int32 accumulator = 0 (VEX zeroes Z2[511:256])
+
+    TESTQ $8, CX               // n % 16 >= 8?
+    JZ   dot512bw_block16
+    VPMOVSXBW (SI), X0         // a -> 8 int16
+    VPMOVSXBW (DI), X1         // b -> 8 int16
+    VPMADDWD X1, X0, X4
+    VPADDD X4, X2, X2          // Z2[511:128] still zero after this
+    ADDQ $8, SI
+    ADDQ $8, DI
+
+dot512bw_block16:
+    TESTQ $16, CX              // n % 32 >= 16?
+    JZ   dot512bw_blocks32
+    VPMOVSXBW (SI), Y0         // a -> 16 int16
+    VPMOVSXBW (DI), Y1         // b -> 16 int16
+    VPMADDWD Y1, Y0, Y4
+    VPADDD Y4, Y2, Y2          // Z2[511:256] still zero after this
+    ADDQ $16, SI
+    ADDQ $16, DI
+
+dot512bw_blocks32:
+    MOVQ CX, AX
+    SHRQ $5, AX                // AX = n / 32
+    JZ   dot512bw_reduce
+
+dot512bw_loop32:
+    VPMOVSXBW (SI), Z0         // a -> 32 int16
+    VPMOVSXBW (DI), Z1         // b -> 32 int16
+    VPMADDWD Z1, Z0, Z4        // 16 int32 pair sums
+    VPADDD Z4, Z2, Z2          // accumulate (wrapping)
+    ADDQ $32, SI
+    ADDQ $32, DI
+    DECQ AX
+    JNZ  dot512bw_loop32
+
+dot512bw_reduce:
+    VEXTRACTI64X4 $1, Z2, Y3
+    VPADDD Y3, Y2, Y2          // fold 16 -> 8 int32
+    VEXTRACTI128 $1, Y2, X3
+    VPADDD X3, X2, X2
+    VPSHUFD $0x4E, X2, X3
+    VPADDD X3, X2, X2
+    VPSHUFD $0xB1, X2, X3
+    VPADDD X3, X2, X2
+    MOVQ X2, AX                // EAX = vector total
+
+    ANDQ $7, CX                // the 8- and 16-wide blocks took n % 32 down to n % 8
+    JZ   dot512bw_done
+
+dot512bw_scalar:
+    MOVBLSX (SI), BX
+    MOVBLSX (DI), DX
+    IMULL DX, BX
+    ADDL BX, AX
+    INCQ SI
+    INCQ DI
+    DECQ CX
+    JNZ  dot512bw_scalar
+
+dot512bw_done:
+    MOVL AX, ret+48(FP)
+    VZEROUPPER
+    RET
+
+// func dotAVX512VNNI(a, b []int8) int32
+// dotAVX512BW's 8-, 16- and 32-wide blocks, then a 64-byte loop that fuses the
+// multiply-accumulate into VPDPBUSD. VPDPBUSD multiplies UNSIGNED bytes of its
+// first source by SIGNED bytes of its second, so a is biased into range: a XOR
+// 0x80 is a+128 read as unsigned. The loop then accumulates sum((a+128)*b) in Z2
+// and, with a second VPDPBUSD against 0x01 bytes, sum(b) in Z7; after the loop
+// Z2 -= Z7<<7 removes the 128*sum(b) the bias added. Both VPDPBUSD (never the
+// saturating VPDPBUSDS) and the correction wrap, and the identity holds modulo
+// 2^32, so the result equals dotGo exactly. The two accumulators are independent
+// chains, so the correction costs no latency on the loop-carried one.
+TEXT ·dotAVX512VNNI(SB), NOSPLIT, $0-52
+    MOVQ a_base+0(FP), SI
+    MOVQ a_len+8(FP), CX
+    MOVQ b_base+24(FP), DI
+
+    VPXOR Y2, Y2, Y2           // int32 accumulator = 0
+    VPXOR Y7, Y7, Y7           // sum(b) over the VNNI loop = 0
+
+    TESTQ $8, CX               // n % 16 >= 8?
+    JZ   dotvnni512_block16
+    VPMOVSXBW (SI), X0
+    VPMOVSXBW (DI), X1
+    VPMADDWD X1, X0, X4
+    VPADDD X4, X2, X2          // Z2[511:128] still zero after this
+    ADDQ $8, SI
+    ADDQ $8, DI
+
+dotvnni512_block16:
+    TESTQ $16, CX              // n % 32 >= 16?
+    JZ   dotvnni512_block32
+    VPMOVSXBW (SI), Y0
+    VPMOVSXBW (DI), Y1
+    VPMADDWD Y1, Y0, Y4
+    VPADDD Y4, Y2, Y2          // Z2[511:256] still zero after this
+    ADDQ $16, SI
+    ADDQ $16, DI
+
+dotvnni512_block32:
+    TESTQ $32, CX              // n % 64 >= 32?
+    JZ   dotvnni512_blocks64
+    VPMOVSXBW (SI), Z0
+    VPMOVSXBW (DI), Z1
+    VPMADDWD Z1, Z0, Z4
+    VPADDD Z4, Z2, Z2
+    ADDQ $32, SI
+    ADDQ $32, DI
+
+dotvnni512_blocks64:
+    MOVQ CX, AX
+    SHRQ $6, AX                // AX = n / 64
+    JZ   dotvnni512_reduce
+
+    MOVL $0x80808080, DX
+    VPBROADCASTD DX, Z5        // 0x80 bytes: the unsigned bias
+    MOVL $0x01010101, DX
+    VPBROADCASTD DX, Z6        // 0x01 bytes: VPDPBUSD against it sums b
+
+dotvnni512_loop64:
+    VMOVDQU64 (SI), Z0
+    VPXORQ Z5, Z0, Z0          // a + 128 as unsigned bytes
+    VMOVDQU64 (DI), Z1         // b, signed bytes
+    VPDPBUSD Z1, Z0, Z2        // Z2 += sum of 4 (a+128)*b per dword
+    VPDPBUSD Z1, Z6, Z7        // Z7 += sum of 4 b per dword
+    ADDQ $64, SI
+    ADDQ $64, DI
+    DECQ AX
+    JNZ  dotvnni512_loop64
+
+    VPSLLD $7, Z7, Z7          // 128 * sum(b)
+    VPSUBD Z7, Z2, Z2          // remove the bias (wrapping)
+
+dotvnni512_reduce:
+    VEXTRACTI64X4 $1, Z2, Y3
+    VPADDD Y3, Y2, Y2          // fold 16 -> 8 int32
+    VEXTRACTI128 $1, Y2, X3
+    VPADDD X3, X2, X2
+    VPSHUFD $0x4E, X2, X3
+    VPADDD X3, X2, X2
+    VPSHUFD $0xB1, X2, X3
+    VPADDD X3, X2, X2
+    MOVQ X2, AX                // EAX = vector total
+
+    ANDQ $7, CX                // the 8-, 16- and 32-wide blocks took n % 64 to n % 8
+    JZ   dotvnni512_done
+
+dotvnni512_scalar:
+    MOVBLSX (SI), BX
+    MOVBLSX (DI), DX
+    IMULL DX, BX
+    ADDL BX, AX
+    INCQ SI
+    INCQ DI
+    DECQ CX
+    JNZ  dotvnni512_scalar
+
+dotvnni512_done:
+    MOVL AX, ret+48(FP)
+    VZEROUPPER
+    RET
+
+// func sadAVX512BW(a, b []int8) int32
+// sadAVX2 at twice the width: the same XOR 0x80 bias turns the unsigned VPSADBW
+// into the signed sum|a-b|, with 16-, 8- and 32-wide blocks ahead of a 64-byte
+// loop. The 0x80 mask is broadcast from a GP register (VPBROADCASTD r32 is
+// AVX-512F), and its low lanes serve the narrower blocks.
+TEXT ·sadAVX512BW(SB), NOSPLIT, $0-52
+    MOVQ a_base+0(FP), SI
+    MOVQ a_len+8(FP), CX
+    MOVQ b_base+24(FP), DI
+
+    MOVL $0x80808080, AX
+    VPBROADCASTD AX, Z4        // 0x80 per byte
+    VPXOR Y2, Y2, Y2           // u64 accumulator (VEX zeroes Z2[511:256])
+
+    TESTQ $16, CX
+    JZ   sad512bw_block8
+    VMOVDQU (SI), X0
+    VMOVDQU (DI), X1
+    VPXOR X4, X0, X0           // a+128
+    VPXOR X4, X1, X1           // b+128
+    VPSADBW X1, X0, X3
+    VPADDQ X3, X2, X2          // Z2[511:128] still zero after this
+    ADDQ $16, SI
+    ADDQ $16, DI
+
+sad512bw_block8:
+    TESTQ $8, CX
+    JZ   sad512bw_block32
+    VMOVQ (SI), X0             // 8 bytes, upper zeroed
+    VMOVQ (DI), X1
+    VPXOR X4, X0, X0           // upper 8 bytes become 0x80 for both a and b
+    VPXOR X4, X1, X1
+    VPSADBW X1, X0, X3         // so they contribute |0x80-0x80| = 0
+    VPADDQ X3, X2, X2
+    ADDQ $8, SI
+    ADDQ $8, DI
+
+sad512bw_block32:
+    TESTQ $32, CX
+    JZ   sad512bw_blocks64
+    VMOVDQU (SI), Y0
+    VMOVDQU (DI), Y1
+    VPXOR Y4, Y0, Y0
+    VPXOR Y4, Y1, Y1
+    VPSADBW Y1, Y0, Y3
+    VPADDQ Y3, Y2, Y2          // Z2[511:256] still zero after this
+    ADDQ $32, SI
+    ADDQ $32, DI
+
+sad512bw_blocks64:
+    MOVQ CX, AX
+    SHRQ $6, AX                // AX = n / 64
+    JZ   sad512bw_reduce
+
+sad512bw_loop64:
+    VMOVDQU64 (SI), Z0
+    VMOVDQU64 (DI), Z1
+    VPXORQ Z4, Z0, Z0          // a + 128
+    VPXORQ Z4, Z1, Z1          // b + 128
+    VPSADBW Z1, Z0, Z3         // sum|a-b| -> 8 u64 lanes
+    VPADDQ Z3, Z2, Z2
+    ADDQ $64, SI
+    ADDQ $64, DI
+    DECQ AX
+    JNZ  sad512bw_loop64
+
+sad512bw_reduce:
+    VEXTRACTI64X4 $1, Z2, Y3
+    VPADDQ Y3, Y2, Y2          // fold 8 -> 4 u64
+    VEXTRACTI128 $1, Y2, X3
+    VPADDQ X3, X2, X2
+    VPSHUFD $0x4E, X2, X3
+    VPADDQ X3, X2, X2
+    MOVQ X2, AX                // u64 running total
+
+    ANDQ $7, CX                // the 16-, 8- and 32-wide blocks took n % 64 to n % 8
+    JZ   sad512bw_done
+
+sad512bw_scalar:
+    MOVBLSX (SI), BX
+    MOVBLSX (DI), DX
+    SUBL DX, BX                // a - b
+    TESTL BX, BX
+    JGE  sad512bw_add
+    NEGL BX                    // |a - b|
+sad512bw_add:
+    ADDQ BX, AX
+    INCQ SI
+    INCQ DI
+    DECQ CX
+    JNZ  sad512bw_scalar
+
+sad512bw_done:
+    MOVL AX, ret+48(FP)
+    VZEROUPPER
+    RET
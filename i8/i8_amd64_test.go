@@ -0,0 +1,131 @@
+//go:build amd64
+
+package i8
+
+import (
+	"testing"
+
+	"github.com/tphakala/simd/cpu"
+)
+
+// reduceKernel describes one amd64 tier of DotProduct or SAD, so the parity
+// checks run identically against every tier the host has.
+type reduceKernel struct {
+	name      string
+	available bool
+	fn        func(a, b []int8) int32
+}
+
+func dotKernels() []reduceKernel {
+	return []reduceKernel{
+		{"AVX512VNNI", cpu.X86.AVX512VNNI, dotAVX512VNNI},
+		{"AVX512BW", cpu.X86.AVX512BW, dotAVX512BW},
+		{"AVX2", cpu.X86.AVX2, dotAVX2},
+	}
+}
+
+func sadKernels() []reduceKernel {
+	return []reduceKernel{
+		{"AVX512BW", cpu.X86.AVX512BW, sadAVX512BW},
+		{"AVX2", cpu.X86.AVX2, sadAVX2},
+	}
+}
+
+// reduceLengths sweeps every length 0..200, so each combination of the 8-, 16-
+// and 32-wide pre-loop blocks and the scalar remainder is reached at several
+// 64-byte loop counts, plus a long ragged length.
+var reduceLengths = func() []int {
+	ns := make([]int, 0, 202)
+	for n := 0; n <= 200; n++ {
+		ns = append(ns, n)
+	}
+	return append(ns, 4099)
+}()
+
+// TestDotAMD64_ParityWithGo drives each kernel directly, over lengths the
+// dispatcher would never route to it, with the -128 extremes planted at both
+// ends so the VNNI kernel's unsigned bias is exercised at its limits.
+func TestDotAMD64_ParityWithGo(t *testing.T) {
+	for _, k := range dotKernels() {
+		t.Run(k.name, func(t *testing.T) {
+			if !k.available {
+				t.Skipf("%s not available", k.name)
+			}
+			for _, n := range reduceLengths {
+				a, b := genI8(n, 71), genI8(n, 72)
+				if n > 0 {
+					a[0], b[0] = -128, -128
+					a[n-1], b[n-1] = -128, 127
+				}
+				if got, want := k.fn(a, b), dotGo(a, b); got != want {
+					t.Errorf("dot%s n=%d: got %d, want %d", k.name, n, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestDotAMD64_Wraparound pins the wrapping int32 accumulation, which the VNNI
+// kernel's bias correction relies on: all -128 products sum past MaxInt32.
+func TestDotAMD64_Wraparound(t *testing.T) {
+	const n = 140000
+	a, b := fillI8(n, -128), fillI8(n, -128)
+	want := int32(int64(len(a)) * 128 * 128) // non-constant: truncates at runtime
+	for _, k := range dotKernels() {
+		t.Run(k.name, func(t *testing.T) {
+			if !k.available {
+				t.Skipf("%s not available", k.name)
+			}
+			if got := k.fn(a, b); got != want {
+				t.Errorf("dot%s wraparound: got %d, want %d", k.name, got, want)
+			}
+		})
+	}
+}
+
+func TestSADAMD64_ParityWithGo(t *testing.T) {
+	for _, k := range sadKernels() {
+		t.Run(k.name, func(t *testing.T) {
+			if !k.available {
+				t.Skipf("%s not available", k.name)
+			}
+			for _, n := range reduceLengths {
+				a, b := genI8(n, 73), genI8(n, 74)
+				if n > 0 {
+					a[0], b[0] = -128, 127
+				}
+				if got, want := k.fn(a, b), sadGo(a, b); got != want {
+					t.Errorf("sad%s n=%d: got %d, want %d", k.name, n, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestReduceAMD64_NoOverRead places each operand at the front of a backing whose
+// slack holds values that would change the result, so a kernel that reads a
+// block past n is caught.
+func TestReduceAMD64_NoOverRead(t *testing.T) {
+	const slack = 64 // one block of the widest kernel
+	for _, n := range []int{1, 7, 8, 15, 31, 33, 63, 65, 127, 129} {
+		ab, bb := fillI8(n+slack, 127), fillI8(n+slack, -128)
+		a, b := ab[:n], bb[:n]
+		for i := range n {
+			a[i], b[i] = int8(i%5-2), int8(i%3-1)
+		}
+		for _, k := range dotKernels() {
+			if k.available {
+				if got, want := k.fn(a, b), dotGo(a, b); got != want {
+					t.Errorf("dot%s n=%d: got %d, want %d", k.name, n, got, want)
+				}
+			}
+		}
+		for _, k := range sadKernels() {
+			if k.available {
+				if got, want := k.fn(a, b), sadGo(a, b); got != want {
+					t.Errorf("sad%s n=%d: got %d, want %d", k.name, n, got, want)
+				}
+			}
+		}
+	}
+}
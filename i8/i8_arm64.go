@@ -16,6 +16,13 @@ var hasNEON = cpu.ARM64.NEON
 // extension, so it implies NEON, but require both explicitly to be safe.
 var hasDotProd = cpu.ARM64.NEON && cpu.ARM64.DOTPROD
 
+// bindKernels re-reads the feature flags cached above from cpu.ARM64.
+// cpu.Override calls it after masking features.
+func bindKernels() {
+	hasNEON = cpu.ARM64.NEON
+	hasDotProd = cpu.ARM64.NEON && cpu.ARM64.DOTPROD
+}
+
 func addSatI8(dst, a, b []int8) {
 	if hasNEON && len(dst) >= minNEON16 {
 		addSatNEON(dst, a, b)
@@ -37,3 +37,6 @@ func dequantizeI8(dst []float32, src []int8, scale float32, zeroPoint int8) {
 func requantizeI8(dst []int8, acc []int32, multiplier int32, shift int, zeroPoint int8) {
 	requantizeGo(dst, acc, multiplier, shift, zeroPoint)
 }
+
+// bindKernels has no flags to re-read: every operation runs pure Go here.
+func bindKernels() {}
@@ -0,0 +1,35 @@
+package i8
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// kernelGo maps every dispatched exported operation to its portable Go
+// reference, the path short or unsupported inputs take on every architecture.
+// kernelBinding (per architecture) reports which kernel the operation is bound
+// to instead; both feed cpu.Kernels.
+var kernelGo = map[string]any{
+	"Abs":               absGo,
+	"AbsDiff":           absDiffGo,
+	"AddSaturate":       addSatGo,
+	"AddScalarSaturate": addScalarSatGo,
+	"Clamp":             clampGo,
+	"Dequantize":        dequantizeGo,
+	"DotProduct":        dotGo,
+	"Max":               maxGo,
+	"MaxAbs":            maxAbsGo,
+	"Min":               minGo,
+	"MinMax":            minMaxGo,
+	"Neg":               negGo,
+	"Quantize":          quantizeGo,
+	"Requantize":        requantizeGo,
+	"SAD":               sadGo,
+	"SubSaturate":       subSatGo,
+	"SubScalarSaturate": subScalarSatGo,
+	"Sum":               sumGo,
+	"SumAbs":            sumAbsGo,
+	"ToInt16":           toI16Go,
+	"ToInt32":           toI32Go,
+}
+
+func init() {
+	dispatch.Register(dispatch.Package{Name: "i8", Ops: kernelGo, Binding: kernelBinding, Rebind: bindKernels})
+}
@@ -0,0 +1,51 @@
+//go:build amd64
+
+package i8
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// avx2Kernels maps each operation to its AVX2 kernel.
+var avx2Kernels = map[string]any{
+	"AddSaturate":       addSatAVX2,
+	"SubSaturate":       subSatAVX2,
+	"AddScalarSaturate": addScalarSatAVX2,
+	"SubScalarSaturate": subScalarSatAVX2,
+	"ToInt16":           toI16AVX2,
+	"ToInt32":           toI32AVX2,
+	"Sum":               sumAVX2,
+	"DotProduct":        dotAVX2,
+	"MinMax":            minMaxAVX2,
+	"Min":               minAVX2,
+	"Max":               maxAVX2,
+	"Clamp":             clampAVX2,
+	"Abs":               absAVX2,
+	"Neg":               negAVX2,
+	"MaxAbs":            maxAbsAVX2,
+	"AbsDiff":           absDiffAVX2,
+	"SumAbs":            sumAbsAVX2,
+	"SAD":               sadAVX2,
+	"Quantize":          quantizeAVX2,
+	"Dequantize":        dequantizeAVX2,
+	"Requantize":        requantizeAVX2,
+}
+
+// kernelBinding reports the kernel op is bound to: the AVX-512 tiers of
+// DotProduct and SAD when the host has them, otherwise the op's AVX2 kernel
+// when the host has AVX2.
+func kernelBinding(op string) dispatch.Binding {
+	switch op {
+	case "DotProduct":
+		if hasAVX512VNNI {
+			return dispatch.Bind(dispatch.AVX512VNNI, dotAVX512VNNI)
+		}
+		if hasAVX512BW {
+			return dispatch.Bind(dispatch.AVX512BW, dotAVX512BW)
+		}
+	case "SAD":
+		if hasAVX512BW {
+			return dispatch.Bind(dispatch.AVX512BW, sadAVX512BW)
+		}
+	}
+	fn, ok := avx2Kernels[op]
+	return dispatch.When(ok && hasAVX2, dispatch.AVX2, fn)
+}
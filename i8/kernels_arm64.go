@@ -0,0 +1,40 @@
+//go:build arm64
+
+package i8
+
+import "github.com/tphakala/simd/internal/dispatch"
+
+// neonKernels maps each operation to its NEON kernel.
+var neonKernels = map[string]any{
+	"AddSaturate":       addSatNEON,
+	"SubSaturate":       subSatNEON,
+	"AddScalarSaturate": addScalarSatNEON,
+	"SubScalarSaturate": subScalarSatNEON,
+	"ToInt16":           toI16NEON,
+	"ToInt32":           toI32NEON,
+	"Sum":               sumNEON,
+	"DotProduct":        dotNEON,
+	"MinMax":            minMaxNEON,
+	"Min":               minNEON,
+	"Max":               maxNEON,
+	"Clamp":             clampNEON,
+	"Abs":               absNEON,
+	"Neg":               negNEON,
+	"MaxAbs":            maxAbsNEON,
+	"AbsDiff":           absDiffNEON,
+	"SumAbs":            sumAbsNEON,
+	"SAD":               sadNEON,
+	"Quantize":          quantizeNEON,
+	"Dequantize":        dequantizeNEON,
+	"Requantize":        requantizeNEON,
+}
+
+// kernelBinding reports the kernel op is bound to: SDOT for DotProduct on
+// FEAT_DotProd hosts, otherwise the NEON kernel when the host has NEON.
+func kernelBinding(op string) dispatch.Binding {
+	if op == "DotProduct" && hasDotProd {
+		return dispatch.Bind(dispatch.DotProd, dotSDOT)
+	}
+	fn, ok := neonKernels[op]
+	return dispatch.When(ok && hasNEON, dispatch.NEON, fn)
+}
@@ -0,0 +1,221 @@
+// Package dispatch is the registry behind cpu.Kernels and cpu.Override: each simd
+// subpackage registers, from its init, a function that reports which
+// implementation every dispatched exported operation is bound to on this host
+// and a function that re-runs its kernel selection, and cpu re-exports the
+// former as structured data and calls the latter when it masks features.
+//
+// Reports are built lazily, when they are queried, from the same function
+// pointers and feature flags the operations dispatch on, so they always describe
+// the current binding and never a copy that could drift from it.
+package dispatch
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Kernel describes the implementation one exported operation of a simd
+// subpackage is bound to on this host.
+type Kernel struct {
+	// Package is the subpackage name, such as "f32" or "crc".
+	Package string
+	// Op is the exported function, such as "DotProduct".
+	Op string
+	// Impl names the implementation tier: "Go" for the portable fallback, or an
+	// instruction-set tier such as "SSE2", "AVX+FMA", "AVX2", "AVX-512",
+	// "AVX-512BW", "AVX-512 VNNI", "AVX-VNNI", "F16C", "F16C+AVX2", "PCLMULQDQ",
+	// "NEON", "NEON+FP16", "NEON+DotProd", "PMULL" or "SVE".
+	Impl string
+	// Requires lists the CPU features (cpu.Features field names) whose presence
+	// selected this implementation; it is empty for Go.
+	Requires []string
+	// Func is the unexported function that implements the operation at this tier,
+	// such as "dotProductAVX512"; useful to tell apart kernels that share a tier.
+	// An operation that routes shapes to several kernels of its tier (InterleaveN
+	// by channel count, say) reports the widest-reaching one.
+	Func string
+	// Fallback is the portable Go function the operation takes on inputs its
+	// kernel does not cover (too short, ragged rows, non-finite arguments and
+	// the like) and on hosts without the kernel's features. It equals Func when
+	// Impl is "Go".
+	Fallback string
+}
+
+// Tier is an implementation tier: a name and the features it requires.
+type Tier struct {
+	Name     string
+	Requires []string
+}
+
+// The tiers the subpackages dispatch between.
+var (
+	Go         = Tier{Name: "Go"}
+	SSE2       = Tier{"SSE2", []string{"SSE2"}}
+	SSE41      = Tier{"SSE4.1", []string{"SSE41"}}
+	AVX        = Tier{"AVX", []string{"AVX"}}
+	AVXFMA     = Tier{"AVX+FMA", []string{"AVX", "FMA"}}
+	AVX2       = Tier{"AVX2", []string{"AVX2"}}
+	AVX2FMA    = Tier{"AVX2+FMA", []string{"AVX2", "FMA"}}
+	AVX512     = Tier{"AVX-512", []string{"AVX512F", "AVX512VL"}}
+	AVX512BW   = Tier{"AVX-512BW", []string{"AVX512F", "AVX512BW"}}
+	AVX512VNNI = Tier{"AVX-512 VNNI", []string{"AVX512BW", "AVX512VNNI"}}
+	AVXVNNI    = Tier{"AVX-VNNI", []string{"AVX2", "AVXVNNI"}}
+	F16C       = Tier{"F16C", []string{"F16C"}}
+	F16CAVX2   = Tier{"F16C+AVX2", []string{"F16C", "AVX2"}}
+	PCLMULQDQ  = Tier{"PCLMULQDQ", []string{"PCLMULQDQ", "SSE41"}}
+	NEON       = Tier{"NEON", []string{"NEON"}}
+	NEONFP16   = Tier{"NEON+FP16", []string{"NEON", "FP16"}}
+	DotProd    = Tier{"NEON+DotProd", []string{"NEON", "DOTPROD"}}
+	PMULL      = Tier{"PMULL", []string{"NEON", "PMULL"}}
+	SVE        = Tier{"SVE", []string{"SVE"}}
+)
+
+// Binding is the tier and implementing function of one operation.
+type Binding struct {
+	Tier Tier
+	Func string
+}
+
+// Bind records that fn implements an operation at tier t.
+func Bind(t Tier, fn any) Binding {
+	return Binding{Tier: t, Func: FuncName(fn)}
+}
+
+// When returns Bind(t, fn) when ok and the zero Binding, which stands for the
+// operation's Go reference, otherwise: the one-feature-gate dispatch most
+// operations use.
+func When(ok bool, t Tier, fn any) Binding {
+	if ok {
+		return Bind(t, fn)
+	}
+	return Binding{}
+}
+
+// Suffix maps a kernel name suffix to the tier a function with that suffix was
+// bound for, for operations dispatched through function pointers: the pointer
+// names the kernel, and the suffix names its tier.
+type Suffix struct {
+	Suffix string
+	Tier   Tier
+}
+
+// Bound reports the kernel a function pointer currently holds, taking its tier
+// from the first suffix that ends its name. A name matching none is a Go
+// function, which several operations may share (AccumulateAdd runs on addImpl),
+// so it reports the zero Binding: the operation's own Go reference.
+func Bound(fn any, suffixes []Suffix) Binding {
+	name := FuncName(fn)
+	for _, s := range suffixes {
+		if strings.HasSuffix(name, s.Suffix) {
+			return Binding{Tier: s.Tier, Func: name}
+		}
+	}
+	return Binding{}
+}
+
+// FuncName returns the unqualified name of the function fn, as the symbol
+// table records it.
+func FuncName(fn any) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	name = name[strings.LastIndexByte(name, '/')+1:]
+	name = name[strings.IndexByte(name, '.')+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// Package is one subpackage's registration.
+type Package struct {
+	// Name is the subpackage name.
+	Name string
+	// Ops maps each dispatched exported operation to its portable Go reference.
+	Ops map[string]any
+	// Binding reports the current binding of one of Ops; the zero Binding means
+	// the Go reference.
+	Binding func(op string) Binding
+	// Rebind re-runs the package's kernel selection against the current
+	// cpu.X86 and cpu.ARM64 flags.
+	Rebind func()
+}
+
+var (
+	mu       sync.Mutex
+	packages []Package
+)
+
+// Register adds a subpackage. It is called from the subpackage's init.
+func Register(p Package) {
+	mu.Lock()
+	defer mu.Unlock()
+	packages = append(packages, p)
+}
+
+// Rebind re-runs every registered package's kernel selection, after cpu has
+// changed the feature flags.
+func Rebind() {
+	mu.Lock()
+	pkgs := slices.Clone(packages)
+	mu.Unlock()
+	for _, p := range pkgs {
+		if p.Rebind != nil {
+			p.Rebind()
+		}
+	}
+}
+
+// Kernels returns every registered operation's current binding, sorted by
+// package and operation.
+func Kernels() []Kernel {
+	mu.Lock()
+	pkgs := slices.Clone(packages)
+	mu.Unlock()
+	var ks []Kernel
+	for _, p := range pkgs {
+		for op := range p.Ops {
+			ks = append(ks, kernel(p, op))
+		}
+	}
+	slices.SortFunc(ks, func(a, b Kernel) int {
+		if c := strings.Compare(a.Package, b.Package); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Op, b.Op)
+	})
+	return ks
+}
+
+// Lookup returns the current binding of one operation.
+func Lookup(pkg, op string) (Kernel, bool) {
+	mu.Lock()
+	i := slices.IndexFunc(packages, func(p Package) bool { return p.Name == pkg })
+	var p Package
+	if i >= 0 {
+		p = packages[i]
+	}
+	mu.Unlock()
+	if i < 0 || p.Ops[op] == nil {
+		return Kernel{}, false
+	}
+	return kernel(p, op), true
+}
+
+func kernel(p Package, op string) Kernel {
+	fallback := FuncName(p.Ops[op])
+	b := p.Binding(op)
+	if b.Func == "" {
+		b = Binding{Tier: Go, Func: fallback}
+	}
+	return Kernel{
+		Package:  p.Name,
+		Op:       op,
+		Impl:     b.Tier.Name,
+		Requires: slices.Clone(b.Tier.Requires),
+		Func:     b.Func,
+		Fallback: fallback,
+	}
+}
@@ -0,0 +1,207 @@
+#!/usr/bin/env python3
+"""Generate the mel spectrogram and PCEN reference vectors for the simd mel
+parity tests (f64/mel_test.go, f32/mel_test.go).
+
+Run from the repo root, like gen_stft_golden.py:
+
+    python3 testdata/gen_mel_golden.py              # -> f64/testdata
+    python3 testdata/gen_mel_golden.py f32/testdata # -> f32/testdata
+
+Each MEL_CASES entry is melspectrogram(y, sr, n_fft, hop_length, window="hann",
+center=True, pad_mode="constant", power=2) with the given filter options; each
+PCEN_CASES entry runs pcen over one of those mel spectrograms with sr/hop_length
+taken from it. The signal (testSignal) and window are regenerated by the Go
+tests, so only the outputs are stored, rounded to 10 significant figures.
+Outputs are written frame-contiguous (frames x mels), the layout
+MelFilterbank.Apply and PCEN.Process produce.
+
+The committed mel_reference_golden.json files come from the stdlib-only
+transcriptions below (transcribe_stft from gen_stft_golden.py, transcribe_mel
+of librosa.filters.mel, transcribe_pcen of librosa.pcen with its lfilter_zi
+initial state), not from librosa: they cross-check the Go code against a second
+port, not against librosa itself. When librosa is importable the script calls
+librosa.feature.melspectrogram and librosa.pcen instead (the filterbank
+requested with dtype=np.float64, since librosa.filters.mel defaults to float32
+weights); each entry records the generator that produced it.
+"""
+import json
+import math
+import os
+import sys
+
+from gen_stft_golden import N, hann, librosa, round_sig, test_signal, transcribe_stft
+
+if librosa is not None:
+    import numpy as np
+
+# (sr, n_fft, hop, n_mels, fmin, fmax, htk, norm); fmax None is sr/2.
+MEL_CASES = [
+    (16000, 400, 160, 40, 0.0, None, False, "slaney"),  # librosa defaults
+    (16000, 512, 128, 64, 20.0, 7600.0, True, None),  # HTK, unit-peak
+    (22050, 2048, 512, 128, 0.0, None, False, "slaney"),
+]
+
+# (mel case index, gain, bias, power, time_constant, eps)
+PCEN_CASES = [
+    (0, 0.98, 2.0, 0.5, 0.4, 1e-6),  # librosa defaults
+    (0, 0.8, 10.0, 0.25, 0.06, 1e-6),
+    (0, 0.98, 2.0, 0.0, 0.4, 1e-6),  # power == 0 branch
+    (0, 0.5, 0.0, 0.5, 0.2, 1e-6),  # bias == 0 branch
+    (1, 0.98, 2.0, 0.5, 0.4, 1e-6),
+]
+
+
+def librosa_mel(sig, sr, nfft, hop, n_mels, fmin, fmax, htk, norm):
+    mel = librosa.feature.melspectrogram(
+        y=np.asarray(sig),
+        sr=sr,
+        n_fft=nfft,
+        hop_length=hop,
+        window="hann",
+        center=True,
+        pad_mode="constant",
+        power=2.0,
+        n_mels=n_mels,
+        fmin=fmin,
+        fmax=fmax,
+        htk=htk,
+        norm=norm,
+        dtype=np.float64,
+    )
+    return mel.T.tolist()  # frames x mels
+
+
+def librosa_pcen(mel, sr, hop, gain, bias, power, tc, eps):
+    out = librosa.pcen(
+        np.asarray(mel).T,
+        sr=sr,
+        hop_length=hop,
+        gain=gain,
+        bias=bias,
+        power=power,
+        time_constant=tc,
+        eps=eps,
+    )
+    return out.T.tolist()
+
+
+def hz_to_mel(f, htk):
+    if htk:
+        return 2595.0 * math.log10(1.0 + f / 700.0)
+    if f >= 1000.0:
+        return 15.0 + math.log(f / 1000.0) / (math.log(6.4) / 27.0)
+    return f / (200.0 / 3)
+
+
+def mel_to_hz(m, htk):
+    if htk:
+        return 700.0 * (10.0 ** (m / 2595.0) - 1.0)
+    if m >= 15.0:
+        return 1000.0 * math.exp(math.log(6.4) / 27.0 * (m - 15.0))
+    return 200.0 / 3 * m
+
+
+def transcribe_mel(sig, sr, nfft, hop, n_mels, fmin, fmax, htk, norm):
+    if fmax is None:
+        fmax = sr / 2.0
+    lo, hi = hz_to_mel(fmin, htk), hz_to_mel(fmax, htk)
+    mel_f = [mel_to_hz(lo + (hi - lo) * i / (n_mels + 1), htk) for i in range(n_mels + 2)]
+    bins = nfft // 2 + 1
+    fft_f = [k * sr / nfft for k in range(bins)]
+    weights = []
+    for m in range(n_mels):
+        enorm = 2.0 / (mel_f[m + 2] - mel_f[m]) if norm == "slaney" else 1.0
+        row = []
+        for f in fft_f:
+            lower = (f - mel_f[m]) / (mel_f[m + 1] - mel_f[m])
+            upper = (mel_f[m + 2] - f) / (mel_f[m + 2] - mel_f[m + 1])
+            row.append(max(0.0, min(lower, upper)) * enorm)
+        weights.append(row)
+    power = transcribe_stft(sig, hann(nfft), nfft, hop, "constant")
+    return [[math.fsum(w * p for w, p in zip(row, frame)) for row in weights] for frame in power]
+
+
+def transcribe_pcen(mel, sr, hop, gain, bias, power, tc, eps):
+    t = tc * sr / hop
+    b = (math.sqrt(1 + 4 * t * t) - 1) / (2 * t * t)
+    # lfilter([b], [1, b-1], zi=lfilter_zi(...)): the smoother starts at the
+    # unit-input steady state, M = 1.
+    state = [1.0] * len(mel[0])
+    out = []
+    for frame in mel:
+        row = []
+        for c, s in enumerate(frame):
+            state[c] = (1 - b) * state[c] + b * s
+            smooth = math.exp(-gain * (math.log(eps) + math.log1p(state[c] / eps)))
+            if power == 0:
+                v = math.log1p(s * smooth)
+            elif bias == 0:
+                v = math.exp(power * (math.log(s) + math.log(smooth)))
+            else:
+                v = bias**power * math.expm1(power * math.log1p(s * smooth / bias))
+            row.append(v)
+        out.append(row)
+    return out
+
+
+def flat(rows):
+    return [round_sig(v) for row in rows for v in row]
+
+
+def main():
+    if librosa is not None:
+        mel_fn, pcen_fn = librosa_mel, librosa_pcen
+        generator = "librosa " + librosa.__version__
+    else:
+        mel_fn, pcen_fn = transcribe_mel, transcribe_pcen
+        generator = "transcribe_mel/transcribe_pcen (stdlib)"
+
+    sig = test_signal(N)
+    mels, mel_out = [], []
+    for sr, nfft, hop, n_mels, fmin, fmax, htk, norm in MEL_CASES:
+        mel = mel_fn(sig, sr, nfft, hop, n_mels, fmin, fmax, htk, norm)
+        mel_out.append(mel)
+        mels.append(
+            {
+                "sr": sr,
+                "nfft": nfft,
+                "hop": hop,
+                "n_mels": n_mels,
+                "fmin": fmin,
+                "fmax": fmax or 0.0,
+                "htk": htk,
+                "norm": norm or "",
+                "generator": generator,
+                "frames": len(mel),
+                "mel": flat(mel),
+            }
+        )
+
+    pcens = []
+    for idx, gain, bias, power, tc, eps in PCEN_CASES:
+        sr, hop = MEL_CASES[idx][0], MEL_CASES[idx][2]
+        pcens.append(
+            {
+                "mel_case": idx,
+                "gain": gain,
+                "bias": bias,
+                "power": power,
+                "time_constant": tc,
+                "eps": eps,
+                "generator": generator,
+                "pcen": flat(pcen_fn(mel_out[idx], sr, hop, gain, bias, power, tc, eps)),
+            }
+        )
+
+    out = {"n": N, "mel": mels, "pcen": pcens}
+    for d in sys.argv[1:] or ["f64/testdata"]:
+        os.makedirs(d, exist_ok=True)
+        path = os.path.join(d, "mel_reference_golden.json")
+        with open(path, "w") as f:
+            json.dump(out, f)
+        print("wrote", path)
+    print(generator, [(m["sr"], m["nfft"], m["n_mels"], m["frames"]) for m in mels])
+
+
+if __name__ == "__main__":
+    main()
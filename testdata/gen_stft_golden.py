@@ -1,5 +1,5 @@
 #!/usr/bin/env python3
-"""Generate a librosa STFT power-spectrogram golden vector for the simd parity
+"""Generate librosa STFT power-spectrogram golden vectors for the simd parity
 tests (f64/stft_test.go, f32/stft_test.go).
 
 Run from the repo root with the project venv. By default it writes the f64
@@ -14,63 +14,139 @@ periodic-Hann window are NOT stored: the Go tests regenerate them with the same
 deterministic formulas (testSignal / hann), so only librosa's power output needs
 pinning. Power is rounded to 10 significant figures (quantization ~1e-10, far
 under the test's 1e-6 parity tolerance) to keep the committed file small.
+
+One entry is written per (nfft, hop) in SIZES: the radix-2 path (1024), the
+speech-front-end sizes 400 and 480 (25/30 ms at 16 kHz, mixed-radix), and an
+odd size (401) that exercises the full-length FFT path.
+
+When librosa is not importable the script falls back to transcribe_stft, a
+stdlib-only transcription of librosa.stft's centering (np.pad constant /
+reflect), windowing and rfft (an exact fsum DFT). Each entry records the
+generator that produced it, and the fallback never overwrites an entry that
+real librosa produced. In the committed files only the 1024 entry is librosa
+output; 400, 480 and 401 are transcribe_stft entries, so re-run under librosa
+to replace them.
 """
 import json
+import math
 import os
 import sys
 
-import librosa
-import numpy as np
+try:
+    import librosa
+    import numpy as np
+except ImportError:
+    librosa = None
+
+SIZES = [(1024, 768), (400, 160), (480, 160), (401, 100)]
+N = 4096
+PADS = [("PadZero", "constant"), ("PadReflect", "reflect")]
+
 
-NFFT, HOP, N = 1024, 768, 4096
+def test_signal(n):
+    # Deterministic real signal; MUST match f64 testSignal / f32 testSignal in Go.
+    return [
+        math.sin(0.3 * t) + 0.5 * math.cos(0.11 * t + 1) - 0.25 * math.sin(0.027 * t)
+        for t in range(n)
+    ]
 
-# Deterministic real signal; MUST match f64 testSignal / f32 testSignal in Go.
-t = np.arange(N, dtype=np.float64)
-sig = np.sin(0.3 * t) + 0.5 * np.cos(0.11 * t + 1) - 0.25 * np.sin(0.027 * t)
 
-# Periodic Hann (fftbins=True); MUST match the Go hann helper.
-win = 0.5 - 0.5 * np.cos(2 * np.pi * np.arange(NFFT) / NFFT)
+def hann(nfft):
+    # Periodic Hann (fftbins=True); MUST match the Go hann helper.
+    return [0.5 - 0.5 * math.cos(2 * math.pi * i / nfft) for i in range(nfft)]
 
 
 def round_sig(x, sig=10):
     return float(f"{x:.{sig}g}")
 
 
-cases = []
-for go_pad, pad_mode in [("PadZero", "constant"), ("PadReflect", "reflect")]:
+def librosa_stft(sig, win, nfft, hop, pad_mode):
     spec = librosa.stft(
-        sig, n_fft=NFFT, hop_length=HOP, window=win, center=True, pad_mode=pad_mode
-    )
-    power = (np.abs(spec) ** 2).T  # frames x bins
-    cases.append(
-        {
-            "go_pad": go_pad,
-            "pad_mode": pad_mode,
-            "frames": int(power.shape[0]),
-            "bins": int(power.shape[1]),
-            "power": [round_sig(v) for v in power.reshape(-1)],
-        }
+        np.asarray(sig),
+        n_fft=nfft,
+        hop_length=hop,
+        window=np.asarray(win),
+        center=True,
+        pad_mode=pad_mode,
     )
+    return (np.abs(spec) ** 2).T.tolist()  # frames x bins
+
+
+def transcribe_stft(sig, win, nfft, hop, pad_mode):
+    # center=True pads n_fft//2 per side, as np.pad(mode=pad_mode) does.
+    half = nfft // 2
+    n = len(sig)
+    if pad_mode == "constant":
+        x = [0.0] * half + sig + [0.0] * half
+    else:
+        x = [sig[half - i] for i in range(half)] + sig
+        x += [sig[n - 2 - i] for i in range(half)]
+    frames = 1 + (len(x) - nfft) // hop
+    cos = [math.cos(2 * math.pi * i / nfft) for i in range(nfft)]
+    sin = [math.sin(2 * math.pi * i / nfft) for i in range(nfft)]
+    out = []
+    for f in range(frames):
+        fr = [x[f * hop + t] * win[t] for t in range(nfft)]
+        row = []
+        for k in range(half + 1):
+            re = math.fsum(fr[t] * cos[k * t % nfft] for t in range(nfft))
+            im = math.fsum(fr[t] * sin[k * t % nfft] for t in range(nfft))
+            row.append(re * re + im * im)
+        out.append(row)
+    return out
+
+
+def main():
+    if librosa is not None:
+        stft_power, generator = librosa_stft, "librosa " + librosa.__version__
+    else:
+        stft_power, generator = transcribe_stft, "transcribe_stft (stdlib)"
+
+    out_dirs = sys.argv[1:] or ["f64/testdata"]
+    kept = {}
+    if librosa is None:
+        for d in out_dirs:
+            try:
+                with open(os.path.join(d, "stft_librosa_golden.json")) as f:
+                    for e in json.load(f)["sizes"]:
+                        if e["generator"].startswith("librosa "):
+                            kept[(e["nfft"], e["hop"])] = e
+            except (OSError, KeyError, ValueError):
+                pass
+
+    sig = test_signal(N)
+    sizes = []
+    for nfft, hop in SIZES:
+        if (nfft, hop) in kept:
+            sizes.append(kept[(nfft, hop)])
+            continue
+        win = hann(nfft)
+        cases = []
+        for go_pad, pad_mode in PADS:
+            power = stft_power(sig, win, nfft, hop, pad_mode)
+            cases.append(
+                {
+                    "go_pad": go_pad,
+                    "pad_mode": pad_mode,
+                    "frames": len(power),
+                    "bins": len(power[0]),
+                    "power": [round_sig(v) for row in power for v in row],
+                }
+            )
+        sizes.append({"nfft": nfft, "hop": hop, "generator": generator, "cases": cases})
+
+    out = {"n": N, "sizes": sizes}
+
+    for d in out_dirs:
+        os.makedirs(d, exist_ok=True)
+        path = os.path.join(d, "stft_librosa_golden.json")
+        with open(path, "w") as f:
+            json.dump(out, f)
+        print("wrote", path)
+
+    for e in sizes:
+        print(e["nfft"], e["hop"], e["generator"], [(c["go_pad"], c["frames"]) for c in e["cases"]])
+
 
-out = {
-    "librosa_version": librosa.__version__,
-    "nfft": NFFT,
-    "hop": HOP,
-    "n": N,
-    "cases": cases,
-}
-
-out_dirs = sys.argv[1:] or ["f64/testdata"]
-for d in out_dirs:
-    os.makedirs(d, exist_ok=True)
-    path = os.path.join(d, "stft_librosa_golden.json")
-    with open(path, "w") as f:
-        json.dump(out, f)
-    print("wrote", path)
-
-print(
-    "librosa",
-    librosa.__version__,
-    "cases",
-    [(c["go_pad"], c["frames"], c["bins"]) for c in cases],
-)
+if __name__ == "__main__":
+    main()